@@ -0,0 +1,126 @@
+// Package controllersは、HTTPリクエストのハンドリングとレスポンス制御を実装します。
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"nft-music/adapters/middlewares"
+	"nft-music/adapters/presenters"
+	"nft-music/usecases/interactor"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+// AuthController はSign-In With Ethereumのログイン用コントローラー
+type AuthController struct {
+	Interactor *interactor.AuthInteractor
+	Error      *presenters.ErrorPresenter
+	Validator  *validator.Validate
+}
+
+// NewAuthController はログイン用コントローラーのコンストラクタ
+func NewAuthController(interactor *interactor.AuthInteractor, logging logging.Logging, validate *validator.Validate) *AuthController {
+	return &AuthController{
+		Interactor: interactor,
+		Error:      presenters.NewErrorPresenter(logging),
+		Validator:  validate,
+	}
+}
+
+// Nonce はSIWEのノンスと署名対象メッセージを発行する
+// @Tags 認証
+// @Summary SIWEのノンスを発行する
+// @Description ウォレットで署名するEIP-4361形式のメッセージを発行する
+// @Accept  json
+// @Produce  json
+// @Param wallet query string true "ウォレットアドレス"
+// @Param chain_id query int false "チェーンID"
+// @Success 200 {object} ports.AuthNonceOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /auth/nonce [get]
+func (controller *AuthController) Nonce(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	chainID, err := strconv.Atoi(c.QueryParam("chain_id"))
+	if err != nil {
+		chainID = 0 // 指定がない場合はメインネットとする
+	}
+
+	output, err := controller.Interactor.Nonce(ctx, c.QueryParam("wallet"), chainID)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// Verify は署名済みのSIWEメッセージを検証してセッショントークンを発行する
+// @Tags 認証
+// @Summary SIWEの署名を検証してログインする
+// @Description 署名済みのEIP-4361メッセージを検証し、Authorizationヘッダーで使うセッショントークンを発行する
+// @Accept  json
+// @Produce  json
+// @Param auth body ports.AuthVerifyInput true "署名済みメッセージ"
+// @Success 200 {object} ports.AuthSessionOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /auth/verify [post]
+func (controller *AuthController) Verify(c echo.Context) error {
+	ctx := c.Request().Context()
+	var input ports.AuthVerifyInput
+
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Verify(ctx, &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// Me はログイン中のユーザーを返す
+// @Tags 認証
+// @Summary ログイン中のユーザーを取得する
+// @Description セッショントークンに紐づくユーザーIDとウォレットアドレスを返す
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} ports.AuthUser
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Router /auth/me [get]
+func (controller *AuthController) Me(c echo.Context) error {
+	authUser, _ := ports.AuthUserFrom(c.Request().Context())
+	return c.JSON(http.StatusOK, authUser)
+}
+
+// Logout はセッションを破棄する
+// @Tags 認証
+// @Summary ログアウトする
+// @Description セッショントークンを無効にする
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {string} string "OK"
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /auth/logout [post]
+func (controller *AuthController) Logout(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if err := controller.Interactor.Logout(ctx, middlewares.BearerToken(c)); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, "OK")
+}
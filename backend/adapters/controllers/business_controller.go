@@ -37,9 +37,12 @@ func NewBusinessController(interactor *interactor.BusinessInteractor, logging lo
 // @Description 職種マスターの情報を1件作成する
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param wallet body ports.BusinessMasterInput true "職種マスター"
 // @Success 200 {object} ports.BusinessMasterOutput
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /businesses [post]
@@ -123,10 +126,13 @@ func (controller *BusinessController) List(c echo.Context) error {
 // @Description 職種マスターの情報を1件修正する
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param id path string true "職種マスターID"
 // @Param wallet body ports.BusinessMasterInput true "職種マスター"
 // @Success 200 {object} ports.BusinessMasterOutput
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /businesses/{id} [put]
@@ -158,9 +164,12 @@ func (controller *BusinessController) Update(c echo.Context) error {
 // @Description 職種マスターの情報を1件削除する
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param id path string true "職種マスターID"
 // @Success 200 {object} ports.BusinessMasterOutput
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /businesses/{id} [delete]
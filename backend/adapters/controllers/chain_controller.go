@@ -0,0 +1,45 @@
+// Package controllersは、HTTPリクエストのハンドリングとレスポンス制御を実装します。
+package controllers
+
+import (
+	"net/http"
+
+	"nft-music/adapters/presenters"
+	"nft-music/usecases/interactor"
+	"nft-music/usecases/logging"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ChainController struct {
+	ChainRegistry *interactor.ChainRegistry
+	Error         *presenters.ErrorPresenter
+}
+
+func NewChainController(chainRegistry *interactor.ChainRegistry, logging logging.Logging) *ChainController {
+	return &ChainController{
+		ChainRegistry: chainRegistry,
+		Error:         presenters.NewErrorPresenter(logging),
+	}
+}
+
+// List は対応しているチェーンを一覧で取得する
+// @Tags チェーン
+// Chain godoc
+// @Summary 対応しているチェーンを一覧で取得する
+// @Description ネイティブ通貨・RPC・ブロックエクスプローラー・マーケットプレイスのアドレスと、バックエンドが接続中かどうかを返す
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} []ports.ChainOutput
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /chains [get]
+func (controller *ChainController) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	outputs, err := controller.ChainRegistry.List(ctx)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, outputs)
+}
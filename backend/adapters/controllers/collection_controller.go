@@ -82,9 +82,12 @@ func (controller *CollectionController) List(c echo.Context) error {
 // @Description コレクションの情報を1件作成する
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param collection body ports.CollectionInput true "コレクション"
 // @Success 200 {object} ports.CollectionOutput
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /collections [post]
@@ -116,10 +119,13 @@ func (controller *CollectionController) Create(c echo.Context) error {
 // @Description コレクションの情報を1件修正する
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param id path string true "コレクションID"
 // @Param collection body ports.CollectionInput true "コレクション"
 // @Success 200 {object} ports.CollectionOutput
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /collections/{id} [put]
@@ -149,9 +155,12 @@ func (controller *CollectionController) Update(c echo.Context) error {
 // @Description コレクションの情報を1件削除する
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param id path string true "コレクションID"
 // @Success 200
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /collections/{id} [delete]
@@ -169,3 +178,149 @@ func (controller *CollectionController) Delete(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, nil)
 }
+
+// Mint はコレクションのコントラクトでミントする
+// @Tags コレクション
+// @Summary コレクションのコントラクトでミントする
+// @Description コレクションの作成者のみ。プラットフォームのウォレットから safeMint を送信し、取り込まれるまで待ってトークンIDを返す
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "コレクションID"
+// @Param mint body ports.CollectionMintInput true "送信先（空の場合は自分のウォレット）"
+// @Success 200 {object} ports.CollectionTokenOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /collections/{id}/mint [post]
+func (controller *CollectionController) Mint(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	idUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	var input ports.CollectionMintInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Mint(ctx, idUUID, &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// Burn はコレクションのトークンをバーンする
+// @Tags コレクション
+// @Summary コレクションのトークンをバーンする
+// @Description トークンの所有者のみ。所有者がプラットフォームのウォレットを承認している場合に burn を送信し、取り込まれるまで待つ
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "コレクションID"
+// @Param token_id path string true "トークンID"
+// @Success 200 {object} ports.CollectionTokenOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /collections/{id}/tokens/{token_id}/burn [post]
+func (controller *CollectionController) Burn(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	idUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Burn(ctx, idUUID, c.Param("token_id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// SetTokenRoyalty はトークンごとのロイヤリティを設定する
+// @Tags コレクション
+// @Summary トークンごとのロイヤリティを設定する
+// @Description コレクションの作成者のみ。プラットフォームでデプロイしたコレクションはコントラクトの setTokenRoyalty も送信する
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "コレクションID"
+// @Param token_id path string true "トークンID"
+// @Param royalty body ports.TokenRoyaltyInput true "ロイヤリティ率（bps）と受取人"
+// @Success 200 {object} ports.TokenRoyaltyOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /collections/{id}/tokens/{token_id}/royalty [put]
+func (controller *CollectionController) SetTokenRoyalty(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	idUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	var input ports.TokenRoyaltyInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.SetTokenRoyalty(ctx, idUUID, c.Param("token_id"), &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// ResetTokenRoyalty はトークンごとのロイヤリティを解除する
+// @Tags コレクション
+// @Summary トークンごとのロイヤリティを解除する
+// @Description コレクションの作成者のみ。解除後はコレクションの既定のロイヤリティが適用される
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "コレクションID"
+// @Param token_id path string true "トークンID"
+// @Success 200
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /collections/{id}/tokens/{token_id}/royalty [delete]
+func (controller *CollectionController) ResetTokenRoyalty(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	idUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Interactor.ResetTokenRoyalty(ctx, idUUID, c.Param("token_id")); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,235 @@
+// Package controllersは、HTTPリクエストのハンドリングとレスポンス制御を実装します。
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"nft-music/adapters/presenters"
+	"nft-music/usecases/interactor"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type ContractAdminController struct {
+	Interactor *interactor.ContractAdminInteractor
+	Error      *presenters.ErrorPresenter
+	Validator  *validator.Validate
+}
+
+func NewContractAdminController(interactor *interactor.ContractAdminInteractor, logging logging.Logging, validate *validator.Validate) *ContractAdminController {
+	return &ContractAdminController{
+		Interactor: interactor,
+		Error:      presenters.NewErrorPresenter(logging),
+		Validator:  validate,
+	}
+}
+
+// State は接続中のコントラクトの設定を出力する
+// @Tags EVM
+// @Summary コントラクトの設定を出力する
+// @Description 接続中のマーケットプレイスのコントラクトのオーナー・一時停止・ロイヤリティ・ミント料をチェーンから読み取る
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} ports.ContractStateOutput
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /contract [get]
+func (controller *ContractAdminController) State(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	output, err := controller.Interactor.State(ctx)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// Pause はミントと売買を一時停止する
+// @Tags 管理
+// @Summary ミントと売買を一時停止する（管理者のみ）
+// @Description コントラクトの pause を送信し、取り込まれるまで待って監査ログを返す
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} ports.ContractAuditOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /contract/pause [post]
+func (controller *ContractAdminController) Pause(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	output, err := controller.Interactor.Pause(ctx)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// Unpause は一時停止を解除する
+// @Tags 管理
+// @Summary 一時停止を解除する（管理者のみ）
+// @Description コントラクトの unpause を送信し、取り込まれるまで待って監査ログを返す
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} ports.ContractAuditOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /contract/unpause [post]
+func (controller *ContractAdminController) Unpause(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	output, err := controller.Interactor.Unpause(ctx)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// UpdateRoyaltyFee はロイヤリティを変更する
+// @Tags 管理
+// @Summary ロイヤリティを変更する（管理者のみ）
+// @Description 二次流通でクリエイターに支払うロイヤリティ（bps）を変更し、取り込まれるまで待って監査ログを返す
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param royalty_fee body ports.RoyaltyFeeInput true "変更後のロイヤリティ"
+// @Success 200 {object} ports.ContractAuditOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /contract/royalty-fee [put]
+func (controller *ContractAdminController) UpdateRoyaltyFee(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var input ports.RoyaltyFeeInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.UpdateRoyaltyFee(ctx, &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// UpdateListingPrice はミント料を変更する
+// @Tags 管理
+// @Summary ミント料を変更する（管理者のみ）
+// @Description ミント料を変更し、取り込まれるまで待って監査ログを返す。以降のミントは変更後のミント料で送信する
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param listing_price body ports.ListingPriceInput true "変更後のミント料"
+// @Success 200 {object} ports.ContractAuditOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /contract/listing-price [put]
+func (controller *ContractAdminController) UpdateListingPrice(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var input ports.ListingPriceInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.UpdateListingPrice(ctx, &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// RenounceOwnership はコントラクトの所有権を放棄する
+// @Tags 管理
+// @Summary コントラクトの所有権を放棄する（管理者のみ）
+// @Description 放棄すると以降のオーナー操作ができなくなるため、confirm に接続中のコントラクトアドレスを指定する
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param renounce body ports.RenounceOwnershipInput true "確認のためのコントラクトアドレス"
+// @Success 200 {object} ports.ContractAuditOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /contract/renounce-ownership [post]
+func (controller *ContractAdminController) RenounceOwnership(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var input ports.RenounceOwnershipInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.RenounceOwnership(ctx, &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// ListAudits はオーナー操作の監査ログを一覧で出力する
+// @Tags 管理
+// @Summary オーナー操作の監査ログを一覧で出力する（管理者のみ）
+// @Description 接続中のコントラクトに対するオーナー操作を新しい順に返す
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param limit query int false "取得件数"
+// @Success 200 {object} []ports.ContractAuditOutput
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /contract/audits [get]
+func (controller *ContractAdminController) ListAudits(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit <= 0 {
+		limit = 0 // パラメータが無い、または無効な場合は0（無制限）とする
+	}
+
+	output, err := controller.Interactor.ListAudits(ctx, limit)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
@@ -4,52 +4,82 @@ package controllers
 import (
 	"net/http"
 
-	"nft-music/contracts"
+	"nft-music/adapters/presenters"
 	"nft-music/usecases/interactor"
 	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 )
 
 type EvmController struct {
 	Interactor *interactor.EvmInteractor
-	Auth       *bind.TransactOpts
-	Contracts  *contracts.Contracts
+	Error      *presenters.ErrorPresenter
+	Validator  *validator.Validate
 	Logging    logging.Logging
 }
 
-func NewBlockChainController(interactor *interactor.EvmInteractor, auth *bind.TransactOpts, contracts *contracts.Contracts, logging logging.Logging) *EvmController {
+func NewBlockChainController(interactor *interactor.EvmInteractor, logging logging.Logging, validate *validator.Validate) *EvmController {
 	return &EvmController{
 		Interactor: interactor,
-		Auth:       auth,
-		Contracts:  contracts,
+		Error:      presenters.NewErrorPresenter(logging),
+		Validator:  validate,
 		Logging:    logging,
 	}
 }
 
-// Signer はEVMにログインする
+// Deploy はマーケットプレイスのコントラクトをデプロイする
 // @Tags EVM
 // Evm godoc
-// @Summary EVMにログインを取得
-// @Description Ethereum Virtual Machineのログイン情報を取得する
+// @Summary マーケットプレイスのコントラクトをデプロイする
+// @Description NFTマーケットプレイスのコントラクトをデプロイして登録簿に記録し、以降のミントの接続先を切り替える（管理者のみ）
 // @Accept  json
 // @Produce  json
-// @Param wallet body ports.WalletInput true "ウォレット"
-// @Success 200 {object} ports.SignerOutput
+// @Security ApiKeyAuth
+// @Param deployment body ports.DeploymentInput true "コントラクトの初期値"
+// @Success 200 {object} ports.DeploymentOutput
 // @Failure 400 {object} ports.ErrorResponseObject
-// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
-// @Router /evm [post]
-func (controller *EvmController) Signer(c echo.Context) error {
+// @Router /deployments [post]
+func (controller *EvmController) Deploy(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	controller.Logging.Debug("通過しました")
+	var input ports.DeploymentInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Deploy(ctx, &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// ListDeployments はデプロイ済みのコントラクトを一覧で取得する
+// @Tags EVM
+// Evm godoc
+// @Summary デプロイ済みのコントラクトを一覧で取得する
+// @Description 接続中のチェーンにデプロイしたコントラクトを新しい順に取得する
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} []ports.DeploymentOutput
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /deployments [get]
+func (controller *EvmController) ListDeployments(c echo.Context) error {
+	ctx := c.Request().Context()
 
-	// controller.Evm.
-	output, err := controller.Interactor.Signer(ctx)
+	output, err := controller.Interactor.ListDeployments(ctx)
 	if err != nil {
-		return err
+		return controller.Error.ErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, output)
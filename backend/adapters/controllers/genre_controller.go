@@ -90,9 +90,12 @@ func (controller *GenreController) List(c echo.Context) error {
 // @Description ジャンルマスターの情報を1件作成する
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param wallet body ports.GenreMasterInput true "ジャンルマスター"
 // @Success 200 {object} ports.GenreMasterOutput
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /genres [post]
@@ -122,10 +125,13 @@ func (controller *GenreController) Create(c echo.Context) error {
 // @Description ジャンルマスターの情報を1件修正する
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param id path string true "ジャンルマスターID"
 // @Param wallet body ports.GenreMasterInput true "ジャンルマスター"
 // @Success 200 {object} ports.GenreMasterOutput
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /genres/{id} [put]
@@ -161,9 +167,12 @@ func (controller *GenreController) Update(c echo.Context) error {
 // @Description ジャンルマスターの情報を1件削除する
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param id path string true "ジャンルマスターID"
 // @Success 200 {string} string "OK"
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /genres/{id} [delete]
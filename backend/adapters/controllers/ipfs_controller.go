@@ -2,6 +2,10 @@
 package controllers
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 
 	"nft-music/adapters/presenters"
@@ -13,6 +17,9 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// maxWalletFieldSize は wallet のパートとして読み取る上限
+const maxWalletFieldSize = 256
+
 // IpfsController はIPFSのアップロード用のコントローラー
 type IpfsController struct {
 	Interactor *interactor.IpfsInteractor
@@ -30,41 +37,113 @@ func NewIpfsController(interactor *interactor.IpfsInteractor, logging logging.Lo
 }
 
 // Upload はイメージをIPFS登録するハンドラー
+// マルチパートの本文をパートごとに読み取り、ファイルはメモリや一時ファイルに溜めずにそのまま保存先に送る
+// wallet はファイルより前のパートで送る（省略した場合はログイン中のウォレット）
 // @Tags IPFS
 // Ipfs godoc
 // @Summary IPFSノードにイメージデータを登録
-// @Description 分散型ストレージIPFSに画像を登録する
+// @Description 分散型ストレージIPFSに画像を登録する。X-Upload-ID を指定すると進捗の確認と取り消しができる
 // @Accept multipart/form-data
 // @Produce  json
+// @Security ApiKeyAuth
+// @Param X-Upload-ID header string false "進捗の確認と取り消しに使うアップロードのID"
+// @Param wallet formData string false "ウォレットアドレス（file より前に送る）"
 // @Param	file	formData file true	"this is a test file"
-// @Param wallet formData string true "ウォレットアドレス"
 // @Success 200 {object} ports.IpfsOutput
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 409 {object} ports.ErrorResponseObject
+// @Failure 413 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /ipfs [post]
 func (controller *IpfsController) Upload(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	wallet := c.FormValue("wallet")
-	header, err := c.FormFile("file")
+	reader, err := c.Request().MultipartReader()
 	if err != nil {
-		return controller.Error.ErrorResponse(c, err)
+		return controller.Error.ErrorResponse(c, fmt.Errorf("BadRequest: %w", err))
 	}
 
-	form := ports.IpfsInput{
-		Wallet: wallet,
-		File:   header.Filename,
+	var form ports.IpfsInput
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return controller.Error.ErrorResponse(c, errors.New("BadRequest: file がありません"))
+		}
+		if err != nil {
+			return controller.Error.ErrorResponse(c, fmt.Errorf("BadRequest: %w", err))
+		}
+
+		switch part.FormName() {
+		case "wallet":
+			wallet, err := io.ReadAll(io.LimitReader(part, maxWalletFieldSize))
+			if err != nil {
+				return controller.Error.ErrorResponse(c, fmt.Errorf("BadRequest: %w", err))
+			}
+			form.Wallet = string(wallet)
+		case "file":
+			form.File = part.FileName()
+			upload := ports.IpfsUpload{
+				UploadID: c.Request().Header.Get("X-Upload-ID"),
+				Filename: part.FileName(),
+				Size:     c.Request().ContentLength,
+				Content:  part,
+			}
+			output, err := controller.Interactor.Upload(ctx, upload, form)
+			if err != nil {
+				return controller.Error.ErrorResponse(c, err)
+			}
+			return c.JSON(http.StatusOK, output)
+		}
 	}
+}
+
+// Progress はアップロード中のファイルの進捗を返すハンドラー
+// @Tags IPFS
+// Ipfs godoc
+// @Summary アップロード中のファイルの進捗
+// @Description X-Upload-ID を指定して POST /ipfs でアップロード中のファイルの受信したバイト数を返す
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "アップロードのID（X-Upload-ID）"
+// @Success 200 {object} ports.IpfsUploadProgress
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Router /ipfs/uploads/{id} [get]
+func (controller *IpfsController) Progress(c echo.Context) error {
+	ctx := c.Request().Context()
 
-	output, err := controller.Interactor.Upload(ctx, header, form)
+	output, err := controller.Interactor.Progress(ctx, c.Param("id"))
 	if err != nil {
 		return controller.Error.ErrorResponse(c, err)
 	}
-
 	return c.JSON(http.StatusOK, output)
 }
 
+// Cancel はアップロード中のファイルを取り消すハンドラー
+// @Tags IPFS
+// Ipfs godoc
+// @Summary アップロード中のファイルの取り消し
+// @Description X-Upload-ID を指定して POST /ipfs でアップロード中のファイルの送信を止める
+// @Security ApiKeyAuth
+// @Param id path string true "アップロードのID（X-Upload-ID）"
+// @Success 200 {string} string "OK"
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Router /ipfs/uploads/{id} [delete]
+func (controller *IpfsController) Cancel(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if err := controller.Interactor.Cancel(ctx, c.Param("id")); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, "OK")
+}
+
 // MetaUpload はMetaJSONをIPFS登録するハンドラー
 // @Tags IPFS
 // Ipfs godoc
@@ -72,9 +151,12 @@ func (controller *IpfsController) Upload(c echo.Context) error {
 // @Description 分散型ストレージIPFSにJSONデータを登録する
 // @Accept json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param json body ports.IpfsMetaInput true "MetaJSON"
 // @Success 200 {object} ports.IpfsOutput
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /ipfs/meta [post]
@@ -86,12 +168,6 @@ func (controller *IpfsController) MetaUpload(c echo.Context) error {
 		return controller.Error.ErrorResponse(c, err)
 	}
 
-	if input.ImageCid == "" {
-		input.FileType = "video"
-	} else {
-		input.FileType = "audio"
-	}
-
 	if err := controller.Validator.Struct(&input); err != nil {
 		return controller.Error.ErrorResponse(c, err)
 	}
@@ -103,3 +179,34 @@ func (controller *IpfsController) MetaUpload(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, output)
 }
+
+// Content はCIDのコンテンツを配信するハンドラー
+// @Tags IPFS
+// @Summary 保存したコンテンツの配信
+// @Description IPFSのネットワークに公開しない保存先（s3・local）のコンテンツを配信する。トークンURIや画像・プレビューのURLは IPFS_PUBLIC_URL + /ipfs/{cid} になる。フル音源は配信せず、クリエイターとトークンの所有者は GET /nfts/detail/{transaction_id}/master/content で取得する
+// @Produce  octet-stream
+// @Param cid path string true "CID"
+// @Success 200 {file} file
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /ipfs/{cid} [get]
+func (controller *IpfsController) Content(c echo.Context) error {
+	body, err := controller.Interactor.Content(c.Request().Context(), c.Param("cid"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+	// CIDの内容は変わらないため長くキャッシュさせる
+	return streamContent(c, body, "public, max-age=31536000, immutable")
+}
+
+// streamContent はコンテンツの先頭から Content-Type を判定して本文を返す
+func streamContent(c echo.Context, body io.ReadCloser, cacheControl string) error {
+	defer body.Close()
+
+	reader := bufio.NewReader(body)
+	head, _ := reader.Peek(512)
+	c.Response().Header().Set(echo.HeaderCacheControl, cacheControl)
+	return c.Stream(http.StatusOK, http.DetectContentType(head), reader)
+}
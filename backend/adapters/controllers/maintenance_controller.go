@@ -0,0 +1,76 @@
+// Package controllersは、HTTPリクエストのハンドリングとレスポンス制御を実装します。
+package controllers
+
+import (
+	"net/http"
+
+	"nft-music/adapters/presenters"
+	"nft-music/usecases/interactor"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type MaintenanceController struct {
+	Interactor *interactor.MaintenanceInteractor
+	Error      *presenters.ErrorPresenter
+	Validator  *validator.Validate
+}
+
+func NewMaintenanceController(interactor *interactor.MaintenanceInteractor, logging logging.Logging, validate *validator.Validate) *MaintenanceController {
+	return &MaintenanceController{
+		Interactor: interactor,
+		Error:      presenters.NewErrorPresenter(logging),
+		Validator:  validate,
+	}
+}
+
+// Status はメンテナンスモードの状態を出力する
+// @Tags 管理
+// @Summary メンテナンスモードの状態を出力する
+// @Description コントラクトの一時停止か運用者の設定でメンテナンス中の間は active が true になり、書き込みのAPIは 503 を返す
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} ports.MaintenanceOutput
+// @Router /maintenance [get]
+func (controller *MaintenanceController) Status(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	return c.JSON(http.StatusOK, controller.Interactor.Status(ctx))
+}
+
+// SetOperator は運用者のメンテナンスモードを切り替える
+// @Tags 管理
+// @Summary メンテナンスモードを切り替える（管理者のみ）
+// @Description 運用者のメンテナンスモードを切り替える。コントラクトが一時停止中の場合は解除してもメンテナンス中のまま
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param maintenance body ports.MaintenanceInput true "メンテナンスモードの指定"
+// @Success 200 {object} ports.MaintenanceOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /maintenance [put]
+func (controller *MaintenanceController) SetOperator(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var input ports.MaintenanceInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.SetOperator(ctx, &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
@@ -2,10 +2,12 @@
 package controllers
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
 
+	"nft-music/domain"
 	"nft-music/usecases/interactor"
 	"nft-music/usecases/ports"
 
@@ -82,14 +84,15 @@ func (controller *NftController) ListByWallet(c echo.Context) error {
 // Search はキーワードでNFTを複数出力するハンドラー
 // @Tags NFT情報
 // @Summary キーワードでNFTを複数出力する
-// @Description キーワードに一致するNFTを複数出力する
+// @Description キーワードに一致するNFTを複数出力する。出品中のバウチャー（遅延ミント）は listing_state が lazy で、id はバウチャーID
 // @Accept  json
 // @Produce  json
 // @Param q query string false "検索キーワード"
 // @Param genre query string false "ジャンルID"
-// @Param min_price query int false "最小価格"
-// @Param max_price query int false "最大価格"
+// @Param min_price query string false "最小価格（例: 0.01 ETH。単位が無い場合はETH）"
+// @Param max_price query string false "最大価格（例: 0.5 ETH。単位が無い場合はETH）"
 // @Param sort query string false "ソート順"
+// @Param listing query string false "出品の状態（minted / lazy。空の場合は両方）"
 // @Success 200 {object} []ports.TransactionOutput
 // @Failure 400 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
@@ -98,17 +101,18 @@ func (controller *NftController) Search(c echo.Context) error {
 	ctx := c.Request().Context()
 	query := c.QueryParam("q")
 	genre := c.QueryParam("genre")
-	minPrice, err := strconv.Atoi(c.QueryParam("min_price"))
+	minPrice, err := queryAmount(c, "min_price")
 	if err != nil {
-		minPrice = 0 // or handle error appropriately
+		return controller.NftInteractor.Error.ErrorResponse(c, err)
 	}
-	maxPrice, err := strconv.Atoi(c.QueryParam("max_price"))
+	maxPrice, err := queryAmount(c, "max_price")
 	if err != nil {
-		maxPrice = 0 // or handle error appropriately
+		return controller.NftInteractor.Error.ErrorResponse(c, err)
 	}
 	sort := c.QueryParam("sort")
+	listing := c.QueryParam("listing")
 
-	outputs, err := controller.NftInteractor.Search(ctx, query, genre, minPrice, maxPrice, sort)
+	outputs, err := controller.NftInteractor.Search(ctx, query, genre, minPrice, maxPrice, sort, listing)
 	if err != nil {
 		return controller.NftInteractor.Error.ErrorResponse(c, err)
 	}
@@ -140,16 +144,102 @@ func (controller *NftController) GetByTransactionid(c echo.Context) error {
 	return c.JSON(http.StatusOK, output)
 }
 
+// Master はNFTのフル音源を出力するハンドラー
+// @Tags NFT情報
+// @Summary NFTのフル音源を出力する
+// @Description 公開するメタデータにはプレビューだけを含めるため、フル音源のCIDはクリエイターとトークンの所有者にのみ返す。所有者はマーケットプレイスのコントラクトで確認する
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param transaction_id path string true "トランザクションID"
+// @Success 200 {object} ports.IpfsOutput
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /nfts/detail/{transaction_id}/master [get]
+func (controller *NftController) Master(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	output, err := controller.NftInteractor.Master(ctx, c.Param("transaction_id"))
+	if err != nil {
+		return controller.NftInteractor.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// MasterContent はNFTのフル音源を配信するハンドラー
+// @Tags NFT情報
+// @Summary NFTのフル音源を配信する
+// @Description クリエイターとトークンの所有者にフル音源を配信する。保存先がIPFSのネットワークに公開しない場合（s3・local）もここから取得できる
+// @Produce  octet-stream
+// @Security ApiKeyAuth
+// @Param transaction_id path string true "トランザクションID"
+// @Success 200 {file} file
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /nfts/detail/{transaction_id}/master/content [get]
+func (controller *NftController) MasterContent(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	body, err := controller.NftInteractor.MasterContent(ctx, c.Param("transaction_id"))
+	if err != nil {
+		return controller.NftInteractor.Error.ErrorResponse(c, err)
+	}
+
+	return streamContent(c, body, "private, no-store")
+}
+
+// GetByToken はチェーン・コントラクト・トークンIDでNFTを1件出力するハンドラー
+// @Tags NFT情報
+// @Summary トークンIDでNFTを1件出力する
+// @Description チェーンID・コントラクトアドレス・トークンIDに紐づくNFTを1件出力する
+// @Accept  json
+// @Produce  json
+// @Param chain_id path int true "チェーンID"
+// @Param contract path string true "コントラクトアドレス"
+// @Param token_id path string true "トークンID"
+// @Success 200 {object} ports.TransactionOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /nfts/token/{chain_id}/{contract}/{token_id} [get]
+func (controller *NftController) GetByToken(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	chainID, err := strconv.Atoi(c.Param("chain_id"))
+	if err != nil {
+		return controller.NftInteractor.Error.ErrorResponse(c, errors.New("BadRequest: chain_id が正しくありません"))
+	}
+
+	output, err := controller.NftInteractor.GetByToken(ctx, chainID, c.Param("contract"), c.Param("token_id"))
+	if err != nil {
+		return controller.NftInteractor.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
 // Mint はブロックチェーンにNFTをで登録するハンドラー
 // @Tags NFT情報
 // Nft godoc
 // @Summary NFTの情報をブロックチェーンに登録する
-// @Description NFTの情報をブロックチェーンに登録する
+// @Description ミントジョブを登録してすぐに返す。登録の状態は GET /nfts/jobs/{id} で確認する
+// @Description MINT_MODE=wallet の場合はクリエイターのウォレットで署名するトランザクション（transaction）を返す。送信後に POST /nfts/jobs/{id}/submit で登録する
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param wallet body ports.NftInput true "ジャンルマスター"
-// @Success 200 {object} ports.TransactionOutput
+// @Success 200 {object} ports.MintJobOutput "MINT_MODE=wallet（署名待ち）"
+// @Success 202 {object} ports.MintJobOutput
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /nfts [post]
@@ -182,11 +272,108 @@ func (controller *NftController) Mint(c echo.Context) error {
 		return controller.NftInteractor.Error.ErrorResponse(c, err)
 	}
 
-	// NFTをブロックチェーンに登録
+	// NFTをブロックチェーンに登録するジョブを登録
 	output, err := controller.NftInteractor.Mint(ctx, &input, token.Cid)
 	if err != nil {
 		return controller.NftInteractor.Error.ErrorResponse(c, err)
 	}
 
+	// ウォレットで署名する場合はまだ受け付けていない
+	if output.Transaction != nil {
+		return c.JSON(http.StatusOK, output)
+	}
+	return c.JSON(http.StatusAccepted, output)
+}
+
+// SubmitMint はクリエイターのウォレットで署名したミントのトランザクションを登録するハンドラー
+// @Tags NFT情報
+// @Summary ウォレットで署名したミントのトランザクションを登録する
+// @Description 署名済みのトランザクション（raw_tx）はノードに送信し、ハッシュ（tx_hash）はウォレットが送信したものを確認する。ミントジョブの内容と一致する場合に取り込まれるまで追跡する
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "ジョブID"
+// @Param submit body ports.MintSubmitInput true "署名済みのトランザクションかトランザクションハッシュ"
+// @Success 200 {object} ports.MintJobOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /nfts/jobs/{id}/submit [post]
+func (controller *NftController) SubmitMint(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var input ports.MintSubmitInput
+	if err := c.Bind(&input); err != nil {
+		return controller.NftInteractor.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.NftInteractor.SubmitMint(ctx, c.Param("id"), &input)
+	if err != nil {
+		return controller.NftInteractor.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// PreviewMint はミントのガス代の見積もりを出力するハンドラー
+// @Tags NFT情報
+// @Summary ミントのガス代を見積もる
+// @Description コントラクトの現在のミント料でミントのトランザクションを送信した場合のガス代を見積もる（送信はしない）
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param chain_id query int true "チェーンID"
+// @Param price query string false "価格（例: 0.015 ETH, 15 gwei。単位が無い場合はネイティブ通貨）。指定する場合はミント料と一致する必要がある"
+// @Success 200 {object} ports.MintPreviewOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /nfts/mint/preview [get]
+func (controller *NftController) PreviewMint(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	chainID, err := strconv.Atoi(c.QueryParam("chain_id"))
+	if err != nil {
+		return controller.NftInteractor.Error.ErrorResponse(c, errors.New("BadRequest: chain_id が正しくありません"))
+	}
+	output, err := controller.NftInteractor.PreviewMint(ctx, chainID, c.QueryParam("price"))
+	if err != nil {
+		return controller.NftInteractor.Error.ErrorResponse(c, err)
+	}
+
 	return c.JSON(http.StatusOK, output)
 }
+
+// GetJob はミントジョブの状態を出力するハンドラー
+// @Tags NFT情報
+// @Summary ミントジョブの状態を出力する
+// @Description ステータスは queued（ウォレットで署名する場合は prepared）→ submitted → mined → confirmed の順に進み、失敗した場合は failed になる
+// @Accept  json
+// @Produce  json
+// @Param id path string true "ジョブID"
+// @Success 200 {object} ports.MintJobOutput
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /nfts/jobs/{id} [get]
+func (controller *NftController) GetJob(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	output, err := controller.NftInteractor.GetJob(ctx, c.Param("id"))
+	if err != nil {
+		return controller.NftInteractor.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// queryAmount はクエリパラメータの金額を読み取る（指定が無い場合は0）
+func queryAmount(c echo.Context, name string) (domain.Amount, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return domain.Amount{}, nil
+	}
+	return domain.ParsePrice(value)
+}
@@ -0,0 +1,88 @@
+// Package controllersは、HTTPリクエストのハンドリングとレスポンス制御を実装します。
+package controllers
+
+import (
+	"net/http"
+
+	"nft-music/adapters/presenters"
+	"nft-music/usecases/interactor"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type ReconcileController struct {
+	Reconciler *interactor.Reconciler
+	Error      *presenters.ErrorPresenter
+	Validator  *validator.Validate
+}
+
+func NewReconcileController(reconciler *interactor.Reconciler, logging logging.Logging, validate *validator.Validate) *ReconcileController {
+	return &ReconcileController{
+		Reconciler: reconciler,
+		Error:      presenters.NewErrorPresenter(logging),
+		Validator:  validate,
+	}
+}
+
+// Reconcile はDBとチェーンを照合する
+// @Tags 管理
+// Reconcile godoc
+// @Summary DBとチェーンを照合する（管理者のみ）
+// @Description トランザクションの行を FetchAllMarketItems・ownerOf・ミントのレシートと照合して食い違いを返す。repair を指定するとステータス・トークンID・価格をチェーンに合わせて直す
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param reconcile body ports.ReconcileInput true "照合の指定"
+// @Success 200 {object} ports.DriftReportOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /reconciliation [post]
+func (controller *ReconcileController) Reconcile(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var input ports.ReconcileInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Reconciler.Reconcile(ctx, &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// LastReport は最後に照合した結果を出力する
+// @Tags 管理
+// @Summary 最後に照合した結果を出力する（管理者のみ）
+// @Description 定期的な照合か POST /reconciliation で最後に照合した結果を返す
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} ports.DriftReportOutput
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /reconciliation [get]
+func (controller *ReconcileController) LastReport(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	output, err := controller.Reconciler.LastReport(ctx)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
@@ -0,0 +1,105 @@
+// Package controllersは、HTTPリクエストのハンドリングとレスポンス制御を実装します。
+package controllers
+
+import (
+	"net/http"
+
+	"nft-music/adapters/presenters"
+	"nft-music/domain"
+	"nft-music/usecases/interactor"
+	"nft-music/usecases/logging"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReplacementController struct {
+	Worker *interactor.MintWorker
+	Error  *presenters.ErrorPresenter
+}
+
+func NewReplacementController(worker *interactor.MintWorker, logging logging.Logging) *ReplacementController {
+	return &ReplacementController{
+		Worker: worker,
+		Error:  presenters.NewErrorPresenter(logging),
+	}
+}
+
+// List はミントジョブのトランザクションを置き換えた記録を出力する
+// @Tags 管理
+// @Summary ミントジョブの置き換えの記録を出力する（管理者のみ）
+// @Description 詰まったミントのトランザクションを同じノンスで送り直した・取り消した記録を古い順に返す（自動の置き換えを含む）
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "ミントジョブのID"
+// @Success 200 {array} ports.ReplacementOutput
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /nfts/jobs/{id}/replacements [get]
+func (controller *ReplacementController) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	outputs, err := controller.Worker.ListReplacements(ctx, c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, outputs)
+}
+
+// SpeedUp は取り込まれないミントのトランザクションをガス代を上げて送り直す
+// @Tags 管理
+// @Summary ミントのトランザクションをガス代を上げて送り直す（管理者のみ）
+// @Description プラットフォームのウォレットから送信して取り込まれていないミントを、同じノンス・同じ内容で元のガス代に上乗せして送り直す。ミントジョブの tx_hash は送り直したトランザクションになる
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "ミントジョブのID"
+// @Success 200 {object} ports.ReplacementOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /nfts/jobs/{id}/speed-up [post]
+func (controller *ReplacementController) SpeedUp(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	output, err := controller.Worker.Replace(ctx, c.Param("id"), domain.ReplacementKindSpeedUp)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// Cancel は取り込まれないミントのトランザクションを取り消す
+// @Tags 管理
+// @Summary ミントのトランザクションを取り消す（管理者のみ）
+// @Description プラットフォームのウォレットから送信して取り込まれていないミントを、同じノンスで自分宛てに0を送金して取り消す。取り消しが取り込まれるとミントジョブは failed になる
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "ミントジョブのID"
+// @Success 200 {object} ports.ReplacementOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Failure 503 {object} ports.ErrorResponseObject
+// @Router /nfts/jobs/{id}/cancel [post]
+func (controller *ReplacementController) Cancel(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	output, err := controller.Worker.Replace(ctx, c.Param("id"), domain.ReplacementKindCancel)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
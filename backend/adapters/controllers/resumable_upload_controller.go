@@ -0,0 +1,283 @@
+// Package controllersは、HTTPリクエストのハンドリングとレスポンス制御を実装します。
+package controllers
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"nft-music/adapters/presenters"
+	"nft-music/usecases/interactor"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// tus プロトコル（https://tus.io/protocols/resumable-upload）のバージョンと対応する拡張
+const (
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,expiration,termination"
+	// tusContentType は PATCH の本文の Content-Type
+	tusContentType = "application/offset+octet-stream"
+)
+
+// ResumableUploadController は tus プロトコルの分割アップロードのコントローラー
+type ResumableUploadController struct {
+	Interactor *interactor.ResumableUploadInteractor
+	Error      *presenters.ErrorPresenter
+}
+
+func NewResumableUploadController(interactor *interactor.ResumableUploadInteractor, logging logging.Logging) *ResumableUploadController {
+	return &ResumableUploadController{
+		Interactor: interactor,
+		Error:      presenters.NewErrorPresenter(logging),
+	}
+}
+
+// Options は対応する tus のバージョンと拡張を返す
+// @Tags IPFS
+// @Summary tus の分割アップロードの対応状況
+// @Description 対応する tus のバージョン（Tus-Version）・拡張（Tus-Extension）・ファイルの上限（Tus-Max-Size）をヘッダーで返す
+// @Success 204
+// @Router /ipfs/tus [options]
+func (controller *ResumableUploadController) Options(c echo.Context) error {
+	header := c.Response().Header()
+	header.Set("Tus-Resumable", tusVersion)
+	header.Set("Tus-Version", tusVersion)
+	header.Set("Tus-Extension", tusExtensions)
+	header.Set("Tus-Max-Size", strconv.FormatInt(controller.Interactor.MaxSize(), 10))
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Create は分割アップロードを作成する
+// @Tags IPFS
+// @Summary tus の分割アップロードを作成
+// @Description 音源のマスターや動画など大きなファイルを分割して送信するアップロードを作成し、Location で送信先のURLを返す。Upload-Metadata の filename は必須
+// @Security ApiKeyAuth
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param Upload-Length header int true "ファイルのバイト数"
+// @Param Upload-Metadata header string true "filename <base64>（カンマ区切りで他の項目も指定できる）"
+// @Success 201
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 412 {object} ports.ErrorResponseObject
+// @Failure 413 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /ipfs/tus [post]
+func (controller *ResumableUploadController) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+	if err := controller.checkVersion(c); err != nil {
+		return err
+	}
+
+	size, err := strconv.ParseInt(c.Request().Header.Get("Upload-Length"), 10, 64)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, errors.New("BadRequest: Upload-Length が正しくありません"))
+	}
+	metadata, err := parseUploadMetadata(c.Request().Header.Get("Upload-Metadata"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Create(ctx, ports.ResumableUploadInput{Size: size, Metadata: metadata})
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	controller.setHeaders(c, output)
+	c.Response().Header().Set(echo.HeaderLocation, strings.TrimSuffix(c.Request().URL.Path, "/")+"/"+output.ID.String())
+	return c.NoContent(http.StatusCreated)
+}
+
+// Head は受信済みのバイト数を返す
+// @Tags IPFS
+// @Summary tus の分割アップロードの受信済みのバイト数
+// @Description 接続が切れた後に再開する位置（Upload-Offset）を返す
+// @Security ApiKeyAuth
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param id path string true "アップロードのID"
+// @Success 200
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 412 {object} ports.ErrorResponseObject
+// @Router /ipfs/tus/{id} [head]
+func (controller *ResumableUploadController) Head(c echo.Context) error {
+	ctx := c.Request().Context()
+	if err := controller.checkVersion(c); err != nil {
+		return err
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, errors.New("record not found: アップロードがありません"))
+	}
+
+	output, err := controller.Interactor.Get(ctx, id)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	controller.setHeaders(c, output)
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return c.NoContent(http.StatusOK)
+}
+
+// Get は完了したアップロードを IPFS に追加した結果を返す
+// @Tags IPFS
+// @Summary tus の分割アップロードの結果
+// @Description 最後の PATCH のレスポンスを受け取れなかった場合に、IPFS に追加した結果を取得する
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "アップロードのID"
+// @Success 200 {object} ports.IpfsOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Router /ipfs/tus/{id} [get]
+func (controller *ResumableUploadController) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, errors.New("record not found: アップロードがありません"))
+	}
+
+	output, err := controller.Interactor.Get(ctx, id)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+	if output.Ipfs == nil {
+		return controller.Error.ErrorResponse(c, errors.New("BadRequest: アップロードが完了していません"))
+	}
+
+	return c.JSON(http.StatusOK, output.Ipfs)
+}
+
+// Patch は Upload-Offset の位置から受信した内容を追記する
+// @Tags IPFS
+// @Summary tus の分割アップロードに追記
+// @Description 受信済みのバイト数（Upload-Offset）から続きを送信する。最後まで受信したら IPFS に追加して 200 で結果を返し、それ以外は 204 を返す
+// @Accept application/offset+octet-stream
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param Upload-Offset header int true "受信済みのバイト数"
+// @Param id path string true "アップロードのID"
+// @Success 200 {object} ports.IpfsOutput
+// @Success 204
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 409 {object} ports.ErrorResponseObject
+// @Failure 412 {object} ports.ErrorResponseObject
+// @Failure 415 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /ipfs/tus/{id} [patch]
+func (controller *ResumableUploadController) Patch(c echo.Context) error {
+	ctx := c.Request().Context()
+	if err := controller.checkVersion(c); err != nil {
+		return err
+	}
+	if c.Request().Header.Get(echo.HeaderContentType) != tusContentType {
+		return c.JSON(http.StatusUnsupportedMediaType, ports.ErrorResponseObject{
+			StatusCode: http.StatusUnsupportedMediaType,
+			ErrorType:  "サポートしていないメディアタイプ",
+			Message:    "Content-Type は " + tusContentType + " にしてください",
+		})
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, errors.New("record not found: アップロードがありません"))
+	}
+	offset, err := strconv.ParseInt(c.Request().Header.Get("Upload-Offset"), 10, 64)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, errors.New("BadRequest: Upload-Offset が正しくありません"))
+	}
+
+	output, err := controller.Interactor.Append(ctx, id, offset, c.Request().Body)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	controller.setHeaders(c, output)
+	if output.Ipfs != nil {
+		return c.JSON(http.StatusOK, output.Ipfs)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Delete は分割アップロードを取り消す
+// @Tags IPFS
+// @Summary tus の分割アップロードを取り消す
+// @Description 受信した内容を削除する
+// @Security ApiKeyAuth
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param id path string true "アップロードのID"
+// @Success 204
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 409 {object} ports.ErrorResponseObject
+// @Failure 412 {object} ports.ErrorResponseObject
+// @Router /ipfs/tus/{id} [delete]
+func (controller *ResumableUploadController) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+	if err := controller.checkVersion(c); err != nil {
+		return err
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, errors.New("record not found: アップロードがありません"))
+	}
+
+	if err := controller.Interactor.Terminate(ctx, id); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	c.Response().Header().Set("Tus-Resumable", tusVersion)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// checkVersion は Tus-Resumable が対応するバージョンか確認する（違う場合は 412 を返す）
+func (controller *ResumableUploadController) checkVersion(c echo.Context) error {
+	if c.Request().Header.Get("Tus-Resumable") == tusVersion {
+		return nil
+	}
+	c.Response().Header().Set("Tus-Version", tusVersion)
+	return c.JSON(http.StatusPreconditionFailed, ports.ErrorResponseObject{
+		StatusCode: http.StatusPreconditionFailed,
+		ErrorType:  "対応していない tus のバージョン",
+		Message:    "Tus-Resumable は " + tusVersion + " にしてください",
+	})
+}
+
+func (controller *ResumableUploadController) setHeaders(c echo.Context, output *ports.ResumableUploadOutput) {
+	header := c.Response().Header()
+	header.Set("Tus-Resumable", tusVersion)
+	header.Set("Upload-Offset", strconv.FormatInt(output.Offset, 10))
+	header.Set("Upload-Length", strconv.FormatInt(output.Size, 10))
+	header.Set("Upload-Expires", output.ExpiresAt.UTC().Format(http.TimeFormat))
+}
+
+// parseUploadMetadata は Upload-Metadata（"key base64値" をカンマで区切ったもの）を読み取る
+func parseUploadMetadata(value string) (map[string]string, error) {
+	metadata := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, encoded, _ := strings.Cut(pair, " ")
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.New("BadRequest: Upload-Metadata の " + key + " が base64 ではありません")
+		}
+		metadata[key] = string(decoded)
+	}
+	return metadata, nil
+}
@@ -0,0 +1,59 @@
+// Package controllersは、HTTPリクエストのハンドリングとレスポンス制御を実装します。
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"nft-music/adapters/presenters"
+	"nft-music/usecases/interactor"
+	"nft-music/usecases/logging"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RoyaltyController ロイヤリティのコントローラー
+type RoyaltyController struct {
+	Interactor *interactor.RoyaltyInteractor
+	Error      *presenters.ErrorPresenter
+}
+
+// NewRoyaltyController ロイヤリティのコントローラーのコンストラクタ
+func NewRoyaltyController(interactor *interactor.RoyaltyInteractor, logging logging.Logging) *RoyaltyController {
+	return &RoyaltyController{
+		Interactor: interactor,
+		Error:      presenters.NewErrorPresenter(logging),
+	}
+}
+
+// Info は販売価格に対するロイヤリティを取得する
+// @Tags ロイヤリティ
+// @Summary 販売価格に対するロイヤリティの受取人と金額を取得する
+// @Description ERC-2981 の royaltyInfo と同じ計算。トークンごとの設定、コレクションの既定値、マーケットプレイスの royaltyFeeBps の順に解決する
+// @Accept  json
+// @Produce  json
+// @Param chain_id path int true "チェーンID"
+// @Param contract path string true "コントラクトアドレス"
+// @Param token_id path string true "トークンID"
+// @Param sale_price query string true "販売価格（ネイティブ通貨の単位、例: 0.5）"
+// @Success 200 {object} ports.RoyaltyInfoOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /royalty/{chain_id}/{contract}/{token_id} [get]
+func (controller *RoyaltyController) Info(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	chainID, err := strconv.Atoi(c.Param("chain_id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, errors.New("BadRequest: chain_id が正しくありません"))
+	}
+
+	output, err := controller.Interactor.Info(ctx, chainID, c.Param("contract"), c.Param("token_id"), c.QueryParam("sale_price"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
@@ -0,0 +1,176 @@
+// Package controllersは、HTTPリクエストのハンドリングとレスポンス制御を実装します。
+package controllers
+
+import (
+	"net/http"
+
+	"nft-music/adapters/presenters"
+	"nft-music/usecases/interactor"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type TradeController struct {
+	Interactor *interactor.TradeInteractor
+	Error      *presenters.ErrorPresenter
+	Validator  *validator.Validate
+	Logging    logging.Logging
+}
+
+func NewTradeController(interactor *interactor.TradeInteractor, logging logging.Logging, validate *validator.Validate) *TradeController {
+	return &TradeController{
+		Interactor: interactor,
+		Error:      presenters.NewErrorPresenter(logging),
+		Validator:  validate,
+		Logging:    logging,
+	}
+}
+
+// Purchase は販売中のNFTを購入するトランザクションを作成する
+// @Tags 二次流通
+// Trade godoc
+// @Summary 販売中のNFTを購入するトランザクションを作成する
+// @Description コントラクトの販売価格と手数料を確認し、ユーザーのウォレットで署名する createMarketSale のトランザクションを返す。送信後は POST /trades/{id}/submit でトランザクションハッシュを登録する
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param token_id path string true "トークンID"
+// @Param purchase body ports.PurchaseInput true "購入するウォレット"
+// @Success 200 {object} ports.TradeOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /nfts/{token_id}/purchase [post]
+func (controller *TradeController) Purchase(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var input ports.PurchaseInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Purchase(ctx, c.Param("token_id"), &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// Resell は所有しているNFTを再出品するトランザクションを作成する
+// @Tags 二次流通
+// Trade godoc
+// @Summary 所有しているNFTを再出品するトランザクションを作成する
+// @Description 所有者と出品手数料を確認し、ユーザーのウォレットで署名する resellToken のトランザクションを返す。送信後は POST /trades/{id}/submit でトランザクションハッシュを登録する
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param token_id path string true "トークンID"
+// @Param resell body ports.ResellInput true "再出品するウォレットと価格"
+// @Success 200 {object} ports.TradeOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /nfts/{token_id}/resell [post]
+func (controller *TradeController) Resell(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var input ports.ResellInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Resell(ctx, c.Param("token_id"), &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// Submit はユーザーが送信したトランザクションを取引に登録する
+// @Tags 二次流通
+// Trade godoc
+// @Summary 送信したトランザクションを取引に登録する
+// @Description トランザクションが取引の内容と一致するか確認し、取り込まれるまで追跡する
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "取引ID"
+// @Param submit body ports.TradeSubmitInput true "トランザクションハッシュ"
+// @Success 200 {object} ports.TradeOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /trades/{id}/submit [post]
+func (controller *TradeController) Submit(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	var input ports.TradeSubmitInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Submit(ctx, id, &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// Get は取引の状態を取得する
+// @Tags 二次流通
+// Trade godoc
+// @Summary 取引の状態を取得する
+// @Description ステータスは prepared → submitted → mined の順に進み、revertした場合は failed になる
+// @Accept  json
+// @Produce  json
+// @Param id path string true "取引ID"
+// @Success 200 {object} ports.TradeOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /trades/{id} [get]
+func (controller *TradeController) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Get(ctx, id)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
@@ -188,10 +188,13 @@ func (controller *UserController) Create(c echo.Context) error {
 // @Description NFTミュージックのアカウントの情報を変更する
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param id path string true "ユーザーID"
 // @Param users body ports.UserInput true "ユーザー情報"
 // @Success 200 {object} ports.UserOutput
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /users/{id} [put]
@@ -223,9 +226,12 @@ func (controller *UserController) Update(c echo.Context) error {
 // @Description NFTミュージックのアカウントを削除する
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param id path string true "ユーザーID"
 // @Success 200 {object} ports.UserOutput
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /users/{id} [delete]
@@ -243,3 +249,40 @@ func (controller *UserController) Delete(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, "OK")
 }
+
+// UpdateRole はNFTミュージックのアカウントの権限変更
+// @Tags アカウント
+// User godoc
+// @Summary NFTミュージックのアカウントの権限を変更する
+// @Description 管理者がユーザーの権限（member / creator / admin）を変更する
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "ユーザーID"
+// @Param role body ports.UserRoleInput true "権限"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /users/{id}/role [put]
+func (controller *UserController) UpdateRole(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	idUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Interactor.Error.ErrorResponse(c, err)
+	}
+
+	var input ports.UserRoleInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Interactor.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Interactor.UpdateRole(ctx, idUUID, input); err != nil {
+		return controller.Interactor.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, "OK")
+}
@@ -0,0 +1,328 @@
+// Package controllersは、HTTPリクエストのハンドリングとレスポンス制御を実装します。
+package controllers
+
+import (
+	"net/http"
+
+	"nft-music/adapters/presenters"
+	"nft-music/usecases/interactor"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// VoucherController 遅延ミントのバウチャーのコントローラー
+type VoucherController struct {
+	Interactor     *interactor.VoucherInteractor
+	IpfsInteractor *interactor.IpfsInteractor
+	Error          *presenters.ErrorPresenter
+	Validator      *validator.Validate
+}
+
+func NewVoucherController(interactor *interactor.VoucherInteractor, ipfsInteractor *interactor.IpfsInteractor, logging logging.Logging, validate *validator.Validate) *VoucherController {
+	return &VoucherController{
+		Interactor:     interactor,
+		IpfsInteractor: ipfsInteractor,
+		Error:          presenters.NewErrorPresenter(logging),
+		Validator:      validate,
+	}
+}
+
+// Create はコレクションのバウチャーを作成する
+// @Tags 遅延ミント
+// @Summary コレクションのバウチャーを作成する
+// @Description メタJSONをIPFSに登録してバウチャーを作成し、クリエイターのウォレットで eth_signTypedData_v4 で署名する EIP-712 のデータ（typed_data）を返す。署名後は POST /vouchers/{id}/sign で署名を登録すると出品される
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "コレクションID"
+// @Param voucher body ports.VoucherInput true "トークンの内容・価格・ロイヤリティ・有効期限"
+// @Success 201 {object} ports.VoucherOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /collections/{id}/vouchers [post]
+func (controller *VoucherController) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	var input ports.VoucherInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	// meta json をIPFSに登録
+	token, err := controller.IpfsInteractor.MetaJSON(ctx, ports.IpfsMetaInput{
+		Name:        input.Name,
+		Description: input.Description,
+		FileType:    input.FileType,
+		ImageCid:    input.ImageCid,
+		AudioCid:    input.AudioCid,
+		VideoCid:    input.VideoCid,
+	})
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Create(ctx, id, &input, token.Cid)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, output)
+}
+
+// ListByCollection はコレクションのバウチャーを取得する
+// @Tags 遅延ミント
+// @Summary コレクションのバウチャーをすべてのステータスで取得する
+// @Description 署名は返さない
+// @Accept  json
+// @Produce  json
+// @Param id path string true "コレクションID"
+// @Success 200 {object} []ports.VoucherOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /collections/{id}/vouchers [get]
+func (controller *VoucherController) ListByCollection(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	outputs, err := controller.Interactor.ListByCollection(ctx, id)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, outputs)
+}
+
+// List は出品中のバウチャーを取得する
+// @Tags 遅延ミント
+// @Summary 出品中のバウチャーを取得する
+// @Description 出品中で有効期限内のバウチャーを新しい順に返す（署名は返さない）
+// @Accept  json
+// @Produce  json
+// @Param genre query string false "ジャンルID"
+// @Param min_price query string false "最小価格（例: 0.01 ETH。単位が無い場合はETH）"
+// @Param max_price query string false "最大価格（例: 0.5 ETH。単位が無い場合はETH）"
+// @Success 200 {object} []ports.VoucherOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /vouchers [get]
+func (controller *VoucherController) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	minPrice, err := queryAmount(c, "min_price")
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+	maxPrice, err := queryAmount(c, "max_price")
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	outputs, err := controller.Interactor.List(ctx, c.QueryParam("genre"), minPrice, maxPrice)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, outputs)
+}
+
+// Get はバウチャーを取得する
+// @Tags 遅延ミント
+// @Summary バウチャーを取得する
+// @Description ステータスは prepared → listed → redeeming → redeemed の順に進み、取り消した場合は cancelled、出品中のまま有効期限が過ぎた場合は expired になる
+// @Accept  json
+// @Produce  json
+// @Param id path string true "バウチャーID"
+// @Success 200 {object} ports.VoucherOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /vouchers/{id} [get]
+func (controller *VoucherController) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Get(ctx, id)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// Sign はクリエイターの署名を登録してバウチャーを出品する
+// @Tags 遅延ミント
+// @Summary クリエイターの署名を登録してバウチャーを出品する
+// @Description 署名者がバウチャーのウォレットと一致するか検証する。署名者がコレクションのコントラクトで署名を認められていない場合は、プラットフォームのウォレットから setMinter を送信する
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "バウチャーID"
+// @Param sign body ports.VoucherSignInput true "EIP-712 の署名"
+// @Success 200 {object} ports.VoucherOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /vouchers/{id}/sign [post]
+func (controller *VoucherController) Sign(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	var input ports.VoucherSignInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Sign(ctx, id, &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// Purchase は出品中のバウチャーを購入するトランザクションを作成する
+// @Tags 遅延ミント
+// @Summary 出品中のバウチャーを購入するトランザクションを作成する
+// @Description 購入者のウォレットで署名する redeem のトランザクション（送金額は販売価格）を返す。送信後は POST /vouchers/{id}/submit でトランザクションハッシュを登録する
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "バウチャーID"
+// @Param purchase body ports.VoucherPurchaseInput true "購入するウォレット"
+// @Success 200 {object} ports.VoucherOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /vouchers/{id}/purchase [post]
+func (controller *VoucherController) Purchase(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	var input ports.VoucherPurchaseInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Purchase(ctx, id, &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// Submit は購入者が送信した redeem のトランザクションを登録する
+// @Tags 遅延ミント
+// @Summary 送信した redeem のトランザクションを登録する
+// @Description トランザクションがバウチャーの内容と一致するか確認し、取り込まれるまで追跡する。revertした場合は出品中に戻る
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "バウチャーID"
+// @Param submit body ports.TradeSubmitInput true "トランザクションハッシュ"
+// @Success 200 {object} ports.VoucherOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /vouchers/{id}/submit [post]
+func (controller *VoucherController) Submit(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	var input ports.TradeSubmitInput
+	if err := c.Bind(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	if err := controller.Validator.Struct(&input); err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Submit(ctx, id, &input)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
+// Cancel はバウチャーを取り消す
+// @Tags 遅延ミント
+// @Summary バウチャーを取り消す
+// @Description 購入者に署名を渡している場合は、プラットフォームのウォレットから cancelVoucher を送信して引き換えられないようにする
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param id path string true "バウチャーID"
+// @Success 200 {object} ports.VoucherOutput
+// @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
+// @Failure 404 {object} ports.ErrorResponseObject
+// @Failure 500 {object} ports.ErrorResponseObject
+// @Router /vouchers/{id} [delete]
+func (controller *VoucherController) Cancel(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	output, err := controller.Interactor.Cancel(ctx, id)
+	if err != nil {
+		return controller.Error.ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
@@ -61,10 +61,13 @@ func (controller *WalletController) List(c echo.Context) error {
 // @Description ウォレットアドレスが登録されているかウォレットテーブルを確認し、なければウォレットアドレスをデータベースに格納する
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param wallet body ports.WalletInput true "ウォレットアドレス"
 // @Success 200 {object} ports.WalletOutput
 // @Success 201 {object} ports.CreatedObject
 // @Failure 400 {object} ports.ErrorResponseObject
+// @Failure 401 {object} ports.ErrorResponseObject
+// @Failure 403 {object} ports.ErrorResponseObject
 // @Failure 404 {object} ports.ErrorResponseObject
 // @Failure 500 {object} ports.ErrorResponseObject
 // @Router /wallets [post]
@@ -0,0 +1,69 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"nft-music/domain"
+
+	"gorm.io/gorm"
+)
+
+// AuthGateway はSIWEのノンスとセッションのリポジトリ
+type AuthGateway struct {
+	Database *gorm.DB
+}
+
+func NewAuthGateway(db *gorm.DB) *AuthGateway {
+	return &AuthGateway{Database: db}
+}
+
+// CreateNonce はノンスを登録する
+func (gateway *AuthGateway) CreateNonce(ctx context.Context, nonce *domain.AuthNonce) error {
+	return gateway.Database.WithContext(ctx).Create(nonce).Error
+}
+
+// GetNonce はノンスを取得する
+func (gateway *AuthGateway) GetNonce(ctx context.Context, nonce string) (*domain.AuthNonce, error) {
+	var result domain.AuthNonce
+	if err := gateway.Database.WithContext(ctx).First(&result, "nonce = ?", nonce).Error; err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UseNonce は未使用のノンスを使用済みにする（同じノンスの再利用を防ぐ）
+func (gateway *AuthGateway) UseNonce(ctx context.Context, nonce string, usedAt time.Time) error {
+	result := gateway.Database.WithContext(ctx).
+		Model(&domain.AuthNonce{}).
+		Where("nonce = ? AND used_at IS NULL", nonce).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("Unauthorized: ノンスは既に使用されています")
+	}
+	return nil
+}
+
+// CreateSession はセッションを登録する
+func (gateway *AuthGateway) CreateSession(ctx context.Context, session *domain.Session) error {
+	return gateway.Database.WithContext(ctx).Create(session).Error
+}
+
+// GetSessionByTokenHash はトークンのハッシュ値からセッションを取得する
+func (gateway *AuthGateway) GetSessionByTokenHash(ctx context.Context, tokenHash string) (*domain.Session, error) {
+	var result domain.Session
+	if err := gateway.Database.WithContext(ctx).First(&result, "token_hash = ?", tokenHash).Error; err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteSessionByTokenHash はトークンのハッシュ値に一致するセッションを削除する
+func (gateway *AuthGateway) DeleteSessionByTokenHash(ctx context.Context, tokenHash string) error {
+	return gateway.Database.WithContext(ctx).Where("token_hash = ?", tokenHash).Delete(&domain.Session{}).Error
+}
@@ -0,0 +1,41 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+	"nft-music/util"
+
+	"gorm.io/gorm"
+)
+
+// ChainGateway は対応しているチェーンのリポジトリ
+type ChainGateway struct {
+	Database *gorm.DB
+}
+
+func NewChainGateway(db *gorm.DB) *ChainGateway {
+	return &ChainGateway{Database: db}
+}
+
+// List は対応しているチェーンをチェーンIDの順に取得する
+func (gateway *ChainGateway) List(ctx context.Context) ([]domain.Chain, error) {
+	var chains []domain.Chain
+	err := gateway.Database.WithContext(ctx).Order("chain_id").Find(&chains).Error
+	return chains, err
+}
+
+// UpdateMarketplace はチェーンで接続するマーケットプレイスのアドレスを更新する
+func (gateway *ChainGateway) UpdateMarketplace(ctx context.Context, chainID int, address string) error {
+	result := gateway.Database.WithContext(ctx).Model(&domain.Chain{}).
+		Where("chain_id = ?", chainID).
+		Updates(map[string]any{"marketplace_address": address, "updated_at": util.JapaneseNowTime()})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
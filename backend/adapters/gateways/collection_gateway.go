@@ -30,6 +30,15 @@ func (gateway *CollectionGateway) Get(ctx context.Context, id uuid.UUID) (*domai
 	return &result, nil
 }
 
+// GetByContract はコントラクトアドレスでコレクションを取得する
+func (gateway *CollectionGateway) GetByContract(ctx context.Context, chainID int, contractAddress string) (*domain.Collection, error) {
+	var result domain.Collection
+	if err := gateway.Database.WithContext(ctx).First(&result, "chain_id = ? AND contract_address = ?", chainID, contractAddress).Error; err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 func (gateway *CollectionGateway) List(ctx context.Context) ([]domain.Collection, error) {
 	var result []domain.Collection
 	if err := gateway.Database.WithContext(ctx).Order("updated_at desc").Find(&result).Error; err != nil {
@@ -46,6 +55,12 @@ func (gateway *CollectionGateway) Update(ctx context.Context, collection *domain
 	return gateway.Database.WithContext(ctx).Updates(&collection).Error
 }
 
+// UpdateRoyalty はコレクションの既定のロイヤリティを更新する（Update は0の値を更新しないため分けている）
+func (gateway *CollectionGateway) UpdateRoyalty(ctx context.Context, id uuid.UUID, royaltyBps int, receiver string) error {
+	return gateway.Database.WithContext(ctx).Model(&domain.Collection{}).Where("id = ?", id).
+		Updates(map[string]any{"royalty_bps": royaltyBps, "royalty_receiver": receiver}).Error
+}
+
 func (gateway *CollectionGateway) Delete(ctx context.Context, collection *domain.Collection) error {
 	return gateway.Database.WithContext(ctx).Delete(collection).Error
 }
@@ -0,0 +1,42 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+
+	"gorm.io/gorm"
+)
+
+// ContractAuditGateway はコントラクトのオーナー操作の監査ログのリポジトリ
+type ContractAuditGateway struct {
+	Database *gorm.DB
+}
+
+func NewContractAuditGateway(db *gorm.DB) *ContractAuditGateway {
+	return &ContractAuditGateway{Database: db}
+}
+
+// Create は監査ログを記録する
+func (gateway *ContractAuditGateway) Create(ctx context.Context, audit *domain.ContractAudit) error {
+	return gateway.Database.WithContext(ctx).Create(audit).Error
+}
+
+// Update は取り込みを待っていた操作の結果を更新する
+func (gateway *ContractAuditGateway) Update(ctx context.Context, audit *domain.ContractAudit) error {
+	return gateway.Database.WithContext(ctx).Model(audit).Select("status", "block_number", "error").Updates(audit).Error
+}
+
+// List はコントラクトの監査ログを新しい順に取得する（limit が0の場合は全件）
+func (gateway *ContractAuditGateway) List(ctx context.Context, chainID int, contractAddress string, limit int) ([]domain.ContractAudit, error) {
+	var audits []domain.ContractAudit
+	query := gateway.Database.WithContext(ctx).
+		Where("chain_id = ? AND contract_address = ?", chainID, contractAddress).
+		Order("created_at desc")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&audits).Error
+	return audits, err
+}
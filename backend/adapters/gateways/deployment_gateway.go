@@ -0,0 +1,47 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+
+	"gorm.io/gorm"
+)
+
+// DeploymentGateway はデプロイ済みコントラクトのリポジトリ
+type DeploymentGateway struct {
+	Database *gorm.DB
+}
+
+func NewDeploymentGateway(db *gorm.DB) *DeploymentGateway {
+	return &DeploymentGateway{Database: db}
+}
+
+// Create はデプロイしたコントラクトを登録する
+func (gateway *DeploymentGateway) Create(ctx context.Context, deployment *domain.Deployment) error {
+	return gateway.Database.WithContext(ctx).Create(deployment).Error
+}
+
+// Latest はチェーンで最後にデプロイしたコントラクトを取得する
+func (gateway *DeploymentGateway) Latest(ctx context.Context, chainID int, contractName string) (*domain.Deployment, error) {
+	var deployment domain.Deployment
+	err := gateway.Database.WithContext(ctx).
+		Where("chain_id = ? AND contract_name = ?", chainID, contractName).
+		Order("created_at desc").
+		First(&deployment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &deployment, nil
+}
+
+// List はチェーンにデプロイしたコントラクトを新しい順に取得する
+func (gateway *DeploymentGateway) List(ctx context.Context, chainID int) ([]domain.Deployment, error) {
+	var deployments []domain.Deployment
+	err := gateway.Database.WithContext(ctx).
+		Where("chain_id = ?", chainID).
+		Order("created_at desc").
+		Find(&deployments).Error
+	return deployments, err
+}
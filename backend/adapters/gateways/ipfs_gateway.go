@@ -2,162 +2,263 @@
 package gateways
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base32"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
-	"os"
+	"strings"
 
 	"nft-music/domain"
-
-	"gorm.io/gorm"
 )
 
-type IpfsGateway struct {
-	Database *gorm.DB
-}
+// ipfsPathPrefix はコンテンツを参照するパスの接頭辞（公開するURLは保存先ごとの公開URL + パス）
+const ipfsPathPrefix = "/ipfs/"
 
-func NewIpfsGateway(db *gorm.DB) *IpfsGateway {
-	return &IpfsGateway{Database: db}
+// KuboIpfsGateway は Kubo（go-ipfs）ノードの HTTP API でコンテンツを追加・固定し、ノードのゲートウェイから取得する
+type KuboIpfsGateway struct {
+	APIURL     string // http://ipfs:5001
+	GatewayURL string // http://ipfs:8080
+	PublicURL  string // https://ipfs.io（IPFSのネットワークに公開されるため、公開のゲートウェイで参照する）
+	Client     *http.Client
 }
 
-func (gateway *IpfsGateway) Get(ctx context.Context, cid string) (*domain.IpfsJSON, error) {
-	client := http.Client{}
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://ipfs:8080/"+cid, nil)
-	if err != nil {
-		return nil, err
+func NewKuboIpfsGateway(apiURL string, gatewayURL string, publicURL string) *KuboIpfsGateway {
+	return &KuboIpfsGateway{
+		APIURL:     strings.TrimRight(apiURL, "/"),
+		GatewayURL: strings.TrimRight(gatewayURL, "/"),
+		PublicURL:  strings.TrimRight(publicURL, "/"),
+		Client:     &http.Client{},
 	}
-	request.Header.Set("Content-Type", "application/json")
-	response, err := client.Do(request)
+}
+
+// Get はゲートウェイからトークンのメタJSONを取得する（/ipns/ のパスもゲートウェイで解決する）
+func (gateway *KuboIpfsGateway) Get(ctx context.Context, path string) (*domain.IpfsJSON, error) {
+	body, err := gateway.get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
+	defer body.Close()
+	return decodeIpfsJSON(body)
+}
 
-	respBody, err := io.ReadAll(response.Body)
+// Cat はゲートウェイからコンテンツを取得する
+func (gateway *KuboIpfsGateway) Cat(ctx context.Context, path string) (io.ReadCloser, error) {
+	cid, err := cidFromPath(path)
 	if err != nil {
 		return nil, err
 	}
-	var ipfsJSON domain.IpfsJSON
-	if err := json.Unmarshal(respBody, &ipfsJSON); err != nil {
-		return nil, err
-	}
-
-	return &ipfsJSON, nil
+	return gateway.get(ctx, ipfsPath(cid))
 }
 
-func (gateway *IpfsGateway) Add(ctx context.Context, body *bytes.Buffer, contentType string) (*domain.IpfsAdd, error) {
-	path := os.Getenv("IPFS_HOST") + os.Getenv("IPFS_API_PORT") + "/api/v0/add"
+// URL は公開のゲートウェイで参照するURLを返す
+func (gateway *KuboIpfsGateway) URL(path string) string {
+	return contentURL(gateway.PublicURL, path)
+}
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodPost, path, body)
+// get はゲートウェイからパスの内容を取得する（呼び出し側で閉じる）
+func (gateway *KuboIpfsGateway) get(ctx context.Context, path string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(path, "/") {
+		path = ipfsPath(path)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, gateway.GatewayURL+path, nil)
 	if err != nil {
 		return nil, err
 	}
-
-	request.Header.Set("Content-Type", contentType)
-	request.Header.Set("Content-Disposition", `form-data; name="file"; filename="file"`)
-
-	client := http.Client{}
-
-	response, err := client.Do(request)
+	response, err := gateway.Client.Do(request)
 	if err != nil {
 		return nil, err
 	}
 	if response.StatusCode != http.StatusOK {
-		respBody, _ := io.ReadAll(response.Body)
-		return nil, fmt.Errorf("API returned non-OK status: %s, body: %s", response.Status, string(respBody))
+		defer response.Body.Close()
+		return nil, statusError(response, path)
 	}
+	return response.Body, nil
+}
 
-	respBody, _ := io.ReadAll(response.Body)
+// Add はノードにコンテンツを追加する
+// マルチパートの本文は io.Pipe で組み立てながら送信し、コンテンツ全体をメモリに載せない
+func (gateway *KuboIpfsGateway) Add(ctx context.Context, filename string, content io.Reader) (*domain.IpfsAdd, error) {
+	body, pipe := io.Pipe()
+	writer := multipart.NewWriter(pipe)
+	written := make(chan error, 1)
+	go func() {
+		part, err := writer.CreateFormFile("file", filename)
+		if err == nil {
+			_, err = io.Copy(part, content)
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		pipe.CloseWithError(err)
+		written <- err
+	}()
 
 	var ipfsAdd domain.IpfsAdd
-	if err := json.Unmarshal(respBody, &ipfsAdd); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+	err := gateway.call(ctx, "add", nil, body, writer.FormDataContentType(), &ipfsAdd)
+	// ノードが途中で応答した場合も書き込み側を止める
+	body.CloseWithError(io.ErrClosedPipe)
+	// コンテンツの読み取りのエラー（上限超過・取り消し）はノードのエラーより優先する
+	// 取り消された場合は、クライアントの次のデータを待っている読み取りの終わりを待たない
+	select {
+	case writeErr := <-written:
+		if writeErr != nil && writeErr != io.ErrClosedPipe {
+			return nil, writeErr
+		}
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+	if err != nil {
+		return nil, err
 	}
-
 	return &ipfsAdd, nil
 }
 
-func (gateway *IpfsGateway) Publish(ctx context.Context, cid string) (*domain.IpfsPublish, error) {
-	path := os.Getenv("IPFS_HOST") + os.Getenv("IPFS_API_PORT") + "/api/v0/name/publish"
+// kuboPublish は name/publish の結果
+type kuboPublish struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
 
-	data := url.Values{}
-	data.Set("arg", cid)
+// kuboPins は pin/add の結果
+type kuboPins struct {
+	Pins     []string `json:"Pins"`
+	Progress int      `json:"Progress"`
+}
 
-	respBody, err := apiRequest(ctx, path, data)
-	if err != nil {
+// Pin はノードにコンテンツを固定し、ノードのIPNSの名前で公開してから参照するパスを解決する
+func (gateway *KuboIpfsGateway) Pin(ctx context.Context, cid string) (*domain.IpfsResolve, error) {
+	var pins kuboPins
+	if err := gateway.call(ctx, "pin/add", url.Values{"arg": {cid}}, nil, "", &pins); err != nil {
 		return nil, err
 	}
 
-	var ipfsPublish domain.IpfsPublish
-	if err := json.Unmarshal(respBody, &ipfsPublish); err != nil {
+	var publish kuboPublish
+	if err := gateway.call(ctx, "name/publish", url.Values{"arg": {cid}}, nil, "", &publish); err != nil {
 		return nil, err
 	}
 
-	return &ipfsPublish, nil
+	var resolve domain.IpfsResolve
+	if err := gateway.call(ctx, "name/resolve", url.Values{"arg": {publish.Name}}, nil, "", &resolve); err != nil {
+		return nil, err
+	}
+	return &resolve, nil
 }
 
-func (gateway *IpfsGateway) Localpin(ctx context.Context, cid string) (*domain.IpfsPins, error) {
-	path := os.Getenv("IPFS_HOST") + os.Getenv("IPFS_API_PORT") + "/api/v0/pin/add"
-	data := url.Values{}
-	data.Set("arg", cid)
-
-	respBody, err := apiRequest(ctx, path, data)
+// call は Kubo の HTTP API（POST /api/v0/<command>）を呼び出して結果のJSONを読み取る
+func (gateway *KuboIpfsGateway) call(ctx context.Context, command string, args url.Values, body io.Reader, contentType string, result any) error {
+	path := gateway.APIURL + "/api/v0/" + command
+	if len(args) > 0 {
+		path += "?" + args.Encode()
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, path, body)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
 	}
 
-	var ipfsPins domain.IpfsPins
-	if err := json.Unmarshal(respBody, &ipfsPins); err != nil {
-		return nil, err
+	response, err := gateway.Client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("ipfs %s returned %s: %s", command, response.Status, string(respBody))
 	}
-	return &ipfsPins, nil
+	if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode ipfs %s response: %w", command, err)
+	}
+	return nil
 }
 
-func (gateway *IpfsGateway) Resolve(ctx context.Context, name string) (*domain.IpfsResolve, error) {
-	path := os.Getenv("IPFS_HOST") + os.Getenv("IPFS_API_PORT") + "/api/v0/name/resolve"
-
-	data := url.Values{}
-	data.Set("arg", name)
+// ipfsPath は CID を参照するパス（/ipfs/<cid>）
+func ipfsPath(cid string) string {
+	return ipfsPathPrefix + cid
+}
 
-	respBody, err := apiRequest(ctx, path, data)
-	if err != nil {
-		return nil, err
+// contentURL は参照するパス（/ipfs/<cid> または CID）を公開URLの下のURLにする（パスが空の場合は空）
+func contentURL(publicURL string, path string) string {
+	if path == "" {
+		return ""
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = ipfsPath(path)
 	}
+	return publicURL + path
+}
 
-	var ipfsResolve domain.IpfsResolve
-	if err := json.Unmarshal(respBody, &ipfsResolve); err != nil {
-		return nil, err
+// cidFromPath は /ipfs/<cid> または CID だけのパスから CID を取り出す
+// オブジェクトのキーやファイル名に使うため、英数字以外を含むものは受け付けない
+func cidFromPath(path string) (string, error) {
+	cid := strings.TrimPrefix(path, ipfsPathPrefix)
+	if cid == "" || strings.IndexFunc(cid, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}) >= 0 {
+		return "", fmt.Errorf("BadRequest: IPFSのパス %q が正しくありません", path)
 	}
-	return &ipfsResolve, nil
+	return cid, nil
 }
 
-func apiRequest(ctx context.Context, path string, data url.Values) ([]byte, error) {
-	dataPath := fmt.Sprintf("%s?%s", path, data.Encode())
+// rawCid はコンテンツのsha256から CIDv1（raw・sha2-256・base32）を作る
+// Kubo で --cid-version=1 --raw-leaves で追加した場合、1ブロック（256KiB）以下のファイルは同じ CID になる
+// それより大きいファイルは UnixFS の CID と一致せず、S3 とローカルの保存先はIPFSのネットワークに公開しないため、
+// コンテンツのキーとしてだけ使い、バックエンドの GET /ipfs/:cid から配信する
+func rawCid(digest []byte) string {
+	// version 1 / raw (0x55) / sha2-256 (0x12) / 32バイト
+	prefix := []byte{0x01, 0x55, 0x12, 0x20}
+	encoded := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(append(prefix, digest...))
+	return "b" + strings.ToLower(encoded)
+}
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodPost, dataPath, bytes.NewBuffer([]byte{}))
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	client := http.Client{}
+// hashingReader は読み取った内容の sha256 とサイズを数える
+type hashingReader struct {
+	reader io.Reader
+	hash   hash.Hash
+	size   int64
+}
 
-	response, err := client.Do(request)
-	if err != nil {
-		return nil, err
-	}
+func newHashingReader(reader io.Reader) *hashingReader {
+	return &hashingReader{reader: reader, hash: sha256.New()}
+}
 
-	respBody, err := io.ReadAll(response.Body)
-	if err != nil {
-		return nil, err
-	}
+func (hashing *hashingReader) Read(p []byte) (int, error) {
+	n, err := hashing.reader.Read(p)
+	hashing.hash.Write(p[:n])
+	hashing.size += int64(n)
+	return n, err
+}
 
-	closeErr := response.Body.Close()
-	if closeErr != nil {
-		return nil, closeErr
+// sum は読み取った内容の sha256（最後まで読み取ってから呼び出す）
+func (hashing *hashingReader) sum() []byte {
+	return hashing.hash.Sum(nil)
+}
+
+// cid は読み取った内容の CID（最後まで読み取ってから呼び出す）
+func (hashing *hashingReader) cid() string {
+	return rawCid(hashing.sum())
+}
+
+func decodeIpfsJSON(reader io.Reader) (*domain.IpfsJSON, error) {
+	var ipfsJSON domain.IpfsJSON
+	if err := json.NewDecoder(reader).Decode(&ipfsJSON); err != nil {
+		return nil, fmt.Errorf("failed to decode ipfs json: %w", err)
 	}
+	return &ipfsJSON, nil
+}
 
-	return respBody, nil
+// statusError は取得に失敗したレスポンスのエラー（見つからない場合は404にする）
+func statusError(response *http.Response, path string) error {
+	if response.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("record not found: %s のコンテンツがありません", path)
+	}
+	respBody, _ := io.ReadAll(response.Body)
+	return fmt.Errorf("failed to get %s: %s: %s", path, response.Status, string(respBody))
 }
@@ -0,0 +1,261 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const metaJSON = `{"name":"NFT Name","image":"https://ipfs.io/ipfs/QmImage"}`
+
+func TestRawCid(t *testing.T) {
+	// 空のファイルを Kubo で --cid-version=1 --raw-leaves で追加した場合の CID
+	digest := sha256.Sum256(nil)
+	assert.Equal(t, "bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku", rawCid(digest[:]))
+}
+
+func TestCidFromPath(t *testing.T) {
+	cid, err := cidFromPath("/ipfs/bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku")
+	require.NoError(t, err)
+	assert.Equal(t, "bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku", cid)
+
+	for _, path := range []string{"", "/ipfs/", "/ipfs/../secret", "/ipns/name"} {
+		_, err := cidFromPath(path)
+		assert.ErrorContains(t, err, "BadRequest", path)
+	}
+}
+
+func TestKuboIpfsGateway_Add(t *testing.T) {
+	ctx := context.Background()
+
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/add" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		reader, err := r.MultipartReader()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		part, err := reader.NextPart()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received, err = io.ReadAll(part)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"Name": part.FileName(), "Hash": "QmMetaHash", "Size": "58"})
+	}))
+	defer server.Close()
+	gateway := NewKuboIpfsGateway(server.URL, server.URL, "https://ipfs.io")
+
+	t.Run("正常系: マルチパートの本文を組み立てながら送信する", func(t *testing.T) {
+		added, err := gateway.Add(ctx, "meta.json", strings.NewReader(metaJSON))
+		require.NoError(t, err)
+		assert.Equal(t, "QmMetaHash", added.Hash)
+		assert.Equal(t, "meta.json", added.Name)
+		assert.Equal(t, metaJSON, string(received))
+	})
+
+	t.Run("異常系: コンテンツの読み取りのエラーを返す", func(t *testing.T) {
+		content := io.MultiReader(strings.NewReader(metaJSON), iotest.ErrReader(errors.New("PayloadTooLarge: 上限を超えています")))
+		_, err := gateway.Add(ctx, "meta.json", content)
+		assert.ErrorContains(t, err, "PayloadTooLarge")
+	})
+}
+
+func TestLocalIpfsGateway(t *testing.T) {
+	ctx := context.Background()
+	gateway := NewLocalIpfsGateway(t.TempDir(), "http://localhost:1323/api/v1")
+
+	t.Run("正常系: 追加したメタJSONをCIDで取得できる", func(t *testing.T) {
+		added, err := gateway.Add(ctx, "meta.json", strings.NewReader(metaJSON))
+		require.NoError(t, err)
+		assert.Equal(t, "meta.json", added.Name)
+		assert.True(t, strings.HasPrefix(added.Hash, "bafkrei"))
+		assert.Equal(t, "58", added.Size)
+
+		// 同じ内容は同じ CID になる
+		again, err := gateway.Add(ctx, "other.json", strings.NewReader(metaJSON))
+		require.NoError(t, err)
+		assert.Equal(t, added.Hash, again.Hash)
+
+		resolve, err := gateway.Pin(ctx, added.Hash)
+		require.NoError(t, err)
+		assert.Equal(t, "/ipfs/"+added.Hash, resolve.Path)
+
+		ipfsJSON, err := gateway.Get(ctx, resolve.Path)
+		require.NoError(t, err)
+		assert.Equal(t, "NFT Name", ipfsJSON.Name)
+
+		// バックエンドの GET /ipfs/:cid から配信する
+		assert.Equal(t, "http://localhost:1323/api/v1/ipfs/"+added.Hash, gateway.URL(added.Hash))
+		assert.Equal(t, "http://localhost:1323/api/v1/ipfs/"+added.Hash, gateway.URL(resolve.Path))
+		assert.Empty(t, gateway.URL(""))
+		body, err := gateway.Cat(ctx, added.Hash)
+		require.NoError(t, err)
+		defer body.Close()
+		content, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, metaJSON, string(content))
+	})
+
+	t.Run("異常系: 保存されていないCIDは見つからない", func(t *testing.T) {
+		_, err := gateway.Cat(ctx, "bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku")
+		assert.ErrorContains(t, err, "record not found")
+
+		_, err = gateway.Cat(ctx, "../meta.json")
+		assert.Error(t, err)
+
+		_, err = gateway.Get(ctx, "/ipfs/bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku")
+		assert.ErrorContains(t, err, "record not found")
+
+		_, err = gateway.Pin(ctx, "bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku")
+		assert.Error(t, err)
+	})
+}
+
+func TestS3IpfsGateway(t *testing.T) {
+	ctx := context.Background()
+
+	// fakeS3 はパス形式のバケットとオブジェクトを保存し、署名の形式とペイロードのハッシュを確認する
+	var mu sync.Mutex
+	buckets := map[string]bool{}
+	objects := map[string][]byte{}
+	contentTypes := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		authorization := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=minioadmin/") || !strings.Contains(authorization, "/us-east-1/s3/aws4_request") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		digest := sha256.Sum256(body)
+		if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(digest[:]) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		switch {
+		case key == "" && r.Method == http.MethodHead:
+			if !buckets[bucket] {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case key == "" && r.Method == http.MethodPut:
+			buckets[bucket] = true
+		case !buckets[bucket]:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPut:
+			objects[key] = body
+			contentTypes[key] = r.Header.Get("Content-Type")
+		case objects[key] == nil:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet:
+			w.Write(objects[key])
+		}
+	}))
+	defer server.Close()
+
+	gateway := NewS3IpfsGateway(server.URL, "", "nft-music", "contents/", "minioadmin", "minioadmin", "http://localhost:1323/api/v1")
+	require.NoError(t, gateway.EnsureBucket(ctx))
+	require.NoError(t, gateway.EnsureBucket(ctx))
+
+	t.Run("正常系: CIDをキーにして保存し取得できる", func(t *testing.T) {
+		added, err := gateway.Add(ctx, "meta.json", strings.NewReader(metaJSON))
+		require.NoError(t, err)
+		assert.Equal(t, rawCid(sha256Bytes(metaJSON)), added.Hash)
+		assert.Equal(t, []byte(metaJSON), objects["contents/"+added.Hash])
+		assert.Equal(t, "application/json", contentTypes["contents/"+added.Hash])
+
+		resolve, err := gateway.Pin(ctx, added.Hash)
+		require.NoError(t, err)
+		assert.Equal(t, "/ipfs/"+added.Hash, resolve.Path)
+
+		ipfsJSON, err := gateway.Get(ctx, resolve.Path)
+		require.NoError(t, err)
+		assert.Equal(t, "NFT Name", ipfsJSON.Name)
+
+		assert.Equal(t, "http://localhost:1323/api/v1/ipfs/"+added.Hash, gateway.URL(added.Hash))
+		body, err := gateway.Cat(ctx, added.Hash)
+		require.NoError(t, err)
+		defer body.Close()
+		content, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, metaJSON, string(content))
+	})
+
+	t.Run("異常系: 保存されていないCIDは見つからない", func(t *testing.T) {
+		_, err := gateway.Cat(ctx, "bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku")
+		assert.ErrorContains(t, err, "record not found")
+
+		_, err = gateway.Get(ctx, "bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku")
+		assert.ErrorContains(t, err, "record not found")
+
+		_, err = gateway.Pin(ctx, "bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku")
+		assert.ErrorContains(t, err, "record not found")
+	})
+}
+
+func TestPinningIpfsGateway(t *testing.T) {
+	ctx := context.Background()
+
+	var requested pinRequest
+	status := pinStatusQueued
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pins" || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&requested)
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(pinStatus{RequestID: "request-1", Status: status})
+	}))
+	defer server.Close()
+
+	gateway := NewPinningIpfsGateway(NewKuboIpfsGateway("http://ipfs:5001", "http://ipfs:8080", "https://ipfs.io"), server.URL+"/", "token")
+
+	t.Run("正常系: 依頼が受け付けられたら参照するパスを返す", func(t *testing.T) {
+		resolve, err := gateway.Pin(ctx, "QmMetaHash")
+		require.NoError(t, err)
+		assert.Equal(t, "/ipfs/QmMetaHash", resolve.Path)
+		assert.Equal(t, "QmMetaHash", requested.Cid)
+	})
+
+	t.Run("異常系: ピン留めに失敗した", func(t *testing.T) {
+		status = pinStatusFailed
+		_, err := gateway.Pin(ctx, "QmMetaHash")
+		assert.ErrorContains(t, err, "failed to pin QmMetaHash")
+	})
+
+	t.Run("異常系: トークンが正しくない", func(t *testing.T) {
+		gateway := NewPinningIpfsGateway(gateway.KuboIpfsGateway, server.URL, "wrong")
+		_, err := gateway.Pin(ctx, "QmMetaHash")
+		assert.ErrorContains(t, err, "401")
+	})
+}
+
+func sha256Bytes(content string) []byte {
+	digest := sha256.Sum256([]byte(content))
+	return digest[:]
+}
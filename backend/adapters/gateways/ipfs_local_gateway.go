@@ -0,0 +1,95 @@
+// Package gatewaysは、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"nft-music/domain"
+)
+
+// LocalIpfsGateway はコンテンツを CID をファイル名にしてローカルのディレクトリに保存する（開発・テスト用）
+// CID は内容の sha256 から作るため、同じ内容は同じファイルになる
+// IPFSのネットワークには公開しないため、コンテンツはバックエンドの GET /ipfs/:cid から配信する
+type LocalIpfsGateway struct {
+	Dir       string
+	PublicURL string // バックエンドのAPIの公開URL（http://localhost:1323/api/v1）
+}
+
+func NewLocalIpfsGateway(dir string, publicURL string) *LocalIpfsGateway {
+	return &LocalIpfsGateway{Dir: dir, PublicURL: strings.TrimRight(publicURL, "/")}
+}
+
+func (gateway *LocalIpfsGateway) Get(ctx context.Context, path string) (*domain.IpfsJSON, error) {
+	file, err := gateway.Cat(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return decodeIpfsJSON(file)
+}
+
+func (gateway *LocalIpfsGateway) Cat(ctx context.Context, path string) (io.ReadCloser, error) {
+	cid, err := cidFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filepath.Join(gateway.Dir, cid))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("record not found: %s のコンテンツがありません", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (gateway *LocalIpfsGateway) URL(path string) string {
+	return contentURL(gateway.PublicURL, path)
+}
+
+// Add は一時ファイルに書き込みながら CID を計算し、書き終えてから CID のファイル名に変える
+func (gateway *LocalIpfsGateway) Add(ctx context.Context, filename string, content io.Reader) (*domain.IpfsAdd, error) {
+	if err := os.MkdirAll(gateway.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	temp, err := os.CreateTemp(gateway.Dir, ".add-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(temp.Name())
+
+	hashing := newHashingReader(content)
+	if _, err := io.Copy(temp, hashing); err != nil {
+		temp.Close()
+		return nil, err
+	}
+	if err := temp.Close(); err != nil {
+		return nil, err
+	}
+
+	cid := hashing.cid()
+	if err := os.Rename(temp.Name(), filepath.Join(gateway.Dir, cid)); err != nil {
+		return nil, err
+	}
+	return &domain.IpfsAdd{Name: filename, Hash: cid, Size: strconv.FormatInt(hashing.size, 10)}, nil
+}
+
+// Pin はファイルが消えないため、保存されていることだけを確認する
+func (gateway *LocalIpfsGateway) Pin(ctx context.Context, cid string) (*domain.IpfsResolve, error) {
+	cid, err := cidFromPath(cid)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(gateway.Dir, cid)); err != nil {
+		return nil, err
+	}
+	return &domain.IpfsResolve{Path: ipfsPath(cid)}, nil
+}
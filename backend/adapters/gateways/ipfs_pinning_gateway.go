@@ -0,0 +1,90 @@
+// Package gatewaysは、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"nft-music/domain"
+)
+
+// ピン留めのステータス（IPFS Pinning Service API）
+const (
+	pinStatusQueued  = "queued"
+	pinStatusPinning = "pinning"
+	pinStatusPinned  = "pinned"
+	pinStatusFailed  = "failed"
+)
+
+// PinningIpfsGateway はコンテンツを Kubo ノードに追加し、IPFS Pinning Service API のリモートのサービスで固定する
+// Pinning Service API にはアップロードが無いため、追加と取得はノードで行い、サービスはノードからコンテンツを取得して固定する
+type PinningIpfsGateway struct {
+	*KuboIpfsGateway
+	ServiceURL string // https://api.pinata.cloud/psa など（/pins は付けない）
+	Token      string // Authorization: Bearer に付けるアクセストークン
+}
+
+func NewPinningIpfsGateway(kubo *KuboIpfsGateway, serviceURL string, token string) *PinningIpfsGateway {
+	return &PinningIpfsGateway{
+		KuboIpfsGateway: kubo,
+		ServiceURL:      strings.TrimRight(serviceURL, "/"),
+		Token:           token,
+	}
+}
+
+// pinRequest は POST /pins で送るピン留めの依頼
+type pinRequest struct {
+	Cid  string `json:"cid"`
+	Name string `json:"name,omitempty"`
+}
+
+// pinStatus はピン留めの依頼の状態
+type pinStatus struct {
+	RequestID string            `json:"requestid"`
+	Status    string            `json:"status"`
+	Info      map[string]string `json:"info,omitempty"`
+}
+
+// Pin はリモートのサービスにピン留めを依頼する
+// サービスがノードからコンテンツを取得するまで時間がかかるため、依頼が受け付けられた時点（queued / pinning）で参照するパスを返す
+func (gateway *PinningIpfsGateway) Pin(ctx context.Context, cid string) (*domain.IpfsResolve, error) {
+	payload, err := json.Marshal(pinRequest{Cid: cid, Name: cid})
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, gateway.ServiceURL+"/pins", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if gateway.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+gateway.Token)
+	}
+
+	response, err := gateway.Client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusAccepted && response.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(response.Body)
+		return nil, fmt.Errorf("pinning service returned %s: %s", response.Status, string(respBody))
+	}
+
+	var status pinStatus
+	if err := json.NewDecoder(response.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("failed to decode pin status: %w", err)
+	}
+	switch status.Status {
+	case pinStatusQueued, pinStatusPinning, pinStatusPinned:
+		return &domain.IpfsResolve{Path: ipfsPath(cid)}, nil
+	case pinStatusFailed:
+		return nil, fmt.Errorf("pinning service failed to pin %s (request %s): %v", cid, status.RequestID, status.Info)
+	}
+	return nil, fmt.Errorf("pinning service returned unknown status %q for %s", status.Status, cid)
+}
@@ -0,0 +1,221 @@
+// Package gatewaysは、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"nft-music/domain"
+)
+
+// emptyPayloadHash は本文の無いリクエストの sha256
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3IpfsGateway はコンテンツを CID をキーにして S3 互換のオブジェクトストレージ（ローカルでは MinIO）に保存する
+// バケットはパス形式（<endpoint>/<bucket>/<key>）で指定し、リクエストには署名バージョン4で署名する
+// IPFSのネットワークには公開しないため、コンテンツはバックエンドの GET /ipfs/:cid から配信する
+type S3IpfsGateway struct {
+	Endpoint        string // http://minio:9000
+	Region          string
+	Bucket          string
+	Prefix          string // オブジェクトのキーの接頭辞
+	AccessKeyID     string
+	SecretAccessKey string
+	PublicURL       string // バックエンドのAPIの公開URL（http://localhost:1323/api/v1）
+	Client          *http.Client
+}
+
+func NewS3IpfsGateway(endpoint string, region string, bucket string, prefix string, accessKeyID string, secretAccessKey string, publicURL string) *S3IpfsGateway {
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &S3IpfsGateway{
+		Endpoint:        strings.TrimRight(endpoint, "/"),
+		Region:          region,
+		Bucket:          bucket,
+		Prefix:          prefix,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		PublicURL:       strings.TrimRight(publicURL, "/"),
+		Client:          &http.Client{},
+	}
+}
+
+// EnsureBucket はバケットが無ければ作成する（MinIO を起動した直後の開発環境向け）
+func (gateway *S3IpfsGateway) EnsureBucket(ctx context.Context) error {
+	response, err := gateway.do(ctx, http.MethodHead, "/"+gateway.Bucket, nil, 0, emptyPayloadHash, "")
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	if response.StatusCode == http.StatusOK {
+		return nil
+	}
+	if response.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to check bucket %s: %s", gateway.Bucket, response.Status)
+	}
+
+	var body []byte
+	if gateway.Region != "us-east-1" {
+		body = []byte("<CreateBucketConfiguration><LocationConstraint>" + gateway.Region + "</LocationConstraint></CreateBucketConfiguration>")
+	}
+	digest := sha256.Sum256(body)
+	response, err = gateway.do(ctx, http.MethodPut, "/"+gateway.Bucket, bytes.NewReader(body), int64(len(body)), hex.EncodeToString(digest[:]), "")
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("failed to create bucket %s: %s: %s", gateway.Bucket, response.Status, string(respBody))
+	}
+	return nil
+}
+
+func (gateway *S3IpfsGateway) Get(ctx context.Context, path string) (*domain.IpfsJSON, error) {
+	body, err := gateway.Cat(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return decodeIpfsJSON(body)
+}
+
+func (gateway *S3IpfsGateway) Cat(ctx context.Context, path string) (io.ReadCloser, error) {
+	cid, err := cidFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	response, err := gateway.do(ctx, http.MethodGet, gateway.objectPath(cid), nil, 0, emptyPayloadHash, "")
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, statusError(response, path)
+	}
+	return response.Body, nil
+}
+
+func (gateway *S3IpfsGateway) URL(path string) string {
+	return contentURL(gateway.PublicURL, path)
+}
+
+// Add は一時ファイルに書き込みながら CID を計算してから、CID をキーにしてアップロードする
+// 署名に使う本文の sha256 は CID の計算と同じ値を使う
+func (gateway *S3IpfsGateway) Add(ctx context.Context, filename string, content io.Reader) (*domain.IpfsAdd, error) {
+	temp, err := os.CreateTemp("", "s3-add-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(temp.Name())
+	defer temp.Close()
+
+	hashing := newHashingReader(content)
+	if _, err := io.Copy(temp, hashing); err != nil {
+		return nil, err
+	}
+	if _, err := temp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	cid := hashing.cid()
+	contentType := mime.TypeByExtension(filepath.Ext(filename))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	response, err := gateway.do(ctx, http.MethodPut, gateway.objectPath(cid), temp, hashing.size, hex.EncodeToString(hashing.sum()), contentType)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(response.Body)
+		return nil, fmt.Errorf("failed to put %s: %s: %s", cid, response.Status, string(respBody))
+	}
+	return &domain.IpfsAdd{Name: filename, Hash: cid, Size: strconv.FormatInt(hashing.size, 10)}, nil
+}
+
+// Pin はオブジェクトが消えないため、保存されていることだけを確認する
+func (gateway *S3IpfsGateway) Pin(ctx context.Context, cid string) (*domain.IpfsResolve, error) {
+	cid, err := cidFromPath(cid)
+	if err != nil {
+		return nil, err
+	}
+	response, err := gateway.do(ctx, http.MethodHead, gateway.objectPath(cid), nil, 0, emptyPayloadHash, "")
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, statusError(response, ipfsPath(cid))
+	}
+	return &domain.IpfsResolve{Path: ipfsPath(cid)}, nil
+}
+
+func (gateway *S3IpfsGateway) objectPath(cid string) string {
+	return "/" + gateway.Bucket + "/" + gateway.Prefix + cid
+}
+
+// do は署名したリクエストを送信する
+func (gateway *S3IpfsGateway) do(ctx context.Context, method string, path string, body io.Reader, size int64, payloadHash string, contentType string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, gateway.Endpoint+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		request.ContentLength = size
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	gateway.sign(request, payloadHash, time.Now().UTC())
+	return gateway.Client.Do(request)
+}
+
+// sign は AWS 署名バージョン4 の Authorization ヘッダーを付ける（host と x-amz-* だけに署名する）
+func (gateway *S3IpfsGateway) sign(request *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		"host:" + request.URL.Host + "\n" + "x-amz-content-sha256:" + payloadHash + "\n" + "x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+	scope := date + "/" + gateway.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hashed[:])
+
+	key := hmacSHA256([]byte("AWS4"+gateway.SecretAccessKey), date)
+	key = hmacSHA256(key, gateway.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", gateway.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,93 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+	"errors"
+
+	"nft-music/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MarketEventGateway はチェーンから取り込んだイベントのリポジトリ
+type MarketEventGateway struct {
+	Database *gorm.DB
+}
+
+func NewMarketEventGateway(db *gorm.DB) *MarketEventGateway {
+	return &MarketEventGateway{Database: db}
+}
+
+// GetCheckpoint はコントラクトのチェックポイントを取得する（まだ無い場合は nil を返す）
+func (gateway *MarketEventGateway) GetCheckpoint(ctx context.Context, chainID int, contractAddress string) (*domain.IndexerCheckpoint, error) {
+	var checkpoint domain.IndexerCheckpoint
+	err := gateway.Database.WithContext(ctx).
+		Where("chain_id = ? AND contract_address = ?", chainID, contractAddress).
+		First(&checkpoint).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// Save はブロック範囲のイベントとチェックポイントを1つのトランザクションで保存する
+// 同じログを再度取り込んだ場合は無視する
+func (gateway *MarketEventGateway) Save(ctx context.Context, events []domain.MarketEvent, transfers []domain.TokenTransfer, checkpoint *domain.IndexerCheckpoint) error {
+	return gateway.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(events) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&events).Error; err != nil {
+				return err
+			}
+		}
+		if len(transfers) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&transfers).Error; err != nil {
+				return err
+			}
+		}
+		return saveCheckpoint(tx, checkpoint)
+	})
+}
+
+// Rollback はチェックポイントより後のブロックのイベントを削除し、チェックポイントを戻す
+func (gateway *MarketEventGateway) Rollback(ctx context.Context, checkpoint *domain.IndexerCheckpoint) error {
+	return gateway.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		where := "chain_id = ? AND contract_address = ? AND block_number > ?"
+		if err := tx.Where(where, checkpoint.ChainID, checkpoint.ContractAddress, checkpoint.BlockNumber).Delete(&domain.MarketEvent{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where(where, checkpoint.ChainID, checkpoint.ContractAddress, checkpoint.BlockNumber).Delete(&domain.TokenTransfer{}).Error; err != nil {
+			return err
+		}
+		return saveCheckpoint(tx, checkpoint)
+	})
+}
+
+// ListOwners は取り込んだTransferから、トークンIDごとの最後の移転先を取得する
+func (gateway *MarketEventGateway) ListOwners(ctx context.Context, chainID int, contractAddress string) (map[string]string, error) {
+	var transfers []domain.TokenTransfer
+	if err := gateway.Database.WithContext(ctx).
+		Select("token_id", "to_address").
+		Where("chain_id = ? AND contract_address = ?", chainID, contractAddress).
+		Order("block_number ASC, log_index ASC").
+		Find(&transfers).Error; err != nil {
+		return nil, err
+	}
+
+	owners := make(map[string]string)
+	for _, transfer := range transfers {
+		owners[transfer.TokenID] = transfer.ToAddress
+	}
+	return owners, nil
+}
+
+func saveCheckpoint(tx *gorm.DB, checkpoint *domain.IndexerCheckpoint) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "contract_address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"block_number", "block_hash", "updated_at"}),
+	}).Create(checkpoint).Error
+}
@@ -0,0 +1,44 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MediaGateway はアップロードしたファイルの記録のリポジトリ
+type MediaGateway struct {
+	Database *gorm.DB
+}
+
+func NewMediaGateway(db *gorm.DB) *MediaGateway {
+	return &MediaGateway{Database: db}
+}
+
+// Create はファイルを記録する
+// 同じ内容のファイル（同じCID）は既に記録したものを残す
+func (gateway *MediaGateway) Create(ctx context.Context, media *domain.MediaFile) error {
+	return gateway.Database.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(media).Error
+}
+
+// GetByCid はCIDのファイルの記録を取得する
+func (gateway *MediaGateway) GetByCid(ctx context.Context, cid string) (*domain.MediaFile, error) {
+	var media domain.MediaFile
+	if err := gateway.Database.WithContext(ctx).First(&media, "cid = ?", cid).Error; err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// GetBySha256 は SHA-256 が一致するファイルのうち最初に記録したものを取得する
+func (gateway *MediaGateway) GetBySha256(ctx context.Context, sha256 string) (*domain.MediaFile, error) {
+	var media domain.MediaFile
+	if err := gateway.Database.WithContext(ctx).Order("created_at ASC").First(&media, "sha256 = ?", sha256).Error; err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
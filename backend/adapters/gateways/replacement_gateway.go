@@ -0,0 +1,39 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+
+	"gorm.io/gorm"
+)
+
+// ReplacementGateway は詰まったトランザクションの置き換えのリポジトリ
+type ReplacementGateway struct {
+	Database *gorm.DB
+}
+
+func NewReplacementGateway(db *gorm.DB) *ReplacementGateway {
+	return &ReplacementGateway{Database: db}
+}
+
+func (gateway *ReplacementGateway) Create(ctx context.Context, replacement *domain.TransactionReplacement) error {
+	return gateway.Database.WithContext(ctx).Create(replacement).Error
+}
+
+// ListByTransaction はミントジョブの置き換えを古い順に取得する
+func (gateway *ReplacementGateway) ListByTransaction(ctx context.Context, transactionID string) ([]*domain.TransactionReplacement, error) {
+	var replacements []*domain.TransactionReplacement
+	if err := gateway.Database.WithContext(ctx).
+		Where("transaction_id = ?", transactionID).
+		Order("created_at ASC").
+		Find(&replacements).Error; err != nil {
+		return nil, err
+	}
+	return replacements, nil
+}
+
+func (gateway *ReplacementGateway) Update(ctx context.Context, replacement *domain.TransactionReplacement) error {
+	return gateway.Database.WithContext(ctx).Save(replacement).Error
+}
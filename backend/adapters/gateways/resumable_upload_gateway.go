@@ -0,0 +1,170 @@
+// Package gatewaysは、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"nft-music/domain"
+
+	"github.com/google/uuid"
+)
+
+// ResumableUploadGateway は tus プロトコルで分割して送信中のファイルをローカルのディレクトリに保存する
+// <id>.info に記録（JSON）を、<id>.bin に受信した内容を置く
+type ResumableUploadGateway struct {
+	Dir string
+}
+
+func NewResumableUploadGateway(dir string) *ResumableUploadGateway {
+	return &ResumableUploadGateway{Dir: dir}
+}
+
+func (gateway *ResumableUploadGateway) Create(ctx context.Context, upload *domain.ResumableUpload) error {
+	if err := os.MkdirAll(gateway.Dir, 0o755); err != nil {
+		return err
+	}
+	data, err := os.OpenFile(gateway.dataPath(upload.ID), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := data.Close(); err != nil {
+		return err
+	}
+	return gateway.Update(ctx, upload)
+}
+
+func (gateway *ResumableUploadGateway) Get(ctx context.Context, id uuid.UUID) (*domain.ResumableUpload, error) {
+	info, err := os.ReadFile(gateway.infoPath(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("record not found: アップロード %s がありません", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var upload domain.ResumableUpload
+	if err := json.Unmarshal(info, &upload); err != nil {
+		return nil, fmt.Errorf("failed to decode upload %s: %w", id, err)
+	}
+	return &upload, nil
+}
+
+// Update は記録を一時ファイルに書いてから置き換える（書き込み中に落ちても前の記録が残る）
+func (gateway *ResumableUploadGateway) Update(ctx context.Context, upload *domain.ResumableUpload) error {
+	info, err := json.Marshal(upload)
+	if err != nil {
+		return err
+	}
+	temp, err := os.CreateTemp(gateway.Dir, ".info-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(info); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), gateway.infoPath(upload.ID))
+}
+
+// Append は offset より後ろを切り詰めてから書き込む
+// 前回の書き込みの後に記録を更新できなかった場合も、記録の Offset から書き直せる
+func (gateway *ResumableUploadGateway) Append(ctx context.Context, id uuid.UUID, offset int64, content io.Reader) (int64, error) {
+	data, err := os.OpenFile(gateway.dataPath(id), os.O_WRONLY, 0o644)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, fmt.Errorf("record not found: アップロード %s がありません", id)
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer data.Close()
+	if err := data.Truncate(offset); err != nil {
+		return 0, err
+	}
+	if _, err := data.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	written, err := io.Copy(data, content)
+	if err != nil {
+		return written, err
+	}
+	return written, data.Sync()
+}
+
+func (gateway *ResumableUploadGateway) Open(ctx context.Context, id uuid.UUID) (io.ReadCloser, error) {
+	data, err := os.Open(gateway.dataPath(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("record not found: アップロード %s がありません", id)
+	}
+	return data, err
+}
+
+func (gateway *ResumableUploadGateway) RemoveData(ctx context.Context, id uuid.UUID) error {
+	if err := os.Remove(gateway.dataPath(id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (gateway *ResumableUploadGateway) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := gateway.RemoveData(ctx, id); err != nil {
+		return err
+	}
+	if err := os.Remove(gateway.infoPath(id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (gateway *ResumableUploadGateway) ListExpired(ctx context.Context, now time.Time) ([]*domain.ResumableUpload, error) {
+	entries, err := os.ReadDir(gateway.Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []*domain.ResumableUpload
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".info")
+		if !ok {
+			continue
+		}
+		id, err := uuid.Parse(name)
+		if err != nil {
+			continue
+		}
+		upload, err := gateway.Get(ctx, id)
+		if _, statErr := os.Stat(gateway.infoPath(id)); errors.Is(statErr, fs.ErrNotExist) {
+			// 一覧を読んだ後に削除された
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if upload.ExpiresAt.Before(now) {
+			expired = append(expired, upload)
+		}
+	}
+	return expired, nil
+}
+
+func (gateway *ResumableUploadGateway) infoPath(id uuid.UUID) string {
+	return filepath.Join(gateway.Dir, id.String()+".info")
+}
+
+func (gateway *ResumableUploadGateway) dataPath(id uuid.UUID) string {
+	return filepath.Join(gateway.Dir, id.String()+".bin")
+}
@@ -0,0 +1,86 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"nft-music/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumableUploadGateway(t *testing.T) {
+	ctx := context.Background()
+	gateway := NewResumableUploadGateway(t.TempDir())
+
+	upload := &domain.ResumableUpload{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		Filename:  "master.flac",
+		Size:      8,
+		Metadata:  map[string]string{"filename": "master.flac"},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, gateway.Create(ctx, upload))
+
+	t.Run("正常系: 途中で切れた書き込みを記録の位置から書き直せる", func(t *testing.T) {
+		written, err := gateway.Append(ctx, upload.ID, 0, io.MultiReader(strings.NewReader("tes"), iotest.ErrReader(io.ErrUnexpectedEOF)))
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.Equal(t, int64(3), written)
+
+		// 記録を更新する前に落ちた場合は、記録の Offset（0）から書き直す
+		written, err = gateway.Append(ctx, upload.ID, 0, strings.NewReader("test"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), written)
+		upload.Offset = 4
+		require.NoError(t, gateway.Update(ctx, upload))
+
+		_, err = gateway.Append(ctx, upload.ID, 4, strings.NewReader("data"))
+		require.NoError(t, err)
+
+		data, err := gateway.Open(ctx, upload.ID)
+		require.NoError(t, err)
+		defer data.Close()
+		content, err := io.ReadAll(data)
+		require.NoError(t, err)
+		assert.Equal(t, "testdata", string(content))
+
+		got, err := gateway.Get(ctx, upload.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), got.Offset)
+		assert.Equal(t, "master.flac", got.Metadata["filename"])
+	})
+
+	t.Run("正常系: 完了後は受信した内容だけを削除して記録を残す", func(t *testing.T) {
+		require.NoError(t, gateway.RemoveData(ctx, upload.ID))
+		_, err := gateway.Open(ctx, upload.ID)
+		assert.ErrorContains(t, err, "record not found")
+		_, err = gateway.Get(ctx, upload.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("正常系: 期限切れのアップロードを一覧して削除する", func(t *testing.T) {
+		expired := &domain.ResumableUpload{ID: uuid.New(), Size: 4, ExpiresAt: time.Now().Add(-time.Minute)}
+		require.NoError(t, gateway.Create(ctx, expired))
+
+		uploads, err := gateway.ListExpired(ctx, time.Now())
+		require.NoError(t, err)
+		require.Len(t, uploads, 1)
+		assert.Equal(t, expired.ID, uploads[0].ID)
+
+		require.NoError(t, gateway.Delete(ctx, expired.ID))
+		_, err = gateway.Get(ctx, expired.ID)
+		assert.ErrorContains(t, err, "record not found")
+		entries, err := os.ReadDir(gateway.Dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1) // 完了した upload の記録だけが残る
+	})
+}
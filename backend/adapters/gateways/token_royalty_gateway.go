@@ -0,0 +1,48 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TokenRoyaltyGateway はトークンごとのロイヤリティのリポジトリ
+type TokenRoyaltyGateway struct {
+	Database *gorm.DB
+}
+
+func NewTokenRoyaltyGateway(db *gorm.DB) *TokenRoyaltyGateway {
+	return &TokenRoyaltyGateway{Database: db}
+}
+
+// Get はトークンのロイヤリティを取得する（設定が無い場合は nil を返す）
+func (gateway *TokenRoyaltyGateway) Get(ctx context.Context, chainID int, contractAddress string, tokenID string) (*domain.TokenRoyalty, error) {
+	var royalties []domain.TokenRoyalty
+	err := gateway.Database.WithContext(ctx).
+		Where("chain_id = ? AND contract_address = ? AND token_id = ?", chainID, contractAddress, tokenID).
+		Limit(1).
+		Find(&royalties).Error
+	if err != nil || len(royalties) == 0 {
+		return nil, err
+	}
+	return &royalties[0], nil
+}
+
+// Save はトークンのロイヤリティを登録し、既に設定がある場合は上書きする
+func (gateway *TokenRoyaltyGateway) Save(ctx context.Context, royalty *domain.TokenRoyalty) error {
+	return gateway.Database.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "contract_address"}, {Name: "token_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"royalty_bps", "receiver", "user_id", "updated_at"}),
+	}).Create(royalty).Error
+}
+
+// Delete はトークンのロイヤリティを削除してコレクションの既定値に戻す
+func (gateway *TokenRoyaltyGateway) Delete(ctx context.Context, chainID int, contractAddress string, tokenID string) error {
+	return gateway.Database.WithContext(ctx).
+		Where("chain_id = ? AND contract_address = ? AND token_id = ?", chainID, contractAddress, tokenID).
+		Delete(&domain.TokenRoyalty{}).Error
+}
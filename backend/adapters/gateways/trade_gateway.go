@@ -0,0 +1,48 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TradeGateway は二次流通の取引のリポジトリ
+type TradeGateway struct {
+	Database *gorm.DB
+}
+
+func NewTradeGateway(db *gorm.DB) *TradeGateway {
+	return &TradeGateway{Database: db}
+}
+
+func (gateway *TradeGateway) Create(ctx context.Context, trade *domain.Trade) error {
+	return gateway.Database.WithContext(ctx).Create(trade).Error
+}
+
+func (gateway *TradeGateway) Get(ctx context.Context, id uuid.UUID) (*domain.Trade, error) {
+	var trade domain.Trade
+	if err := gateway.Database.WithContext(ctx).First(&trade, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &trade, nil
+}
+
+// ListByStatus は指定したステータスの取引を古い順に取得する
+func (gateway *TradeGateway) ListByStatus(ctx context.Context, statuses ...string) ([]*domain.Trade, error) {
+	var trades []*domain.Trade
+	if err := gateway.Database.WithContext(ctx).
+		Where("status IN ?", statuses).
+		Order("created_at ASC").
+		Find(&trades).Error; err != nil {
+		return nil, err
+	}
+	return trades, nil
+}
+
+func (gateway *TradeGateway) Update(ctx context.Context, trade *domain.Trade) error {
+	return gateway.Database.WithContext(ctx).Save(trade).Error
+}
@@ -47,7 +47,7 @@ func (gateway *TransactionGateway) ListByWallet(ctx context.Context, wallet stri
 	return transactions, nil
 }
 
-func (gateway *TransactionGateway) Search(ctx context.Context, genre string, minPrice int, maxPrice int, sort string) ([]*domain.Transaction, error) {
+func (gateway *TransactionGateway) Search(ctx context.Context, genre string, minPrice domain.Amount, maxPrice domain.Amount, sort string) ([]*domain.Transaction, error) {
 	var transactions []*domain.Transaction
 
 	db := gateway.Database.WithContext(ctx)
@@ -56,11 +56,11 @@ func (gateway *TransactionGateway) Search(ctx context.Context, genre string, min
 		db = db.Where("transactions.genre_id = ?", genre)
 	}
 
-	if minPrice > 0 {
+	if minPrice.Sign() > 0 {
 		db = db.Where("transactions.price >= ?", minPrice)
 	}
 
-	if maxPrice > 0 {
+	if maxPrice.Sign() > 0 {
 		db = db.Where("transactions.price <= ?", maxPrice)
 	}
 
@@ -93,6 +93,48 @@ func (gateway *TransactionGateway) GetByTransactionid(ctx context.Context, trans
 	return &transaction, nil
 }
 
+// GetByToken はチェーン・コントラクト・トークンIDでミントしたトランザクションを取得する
+func (gateway *TransactionGateway) GetByToken(ctx context.Context, chainID int, contractAddress string, tokenID string) (*domain.Transaction, error) {
+	var transaction domain.Transaction
+	if err := gateway.Database.WithContext(ctx).
+		Table("transactions").
+		Select("transactions.*, genre_masters.name as genre_name").
+		Joins("LEFT JOIN genre_masters ON transactions.genre_id = genre_masters.id").
+		Where("transactions.chain_id = ? AND transactions.contract_address = ? AND transactions.token_id = ?", chainID, contractAddress, tokenID).
+		First(&transaction).Error; err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// ListByStatus は指定したステータスのトランザクションを古い順に取得する
+func (gateway *TransactionGateway) ListByStatus(ctx context.Context, statuses ...string) ([]*domain.Transaction, error) {
+	var transactions []*domain.Transaction
+	if err := gateway.Database.WithContext(ctx).
+		Where("status IN ?", statuses).
+		Order("created_at ASC").
+		Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// ListByContract はコントラクトでミントしたトランザクションを古い順に取得する
+func (gateway *TransactionGateway) ListByContract(ctx context.Context, chainID int, contractAddress string) ([]*domain.Transaction, error) {
+	var transactions []*domain.Transaction
+	if err := gateway.Database.WithContext(ctx).
+		Where("chain_id = ? AND contract_address = ?", chainID, contractAddress).
+		Order("created_at ASC").
+		Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
 func (gateway *TransactionGateway) Create(ctx context.Context, transaction *domain.Transaction) error {
 	return gateway.Database.WithContext(ctx).Create(&transaction).Error
 }
+
+func (gateway *TransactionGateway) Update(ctx context.Context, transaction *domain.Transaction) error {
+	return gateway.Database.WithContext(ctx).Save(transaction).Error
+}
@@ -3,8 +3,10 @@ package gateways
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
+	"math/big"
 	"testing"
 	"time"
 
@@ -64,9 +66,9 @@ func seedData() {
 	db.Create(&domain.GenreMaster{ID: genreID2, Name: "Pop", CreatedAt: now, UpdatedAt: now})
 
 	// --- Seed Transactions ---
-	db.Create(&domain.Transaction{ID: "tx1", UserID: userID1, TokenURL: fmt.Sprintf("/ipfs/%s", ipfsID1), GenreID: genreID1, Price: 100, CreatedAt: now.Add(-time.Hour * 2), UpdatedAt: now})
-	db.Create(&domain.Transaction{ID: "tx2", UserID: userID2, TokenURL: fmt.Sprintf("/ipfs/%s", ipfsID2), GenreID: genreID2, Price: 200, CreatedAt: now.Add(-time.Hour * 1), UpdatedAt: now})
-	db.Create(&domain.Transaction{ID: "tx3", UserID: userID1, TokenURL: fmt.Sprintf("/ipfs/%s", ipfsID3), GenreID: genreID1, Price: 150, CreatedAt: now, UpdatedAt: now})
+	db.Create(&domain.Transaction{ID: "tx1", UserID: userID1, TokenURL: fmt.Sprintf("/ipfs/%s", ipfsID1), GenreID: genreID1, Price: domain.NewAmount(big.NewInt(100)), CreatedAt: now.Add(-time.Hour * 2), UpdatedAt: now})
+	db.Create(&domain.Transaction{ID: "tx2", UserID: userID2, TokenURL: fmt.Sprintf("/ipfs/%s", ipfsID2), GenreID: genreID2, Price: domain.NewAmount(big.NewInt(200)), CreatedAt: now.Add(-time.Hour * 1), UpdatedAt: now})
+	db.Create(&domain.Transaction{ID: "tx3", UserID: userID1, TokenURL: fmt.Sprintf("/ipfs/%s", ipfsID3), GenreID: genreID1, Price: domain.NewAmount(big.NewInt(150)), CreatedAt: now, UpdatedAt: now})
 }
 
 func TestTransactionGateway_Search(t *testing.T) {
@@ -77,33 +79,33 @@ func TestTransactionGateway_Search(t *testing.T) {
 	t.Run("by genre ID", func(t *testing.T) {
 		var genre PopGenreMaster
 		db.Where("name = ?", "Pop").First(&genre)
-		results, err := gateway.Search(ctx, genre.ID.String(), 0, 0, "")
+		results, err := gateway.Search(ctx, genre.ID.String(), domain.Amount{}, domain.Amount{}, "")
 		assert.NoError(t, err)
 		assert.Len(t, results, 1)
 		assert.Equal(t, "tx2", results[0].ID)
 	})
 
 	t.Run("by min price 150", func(t *testing.T) {
-		results, err := gateway.Search(ctx, "", 150, 0, "")
+		results, err := gateway.Search(ctx, "", domain.NewAmount(big.NewInt(150)), domain.Amount{}, "")
 		assert.NoError(t, err)
 		assert.Len(t, results, 2)
 	})
 
 	t.Run("by max price 150", func(t *testing.T) {
-		results, err := gateway.Search(ctx, "", 0, 150, "")
+		results, err := gateway.Search(ctx, "", domain.Amount{}, domain.NewAmount(big.NewInt(150)), "")
 		assert.NoError(t, err)
 		assert.Len(t, results, 2)
 	})
 
 	t.Run("by price range 120 to 180", func(t *testing.T) {
-		results, err := gateway.Search(ctx, "", 120, 180, "")
+		results, err := gateway.Search(ctx, "", domain.NewAmount(big.NewInt(120)), domain.NewAmount(big.NewInt(180)), "")
 		assert.NoError(t, err)
 		assert.Len(t, results, 1)
 		assert.Equal(t, "tx3", results[0].ID)
 	})
 
 	t.Run("sort by price asc", func(t *testing.T) {
-		results, err := gateway.Search(ctx, "", 0, 0, "price_asc")
+		results, err := gateway.Search(ctx, "", domain.Amount{}, domain.Amount{}, "price_asc")
 		assert.NoError(t, err)
 		assert.Len(t, results, 3)
 		assert.Equal(t, "tx1", results[0].ID)
@@ -112,7 +114,7 @@ func TestTransactionGateway_Search(t *testing.T) {
 	})
 
 	t.Run("sort by price desc", func(t *testing.T) {
-		results, err := gateway.Search(ctx, "", 0, 0, "price_desc")
+		results, err := gateway.Search(ctx, "", domain.Amount{}, domain.Amount{}, "price_desc")
 		assert.NoError(t, err)
 		assert.Len(t, results, 3)
 		assert.Equal(t, "tx2", results[0].ID)
@@ -121,7 +123,7 @@ func TestTransactionGateway_Search(t *testing.T) {
 	})
 
 	t.Run("sort by newest (default)", func(t *testing.T) {
-		results, err := gateway.Search(ctx, "", 0, 0, "")
+		results, err := gateway.Search(ctx, "", domain.Amount{}, domain.Amount{}, "")
 		assert.NoError(t, err)
 		assert.Len(t, results, 3)
 		assert.Equal(t, "tx3", results[0].ID)
@@ -130,6 +132,74 @@ func TestTransactionGateway_Search(t *testing.T) {
 	})
 }
 
+func TestTransactionGateway_ListByStatusAndUpdate(t *testing.T) {
+	gateway := setupTransactionTestDB()
+	seedData()
+	ctx := context.Background()
+
+	db.Model(&domain.Transaction{}).Where("id IN ?", []string{"tx1", "tx3"}).Update("status", domain.TransactionStatusQueued)
+	db.Model(&domain.Transaction{}).Where("id = ?", "tx2").Update("status", domain.TransactionStatusConfirmed)
+
+	t.Run("list queued jobs oldest first", func(t *testing.T) {
+		results, err := gateway.ListByStatus(ctx, domain.TransactionStatusQueued, domain.TransactionStatusSubmitted)
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "tx1", results[0].ID)
+		assert.Equal(t, "tx3", results[1].ID)
+	})
+
+	t.Run("update status and receipt", func(t *testing.T) {
+		transaction, err := gateway.GetByTransactionid(ctx, "tx1")
+		assert.NoError(t, err)
+
+		transaction.Status = domain.TransactionStatusMined
+		transaction.TxHash = sql.NullString{String: "0xabc", Valid: true}
+		transaction.BlockNumber = sql.NullInt64{Int64: 42, Valid: true}
+		assert.NoError(t, gateway.Update(ctx, transaction))
+
+		updated, err := gateway.GetByTransactionid(ctx, "tx1")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TransactionStatusMined, updated.Status)
+		assert.Equal(t, "0xabc", updated.TxHash.String)
+		assert.Equal(t, int64(42), updated.BlockNumber.Int64)
+	})
+}
+
+func TestTransactionGateway_GetByToken(t *testing.T) {
+	gateway := setupTransactionTestDB()
+	seedData()
+	ctx := context.Background()
+
+	contract := "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	db.Model(&domain.Transaction{}).Where("id = ?", "tx2").Updates(map[string]any{"chain_id": 1337, "contract_address": contract, "token_id": "2"})
+
+	t.Run("get by chain, contract and token id", func(t *testing.T) {
+		result, err := gateway.GetByToken(ctx, 1337, contract, "2")
+		assert.NoError(t, err)
+		assert.Equal(t, "tx2", result.ID)
+	})
+
+	t.Run("not found on another chain", func(t *testing.T) {
+		_, err := gateway.GetByToken(ctx, 56, contract, "2")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestTransactionGateway_ListByContract(t *testing.T) {
+	gateway := setupTransactionTestDB()
+	seedData()
+	ctx := context.Background()
+
+	contract := "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	db.Model(&domain.Transaction{}).Where("id IN ?", []string{"tx1", "tx3"}).Updates(map[string]any{"chain_id": 1337, "contract_address": contract})
+
+	results, err := gateway.ListByContract(ctx, 1337, contract)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "tx1", results[0].ID)
+	assert.Equal(t, "tx3", results[1].ID)
+}
+
 type PopGenreMaster struct {
 	ID uuid.UUID `gorm:"primaryKey;type:char(36)"`
 }
@@ -0,0 +1,85 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VoucherGateway は遅延ミントのバウチャーのリポジトリ
+type VoucherGateway struct {
+	Database *gorm.DB
+}
+
+func NewVoucherGateway(db *gorm.DB) *VoucherGateway {
+	return &VoucherGateway{Database: db}
+}
+
+func (gateway *VoucherGateway) Create(ctx context.Context, voucher *domain.MintVoucher) error {
+	return gateway.Database.WithContext(ctx).Create(voucher).Error
+}
+
+func (gateway *VoucherGateway) Get(ctx context.Context, id uuid.UUID) (*domain.MintVoucher, error) {
+	var voucher domain.MintVoucher
+	if err := gateway.Database.WithContext(ctx).First(&voucher, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &voucher, nil
+}
+
+// ListByCollection はコレクションのバウチャーを新しい順に取得する
+func (gateway *VoucherGateway) ListByCollection(ctx context.Context, collectionID uuid.UUID) ([]*domain.MintVoucher, error) {
+	var vouchers []*domain.MintVoucher
+	if err := gateway.Database.WithContext(ctx).
+		Where("collection_id = ?", collectionID).
+		Order("created_at DESC").
+		Find(&vouchers).Error; err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+// ListByStatus は指定したステータスのバウチャーを古い順に取得する
+func (gateway *VoucherGateway) ListByStatus(ctx context.Context, statuses ...string) ([]*domain.MintVoucher, error) {
+	var vouchers []*domain.MintVoucher
+	if err := gateway.Database.WithContext(ctx).
+		Where("status IN ?", statuses).
+		Order("created_at ASC").
+		Find(&vouchers).Error; err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+// Search は出品中で有効期限内のバウチャーを新しい順に検索する
+func (gateway *VoucherGateway) Search(ctx context.Context, genre string, minPrice domain.Amount, maxPrice domain.Amount, after int64) ([]*domain.MintVoucher, error) {
+	var vouchers []*domain.MintVoucher
+
+	db := gateway.Database.WithContext(ctx).
+		Where("status = ? AND expiry >= ?", domain.VoucherStatusListed, after)
+
+	if genre != "" {
+		db = db.Where("genre_id = ?", genre)
+	}
+
+	if minPrice.Sign() > 0 {
+		db = db.Where("price >= ?", minPrice)
+	}
+
+	if maxPrice.Sign() > 0 {
+		db = db.Where("price <= ?", maxPrice)
+	}
+
+	if err := db.Order("created_at DESC").Find(&vouchers).Error; err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+func (gateway *VoucherGateway) Update(ctx context.Context, voucher *domain.MintVoucher) error {
+	return gateway.Database.WithContext(ctx).Save(voucher).Error
+}
@@ -0,0 +1,41 @@
+// Package middlewares は、HTTPリクエストの前後処理を行うミドルウェアを実装します。
+package middlewares
+
+import (
+	"strings"
+
+	"nft-music/usecases/interactor"
+	"nft-music/usecases/ports"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AuthUserKey は echo.Context に認証済みユーザーを格納するキー
+const AuthUserKey = "auth_user"
+
+// Auth はセッショントークンを検証し、認証済みのウォレットをリクエストのコンテキストに格納する
+func Auth(authInteractor *interactor.AuthInteractor) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			request := c.Request()
+			authUser, err := authInteractor.Authenticate(request.Context(), BearerToken(c))
+			if err != nil {
+				return authInteractor.Error.ErrorResponse(c, err)
+			}
+
+			c.Set(AuthUserKey, authUser)
+			c.SetRequest(request.WithContext(ports.WithAuthUser(request.Context(), authUser)))
+			return next(c)
+		}
+	}
+}
+
+// BearerToken は Authorization ヘッダーから Bearer トークンを取り出す
+func BearerToken(c echo.Context) string {
+	header := c.Request().Header.Get(echo.HeaderAuthorization)
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,34 @@
+// Package middlewares は、HTTPリクエストの前後処理を行うミドルウェアを実装します。
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+
+	"nft-music/usecases/interactor"
+	"nft-music/usecases/ports"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Maintenance はメンテナンス中の書き込みのAPIに、再試行の目安を付けた 503 を返す
+// 読み取りのAPIと、メンテナンスを解除する管理者のAPIには付けない
+func Maintenance(maintenanceInteractor *interactor.MaintenanceInteractor) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			status := maintenanceInteractor.Status(c.Request().Context())
+			if !status.Active {
+				return next(c)
+			}
+
+			c.Response().Header().Set("Retry-After", strconv.Itoa(status.RetryAfter))
+			return c.JSON(http.StatusServiceUnavailable, ports.MaintenanceResponseObject{
+				StatusCode:  http.StatusServiceUnavailable,
+				ErrorType:   "メンテナンス中のため書き込みを受け付けていません",
+				Message:     status.Reason,
+				RetryAfter:  status.RetryAfter,
+				Maintenance: *status,
+			})
+		}
+	}
+}
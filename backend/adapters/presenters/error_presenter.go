@@ -34,6 +34,9 @@ func (presenter *ErrorPresenter) ErrorResponse(c echo.Context, err error) error
 		} else if isUnauthorizedError(err.Error()) {
 			code = http.StatusUnauthorized
 			errorType = "保護された API への認証されていないリクエスト"
+		} else if isForbiddenError(err.Error()) {
+			code = http.StatusForbidden
+			errorType = "リクエストしたユーザーには操作の権限がありません"
 		} else if isRecordNotFoundError(err.Error()) {
 			code = http.StatusNotFound
 			errorType = "DBのテーブルにデータがありません"
@@ -43,6 +46,12 @@ func (presenter *ErrorPresenter) ErrorResponse(c echo.Context, err error) error
 		} else if isDuplicatedUError(err.Error()) {
 			code = http.StatusConflict
 			errorType = "現在のサーバーの状態と競合"
+		} else if isPayloadTooLargeError(err.Error()) {
+			code = http.StatusRequestEntityTooLarge
+			errorType = "リクエストの本文が上限を超えています"
+		} else if isServiceUnavailableError(err.Error()) {
+			code = http.StatusServiceUnavailable
+			errorType = "サービスが一時的に利用できません"
 		} else if isCreated(err.Error()) {
 			code = http.StatusCreated
 			errorType = "正常"
@@ -92,13 +101,25 @@ func isNotFoundError(msg string) bool {
 }
 
 func isDuplicatedUError(msg string) bool {
-	return strings.Contains(msg, "Already Exist")
+	return strings.Contains(msg, "Already Exist") || strings.Contains(msg, "Conflict")
 }
 
 func isUnauthorizedError(msg string) bool {
 	return strings.Contains(msg, "Unauthorized")
 }
 
+func isForbiddenError(msg string) bool {
+	return strings.Contains(msg, "Forbidden")
+}
+
+func isPayloadTooLargeError(msg string) bool {
+	return strings.Contains(msg, "PayloadTooLarge")
+}
+
+func isServiceUnavailableError(msg string) bool {
+	return strings.Contains(msg, "ServiceUnavailable")
+}
+
 func isCreated(msg string) bool {
 	return strings.Contains(msg, "Already Created")
 }
@@ -20,6 +20,176 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "セッショントークンを無効にする",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "認証"
+                ],
+                "summary": "ログアウトする",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "セッショントークンに紐づくユーザーIDとウォレットアドレスを返す",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "認証"
+                ],
+                "summary": "ログイン中のユーザーを取得する",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.AuthUser"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/nonce": {
+            "get": {
+                "description": "ウォレットで署名するEIP-4361形式のメッセージを発行する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "認証"
+                ],
+                "summary": "SIWEのノンスを発行する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ウォレットアドレス",
+                        "name": "wallet",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "チェーンID",
+                        "name": "chain_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.AuthNonceOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify": {
+            "post": {
+                "description": "署名済みのEIP-4361メッセージを検証し、Authorizationヘッダーで使うセッショントークンを発行する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "認証"
+                ],
+                "summary": "SIWEの署名を検証してログインする",
+                "parameters": [
+                    {
+                        "description": "署名済みメッセージ",
+                        "name": "auth",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.AuthVerifyInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.AuthSessionOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
         "/businesses": {
             "get": {
                 "description": "職種マスターの情報をリストで取得する",
@@ -64,6 +234,11 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "職種マスターの情報を1件作成する",
                 "consumes": [
                     "application/json"
@@ -99,6 +274,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -164,6 +351,11 @@ const docTemplate = `{
                 }
             },
             "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "職種マスターの情報を1件修正する",
                 "consumes": [
                     "application/json"
@@ -206,6 +398,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -221,6 +425,11 @@ const docTemplate = `{
                 }
             },
             "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "職種マスターの情報を1件削除する",
                 "consumes": [
                     "application/json"
@@ -254,6 +463,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -269,6 +490,38 @@ const docTemplate = `{
                 }
             }
         },
+        "/chains": {
+            "get": {
+                "description": "ネイティブ通貨・RPC・ブロックエクスプローラー・マーケットプレイスのアドレスと、バックエンドが接続中かどうかを返す",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "チェーン"
+                ],
+                "summary": "対応しているチェーンを一覧で取得する",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ports.ChainOutput"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
         "/collections": {
             "get": {
                 "description": "コレクションの情報をリストで取得する",
@@ -313,6 +566,11 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "コレクションの情報を1件作成する",
                 "consumes": [
                     "application/json"
@@ -348,6 +606,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -413,6 +683,11 @@ const docTemplate = `{
                 }
             },
             "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "コレクションの情報を1件修正する",
                 "consumes": [
                     "application/json"
@@ -455,6 +730,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -470,6 +757,11 @@ const docTemplate = `{
                 }
             },
             "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "コレクションの情報を1件削除する",
                 "consumes": [
                     "application/json"
@@ -500,6 +792,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -515,9 +819,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/evm": {
+        "/collections/{id}/mint": {
             "post": {
-                "description": "Ethereum Virtual Machineのログイン情報を取得する",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "コレクションの作成者のみ。プラットフォームのウォレットから safeMint を送信し、取り込まれるまで待ってトークンIDを返す",
                 "consumes": [
                     "application/json"
                 ],
@@ -525,17 +834,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EVM"
+                    "コレクション"
                 ],
-                "summary": "EVMにログインを取得",
+                "summary": "コレクションのコントラクトでミントする",
                 "parameters": [
                     {
-                        "description": "ウォレット",
-                        "name": "wallet",
+                        "type": "string",
+                        "description": "コレクションID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "送信先（空の場合は自分のウォレット）",
+                        "name": "mint",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/ports.WalletInput"
+                            "$ref": "#/definitions/ports.CollectionMintInput"
                         }
                     }
                 ],
@@ -543,7 +859,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ports.SignerOutput"
+                            "$ref": "#/definitions/ports.CollectionTokenOutput"
                         }
                     },
                     "400": {
@@ -552,6 +868,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -563,13 +891,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
                     }
                 }
             }
         },
-        "/genres": {
-            "get": {
-                "description": "ジャンルマスターの情報をリストで取得する",
+        "/collections/{id}/tokens/{token_id}/burn": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "トークンの所有者のみ。所有者がプラットフォームのウォレットを承認している場合に burn を送信し、取り込まれるまで待つ",
                 "consumes": [
                     "application/json"
                 ],
@@ -577,17 +916,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ジャンルマスター"
+                    "コレクション"
+                ],
+                "summary": "コレクションのトークンをバーンする",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "コレクションID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "トークンID",
+                        "name": "token_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "ジャンルマスターの情報をリストで取得する",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/ports.GenreMasterOutput"
-                            }
+                            "$ref": "#/definitions/ports.CollectionTokenOutput"
                         }
                     },
                     "400": {
@@ -596,52 +948,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/ports.ErrorResponseObject"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
-                    }
-                }
-            },
-            "post": {
-                "description": "ジャンルマスターの情報を1件作成する",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ジャンルマスター"
-                ],
-                "summary": "ジャンルマスターの情報を1件作成する",
-                "parameters": [
-                    {
-                        "description": "ジャンルマスター",
-                        "name": "wallet",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/ports.GenreMasterInput"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/ports.GenreMasterOutput"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
@@ -657,13 +971,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
                     }
                 }
             }
         },
-        "/genres/{id}": {
-            "get": {
-                "description": "ジャンルマスターの情報を出力し",
+        "/collections/{id}/tokens/{token_id}/royalty": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "コレクションの作成者のみ。プラットフォームでデプロイしたコレクションはコントラクトの setTokenRoyalty も送信する",
                 "consumes": [
                     "application/json"
                 ],
@@ -671,23 +996,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ジャンルマスター"
+                    "コレクション"
                 ],
-                "summary": "ジャンルマスターから特定の１レコードを抽出する",
+                "summary": "トークンごとのロイヤリティを設定する",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ジャンルマスターID",
+                        "description": "コレクションID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "トークンID",
+                        "name": "token_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ロイヤリティ率（bps）と受取人",
+                        "name": "royalty",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.TokenRoyaltyInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ports.GenreMasterOutput"
+                            "$ref": "#/definitions/ports.TokenRoyaltyOutput"
                         }
                     },
                     "400": {
@@ -696,6 +1037,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -707,11 +1060,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
                     }
                 }
             },
-            "put": {
-                "description": "ジャンルマスターの情報を1件修正する",
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "コレクションの作成者のみ。解除後はコレクションの既定のロイヤリティが適用される",
                 "consumes": [
                     "application/json"
                 ],
@@ -719,33 +1083,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ジャンルマスター"
+                    "コレクション"
                 ],
-                "summary": "ジャンルマスターの情報を1件修正する",
+                "summary": "トークンごとのロイヤリティを解除する",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ジャンルマスターID",
+                        "description": "コレクションID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "ジャンルマスター",
-                        "name": "wallet",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/ports.GenreMasterInput"
-                        }
+                        "type": "string",
+                        "description": "トークンID",
+                        "name": "token_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/ports.GenreMasterOutput"
-                        }
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -753,6 +1112,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -764,11 +1135,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "ジャンルマスターの情報を1件削除する",
+            }
+        },
+        "/collections/{id}/vouchers": {
+            "get": {
+                "description": "署名は返さない",
                 "consumes": [
                     "application/json"
                 ],
@@ -776,13 +1155,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ジャンルマスター"
+                    "遅延ミント"
                 ],
-                "summary": "ジャンルマスターの情報を1件削除する",
+                "summary": "コレクションのバウチャーをすべてのステータスで取得する",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ジャンルマスターID",
+                        "description": "コレクションID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -792,7 +1171,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ports.VoucherOutput"
+                            }
                         }
                     },
                     "400": {
@@ -801,12 +1183,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/ports.ErrorResponseObject"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -814,42 +1190,47 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/ipfs": {
+            },
             "post": {
-                "description": "分散型ストレージIPFSに画像を登録する",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "メタJSONをIPFSに登録してバウチャーを作成し、クリエイターのウォレットで eth_signTypedData_v4 で署名する EIP-712 のデータ（typed_data）を返す。署名後は POST /vouchers/{id}/sign で署名を登録すると出品される",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "IPFS"
+                    "遅延ミント"
                 ],
-                "summary": "IPFSノードにイメージデータを登録",
+                "summary": "コレクションのバウチャーを作成する",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "this is a test file",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "コレクションID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "ウォレットアドレス",
-                        "name": "wallet",
-                        "in": "formData",
-                        "required": true
+                        "description": "トークンの内容・価格・ロイヤリティ・有効期限",
+                        "name": "voucher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.VoucherInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/ports.IpfsOutput"
+                            "$ref": "#/definitions/ports.VoucherOutput"
                         }
                     },
                     "400": {
@@ -858,6 +1239,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -873,9 +1266,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ipfs/meta": {
-            "post": {
-                "description": "分散型ストレージIPFSにJSONデータを登録する",
+        "/contract": {
+            "get": {
+                "description": "接続中のマーケットプレイスのコントラクトのオーナー・一時停止・ロイヤリティ・ミント料をチェーンから読み取る",
                 "consumes": [
                     "application/json"
                 ],
@@ -883,41 +1276,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "IPFS"
-                ],
-                "summary": "IPFSノードにJSONデータを登録",
-                "parameters": [
-                    {
-                        "description": "MetaJSON",
-                        "name": "json",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/ports.IpfsMetaInput"
-                        }
-                    }
+                    "EVM"
                 ],
+                "summary": "コントラクトの設定を出力する",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ports.IpfsOutput"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                            "$ref": "#/definitions/ports.ContractStateOutput"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
@@ -925,9 +1301,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/nfts": {
+        "/contract/audits": {
             "get": {
-                "description": "はブロックチェーンにNFTを複数出力する",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "接続中のコントラクトに対するオーナー操作を新しい順に返す",
                 "consumes": [
                     "application/json"
                 ],
@@ -935,9 +1316,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "NFT情報"
+                    "管理"
                 ],
-                "summary": "ブロックチェーンにNFTを複数出力する",
+                "summary": "オーナー操作の監査ログを一覧で出力する（管理者のみ）",
                 "parameters": [
                     {
                         "type": "integer",
@@ -952,18 +1333,18 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/ports.TransactionOutput"
+                                "$ref": "#/definitions/ports.ContractAuditOutput"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
@@ -973,11 +1354,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
                     }
                 }
-            },
-            "post": {
-                "description": "NFTの情報をブロックチェーンに登録する",
+            }
+        },
+        "/contract/listing-price": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "ミント料を変更し、取り込まれるまで待って監査ログを返す。以降のミントは変更後のミント料で送信する",
                 "consumes": [
                     "application/json"
                 ],
@@ -985,17 +1379,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "NFT情報"
+                    "管理"
                 ],
-                "summary": "NFTの情報をブロックチェーンに登録する",
+                "summary": "ミント料を変更する（管理者のみ）",
                 "parameters": [
                     {
-                        "description": "ジャンルマスター",
-                        "name": "wallet",
+                        "description": "変更後のミント料",
+                        "name": "listing_price",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/ports.NftInput"
+                            "$ref": "#/definitions/ports.ListingPriceInput"
                         }
                     }
                 ],
@@ -1003,7 +1397,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ports.TransactionOutput"
+                            "$ref": "#/definitions/ports.ContractAuditOutput"
                         }
                     },
                     "400": {
@@ -1012,8 +1406,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
@@ -1023,13 +1423,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
                     }
                 }
             }
         },
-        "/nfts/detail/{transaction_id}": {
-            "get": {
-                "description": "トランザクションIDに紐づくNFTを1件出力する",
+        "/contract/pause": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "コントラクトの pause を送信し、取り込まれるまで待って監査ログを返す",
                 "consumes": [
                     "application/json"
                 ],
@@ -1037,23 +1448,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "NFT情報"
-                ],
-                "summary": "トランザクションIDでNFTを1件出力する",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "トランザクションID",
-                        "name": "transaction_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "管理"
                 ],
+                "summary": "ミントと売買を一時停止する（管理者のみ）",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ports.TransactionOutput"
+                            "$ref": "#/definitions/ports.ContractAuditOutput"
                         }
                     },
                     "400": {
@@ -1062,8 +1464,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
@@ -1073,13 +1481,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
                     }
                 }
             }
         },
-        "/nfts/search": {
-            "get": {
-                "description": "キーワードに一致するNFTを複数出力する",
+        "/contract/renounce-ownership": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "放棄すると以降のオーナー操作ができなくなるため、confirm に接続中のコントラクトアドレスを指定する",
                 "consumes": [
                     "application/json"
                 ],
@@ -1087,49 +1506,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "NFT情報"
+                    "管理"
                 ],
-                "summary": "キーワードでNFTを複数出力する",
+                "summary": "コントラクトの所有権を放棄する（管理者のみ）",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "検索キーワード",
-                        "name": "q",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "ジャンルID",
-                        "name": "genre",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "最小価格",
-                        "name": "min_price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "最大価格",
-                        "name": "max_price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "ソート順",
-                        "name": "sort",
-                        "in": "query"
+                        "description": "確認のためのコントラクトアドレス",
+                        "name": "renounce",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.RenounceOwnershipInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/ports.TransactionOutput"
-                            }
+                            "$ref": "#/definitions/ports.ContractAuditOutput"
                         }
                     },
                     "400": {
@@ -1138,18 +1533,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
                     }
                 }
             }
         },
-        "/nfts/{wallet}": {
-            "get": {
-                "description": "ウォレットアドレスに紐づくNFTを複数出力する",
+        "/contract/royalty-fee": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "二次流通でクリエイターに支払うロイヤリティ（bps）を変更し、取り込まれるまで待って監査ログを返す",
                 "consumes": [
                     "application/json"
                 ],
@@ -1157,26 +1575,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "NFT情報"
+                    "管理"
                 ],
-                "summary": "ウォレットアドレスでNFTを複数出力する",
+                "summary": "ロイヤリティを変更する（管理者のみ）",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ウォレットアドレス",
-                        "name": "wallet",
-                        "in": "path",
-                        "required": true
+                        "description": "変更後のロイヤリティ",
+                        "name": "royalty_fee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.RoyaltyFeeInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/ports.TransactionOutput"
-                            }
+                            "$ref": "#/definitions/ports.ContractAuditOutput"
                         }
                     },
                     "400": {
@@ -1185,8 +1602,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
@@ -1196,13 +1619,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
                     }
                 }
             }
         },
-        "/users": {
-            "get": {
-                "description": "NFTミュージックのアカウントを登録する",
+        "/contract/unpause": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "コントラクトの unpause を送信し、取り込まれるまで待って監査ログを返す",
                 "consumes": [
                     "application/json"
                 ],
@@ -1210,17 +1644,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "アカウント"
+                    "管理"
                 ],
-                "summary": "NFTミュージックのアカウントを登録する",
+                "summary": "一時停止を解除する（管理者のみ）",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/ports.UserOutput"
-                            }
+                            "$ref": "#/definitions/ports.ContractAuditOutput"
                         }
                     },
                     "400": {
@@ -1229,8 +1660,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
@@ -1240,11 +1677,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
                     }
                 }
-            },
-            "post": {
-                "description": "NFTミュージックのアカウントを登録する",
+            }
+        },
+        "/deployments": {
+            "get": {
+                "description": "接続中のチェーンにデプロイしたコントラクトを新しい順に取得する",
                 "consumes": [
                     "application/json"
                 ],
@@ -1252,37 +1697,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "アカウント"
-                ],
-                "summary": "NFTミュージックのアカウントを登録する",
-                "parameters": [
-                    {
-                        "description": "ユーザー情報",
-                        "name": "users",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/ports.UserInput"
-                        }
-                    }
+                    "EVM"
                 ],
+                "summary": "デプロイ済みのコントラクトを一覧で取得する",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ports.UserOutput"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/ports.ErrorResponseObject"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ports.DeploymentOutput"
+                            }
                         }
                     },
                     "500": {
@@ -1292,11 +1717,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/wallet/{wallet}": {
-            "get": {
-                "description": "ウォレットアドレスをキーにNFTミュージックのアカウント情報を取得する",
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "NFTマーケットプレイスのコントラクトをデプロイして登録簿に記録し、以降のミントの接続先を切り替える（管理者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -1304,23 +1732,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "アカウント"
+                    "EVM"
                 ],
-                "summary": "ウォレットアドレスからアカウント情報を取得する",
+                "summary": "マーケットプレイスのコントラクトをデプロイする",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ウォレットアドレス",
-                        "name": "wallet",
-                        "in": "path",
-                        "required": true
+                        "description": "コントラクトの初期値",
+                        "name": "deployment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.DeploymentInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ports.UserOutput"
+                            "$ref": "#/definitions/ports.DeploymentOutput"
                         }
                     },
                     "400": {
@@ -1329,8 +1759,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
@@ -1344,9 +1780,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/genres": {
             "get": {
-                "description": "NFTミュージックのアカウントを登録する",
+                "description": "ジャンルマスターの情報をリストで取得する",
                 "consumes": [
                     "application/json"
                 ],
@@ -1354,23 +1790,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "アカウント"
-                ],
-                "summary": "NFTミュージックのアカウントを登録する",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ユーザーID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "ジャンルマスター"
                 ],
+                "summary": "ジャンルマスターの情報をリストで取得する",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ports.UserOutput"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ports.GenreMasterOutput"
+                            }
                         }
                     },
                     "400": {
@@ -1393,8 +1823,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "NFTミュージックのアカウントの情報を変更する",
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "ジャンルマスターの情報を1件作成する",
                 "consumes": [
                     "application/json"
                 ],
@@ -1402,24 +1837,141 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "アカウント"
+                    "ジャンルマスター"
                 ],
-                "summary": "NFTミュージックのアカウントの情報を変更する",
+                "summary": "ジャンルマスターの情報を1件作成する",
+                "parameters": [
+                    {
+                        "description": "ジャンルマスター",
+                        "name": "wallet",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.GenreMasterInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.GenreMasterOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/genres/{id}": {
+            "get": {
+                "description": "ジャンルマスターの情報を出力し",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ジャンルマスター"
+                ],
+                "summary": "ジャンルマスターから特定の１レコードを抽出する",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ユーザーID",
+                        "description": "ジャンルマスターID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.GenreMasterOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
                     {
-                        "description": "ユーザー情報",
-                        "name": "users",
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "ジャンルマスターの情報を1件修正する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ジャンルマスター"
+                ],
+                "summary": "ジャンルマスターの情報を1件修正する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ジャンルマスターID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ジャンルマスター",
+                        "name": "wallet",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/ports.UserInput"
+                            "$ref": "#/definitions/ports.GenreMasterInput"
                         }
                     }
                 ],
@@ -1427,7 +1979,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ports.UserOutput"
+                            "$ref": "#/definitions/ports.GenreMasterOutput"
                         }
                     },
                     "400": {
@@ -1436,6 +1988,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1451,7 +2015,12 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "NFTミュージックのアカウントを削除する",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "ジャンルマスターの情報を1件削除する",
                 "consumes": [
                     "application/json"
                 ],
@@ -1459,13 +2028,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "アカウント"
+                    "ジャンルマスター"
                 ],
-                "summary": "NFTミュージックのアカウントを削除する",
+                "summary": "ジャンルマスターの情報を1件削除する",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ユーザーID",
+                        "description": "ジャンルマスターID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1475,7 +2044,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ports.UserOutput"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1484,6 +2053,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1499,27 +2080,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/wallets": {
-            "get": {
-                "description": "仮想通貨のウォレットの情報を出力する",
+        "/ipfs": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分散型ストレージIPFSに画像を登録する。X-Upload-ID を指定すると進捗の確認と取り消しができる",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ウォレット情報"
+                    "IPFS"
+                ],
+                "summary": "IPFSノードにイメージデータを登録",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "進捗の確認と取り消しに使うアップロードのID",
+                        "name": "X-Upload-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ウォレットアドレス（file より前に送る）",
+                        "name": "wallet",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "this is a test file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
                 ],
-                "summary": "ウォレットの情報をデータベースから抽出する",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/ports.WalletOutput"
-                            }
+                            "$ref": "#/definitions/ports.IpfsOutput"
                         }
                     },
                     "400": {
@@ -1528,12 +2132,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1541,9 +2169,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/ipfs/meta": {
             "post": {
-                "description": "ウォレットアドレスが登録されているかウォレットテーブルを確認し、なければウォレットアドレスをデータベースに格納する",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分散型ストレージIPFSにJSONデータを登録する",
                 "consumes": [
                     "application/json"
                 ],
@@ -1551,17 +2186,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ウォレット情報"
+                    "IPFS"
                 ],
-                "summary": "ウォレットアドレスをデータベースに格納する",
+                "summary": "IPFSノードにJSONデータを登録",
                 "parameters": [
                     {
-                        "description": "ウォレットアドレス",
-                        "name": "wallet",
+                        "description": "MetaJSON",
+                        "name": "json",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/ports.WalletInput"
+                            "$ref": "#/definitions/ports.IpfsMetaInput"
                         }
                     }
                 ],
@@ -1569,17 +2204,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ports.WalletOutput"
+                            "$ref": "#/definitions/ports.IpfsOutput"
                         }
                     },
-                    "201": {
-                        "description": "Created",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ports.CreatedObject"
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ports.ErrorResponseObject"
                         }
@@ -1598,326 +2239,4199 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "ports.BusinessMasterInput": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "name": {
-                    "type": "string",
-                    "example": "建築"
-                }
-            }
         },
-        "ports.BusinessMasterOutput": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string",
-                    "example": "2024-11-04T20:51:26Z"
+        "/ipfs/tus": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "音源のマスターや動画など大きなファイルを分割して送信するアップロードを作成し、Location で送信先のURLを返す。Upload-Metadata の filename は必須",
+                "tags": [
+                    "IPFS"
+                ],
+                "summary": "tus の分割アップロードを作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ファイルのバイト数",
+                        "name": "Upload-Length",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "filename \u003cbase64\u003e（カンマ区切りで他の項目も指定できる）",
+                        "name": "Upload-Metadata",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            },
+            "options": {
+                "description": "対応する tus のバージョン（Tus-Version）・拡張（Tus-Extension）・ファイルの上限（Tus-Max-Size）をヘッダーで返す",
+                "tags": [
+                    "IPFS"
+                ],
+                "summary": "tus の分割アップロードの対応状況",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/ipfs/tus/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "最後の PATCH のレスポンスを受け取れなかった場合に、IPFS に追加した結果を取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "IPFS"
+                ],
+                "summary": "tus の分割アップロードの結果",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "アップロードのID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.IpfsOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "受信した内容を削除する",
+                "tags": [
+                    "IPFS"
+                ],
+                "summary": "tus の分割アップロードを取り消す",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "アップロードのID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "接続が切れた後に再開する位置（Upload-Offset）を返す",
+                "tags": [
+                    "IPFS"
+                ],
+                "summary": "tus の分割アップロードの受信済みのバイト数",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "アップロードのID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "受信済みのバイト数（Upload-Offset）から続きを送信する。最後まで受信したら IPFS に追加して 200 で結果を返し、それ以外は 204 を返す",
+                "consumes": [
+                    "application/offset+octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "IPFS"
+                ],
+                "summary": "tus の分割アップロードに追記",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "受信済みのバイト数",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "アップロードのID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.IpfsOutput"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/ipfs/uploads/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "X-Upload-ID を指定して POST /ipfs でアップロード中のファイルの受信したバイト数を返す",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "IPFS"
+                ],
+                "summary": "アップロード中のファイルの進捗",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "アップロードのID（X-Upload-ID）",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.IpfsUploadProgress"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "X-Upload-ID を指定して POST /ipfs でアップロード中のファイルの送信を止める",
+                "tags": [
+                    "IPFS"
+                ],
+                "summary": "アップロード中のファイルの取り消し",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "アップロードのID（X-Upload-ID）",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/ipfs/{cid}": {
+            "get": {
+                "description": "IPFSのネットワークに公開しない保存先（s3・local）のコンテンツを配信する。トークンURIや画像・プレビューのURLは IPFS_PUBLIC_URL + /ipfs/{cid} になる。フル音源は配信せず、クリエイターとトークンの所有者は GET /nfts/detail/{transaction_id}/master/content で取得する",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "IPFS"
+                ],
+                "summary": "保存したコンテンツの配信",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "CID",
+                        "name": "cid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/maintenance": {
+            "get": {
+                "description": "コントラクトの一時停止か運用者の設定でメンテナンス中の間は active が true になり、書き込みのAPIは 503 を返す",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "管理"
+                ],
+                "summary": "メンテナンスモードの状態を出力する",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.MaintenanceOutput"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "運用者のメンテナンスモードを切り替える。コントラクトが一時停止中の場合は解除してもメンテナンス中のまま",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "管理"
+                ],
+                "summary": "メンテナンスモードを切り替える（管理者のみ）",
+                "parameters": [
+                    {
+                        "description": "メンテナンスモードの指定",
+                        "name": "maintenance",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.MaintenanceInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.MaintenanceOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts": {
+            "get": {
+                "description": "はブロックチェーンにNFTを複数出力する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFT情報"
+                ],
+                "summary": "ブロックチェーンにNFTを複数出力する",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取得件数",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ports.TransactionOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "ミントジョブを登録してすぐに返す。登録の状態は GET /nfts/jobs/{id} で確認する\nMINT_MODE=wallet の場合はクリエイターのウォレットで署名するトランザクション（transaction）を返す。送信後に POST /nfts/jobs/{id}/submit で登録する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFT情報"
+                ],
+                "summary": "NFTの情報をブロックチェーンに登録する",
+                "parameters": [
+                    {
+                        "description": "ジャンルマスター",
+                        "name": "wallet",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.NftInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "MINT_MODE=wallet（署名待ち）",
+                        "schema": {
+                            "$ref": "#/definitions/ports.MintJobOutput"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/ports.MintJobOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts/detail/{transaction_id}": {
+            "get": {
+                "description": "トランザクションIDに紐づくNFTを1件出力する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFT情報"
+                ],
+                "summary": "トランザクションIDでNFTを1件出力する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "トランザクションID",
+                        "name": "transaction_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.TransactionOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts/detail/{transaction_id}/master": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "公開するメタデータにはプレビューだけを含めるため、フル音源のCIDはクリエイターとトークンの所有者にのみ返す。所有者はマーケットプレイスのコントラクトで確認する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFT情報"
+                ],
+                "summary": "NFTのフル音源を出力する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "トランザクションID",
+                        "name": "transaction_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.IpfsOutput"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts/detail/{transaction_id}/master/content": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "クリエイターとトークンの所有者にフル音源を配信する。保存先がIPFSのネットワークに公開しない場合（s3・local）もここから取得できる",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "NFT情報"
+                ],
+                "summary": "NFTのフル音源を配信する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "トランザクションID",
+                        "name": "transaction_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts/jobs/{id}": {
+            "get": {
+                "description": "ステータスは queued（ウォレットで署名する場合は prepared）→ submitted → mined → confirmed の順に進み、失敗した場合は failed になる",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFT情報"
+                ],
+                "summary": "ミントジョブの状態を出力する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ジョブID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.MintJobOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts/jobs/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "プラットフォームのウォレットから送信して取り込まれていないミントを、同じノンスで自分宛てに0を送金して取り消す。取り消しが取り込まれるとミントジョブは failed になる",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "管理"
+                ],
+                "summary": "ミントのトランザクションを取り消す（管理者のみ）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ミントジョブのID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ReplacementOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts/jobs/{id}/replacements": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "詰まったミントのトランザクションを同じノンスで送り直した・取り消した記録を古い順に返す（自動の置き換えを含む）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "管理"
+                ],
+                "summary": "ミントジョブの置き換えの記録を出力する（管理者のみ）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ミントジョブのID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ports.ReplacementOutput"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts/jobs/{id}/speed-up": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "プラットフォームのウォレットから送信して取り込まれていないミントを、同じノンス・同じ内容で元のガス代に上乗せして送り直す。ミントジョブの tx_hash は送り直したトランザクションになる",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "管理"
+                ],
+                "summary": "ミントのトランザクションをガス代を上げて送り直す（管理者のみ）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ミントジョブのID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ReplacementOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts/jobs/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "署名済みのトランザクション（raw_tx）はノードに送信し、ハッシュ（tx_hash）はウォレットが送信したものを確認する。ミントジョブの内容と一致する場合に取り込まれるまで追跡する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFT情報"
+                ],
+                "summary": "ウォレットで署名したミントのトランザクションを登録する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ジョブID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "署名済みのトランザクションかトランザクションハッシュ",
+                        "name": "submit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.MintSubmitInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.MintJobOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts/mint/preview": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "コントラクトの現在のミント料でミントのトランザクションを送信した場合のガス代を見積もる（送信はしない）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFT情報"
+                ],
+                "summary": "ミントのガス代を見積もる",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "チェーンID",
+                        "name": "chain_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "価格（例: 0.015 ETH, 15 gwei。単位が無い場合はネイティブ通貨）。指定する場合はミント料と一致する必要がある",
+                        "name": "price",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.MintPreviewOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts/search": {
+            "get": {
+                "description": "キーワードに一致するNFTを複数出力する。出品中のバウチャー（遅延ミント）は listing_state が lazy で、id はバウチャーID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFT情報"
+                ],
+                "summary": "キーワードでNFTを複数出力する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "検索キーワード",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ジャンルID",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "最小価格（例: 0.01 ETH。単位が無い場合はETH）",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "最大価格（例: 0.5 ETH。単位が無い場合はETH）",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ソート順",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "出品の状態（minted / lazy。空の場合は両方）",
+                        "name": "listing",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ports.TransactionOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts/token/{chain_id}/{contract}/{token_id}": {
+            "get": {
+                "description": "チェーンID・コントラクトアドレス・トークンIDに紐づくNFTを1件出力する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFT情報"
+                ],
+                "summary": "トークンIDでNFTを1件出力する",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "チェーンID",
+                        "name": "chain_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "コントラクトアドレス",
+                        "name": "contract",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "トークンID",
+                        "name": "token_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.TransactionOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts/{token_id}/purchase": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "コントラクトの販売価格と手数料を確認し、ユーザーのウォレットで署名する createMarketSale のトランザクションを返す。送信後は POST /trades/{id}/submit でトランザクションハッシュを登録する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "二次流通"
+                ],
+                "summary": "販売中のNFTを購入するトランザクションを作成する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "トークンID",
+                        "name": "token_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "購入するウォレット",
+                        "name": "purchase",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.PurchaseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.TradeOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts/{token_id}/resell": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "所有者と出品手数料を確認し、ユーザーのウォレットで署名する resellToken のトランザクションを返す。送信後は POST /trades/{id}/submit でトランザクションハッシュを登録する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "二次流通"
+                ],
+                "summary": "所有しているNFTを再出品するトランザクションを作成する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "トークンID",
+                        "name": "token_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "再出品するウォレットと価格",
+                        "name": "resell",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.ResellInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.TradeOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/nfts/{wallet}": {
+            "get": {
+                "description": "ウォレットアドレスに紐づくNFTを複数出力する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFT情報"
+                ],
+                "summary": "ウォレットアドレスでNFTを複数出力する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ウォレットアドレス",
+                        "name": "wallet",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ports.TransactionOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/reconciliation": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "定期的な照合か POST /reconciliation で最後に照合した結果を返す",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "管理"
+                ],
+                "summary": "最後に照合した結果を出力する（管理者のみ）",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.DriftReportOutput"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "トランザクションの行を FetchAllMarketItems・ownerOf・ミントのレシートと照合して食い違いを返す。repair を指定するとステータス・トークンID・価格をチェーンに合わせて直す",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "管理"
+                ],
+                "summary": "DBとチェーンを照合する（管理者のみ）",
+                "parameters": [
+                    {
+                        "description": "照合の指定",
+                        "name": "reconcile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.ReconcileInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.DriftReportOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/royalty/{chain_id}/{contract}/{token_id}": {
+            "get": {
+                "description": "ERC-2981 の royaltyInfo と同じ計算。トークンごとの設定、コレクションの既定値、マーケットプレイスの royaltyFeeBps の順に解決する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ロイヤリティ"
+                ],
+                "summary": "販売価格に対するロイヤリティの受取人と金額を取得する",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "チェーンID",
+                        "name": "chain_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "コントラクトアドレス",
+                        "name": "contract",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "トークンID",
+                        "name": "token_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "販売価格（ネイティブ通貨の単位、例: 0.5）",
+                        "name": "sale_price",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.RoyaltyInfoOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/{id}": {
+            "get": {
+                "description": "ステータスは prepared → submitted → mined の順に進み、revertした場合は failed になる",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "二次流通"
+                ],
+                "summary": "取引の状態を取得する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "取引ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.TradeOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "トランザクションが取引の内容と一致するか確認し、取り込まれるまで追跡する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "二次流通"
+                ],
+                "summary": "送信したトランザクションを取引に登録する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "取引ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "トランザクションハッシュ",
+                        "name": "submit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.TradeSubmitInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.TradeOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "NFTミュージックのアカウントを登録する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "アカウント"
+                ],
+                "summary": "NFTミュージックのアカウントを登録する",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ports.UserOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "NFTミュージックのアカウントを登録する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "アカウント"
+                ],
+                "summary": "NFTミュージックのアカウントを登録する",
+                "parameters": [
+                    {
+                        "description": "ユーザー情報",
+                        "name": "users",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.UserOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/wallet/{wallet}": {
+            "get": {
+                "description": "ウォレットアドレスをキーにNFTミュージックのアカウント情報を取得する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "アカウント"
+                ],
+                "summary": "ウォレットアドレスからアカウント情報を取得する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ウォレットアドレス",
+                        "name": "wallet",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.UserOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "NFTミュージックのアカウントを登録する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "アカウント"
+                ],
+                "summary": "NFTミュージックのアカウントを登録する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.UserOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "NFTミュージックのアカウントの情報を変更する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "アカウント"
+                ],
+                "summary": "NFTミュージックのアカウントの情報を変更する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ユーザー情報",
+                        "name": "users",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.UserOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "NFTミュージックのアカウントを削除する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "アカウント"
+                ],
+                "summary": "NFTミュージックのアカウントを削除する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.UserOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "管理者がユーザーの権限（member / creator / admin）を変更する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "アカウント"
+                ],
+                "summary": "NFTミュージックのアカウントの権限を変更する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "権限",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.UserRoleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers": {
+            "get": {
+                "description": "出品中で有効期限内のバウチャーを新しい順に返す（署名は返さない）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "遅延ミント"
+                ],
+                "summary": "出品中のバウチャーを取得する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ジャンルID",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "最小価格（例: 0.01 ETH。単位が無い場合はETH）",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "最大価格（例: 0.5 ETH。単位が無い場合はETH）",
+                        "name": "max_price",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ports.VoucherOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers/{id}": {
+            "get": {
+                "description": "ステータスは prepared → listed → redeeming → redeemed の順に進み、取り消した場合は cancelled、出品中のまま有効期限が過ぎた場合は expired になる",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "遅延ミント"
+                ],
+                "summary": "バウチャーを取得する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "バウチャーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.VoucherOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "購入者に署名を渡している場合は、プラットフォームのウォレットから cancelVoucher を送信して引き換えられないようにする",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "遅延ミント"
+                ],
+                "summary": "バウチャーを取り消す",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "バウチャーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.VoucherOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers/{id}/purchase": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "購入者のウォレットで署名する redeem のトランザクション（送金額は販売価格）を返す。送信後は POST /vouchers/{id}/submit でトランザクションハッシュを登録する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "遅延ミント"
+                ],
+                "summary": "出品中のバウチャーを購入するトランザクションを作成する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "バウチャーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "購入するウォレット",
+                        "name": "purchase",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.VoucherPurchaseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.VoucherOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers/{id}/sign": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "署名者がバウチャーのウォレットと一致するか検証する。署名者がコレクションのコントラクトで署名を認められていない場合は、プラットフォームのウォレットから setMinter を送信する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "遅延ミント"
+                ],
+                "summary": "クリエイターの署名を登録してバウチャーを出品する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "バウチャーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "EIP-712 の署名",
+                        "name": "sign",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.VoucherSignInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.VoucherOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "トランザクションがバウチャーの内容と一致するか確認し、取り込まれるまで追跡する。revertした場合は出品中に戻る",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "遅延ミント"
+                ],
+                "summary": "送信した redeem のトランザクションを登録する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "バウチャーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "トランザクションハッシュ",
+                        "name": "submit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.TradeSubmitInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.VoucherOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallets": {
+            "get": {
+                "description": "仮想通貨のウォレットの情報を出力する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ウォレット情報"
+                ],
+                "summary": "ウォレットの情報をデータベースから抽出する",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ports.WalletOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "ウォレットアドレスが登録されているかウォレットテーブルを確認し、なければウォレットアドレスをデータベースに格納する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ウォレット情報"
+                ],
+                "summary": "ウォレットアドレスをデータベースに格納する",
+                "parameters": [
+                    {
+                        "description": "ウォレットアドレス",
+                        "name": "wallet",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ports.WalletInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ports.WalletOutput"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/ports.CreatedObject"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ports.ErrorResponseObject"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.AudioMetadata": {
+            "type": "object",
+            "properties": {
+                "bit_depth": {
+                    "description": "非可逆圧縮の場合は省略",
+                    "type": "integer",
+                    "example": 24
+                },
+                "bitrate": {
+                    "description": "平均のビットレート（bps）",
+                    "type": "integer",
+                    "example": 2304000
+                },
+                "channels": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "codec": {
+                    "description": "mp3 / flac / pcm / float / aac / alac / vorbis / opus",
+                    "type": "string",
+                    "example": "flac"
+                },
+                "duration": {
+                    "description": "再生時間（秒）",
+                    "type": "number",
+                    "example": 215.48
+                },
+                "format": {
+                    "description": "mp3 / flac / wav / aac / m4a / ogg",
+                    "type": "string",
+                    "example": "flac"
+                },
+                "sample_rate": {
+                    "type": "integer",
+                    "example": 96000
+                },
+                "tags": {
+                    "description": "埋め込みのタグ（title / artist / album / album_artist / genre / track / date / composer / isrc）",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "ports.AuthNonceOutput": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-11-04T20:51:26Z"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "music.threenext.com wants you to sign in with your Ethereum account:..."
+                },
+                "nonce": {
+                    "type": "string",
+                    "example": "4f2a9c1d8e7b6a5f"
+                }
+            }
+        },
+        "ports.AuthSessionOutput": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-11-04T20:51:26Z"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "3b5d5c3712955042212316173ccf37be800..."
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "01932563-f671-71ff-9a0d-c452de9d06aa"
+                },
+                "wallet": {
+                    "type": "string",
+                    "example": "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"
+                }
+            }
+        },
+        "ports.AuthUser": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "creator"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "01932563-f671-71ff-9a0d-c452de9d06aa"
+                },
+                "wallet": {
+                    "type": "string",
+                    "example": "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"
+                }
+            }
+        },
+        "ports.AuthVerifyInput": {
+            "type": "object",
+            "required": [
+                "message",
+                "signature"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "music.threenext.com wants you to sign in with your Ethereum account:..."
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "0x5d1c...1b"
+                }
+            }
+        },
+        "ports.BusinessMasterInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "建築"
+                }
+            }
+        },
+        "ports.BusinessMasterOutput": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-11-04T20:51:26Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "建築"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-11-04T20:51:26Z"
+                }
+            }
+        },
+        "ports.ChainOutput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "バックエンドが接続中のチェーン",
+                    "type": "boolean"
+                },
+                "chain_id": {
+                    "type": "integer",
+                    "example": 11155111
+                },
+                "confirmations": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "decimals": {
+                    "type": "integer",
+                    "example": 18
+                },
+                "explorer_url": {
+                    "type": "string",
+                    "example": "https://sepolia.etherscan.io"
+                },
+                "marketplace_address": {
+                    "type": "string",
+                    "example": "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Sepolia"
+                },
+                "rpc_url": {
+                    "type": "string",
+                    "example": "https://rpc.sepolia.org"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "ETH"
+                }
+            }
+        },
+        "ports.CollectionInput": {
+            "type": "object",
+            "properties": {
+                "banner_image_url": {
+                    "type": "string",
+                    "example": "https://www.yahoo.com/img/test.jpg"
+                },
+                "chain_id": {
+                    "description": "0 の場合は接続中のチェーン",
+                    "type": "integer",
+                    "example": 1337
+                },
+                "contract_address": {
+                    "description": "空の場合はコレクションのコントラクトをデプロイする",
+                    "type": "string",
+                    "example": "0x495f947276749ce646f68ac8c248420045075b34"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "私はいつでも明るいです"
+                },
+                "external_url": {
+                    "type": "string",
+                    "example": "https://www.yahoo.com"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://www.yahoo.com/img/test.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "建築"
+                },
+                "royalty": {
+                    "description": "非推奨: ロイヤリティ率（%）。royalty_bps が0の場合のみ royalty*100 bps として使う",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 0
+                },
+                "royalty_bps": {
+                    "description": "既定のロイヤリティ率（bps）",
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 0,
+                    "example": 750
+                },
+                "royalty_receiver": {
+                    "description": "チェックサム付きのアドレス（royalty_bps が0より大きい場合は必須）",
+                    "type": "string",
+                    "example": "0x495F947276749Ce646f68AC8c248420045075B34"
+                },
+                "symbol": {
+                    "description": "デプロイする場合のシンボル",
+                    "type": "string",
+                    "example": "ARCH"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
+                }
+            }
+        },
+        "ports.CollectionMintInput": {
+            "type": "object",
+            "properties": {
+                "to": {
+                    "description": "空の場合はリクエストしたユーザーのウォレット",
+                    "type": "string",
+                    "example": "0xc5309Ef694C81C4a8e946F2810e09516436daeB5"
+                }
+            }
+        },
+        "ports.CollectionOutput": {
+            "type": "object",
+            "properties": {
+                "banner_image_url": {
+                    "type": "string",
+                    "example": "https://www.yahoo.com/img/test.jpg"
+                },
+                "chain_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "contract_address": {
+                    "type": "string",
+                    "example": "0x495f947276749ce646f68ac8c248420045075b34"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-11-04T20:51:26Z"
+                },
+                "deploy_tx": {
+                    "description": "外部でデプロイした場合は空",
+                    "type": "string",
+                    "example": "0x3f1c0e2b7a9d4c5e6f8a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "私はいつでも明るいです"
+                },
+                "external_url": {
+                    "type": "string",
+                    "example": "https://www.yahoo.com"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://www.yahoo.com/img/test.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "建築"
+                },
+                "royalty": {
+                    "description": "非推奨: royalty_bps を%に切り捨てた値",
+                    "type": "integer",
+                    "example": 7
+                },
+                "royalty_bps": {
+                    "type": "integer",
+                    "example": 750
+                },
+                "royalty_receiver": {
+                    "type": "string",
+                    "example": "0x495F947276749Ce646f68AC8c248420045075B34"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-11-04T20:51:26Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
+                }
+            }
+        },
+        "ports.CollectionTokenOutput": {
+            "type": "object",
+            "properties": {
+                "block_number": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "chain_id": {
+                    "type": "integer",
+                    "example": 1337
+                },
+                "collection_id": {
+                    "type": "string",
+                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
+                },
+                "contract_address": {
+                    "type": "string",
+                    "example": "0x495f947276749ce646f68ac8c248420045075b34"
+                },
+                "owner": {
+                    "description": "バーンした場合は空",
+                    "type": "string",
+                    "example": "0xc5309Ef694C81C4a8e946F2810e09516436daeB5"
+                },
+                "token_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "tx_hash": {
+                    "type": "string",
+                    "example": "0x3f1c0e2b7a9d4c5e6f8a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6"
+                }
+            }
+        },
+        "ports.ContractAuditOutput": {
+            "type": "object",
+            "properties": {
+                "block_number": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "chain_id": {
+                    "type": "integer",
+                    "example": 1337
+                },
+                "contract_address": {
+                    "type": "string",
+                    "example": "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-11-04T20:51:26Z"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
+                },
+                "new_value": {
+                    "type": "string",
+                    "example": "2000000000000000"
+                },
+                "old_value": {
+                    "type": "string",
+                    "example": "1000000000000000"
+                },
+                "operation": {
+                    "description": "pause / unpause / update_royalty_fee / update_listing_price / renounce_ownership",
+                    "type": "string",
+                    "example": "update_listing_price"
+                },
+                "status": {
+                    "description": "pending / mined / failed（pending は取り込みを待っている操作）",
+                    "type": "string",
+                    "example": "mined"
+                },
+                "tx_hash": {
+                    "type": "string",
+                    "example": "0x9d605694113bde48dd07bf4d6f408906d3d5a9ee6656df600209ad6a38b2669e"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
+                },
+                "wallet": {
+                    "type": "string",
+                    "example": "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"
+                }
+            }
+        },
+        "ports.ContractStateOutput": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+                },
+                "chain_id": {
+                    "type": "integer",
+                    "example": 1337
+                },
+                "listing_price": {
+                    "description": "ミント料（wei）",
+                    "type": "string",
+                    "example": "1000000000000000"
+                },
+                "listing_price_formatted": {
+                    "type": "string",
+                    "example": "0.001 ETH"
+                },
+                "owner": {
+                    "description": "放棄した場合はゼロアドレス",
+                    "type": "string",
+                    "example": "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"
+                },
+                "paused": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "royalty_fee_bps": {
+                    "type": "integer",
+                    "example": 1000
+                }
+            }
+        },
+        "ports.CreatedObject": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "01932564-3432-7b56-b772-7c69586e1897"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "データベースには既に存在しています"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 201
+                }
+            }
+        },
+        "ports.DeploymentInput": {
+            "type": "object",
+            "required": [
+                "listing_price",
+                "name",
+                "symbol"
+            ],
+            "properties": {
+                "listing_price": {
+                    "description": "単位が無い場合はネイティブ通貨",
+                    "type": "string",
+                    "example": "0.001 ETH"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "NFT Music"
+                },
+                "royalty_fee_bps": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 0,
+                    "example": 1000
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "NFTM"
+                }
+            }
+        },
+        "ports.DeploymentOutput": {
+            "type": "object",
+            "properties": {
+                "abi_version": {
+                    "type": "string",
+                    "example": "0x5e0c31ab94d30313ef07942d605694113bde48dd07bf4d6f408906d3d5a9ee66"
+                },
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "address": {
+                    "type": "string",
+                    "example": "0x47CD2D0873833Ba015e0C31AB94D30313eF07942"
+                },
+                "block_number": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "chain_id": {
+                    "type": "integer",
+                    "example": 1337
+                },
+                "contract_name": {
+                    "type": "string",
+                    "example": "NFTMarketplace"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-11-04T20:51:26Z"
+                },
+                "deploy_tx": {
+                    "type": "string",
+                    "example": "0x9d605694113bde48dd07bf4d6f408906d3d5a9ee6656df600209ad6a38b2669e"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
+                }
+            }
+        },
+        "ports.DriftOutput": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "description": "DBの値",
+                    "type": "string",
+                    "example": "submitted"
+                },
+                "expected": {
+                    "description": "チェーンの値",
+                    "type": "string",
+                    "example": "failed"
+                },
+                "kind": {
+                    "description": "missing_row / missing_token / stale_status / token_id / wrong_owner / wrong_price",
+                    "type": "string",
+                    "example": "stale_status"
+                },
+                "repaired": {
+                    "type": "boolean"
+                },
+                "token_id": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "transaction_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ports.DriftReportOutput": {
+            "type": "object",
+            "properties": {
+                "chain_id": {
+                    "type": "integer",
+                    "example": 1337
+                },
+                "contract_address": {
+                    "type": "string",
+                    "example": "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+                },
+                "drifts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ports.DriftOutput"
+                    }
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "repair": {
+                    "type": "boolean"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "tokens": {
+                    "description": "チェーンのトークン数",
+                    "type": "integer",
+                    "example": 12
+                },
+                "transactions": {
+                    "description": "照合したトランザクションの行数",
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "ports.ErrorResponseObject": {
+            "type": "object",
+            "properties": {
+                "error_type": {
+                    "type": "string",
+                    "example": "リクエストの構文が不正やページが見つからない等のタイプ別"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "原因となるのエラーメッセージ"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "ports.GenreMasterInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "エレクトロニック・ダンス・ミュージック"
+                }
+            }
+        },
+        "ports.GenreMasterOutput": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-11-04T20:51:26Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01932573-3f06-78c6-9743-b495cfcc2167"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "エレクトロニック・ダンス・ミュージック"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-11-04T20:51:26Z"
+                }
+            }
+        },
+        "ports.IpfsMetaInput": {
+            "type": "object",
+            "required": [
+                "audio_cid",
+                "description",
+                "image_cid",
+                "name",
+                "video_cid"
+            ],
+            "properties": {
+                "audio_cid": {
+                    "type": "string",
+                    "example": "QmPYVinZ3fmWSetwc9FWMF9b93hfKjWiHtygXfVY8exwEM"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "良いNFTです"
+                },
+                "file_type": {
+                    "description": "audio_cid・video_cid のアップロードしたファイルから決める（どちらも無い場合のみ指定した値を使う）",
+                    "type": "string",
+                    "example": "audio"
+                },
+                "image_cid": {
+                    "type": "string",
+                    "example": "QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"
+                },
+                "insentive": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "name": {
+                    "type": "string",
+                    "example": "GoodNFT"
+                },
+                "video_cid": {
+                    "type": "string",
+                    "example": "QmQfESxcop5u9zjvK5hJyX8oKJJZCsEYVh4kTU64Wzmxqz"
+                }
+            }
+        },
+        "ports.IpfsOutput": {
+            "type": "object",
+            "properties": {
+                "audio": {
+                    "description": "音声ファイルの技術的なメタデータ",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AudioMetadata"
+                        }
+                    ]
+                },
+                "cid": {
+                    "type": "string"
+                },
+                "file_type": {
+                    "description": "アップロードしたファイルの種類（audio / image / video）",
+                    "type": "string",
+                    "example": "audio"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "preview_cid": {
+                    "description": "音声ファイルから切り出したプレビューのCID（切り出せない形式は省略）",
+                    "type": "string"
+                },
+                "preview_path": {
+                    "description": "プレビューを参照するパス",
+                    "type": "string"
+                },
+                "sha256": {
+                    "description": "アップロードしたファイルのSHA-256（16進）",
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 209715200
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ports.IpfsUploadProgress": {
+            "type": "object",
+            "properties": {
+                "filename": {
+                    "type": "string",
+                    "example": "track.wav"
+                },
+                "limit": {
+                    "description": "ファイルの種類ごとの上限",
+                    "type": "integer",
+                    "example": 314572800
+                },
+                "received": {
+                    "description": "受信したバイト数",
+                    "type": "integer",
+                    "example": 10485760
+                },
+                "size": {
+                    "description": "リクエストの Content-Length（分からない場合は -1）",
+                    "type": "integer",
+                    "example": 209715200
+                },
+                "upload_id": {
+                    "type": "string",
+                    "example": "0b7c6f4e-track-01"
+                }
+            }
+        },
+        "ports.ListingPriceInput": {
+            "type": "object",
+            "required": [
+                "listing_price"
+            ],
+            "properties": {
+                "listing_price": {
+                    "description": "単位が無い場合はネイティブ通貨",
+                    "type": "string",
+                    "example": "0.001 ETH"
+                }
+            }
+        },
+        "ports.MaintenanceInput": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "description": "この時間が過ぎるとメンテナンスモードを終える（省略した場合は解除するまで続け、再試行の目安に既定値を使う）",
+                    "type": "string",
+                    "example": "30m"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "データベースのメンテナンス中です"
+                }
+            }
+        },
+        "ports.MaintenanceOutput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "書き込みのAPIを止めている",
+                    "type": "boolean",
+                    "example": true
+                },
+                "checked_at": {
+                    "description": "最後にコントラクトの状態を確認した日時",
+                    "type": "string"
+                },
+                "contract_paused": {
+                    "description": "マーケットプレイスのコントラクトが一時停止中",
+                    "type": "boolean",
+                    "example": true
+                },
+                "operator": {
+                    "description": "運用者がメンテナンスモードにしている",
+                    "type": "boolean",
+                    "example": false
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "マーケットプレイスのコントラクトが一時停止されています"
+                },
+                "retry_after": {
+                    "description": "再試行までの秒数（停止していない場合は0）",
+                    "type": "integer",
+                    "example": 60
+                },
+                "until": {
+                    "description": "運用者が設定した終了時刻",
+                    "type": "string"
+                }
+            }
+        },
+        "ports.MintJobOutput": {
+            "type": "object",
+            "properties": {
+                "block_number": {
+                    "type": "integer"
+                },
+                "chain_id": {
+                    "type": "integer"
+                },
+                "contract_address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
                 },
                 "id": {
-                    "type": "string",
-                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
+                    "type": "string"
                 },
-                "name": {
+                "revert_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "queued / prepared / submitted / mined / confirmed / failed",
                     "type": "string",
-                    "example": "建築"
+                    "example": "queued"
+                },
+                "transaction": {
+                    "description": "prepared の場合のみ。クリエイターのウォレットで署名する",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ports.UnsignedTxOutput"
+                        }
+                    ]
+                },
+                "tx_hash": {
+                    "type": "string"
                 },
                 "updated_at": {
-                    "type": "string",
-                    "example": "2024-11-04T20:51:26Z"
+                    "type": "string"
                 }
             }
         },
-        "ports.CollectionInput": {
+        "ports.MintPreviewOutput": {
             "type": "object",
             "properties": {
-                "banner_image_url": {
+                "base_fee": {
                     "type": "string",
-                    "example": "https://www.yahoo.com/img/test.jpg"
+                    "example": "1000000000"
                 },
                 "chain_id": {
-                    "type": "integer",
-                    "example": 1
+                    "type": "integer"
                 },
-                "contract_address": {
+                "estimated_gas_cost": {
                     "type": "string",
-                    "example": "0x495f947276749ce646f68ac8c248420045075b34"
+                    "example": "600000000000000"
                 },
-                "description": {
+                "estimated_total": {
+                    "description": "ミント料 + 見込みのガス代",
                     "type": "string",
-                    "example": "私はいつでも明るいです"
+                    "example": "601000110000000"
                 },
-                "external_url": {
+                "fee_type": {
+                    "description": "legacy / dynamic",
                     "type": "string",
-                    "example": "https://www.yahoo.com"
+                    "example": "dynamic"
                 },
-                "image_url": {
+                "gas_limit": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "gas_price": {
                     "type": "string",
-                    "example": "https://www.yahoo.com/img/test.jpg"
+                    "example": "2000000000"
                 },
-                "name": {
+                "max_fee_per_gas": {
                     "type": "string",
-                    "example": "建築"
+                    "example": "3500000000"
                 },
-                "royalty": {
-                    "type": "integer",
-                    "example": 10
+                "max_gas_cost": {
+                    "type": "string",
+                    "example": "840000000000000"
                 },
-                "royalty_receiver": {
+                "max_priority_fee_per_gas": {
                     "type": "string",
-                    "example": "0x495f947276749ce646f68ac8c248420045075b34"
+                    "example": "1500000000"
                 },
-                "user_id": {
+                "value": {
+                    "description": "ミント料",
                     "type": "string",
-                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
+                    "example": "1000110000000"
                 }
             }
         },
-        "ports.CollectionOutput": {
+        "ports.MintSubmitInput": {
             "type": "object",
             "properties": {
-                "banner_image_url": {
+                "raw_tx": {
                     "type": "string",
-                    "example": "https://www.yahoo.com/img/test.jpg"
+                    "example": "0x02f8b4820539038459682f00845d21dba0830249f0945fbdb2315678afecb367f032d93f642f64180aa3..."
+                },
+                "tx_hash": {
+                    "type": "string",
+                    "example": "0x9d605694113bde48dd07bf4d6f408906d3d5a9ee6656df600209ad6a38b2669e"
+                }
+            }
+        },
+        "ports.NftInput": {
+            "type": "object",
+            "required": [
+                "chain_id",
+                "description",
+                "genre_id",
+                "insentive",
+                "name",
+                "status",
+                "wallet"
+            ],
+            "properties": {
+                "audio_cid": {
+                    "type": "string",
+                    "example": "QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"
                 },
                 "chain_id": {
+                    "description": "chains テーブルのチェーンID",
                     "type": "integer",
-                    "example": 1
+                    "example": 1337
                 },
-                "contract_address": {
+                "description": {
                     "type": "string",
-                    "example": "0x495f947276749ce646f68ac8c248420045075b34"
+                    "example": "良いNFTです"
                 },
-                "created_at": {
+                "file_type": {
                     "type": "string",
-                    "example": "2024-11-04T20:51:26Z"
+                    "example": "audio"
                 },
-                "description": {
+                "genre_id": {
                     "type": "string",
-                    "example": "私はいつでも明るいです"
+                    "example": "019504e3-d996-7979-8043-ef03fa7a6d89"
                 },
-                "external_url": {
+                "image_cid": {
                     "type": "string",
-                    "example": "https://www.yahoo.com"
+                    "example": "QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"
                 },
-                "id": {
+                "insentive": {
                     "type": "string",
-                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
+                    "example": "20"
                 },
-                "image_url": {
+                "name": {
                     "type": "string",
-                    "example": "https://www.yahoo.com/img/test.jpg"
+                    "example": "GoodNFT"
                 },
-                "name": {
+                "price": {
+                    "description": "省略した場合はコントラクトのミント料。指定する場合はミント料と一致する必要がある（単位が無い場合はネイティブ通貨）",
                     "type": "string",
-                    "example": "建築"
+                    "example": "0.015 ETH"
                 },
-                "royalty": {
-                    "type": "integer",
-                    "example": 10
+                "sale": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "royalty_receiver": {
+                "status": {
                     "type": "string",
-                    "example": "0x495f947276749ce646f68ac8c248420045075b34"
+                    "example": "mint"
                 },
-                "updated_at": {
+                "video_cid": {
                     "type": "string",
-                    "example": "2024-11-04T20:51:26Z"
+                    "example": "QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"
                 },
-                "user_id": {
+                "wallet": {
                     "type": "string",
-                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
+                    "example": "0xc5309Ef694C81C4a8e946F2810e09516436daeB5"
                 }
             }
         },
-        "ports.CreatedObject": {
+        "ports.PurchaseInput": {
             "type": "object",
+            "required": [
+                "wallet"
+            ],
             "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "01932564-3432-7b56-b772-7c69586e1897"
-                },
-                "message": {
+                "wallet": {
                     "type": "string",
-                    "example": "データベースには既に存在しています"
-                },
-                "status_code": {
-                    "type": "integer",
-                    "example": 201
+                    "example": "0xc5309Ef694C81C4a8e946F2810e09516436daeB5"
                 }
             }
         },
-        "ports.ErrorResponseObject": {
+        "ports.ReconcileInput": {
             "type": "object",
             "properties": {
-                "error_type": {
-                    "type": "string",
-                    "example": "リクエストの構文が不正やページが見つからない等のタイプ別"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "原因となるのエラーメッセージ"
-                },
-                "status_code": {
-                    "type": "integer",
-                    "example": 0
+                "repair": {
+                    "description": "安全に直せる食い違い（ステータス・トークンID・価格）をチェーンに合わせて直す",
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "ports.GenreMasterInput": {
+        "ports.RenounceOwnershipInput": {
             "type": "object",
             "required": [
-                "name"
+                "confirm"
             ],
             "properties": {
-                "name": {
+                "confirm": {
                     "type": "string",
-                    "example": "エレクトロニック・ダンス・ミュージック"
+                    "example": "0x5FbDB2315678afecb367f032d93F642f64180aa3"
                 }
             }
         },
-        "ports.GenreMasterOutput": {
+        "ports.ReplacementOutput": {
             "type": "object",
             "properties": {
                 "created_at": {
+                    "type": "string"
+                },
+                "gas_fee_cap": {
+                    "description": "dynamic のみ",
                     "type": "string",
-                    "example": "2024-11-04T20:51:26Z"
+                    "example": "4600000000"
+                },
+                "gas_price": {
+                    "description": "legacy のみ",
+                    "type": "string",
+                    "example": "2200000000"
+                },
+                "gas_tip_cap": {
+                    "description": "dynamic のみ",
+                    "type": "string",
+                    "example": "1700000000"
                 },
                 "id": {
                     "type": "string",
-                    "example": "01932573-3f06-78c6-9743-b495cfcc2167"
+                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
                 },
-                "name": {
+                "kind": {
+                    "description": "speed_up / cancel",
                     "type": "string",
-                    "example": "エレクトロニック・ダンス・ミュージック"
+                    "example": "speed_up"
                 },
-                "updated_at": {
+                "nonce": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "replaced_tx_hash": {
                     "type": "string",
-                    "example": "2024-11-04T20:51:26Z"
+                    "example": "0x9d605694113bde48dd07bf4d6f408906d3d5a9ee6656df600209ad6a38b2669e"
+                },
+                "status": {
+                    "description": "pending / mined / replaced / failed",
+                    "type": "string",
+                    "example": "pending"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "tx_hash": {
+                    "type": "string",
+                    "example": "0x2f1c3a5e6b7d8f90a1b2c3d4e5f60718293a4b5c6d7e8f9001a2b3c4d5e6f708"
                 }
             }
         },
-        "ports.IpfsMetaInput": {
+        "ports.ResellInput": {
             "type": "object",
             "required": [
-                "audio_cid",
-                "description",
-                "file_type",
-                "image_cid",
-                "name",
-                "video_cid"
+                "price",
+                "wallet"
             ],
             "properties": {
-                "audio_cid": {
+                "price": {
+                    "description": "単位が無い場合はETH",
                     "type": "string",
-                    "example": "QmPYVinZ3fmWSetwc9FWMF9b93hfKjWiHtygXfVY8exwEM"
+                    "example": "0.002 ETH"
                 },
-                "description": {
+                "wallet": {
                     "type": "string",
-                    "example": "良いNFTです"
+                    "example": "0xc5309Ef694C81C4a8e946F2810e09516436daeB5"
+                }
+            }
+        },
+        "ports.RoyaltyFeeInput": {
+            "type": "object",
+            "properties": {
+                "royalty_fee_bps": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 0,
+                    "example": 1000
+                }
+            }
+        },
+        "ports.RoyaltyInfoOutput": {
+            "type": "object",
+            "properties": {
+                "chain_id": {
+                    "type": "integer",
+                    "example": 1337
                 },
-                "file_type": {
+                "contract_address": {
                     "type": "string",
-                    "example": "audio"
+                    "example": "0x495F947276749Ce646f68AC8c248420045075B34"
                 },
-                "image_cid": {
+                "receiver": {
                     "type": "string",
-                    "example": "QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"
+                    "example": "0xc5309Ef694C81C4a8e946F2810e09516436daeB5"
                 },
-                "insentive": {
-                    "type": "integer",
-                    "example": 10
+                "remaining": {
+                    "description": "販売価格からロイヤリティを差し引いた金額（wei）",
+                    "type": "string",
+                    "example": "1850000000000000"
                 },
-                "name": {
+                "royalty_amount": {
+                    "description": "wei",
                     "type": "string",
-                    "example": "GoodNFT"
+                    "example": "150000000000000"
                 },
-                "video_cid": {
+                "royalty_bps": {
+                    "type": "integer",
+                    "example": 750
+                },
+                "sale_price": {
+                    "description": "wei",
                     "type": "string",
-                    "example": "QmQfESxcop5u9zjvK5hJyX8oKJJZCsEYVh4kTU64Wzmxqz"
-                }
-            }
-        },
-        "ports.IpfsOutput": {
-            "type": "object",
-            "properties": {
-                "cid": {
-                    "type": "string"
+                    "example": "2000000000000000"
                 },
-                "path": {
-                    "type": "string"
+                "source": {
+                    "description": "token / collection / marketplace",
+                    "type": "string",
+                    "example": "collection"
                 },
-                "user_id": {
-                    "type": "string"
+                "token_id": {
+                    "type": "string",
+                    "example": "0"
                 }
             }
         },
-        "ports.NftInput": {
+        "ports.TokenRoyaltyInput": {
             "type": "object",
             "required": [
-                "chain_id",
-                "description",
-                "genre_id",
-                "insentive",
-                "name",
-                "price",
-                "status",
-                "wallet"
+                "receiver"
             ],
             "properties": {
-                "audio_cid": {
+                "receiver": {
+                    "description": "チェックサム付きのアドレス",
+                    "type": "string",
+                    "example": "0xc5309Ef694C81C4a8e946F2810e09516436daeB5"
+                },
+                "royalty_bps": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 0,
+                    "example": 750
+                }
+            }
+        },
+        "ports.TokenRoyaltyOutput": {
+            "type": "object",
+            "properties": {
+                "chain_id": {
+                    "type": "integer",
+                    "example": 1337
+                },
+                "collection_id": {
                     "type": "string",
-                    "example": "QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"
+                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
                 },
-                "chain_id": {
+                "contract_address": {
+                    "type": "string",
+                    "example": "0x495F947276749Ce646f68AC8c248420045075B34"
+                },
+                "receiver": {
+                    "type": "string",
+                    "example": "0xc5309Ef694C81C4a8e946F2810e09516436daeB5"
+                },
+                "royalty_bps": {
                     "type": "integer",
-                    "example": 222
+                    "example": 750
                 },
-                "description": {
+                "token_id": {
                     "type": "string",
-                    "example": "良いNFTです"
+                    "example": "0"
                 },
-                "file_type": {
+                "tx_hash": {
+                    "description": "プラットフォームでデプロイしたコレクションの場合はチェーンにも設定する",
                     "type": "string",
-                    "example": "audio"
+                    "example": "0x3f1c0e2b7a9d4c5e6f8a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6"
                 },
-                "genre_id": {
+                "updated_at": {
                     "type": "string",
-                    "example": "019504e3-d996-7979-8043-ef03fa7a6d89"
+                    "example": "2024-11-04T20:51:26Z"
+                }
+            }
+        },
+        "ports.TradeOutput": {
+            "type": "object",
+            "properties": {
+                "block_number": {
+                    "type": "integer"
                 },
-                "image_cid": {
+                "contract_address": {
                     "type": "string",
-                    "example": "QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"
+                    "example": "0x47CD2D0873833Ba015e0C31AB94D30313eF07942"
                 },
-                "insentive": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "string",
-                    "example": "20"
+                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
                 },
-                "name": {
+                "kind": {
                     "type": "string",
-                    "example": "GoodNFT"
+                    "example": "purchase"
                 },
                 "price": {
+                    "description": "wei",
                     "type": "string",
-                    "example": "1000.11"
+                    "example": "2000000000000000"
                 },
-                "sale": {
-                    "type": "boolean",
-                    "example": false
+                "revert_reason": {
+                    "type": "string"
+                },
+                "royalty": {
+                    "description": "販売価格に対するロイヤリティの内訳（prepared の場合のみ）",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ports.RoyaltyInfoOutput"
+                        }
+                    ]
                 },
                 "status": {
+                    "description": "prepared / submitted / mined / failed",
                     "type": "string",
-                    "example": "mint"
+                    "example": "prepared"
                 },
-                "video_cid": {
+                "token_id": {
                     "type": "string",
-                    "example": "QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"
+                    "example": "1"
+                },
+                "transaction": {
+                    "description": "prepared の場合のみ",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ports.UnsignedTxOutput"
+                        }
+                    ]
+                },
+                "tx_hash": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 },
                 "wallet": {
                     "type": "string",
@@ -1925,14 +6439,13 @@ const docTemplate = `{
                 }
             }
         },
-        "ports.SignerOutput": {
+        "ports.TradeSubmitInput": {
             "type": "object",
+            "required": [
+                "tx_hash"
+            ],
             "properties": {
-                "address_hex": {
-                    "type": "string",
-                    "example": "0x47CD2D0873833Ba015e0C31AB94D30313eF07942"
-                },
-                "transaction_hash": {
+                "tx_hash": {
                     "type": "string",
                     "example": "0x9d605694113bde48dd07bf4d6f408906d3d5a9ee6656df600209ad6a38b2669e"
                 }
@@ -1941,14 +6454,29 @@ const docTemplate = `{
         "ports.TransactionOutput": {
             "type": "object",
             "properties": {
+                "audio": {
+                    "description": "メタデータに含まれる音声ファイルの技術的なメタデータ",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AudioMetadata"
+                        }
+                    ]
+                },
                 "audio_url": {
+                    "description": "フル音源（以前に作成したメタデータのみ。所有者は GET /nfts/detail/{transaction_id}/master で取得する）",
                     "type": "string"
                 },
                 "chain_id": {
                     "type": "integer"
                 },
+                "contract_address": {
+                    "type": "string",
+                    "example": "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+                },
                 "cost": {
-                    "type": "integer"
+                    "description": "wei",
+                    "type": "string",
+                    "example": "15210000000000000"
                 },
                 "created_at": {
                     "type": "string"
@@ -1974,14 +6502,29 @@ const docTemplate = `{
                 "insentive": {
                     "type": "integer"
                 },
+                "listing_state": {
+                    "description": "minted / lazy（lazy の場合 id はバウチャーID）",
+                    "type": "string",
+                    "example": "minted"
+                },
                 "name": {
                     "type": "string"
                 },
                 "nonce": {
                     "type": "integer"
                 },
+                "preview_url": {
+                    "description": "購入前に試聴するプレビュー",
+                    "type": "string"
+                },
                 "price": {
-                    "type": "number"
+                    "description": "wei",
+                    "type": "string",
+                    "example": "15000000000000000"
+                },
+                "price_formatted": {
+                    "type": "string",
+                    "example": "0.015 ETH"
                 },
                 "sale": {
                     "type": "boolean"
@@ -1993,11 +6536,16 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "token_id": {
-                    "type": "integer"
+                    "description": "ミントが取り込まれるまでは空",
+                    "type": "string",
+                    "example": "1"
                 },
                 "token_url": {
                     "type": "string"
                 },
+                "tx_hash": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 },
@@ -2009,6 +6557,40 @@ const docTemplate = `{
                 }
             }
         },
+        "ports.UnsignedTxOutput": {
+            "type": "object",
+            "properties": {
+                "chain_id": {
+                    "type": "integer",
+                    "example": 1337
+                },
+                "data": {
+                    "type": "string",
+                    "example": "0xbe9af5360000000000000000000000000000000000000000000000000000000000000001"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "0xc5309Ef694C81C4a8e946F2810e09516436daeB5"
+                },
+                "gas": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "nonce": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "to": {
+                    "type": "string",
+                    "example": "0x47CD2D0873833Ba015e0C31AB94D30313eF07942"
+                },
+                "value": {
+                    "description": "wei",
+                    "type": "string",
+                    "example": "2000000000000000"
+                }
+            }
+        },
         "ports.UserInput": {
             "type": "object",
             "required": [
@@ -2047,10 +6629,6 @@ const docTemplate = `{
                     "minLength": 10,
                     "example": "私はいつでも明るいです"
                 },
-                "role": {
-                    "type": "string",
-                    "example": "member"
-                },
                 "wallet": {
                     "type": "string",
                     "example": "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"
@@ -2118,6 +6696,204 @@ const docTemplate = `{
                 }
             }
         },
+        "ports.UserRoleInput": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "member",
+                        "creator",
+                        "admin"
+                    ],
+                    "example": "creator"
+                }
+            }
+        },
+        "ports.VoucherInput": {
+            "type": "object",
+            "required": [
+                "description",
+                "expires_at",
+                "genre_id",
+                "name",
+                "price",
+                "wallet"
+            ],
+            "properties": {
+                "audio_cid": {
+                    "type": "string",
+                    "example": "QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "良いNFTです"
+                },
+                "expires_at": {
+                    "description": "有効期限（秒未満は切り捨て）",
+                    "type": "string",
+                    "example": "2026-12-31T23:59:59+09:00"
+                },
+                "file_type": {
+                    "type": "string",
+                    "example": "audio"
+                },
+                "genre_id": {
+                    "type": "string",
+                    "example": "019504e3-d996-7979-8043-ef03fa7a6d89"
+                },
+                "image_cid": {
+                    "type": "string",
+                    "example": "QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "GoodNFT"
+                },
+                "price": {
+                    "description": "単位が無い場合はネイティブ通貨",
+                    "type": "string",
+                    "example": "0.015 ETH"
+                },
+                "royalty_bps": {
+                    "description": "0 の場合はコレクションの既定値",
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 0,
+                    "example": 750
+                },
+                "royalty_receiver": {
+                    "description": "チェックサム付きのアドレス（royalty_bps が0より大きい場合は必須）",
+                    "type": "string",
+                    "example": "0xc5309Ef694C81C4a8e946F2810e09516436daeB5"
+                },
+                "video_cid": {
+                    "type": "string",
+                    "example": "QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"
+                },
+                "wallet": {
+                    "description": "署名するクリエイターのウォレット（代金の受取人）",
+                    "type": "string",
+                    "example": "0xc5309Ef694C81C4a8e946F2810e09516436daeB5"
+                }
+            }
+        },
+        "ports.VoucherOutput": {
+            "type": "object",
+            "properties": {
+                "buyer": {
+                    "type": "string",
+                    "example": "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"
+                },
+                "chain_id": {
+                    "type": "integer",
+                    "example": 1337
+                },
+                "collection_id": {
+                    "type": "string",
+                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
+                },
+                "contract_address": {
+                    "type": "string",
+                    "example": "0x495F947276749Ce646f68AC8c248420045075B34"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-12-31T23:59:59+09:00"
+                },
+                "genre_id": {
+                    "type": "string",
+                    "example": "019504e3-d996-7979-8043-ef03fa7a6d89"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0193254c-a151-7c4c-b06a-259da7258a27"
+                },
+                "price": {
+                    "description": "wei",
+                    "type": "string",
+                    "example": "15000000000000000"
+                },
+                "price_formatted": {
+                    "type": "string",
+                    "example": "0.015 ETH"
+                },
+                "royalty_bps": {
+                    "type": "integer",
+                    "example": 750
+                },
+                "royalty_receiver": {
+                    "type": "string",
+                    "example": "0xc5309Ef694C81C4a8e946F2810e09516436daeB5"
+                },
+                "signer": {
+                    "type": "string",
+                    "example": "0xc5309Ef694C81C4a8e946F2810e09516436daeB5"
+                },
+                "status": {
+                    "description": "prepared / listed / redeeming / redeemed / cancelled / expired",
+                    "type": "string",
+                    "example": "listed"
+                },
+                "token_id": {
+                    "description": "redeemed の場合のみ",
+                    "type": "string",
+                    "example": "0"
+                },
+                "token_url": {
+                    "type": "string",
+                    "example": "/ipfs/QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"
+                },
+                "transaction": {
+                    "description": "購入した場合のみ。購入者のウォレットで署名する",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ports.UnsignedTxOutput"
+                        }
+                    ]
+                },
+                "tx_hash": {
+                    "type": "string"
+                },
+                "typed_data": {
+                    "description": "prepared の場合のみ。eth_signTypedData_v4 でクリエイターのウォレットで署名する",
+                    "type": "object"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "ports.VoucherPurchaseInput": {
+            "type": "object",
+            "required": [
+                "wallet"
+            ],
+            "properties": {
+                "wallet": {
+                    "type": "string",
+                    "example": "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"
+                }
+            }
+        },
+        "ports.VoucherSignInput": {
+            "type": "object",
+            "required": [
+                "signature"
+            ],
+            "properties": {
+                "signature": {
+                    "type": "string",
+                    "example": "0x5c2a3f...1b"
+                }
+            }
+        },
         "ports.WalletInput": {
             "type": "object",
             "required": [
@@ -2150,6 +6926,7 @@ const docTemplate = `{
     },
     "securityDefinitions": {
         "ApiKeyAuth": {
+            "description": "POST /auth/verify で発行したセッショントークンを \"Bearer \u003cセッショントークン\u003e\" の形式で指定します",
             "type": "apiKey",
             "name": "Authorization",
             "in": "header"
@@ -13,27 +13,23 @@ type IpfsJSON struct {
 	Description string `json:"description"`
 	FileType    string `json:"file_type"`
 	ImageCid    string `json:"image_cid"`
-	AudioCid    string `json:"audio_cid"`
+	AudioCid    string `json:"audio_cid,omitempty"` // フル音源は公開するメタデータに含めない（以前に作成したメタデータのみ）
 	VideoCid    string `json:"video_cid"`
 	Insentive   int    `json:"insentive"`
+
+	Audio       *AudioMetadata `json:"audio,omitempty"`        // 音声ファイルの技術的なメタデータ
+	PreviewCid  string         `json:"preview_cid,omitempty"`  // 購入前に試聴するプレビューのCID
+	AudioSha256 string         `json:"audio_sha256,omitempty"` // フル音源のSHA-256（所有者にフル音源を返すときの検索に使う）
 }
 
+// IpfsAdd は保存したコンテンツ（Hash はCID）
 type IpfsAdd struct {
 	Name string `json:"Name"`
 	Hash string `json:"Hash"`
 	Size string `json:"Size"`
 }
 
-type IpfsPublish struct {
-	Name  string `json:"Name"`
-	Value string `json:"Value"`
-}
-
-type IpfsPins struct {
-	Pins     []string `json:"Pins"`
-	Progress int      `json:"Progress"`
-}
-
+// IpfsResolve はコンテンツを参照するパス（/ipfs/<cid>）
 type IpfsResolve struct {
 	Path string `json:"Path"`
 }
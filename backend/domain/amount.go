@@ -0,0 +1,187 @@
+// Package domain は、ドメインモデルとビジネスルールを定義します。
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+// amountUnits は単位を付けて金額を入力する場合の小数点以下の桁数
+var amountUnits = map[string]int{
+	"wei":    0,
+	"kwei":   3,
+	"mwei":   6,
+	"gwei":   9,
+	"szabo":  12,
+	"finney": 15,
+	"ether":  18,
+	"eth":    18,
+}
+
+// Amount はweiで表した金額です
+// JSONではweiの10進数の文字列、DBでは DECIMAL(78,0) で扱います（NULL を許すカラムには *Amount を使います）
+type Amount struct {
+	wei *big.Int
+}
+
+// NewAmount はweiの金額を作成します
+func NewAmount(wei *big.Int) Amount {
+	if wei == nil {
+		return Amount{wei: new(big.Int)}
+	}
+	return Amount{wei: new(big.Int).Set(wei)}
+}
+
+// ParseAmount は "15000", "15 gwei", "0.015 ETH" のような金額を読み取ります（単位が無い場合はwei）
+// JSON やDBのweiの金額に使います。利用者が入力した価格には ParsePrice を使います
+func ParseAmount(input string) (Amount, error) {
+	return parseAmount(input, amountUnits, "wei")
+}
+
+// ParsePrice は利用者が入力した価格を読み取ります（単位が無い場合はETH）
+// フロントエンドと以前のAPIは価格をETHで入力するため、weiで指定する場合は "15000 wei" のように単位を付けます
+func ParsePrice(input string) (Amount, error) {
+	return parseAmount(input, amountUnits, "eth")
+}
+
+func parseAmount(input string, units map[string]int, defaultUnit string) (Amount, error) {
+	input = strings.TrimSpace(input)
+	split := strings.IndexFunc(input, unicode.IsLetter)
+	number, unit := input, defaultUnit
+	if split >= 0 {
+		number, unit = strings.TrimSpace(input[:split]), strings.ToLower(strings.TrimSpace(input[split:]))
+	}
+
+	decimals, ok := units[unit]
+	if !ok {
+		return Amount{}, fmt.Errorf("BadRequest: 金額の単位 %s には対応していません", unit)
+	}
+	rat, ok := new(big.Rat).SetString(number)
+	if !ok || number == "" || strings.ContainsAny(number, "/eE") {
+		return Amount{}, fmt.Errorf("BadRequest: 金額 %q が正しくありません", input)
+	}
+	if rat.Sign() < 0 {
+		return Amount{}, fmt.Errorf("BadRequest: 金額 %q が負の値です", input)
+	}
+	rat.Mul(rat, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	if !rat.IsInt() {
+		return Amount{}, fmt.Errorf("BadRequest: 金額 %q に1wei未満の端数があります", input)
+	}
+	return Amount{wei: new(big.Int).Set(rat.Num())}, nil
+}
+
+// Wei はweiの金額を返します（変更しても元の金額は変わりません）
+func (amount Amount) Wei() *big.Int {
+	if amount.wei == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(amount.wei)
+}
+
+// Sign は金額が負・0・正のとき -1・0・1 を返します
+func (amount Amount) Sign() int {
+	if amount.wei == nil {
+		return 0
+	}
+	return amount.wei.Sign()
+}
+
+// Cmp は金額を比較します
+func (amount Amount) Cmp(other Amount) int {
+	return amount.Wei().Cmp(other.Wei())
+}
+
+// Add は金額を足します
+func (amount Amount) Add(other Amount) Amount {
+	return Amount{wei: new(big.Int).Add(amount.Wei(), other.Wei())}
+}
+
+// String はweiの10進数の文字列を返します
+func (amount Amount) String() string {
+	return amount.Wei().String()
+}
+
+// Format は小数点以下 decimals 桁の単位で表した10進数の文字列を返します（末尾の0は省略）
+func (amount Amount) Format(decimals int) string {
+	wei := amount.Wei()
+	if decimals <= 0 {
+		return wei.String()
+	}
+	digits := new(big.Int).Abs(wei).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	integer, fraction := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	if wei.Sign() < 0 {
+		integer = "-" + integer
+	}
+	if fraction == "" {
+		return integer
+	}
+	return integer + "." + fraction
+}
+
+// MarshalJSON はweiの10進数の文字列として出力します
+func (amount Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(amount.String())
+}
+
+// UnmarshalJSON は文字列か数値の金額を読み取ります（"15 gwei" のように単位も指定できます）
+func (amount *Amount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*amount = Amount{}
+		return nil
+	}
+	var input string
+	if err := json.Unmarshal(data, &input); err != nil {
+		input = string(data)
+	}
+	parsed, err := ParseAmount(input)
+	if err != nil {
+		return err
+	}
+	*amount = parsed
+	return nil
+}
+
+// Value はDBにweiの10進数で保存します
+func (amount Amount) Value() (driver.Value, error) {
+	return amount.String(), nil
+}
+
+// Scan はDBのweiの10進数を読み取ります
+func (amount *Amount) Scan(src any) error {
+	var input string
+	switch value := src.(type) {
+	case nil:
+		*amount = Amount{}
+		return nil
+	case []byte:
+		input = string(value)
+	case string:
+		input = value
+	case int64:
+		*amount = Amount{wei: big.NewInt(value)}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Amount", src)
+	}
+
+	// DECIMAL の小数点以下（0のみ）は取り除く
+	input, _, _ = strings.Cut(input, ".")
+	wei, ok := new(big.Int).SetString(input, 10)
+	if !ok {
+		return fmt.Errorf("invalid amount %q", input)
+	}
+	*amount = Amount{wei: wei}
+	return nil
+}
+
+// GormDataType はマイグレーションで作成するカラムの型です
+func (Amount) GormDataType() string {
+	return "decimal(78,0)"
+}
@@ -0,0 +1,104 @@
+// Package domain は、ドメインモデルとビジネスルールを定義します。
+package domain
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		input string
+		wei   string
+	}{
+		{"15000", "15000"},
+		{"15 gwei", "15000000000"},
+		{"0.015 ETH", "15000000000000000"},
+		{"0.015ether", "15000000000000000"},
+		{"1000.11 eth", "1000110000000000000000"},
+		// float64 では桁が落ちる金額
+		{"123456789012345678901234567890", "123456789012345678901234567890"},
+	}
+	for _, test := range tests {
+		amount, err := ParseAmount(test.input)
+		require.NoError(t, err, test.input)
+		assert.Equal(t, test.wei, amount.String(), test.input)
+	}
+
+	t.Run("異常系: 正しくない金額は読み取らない", func(t *testing.T) {
+		for _, input := range []string{"", "abc", "-1", "0.5 wei", "1e18", "1/2 ETH", "1 BNB"} {
+			_, err := ParseAmount(input)
+			assert.ErrorContains(t, err, "BadRequest", input)
+		}
+	})
+
+	t.Run("正常系: 利用者が入力した価格は単位が無い場合ETHで読み取る", func(t *testing.T) {
+		amount, err := ParsePrice("0.001")
+		require.NoError(t, err)
+		assert.Equal(t, "1000000000000000", amount.String())
+
+		amount, err = ParsePrice("15000 wei")
+		require.NoError(t, err)
+		assert.Equal(t, "15000", amount.String())
+	})
+
+	t.Run("正常系: チェーンのネイティブ通貨の単位で読み取る", func(t *testing.T) {
+		chain := &Chain{Symbol: "BNB", Decimals: 18}
+		amount, err := chain.ParsePrice("0.5 BNB")
+		require.NoError(t, err)
+		assert.Equal(t, "500000000000000000", amount.String())
+		assert.Equal(t, "0.5 BNB", chain.FormatAmount(amount))
+
+		amount, err = chain.ParsePrice("1")
+		require.NoError(t, err)
+		assert.Equal(t, "1000000000000000000", amount.String())
+	})
+}
+
+func TestAmount(t *testing.T) {
+	t.Run("正常系: 小数点以下の桁数で表示する", func(t *testing.T) {
+		assert.Equal(t, "0.015", NewAmount(big.NewInt(15000000000000000)).Format(18))
+		assert.Equal(t, "2", NewAmount(big.NewInt(2000)).Format(3))
+		assert.Equal(t, "0", Amount{}.Format(18))
+	})
+
+	t.Run("正常系: JSONではweiの文字列で扱う", func(t *testing.T) {
+		data, err := json.Marshal(struct {
+			Price Amount `json:"price"`
+		}{NewAmount(big.NewInt(1000))})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"price":"1000"}`, string(data))
+
+		var input struct {
+			Price Amount `json:"price"`
+			Value Amount `json:"value"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(`{"price":"15 gwei","value":42}`), &input))
+		assert.Equal(t, "15000000000", input.Price.String())
+		assert.Equal(t, "42", input.Value.String())
+	})
+
+	t.Run("正常系: DECIMALのカラムを読み書きする", func(t *testing.T) {
+		value, err := Amount{}.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "0", value)
+
+		var amount Amount
+		require.NoError(t, amount.Scan([]byte("15000000000000000.0")))
+		assert.Equal(t, "15000000000000000", amount.String())
+		require.NoError(t, amount.Scan(int64(7)))
+		assert.Equal(t, "7", amount.String())
+		assert.Error(t, amount.Scan(1.5))
+	})
+
+	t.Run("正常系: Weiを変更しても元の金額は変わらない", func(t *testing.T) {
+		amount := NewAmount(big.NewInt(10))
+		amount.Wei().SetInt64(99)
+		assert.Equal(t, "10", amount.String())
+		assert.Equal(t, "30", amount.Add(NewAmount(big.NewInt(20))).String())
+	})
+}
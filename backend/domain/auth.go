@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuthNonce はSIWEログインで発行するノンスの構造体
+type AuthNonce struct {
+	Nonce     string       `gorm:"primaryKey"`
+	Wallet    string       `gorm:"wallet"`
+	ChainID   int          `gorm:"chain_id"`
+	ExpiresAt time.Time    `gorm:"expires_at"`
+	UsedAt    sql.NullTime `gorm:"used_at"`
+	CreatedAt time.Time    `gorm:"created_at"`
+}
+
+// Session はログインセッションの構造体（トークンはハッシュ値のみ保存する）
+type Session struct {
+	ID        uuid.UUID `gorm:"id"`
+	TokenHash string    `gorm:"token_hash"`
+	UserID    uuid.UUID `gorm:"user_id"`
+	Wallet    string    `gorm:"wallet"`
+	ExpiresAt time.Time `gorm:"expires_at"`
+	CreatedAt time.Time `gorm:"created_at"`
+}
@@ -0,0 +1,38 @@
+// Package domain は、ドメインモデルとビジネスルールを定義します。
+package domain
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// Chain は対応しているチェーンの構造体です
+type Chain struct {
+	ChainID            int            `gorm:"primaryKey;autoIncrement:false"`
+	Name               string         `gorm:"name"`
+	Symbol             string         `gorm:"symbol"`
+	Decimals           int            `gorm:"decimals"`
+	RPCURL             string         `gorm:"column:rpc_url"`
+	ExplorerURL        string         `gorm:"column:explorer_url"`
+	MarketplaceAddress sql.NullString `gorm:"marketplace_address"`
+	Confirmations      uint64         `gorm:"confirmations"`
+	CreatedAt          time.Time      `gorm:"created_at"`
+	UpdatedAt          time.Time      `gorm:"updated_at"`
+}
+
+// ParsePrice は "0.015 BNB" のようにチェーンのネイティブ通貨の単位でも利用者が入力した価格を読み取ります（単位が無い場合はネイティブ通貨）
+func (chain *Chain) ParsePrice(input string) (Amount, error) {
+	units := make(map[string]int, len(amountUnits)+1)
+	for unit, decimals := range amountUnits {
+		units[unit] = decimals
+	}
+	symbol := strings.ToLower(chain.Symbol)
+	units[symbol] = chain.Decimals
+	return parseAmount(input, units, symbol)
+}
+
+// FormatAmount は金額をネイティブ通貨の単位で表示します（例: "0.015 ETH"）
+func (chain *Chain) FormatAmount(amount Amount) string {
+	return amount.Format(chain.Decimals) + " " + chain.Symbol
+}
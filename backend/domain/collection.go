@@ -13,11 +13,12 @@ type Collection struct {
 	ChainID         int            `gorm:"chain_id"`
 	Name            string         `gorm:"name"`
 	ContractAddress string         `gorm:"contract_address"`
+	DeployTx        sql.NullString `gorm:"deploy_tx" swaggertype:"string"`
 	Description     sql.NullString `gorm:"description"`
 	ImageURL        sql.NullString `gorm:"image_url" swaggertype:"string"`
 	BannerImageURL  sql.NullString `gorm:"banner_image_url" swaggertype:"string"`
 	ExternalURL     sql.NullString `gorm:"external_url" swaggertype:"string"`
-	Royalty         int            `gorm:"royalty"`
+	RoyaltyBps      int            `gorm:"royalty_bps"`
 	RoyaltyReceiver string         `gorm:"royalty_receiver" swaggertype:"string"`
 	CreatedAt       time.Time      `gorm:"created_at"`
 	UpdatedAt       time.Time      `gorm:"updated_at"`
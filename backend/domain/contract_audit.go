@@ -0,0 +1,42 @@
+// Package domain は、ドメインモデルとビジネスルールを定義します。
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 管理者が実行するコントラクトのオーナー操作
+const (
+	ContractOperationPause              = "pause"
+	ContractOperationUnpause            = "unpause"
+	ContractOperationUpdateRoyaltyFee   = "update_royalty_fee"
+	ContractOperationUpdateListingPrice = "update_listing_price"
+	ContractOperationRenounceOwnership  = "renounce_ownership"
+)
+
+// オーナー操作の結果
+const (
+	ContractAuditStatusPending = "pending" // 送信済みで取り込み待ち
+	ContractAuditStatusMined   = "mined"   // ブロックに取り込まれた
+	ContractAuditStatusFailed  = "failed"  // 送信に失敗したかrevertした
+)
+
+// ContractAudit はコントラクトのオーナー操作の監査ログです（変更前の値は送信の直前にチェーンから読んだ値、変更後の値は指定した値）
+type ContractAudit struct {
+	ID              uuid.UUID      `gorm:"id"`
+	ChainID         int            `gorm:"chain_id"`
+	ContractAddress string         `gorm:"contract_address"`
+	Operation       string         `gorm:"operation"`
+	OldValue        string         `gorm:"old_value"`
+	NewValue        string         `gorm:"new_value"`
+	TxHash          sql.NullString `gorm:"tx_hash"`
+	BlockNumber     sql.NullInt64  `gorm:"block_number"`
+	Status          string         `gorm:"status"`
+	Error           sql.NullString `gorm:"error"`
+	UserID          uuid.UUID      `gorm:"user_id"`
+	Wallet          string         `gorm:"wallet"`
+	CreatedAt       time.Time      `gorm:"created_at"`
+}
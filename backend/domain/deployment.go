@@ -0,0 +1,24 @@
+// Package domain は、ドメインモデルとビジネスルールを定義します。
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ContractNFTMarketplace はNFTマーケットプレイスのコントラクト名
+const ContractNFTMarketplace = "NFTMarketplace"
+
+// Deployment はデプロイ済みコントラクトの構造体です
+type Deployment struct {
+	ID           uuid.UUID     `gorm:"id"`
+	ChainID      int           `gorm:"chain_id"`
+	ContractName string        `gorm:"contract_name"`
+	Address      string        `gorm:"address"`
+	DeployTx     string        `gorm:"deploy_tx"`
+	BlockNumber  uint64        `gorm:"block_number"`
+	AbiVersion   string        `gorm:"abi_version"`
+	DeployedBy   uuid.NullUUID `gorm:"deployed_by"`
+	CreatedAt    time.Time     `gorm:"created_at"`
+}
@@ -0,0 +1,36 @@
+// Package domain は、ドメインモデルとビジネスルールを定義します。
+package domain
+
+import "time"
+
+// DBとチェーンの食い違いの種類
+const (
+	DriftMissingRow   = "missing_row"   // チェーンのトークンに対応するトランザクションの行が無い
+	DriftMissingToken = "missing_token" // 行のトークンがチェーンに無い
+	DriftStaleStatus  = "stale_status"  // 行のステータスがレシートと異なる
+	DriftTokenID      = "token_id"      // 行のトークンIDがレシートと異なる（未記録を含む）
+	DriftWrongOwner   = "wrong_owner"   // 取り込んだTransferの所有者が ownerOf と異なる
+	DriftWrongPrice   = "wrong_price"   // 行の価格がミントしたときの価格と異なる
+)
+
+// Drift はDBとチェーンの食い違いです（Expected はチェーンの値、Actual はDBの値）
+type Drift struct {
+	Kind          string
+	TransactionID string // 行が無い場合は空
+	TokenID       string // 分からない場合は空
+	Expected      string
+	Actual        string
+	Repaired      bool
+}
+
+// DriftReport はDBとチェーンを照合した結果です
+type DriftReport struct {
+	ChainID         int
+	ContractAddress string
+	Tokens          int // チェーンのトークン数
+	Transactions    int // 照合したトランザクションの行数
+	Repair          bool
+	Drifts          []Drift
+	StartedAt       time.Time
+	FinishedAt      time.Time
+}
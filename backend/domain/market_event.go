@@ -0,0 +1,56 @@
+// Package domain は、ドメインモデルとビジネスルールを定義します。
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// マーケットのイベントの種類
+const (
+	MarketEventMint   = "mint"   // MarketItemCreated（createToken）
+	MarketEventSale   = "sale"   // MarketItemSold（createMarketSale）
+	MarketEventResale = "resale" // 所有者からコントラクトへのTransfer（resellToken）
+)
+
+// MarketEvent はチェーンから取り込んだミント・販売・再出品の構造体です
+type MarketEvent struct {
+	ID              uuid.UUID `gorm:"id"`
+	ChainID         int       `gorm:"chain_id"`
+	ContractAddress string    `gorm:"contract_address"`
+	BlockNumber     uint64    `gorm:"block_number"`
+	BlockHash       string    `gorm:"block_hash"`
+	TxHash          string    `gorm:"tx_hash"`
+	LogIndex        uint      `gorm:"log_index"`
+	EventType       string    `gorm:"event_type"`
+	TokenID         string    `gorm:"token_id"` // uint256を10進数で保存する
+	Seller          string    `gorm:"seller"`
+	Buyer           string    `gorm:"buyer"`
+	Price           *Amount   `gorm:"price"` // 取得できない場合は nil
+	CreatedAt       time.Time `gorm:"created_at"`
+}
+
+// TokenTransfer はチェーンから取り込んだERC-721のTransferの構造体です
+type TokenTransfer struct {
+	ID              uuid.UUID `gorm:"id"`
+	ChainID         int       `gorm:"chain_id"`
+	ContractAddress string    `gorm:"contract_address"`
+	BlockNumber     uint64    `gorm:"block_number"`
+	BlockHash       string    `gorm:"block_hash"`
+	TxHash          string    `gorm:"tx_hash"`
+	LogIndex        uint      `gorm:"log_index"`
+	TokenID         string    `gorm:"token_id"`
+	FromAddress     string    `gorm:"from_address"`
+	ToAddress       string    `gorm:"to_address"`
+	CreatedAt       time.Time `gorm:"created_at"`
+}
+
+// IndexerCheckpoint はインデクサーが取り込み済みの最後のブロックです
+type IndexerCheckpoint struct {
+	ChainID         int       `gorm:"chain_id"`
+	ContractAddress string    `gorm:"contract_address"`
+	BlockNumber     uint64    `gorm:"block_number"`
+	BlockHash       string    `gorm:"block_hash"`
+	UpdatedAt       time.Time `gorm:"updated_at"`
+}
@@ -0,0 +1,74 @@
+// Package domain は、ドメインモデルとビジネスルールを定義します。
+package domain
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// アップロードしたファイルの種類
+const (
+	MediaKindAudio = "audio"
+	MediaKindImage = "image"
+	MediaKindVideo = "video"
+)
+
+// MediaFile は IPFS にアップロードしたファイルの記録です
+// 音声ファイルはアップロード時に内容を確認し、読み取った技術的なメタデータを Audio に保存します
+// 購入前に試聴するプレビューを切り出した場合は PreviewCid に保存します。公開するメタデータにはプレビューだけを含め、
+// フル音源（Cid）はトークンの所有者とクリエイターにのみ返します
+type MediaFile struct {
+	ID         uuid.UUID      `gorm:"id"`
+	Cid        string         `gorm:"cid"`
+	UserID     uuid.UUID      `gorm:"user_id"`
+	Filename   string         `gorm:"filename"`
+	Kind       string         `gorm:"kind"` // audio / image / video（それ以外は空）
+	Size       int64          `gorm:"size"`
+	Sha256     string         `gorm:"sha256"`
+	Audio      *AudioMetadata `gorm:"audio"`       // 音声ファイルのみ
+	PreviewCid sql.NullString `gorm:"preview_cid"` // 音声ファイルから切り出したプレビュー（切り出せない形式は NULL）
+	CreatedAt  time.Time      `gorm:"created_at"`
+	UpdatedAt  time.Time      `gorm:"updated_at"`
+}
+
+// AudioMetadata は音声ファイルから読み取った技術的なメタデータです
+type AudioMetadata struct {
+	Format     string            `json:"format" example:"flac"`     // mp3 / flac / wav / aac / m4a / ogg
+	Codec      string            `json:"codec" example:"flac"`      // mp3 / flac / pcm / float / aac / alac / vorbis / opus
+	Duration   float64           `json:"duration" example:"215.48"` // 再生時間（秒）
+	SampleRate int               `json:"sample_rate" example:"96000"`
+	BitDepth   int               `json:"bit_depth,omitempty" example:"24"` // 非可逆圧縮の場合は省略
+	Channels   int               `json:"channels" example:"2"`
+	Bitrate    int               `json:"bitrate" example:"2304000"` // 平均のビットレート（bps）
+	Tags       map[string]string `json:"tags,omitempty"`            // 埋め込みのタグ（title / artist / album / album_artist / genre / track / date / composer / isrc）
+}
+
+// Value はDBにJSONで保存します
+func (metadata AudioMetadata) Value() (driver.Value, error) {
+	return json.Marshal(metadata)
+}
+
+// Scan はDBのJSONを読み取ります
+func (metadata *AudioMetadata) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*metadata = AudioMetadata{}
+		return nil
+	case []byte:
+		return json.Unmarshal(value, metadata)
+	case string:
+		return json.Unmarshal([]byte(value), metadata)
+	default:
+		return fmt.Errorf("cannot scan %T into AudioMetadata", src)
+	}
+}
+
+// GormDataType はマイグレーションで作成するカラムの型です
+func (AudioMetadata) GormDataType() string {
+	return "json"
+}
@@ -16,7 +16,7 @@ type NFT struct {
 	GenreID       uuid.UUID `gorm:"genre_id"`
 	Status        string    `gorm:"status"`
 	Sale          bool      `gorm:"sale"`
-	Price         float64   `gorm:"price"`
+	Price         Amount    `gorm:"price"`
 	Insentive     int       `gorm:"insentive"`
 	CreatedAt     time.Time `gorm:"created_at"`
 	UpdatedAt     time.Time `gorm:"updated_at"`
@@ -0,0 +1,40 @@
+// Package domain は、ドメインモデルとビジネスルールを定義します。
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 詰まったトランザクションの置き換え方
+const (
+	ReplacementKindSpeedUp = "speed_up" // 同じノンス・同じ内容でガス代を上げて送り直す
+	ReplacementKindCancel  = "cancel"   // 同じノンスで自分宛てに0を送金して元のトランザクションを取り消す
+)
+
+// 置き換えのステータス
+const (
+	ReplacementStatusPending  = "pending"  // 送信済み（送信する前に記録する）
+	ReplacementStatusMined    = "mined"    // この置き換えがブロックに取り込まれた
+	ReplacementStatusReplaced = "replaced" // 同じノンスの別のトランザクションが取り込まれた
+	ReplacementStatusFailed   = "failed"   // ノードが受け付けなかった（送信されていない）
+)
+
+// TransactionReplacement はプラットフォームのウォレットから送信したミントのトランザクションを同じノンスで置き換えた記録です
+// 置き換えるたびに1行作り、ReplacedTxHash で置き換える前のトランザクションをたどれます
+type TransactionReplacement struct {
+	ID             uuid.UUID     `gorm:"id"`
+	TransactionID  string        `gorm:"transaction_id"`
+	Kind           string        `gorm:"kind"`
+	Nonce          int           `gorm:"nonce"`
+	ReplacedTxHash string        `gorm:"replaced_tx_hash"`
+	TxHash         string        `gorm:"tx_hash"`
+	GasPrice       *Amount       `gorm:"gas_price"`   // legacy のみ
+	GasTipCap      *Amount       `gorm:"gas_tip_cap"` // dynamic のみ
+	GasFeeCap      *Amount       `gorm:"gas_fee_cap"` // dynamic のみ
+	Status         string        `gorm:"status"`
+	UserID         uuid.NullUUID `gorm:"user_id"` // 管理者が置き換えた場合のみ（監視による置き換えは NULL）
+	CreatedAt      time.Time     `gorm:"created_at"`
+	UpdatedAt      time.Time     `gorm:"updated_at"`
+}
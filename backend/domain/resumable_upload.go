@@ -0,0 +1,31 @@
+// Package domain は、ドメインモデルとビジネスルールを定義します。
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResumableUpload は tus プロトコルで分割して送信中のファイルです
+// 受信した内容はローカルのディスクに追記し、Offset が Size に達したら IPFS に追加します
+type ResumableUpload struct {
+	ID        uuid.UUID         `json:"id"`
+	UserID    uuid.UUID         `json:"user_id"`
+	Wallet    string            `json:"wallet"`
+	Filename  string            `json:"filename"`
+	Size      int64             `json:"size"`     // Upload-Length
+	Offset    int64             `json:"offset"`   // 受信済みのバイト数
+	Metadata  map[string]string `json:"metadata"` // Upload-Metadata
+	Cid       string            `json:"cid"`      // 完了して IPFS に追加したコンテンツ（完了前は空）
+	Path      string            `json:"path"`
+	Sha256    string            `json:"sha256"`
+	ExpiresAt time.Time         `json:"expires_at"` // 受信するたびに延ばし、過ぎたものは削除する
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// Completed は IPFS への追加まで終わったか
+func (upload *ResumableUpload) Completed() bool {
+	return upload.Cid != ""
+}
@@ -0,0 +1,45 @@
+// Package domain は、ドメインモデルとビジネスルールを定義します。
+package domain
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxRoyaltyBps はロイヤリティ率の上限（ERC-2981 の分母と同じ10000 bps = 100%）
+const MaxRoyaltyBps = 10000
+
+// ロイヤリティの設定元（トークン → コレクション → マーケットプレイスの順に優先する）
+const (
+	RoyaltySourceToken       = "token"       // トークンごとの設定
+	RoyaltySourceCollection  = "collection"  // コレクションの既定値
+	RoyaltySourceMarketplace = "marketplace" // マーケットプレイスのコントラクトの royaltyFeeBps（受取人はクリエイター）
+)
+
+// Royalty は二次流通で受取人に支払うロイヤリティです
+type Royalty struct {
+	Bps      int    // ロイヤリティ率（1/100 %）
+	Receiver string // 受取人のウォレットアドレス
+	Source   string // 設定元
+}
+
+// Amount は販売価格に対するロイヤリティの金額を返します（ERC-2981 の royaltyInfo と同じく1wei未満は切り捨て）
+func (royalty Royalty) Amount(salePrice Amount) Amount {
+	amount := new(big.Int).Mul(salePrice.Wei(), big.NewInt(int64(royalty.Bps)))
+	return NewAmount(amount.Div(amount, big.NewInt(MaxRoyaltyBps)))
+}
+
+// TokenRoyalty はトークンごとのロイヤリティの構造体です（コレクションの既定値より優先します）
+type TokenRoyalty struct {
+	ID              uuid.UUID `gorm:"id"`
+	ChainID         int       `gorm:"chain_id"`
+	ContractAddress string    `gorm:"contract_address"`
+	TokenID         string    `gorm:"token_id"`
+	RoyaltyBps      int       `gorm:"royalty_bps"`
+	Receiver        string    `gorm:"receiver"`
+	UserID          uuid.UUID `gorm:"user_id"` // 設定したユーザー
+	CreatedAt       time.Time `gorm:"created_at"`
+	UpdatedAt       time.Time `gorm:"updated_at"`
+}
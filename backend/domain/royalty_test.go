@@ -0,0 +1,17 @@
+// Package domain は、ドメインモデルとビジネスルールを定義します。
+package domain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoyalty_Amount(t *testing.T) {
+	salePrice := NewAmount(big.NewInt(1_000_003))
+
+	assert.Equal(t, "75000", Royalty{Bps: 750}.Amount(salePrice).String()) // 75000.225 は切り捨て
+	assert.Equal(t, "0", Royalty{Bps: 0}.Amount(salePrice).String())
+	assert.Equal(t, "1000003", Royalty{Bps: MaxRoyaltyBps}.Amount(salePrice).String())
+}
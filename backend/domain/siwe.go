@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const siweHeaderSuffix = " wants you to sign in with your Ethereum account:"
+
+// SiweMessage はEIP-4361 (Sign-In With Ethereum) のメッセージ構造体
+type SiweMessage struct {
+	Domain         string
+	Address        string
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        int
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime time.Time
+}
+
+// String はEIP-4361で定められた書式のメッセージ文字列を返す
+func (message *SiweMessage) String() string {
+	var builder strings.Builder
+	builder.WriteString(message.Domain + siweHeaderSuffix + "\n")
+	builder.WriteString(message.Address + "\n")
+	builder.WriteString("\n")
+	if message.Statement != "" {
+		builder.WriteString(message.Statement + "\n")
+	}
+	builder.WriteString("\n")
+	builder.WriteString("URI: " + message.URI + "\n")
+	builder.WriteString("Version: " + message.Version + "\n")
+	builder.WriteString("Chain ID: " + strconv.Itoa(message.ChainID) + "\n")
+	builder.WriteString("Nonce: " + message.Nonce + "\n")
+	builder.WriteString("Issued At: " + message.IssuedAt.UTC().Format(time.RFC3339))
+	if !message.ExpirationTime.IsZero() {
+		builder.WriteString("\nExpiration Time: " + message.ExpirationTime.UTC().Format(time.RFC3339))
+	}
+	return builder.String()
+}
+
+// ParseSiweMessage はEIP-4361の書式のメッセージ文字列を解析する
+func ParseSiweMessage(raw string) (*SiweMessage, error) {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	if len(lines) < 2 || !strings.HasSuffix(lines[0], siweHeaderSuffix) {
+		return nil, errors.New("BadRequest: SIWEメッセージのヘッダーが不正です")
+	}
+
+	message := &SiweMessage{
+		Domain:  strings.TrimSuffix(lines[0], siweHeaderSuffix),
+		Address: strings.TrimSpace(lines[1]),
+	}
+
+	index := 2
+	// 空行 → ステートメント(任意) → 空行 の順に続く
+	if index < len(lines) && lines[index] == "" {
+		index++
+	}
+	if index < len(lines) && !strings.Contains(lines[index], ": ") && lines[index] != "" {
+		message.Statement = lines[index]
+		index++
+	}
+	if index < len(lines) && lines[index] == "" {
+		index++
+	}
+
+	for ; index < len(lines); index++ {
+		key, value, found := strings.Cut(lines[index], ": ")
+		if !found {
+			return nil, fmt.Errorf("BadRequest: SIWEメッセージの行が不正です: %q", lines[index])
+		}
+		switch key {
+		case "URI":
+			message.URI = value
+		case "Version":
+			message.Version = value
+		case "Chain ID":
+			chainID, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("BadRequest: Chain IDが不正です: %w", err)
+			}
+			message.ChainID = chainID
+		case "Nonce":
+			message.Nonce = value
+		case "Issued At":
+			issuedAt, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("BadRequest: Issued Atが不正です: %w", err)
+			}
+			message.IssuedAt = issuedAt
+		case "Expiration Time":
+			expirationTime, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("BadRequest: Expiration Timeが不正です: %w", err)
+			}
+			message.ExpirationTime = expirationTime
+		}
+	}
+
+	if message.Domain == "" || message.Address == "" || message.URI == "" || message.Nonce == "" || message.IssuedAt.IsZero() {
+		return nil, errors.New("BadRequest: SIWEメッセージの必須項目が不足しています")
+	}
+
+	return message, nil
+}
@@ -0,0 +1,43 @@
+// Package domain は、ドメインモデルとビジネスルールを定義します。
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 二次流通の取引の種類
+const (
+	TradeKindPurchase = "purchase" // createMarketSale
+	TradeKindResell   = "resell"   // resellToken
+)
+
+// 取引のステータス（prepared → submitted → mined / failed）
+const (
+	TradeStatusPrepared  = "prepared"  // 署名前のトランザクションを返した
+	TradeStatusSubmitted = "submitted" // ユーザーが送信したトランザクションを受け付けた
+	TradeStatusMined     = "mined"     // ブロックに取り込まれた
+	TradeStatusFailed    = "failed"    // revertした
+)
+
+// Trade はユーザーのウォレットで署名する購入・再出品の構造体です
+type Trade struct {
+	ID              uuid.UUID      `gorm:"id"`
+	Kind            string         `gorm:"kind"`
+	ChainID         int            `gorm:"chain_id"`
+	ContractAddress string         `gorm:"contract_address"`
+	TokenID         string         `gorm:"token_id"`
+	UserID          uuid.UUID      `gorm:"user_id"`
+	Wallet          string         `gorm:"wallet"`
+	Price           Amount         `gorm:"price"`
+	Value           Amount         `gorm:"value"` // 送金額
+	Calldata        string         `gorm:"calldata"`
+	TxHash          sql.NullString `gorm:"tx_hash"`
+	BlockNumber     sql.NullInt64  `gorm:"block_number"`
+	Status          string         `gorm:"status"`
+	RevertReason    sql.NullString `gorm:"revert_reason"`
+	CreatedAt       time.Time      `gorm:"created_at"`
+	UpdatedAt       time.Time      `gorm:"updated_at"`
+}
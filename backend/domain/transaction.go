@@ -7,21 +7,39 @@ import (
 	"github.com/google/uuid"
 )
 
+// ミントジョブのステータス（queued / prepared → submitted → mined → confirmed / failed）
+const (
+	TransactionStatusQueued    = "queued"    // 受け付け済みで未送信
+	TransactionStatusPrepared  = "prepared"  // クリエイターのウォレットでの署名待ち
+	TransactionStatusSubmitted = "submitted" // CreateTokenを送信済み
+	TransactionStatusMined     = "mined"     // ブロックに取り込まれた
+	TransactionStatusConfirmed = "confirmed" // 必要な承認数に達した
+	TransactionStatusFailed    = "failed"    // 送信失敗またはrevert
+
+	// TransactionStatusCreated はミントジョブを導入する前に送信したミントのステータス（レシートを確認していない）
+	// go run . backfill でレシートから mined / failed にする
+	TransactionStatusCreated = "created"
+)
+
 type Transaction struct {
 	ID              string         `gorm:"id"`
 	UserID          uuid.UUID      `gorm:"user_id"`
 	ChainID         int            `gorm:"chain_id"`
 	ContractAddress string         `gorm:"contract_address"`
+	TokenID         sql.NullString `gorm:"token_id"` // ミントのレシートから取得したトークンID（uint256を10進数で保存する）
+	TxHash          sql.NullString `gorm:"tx_hash"`
+	BlockNumber     sql.NullInt64  `gorm:"block_number"`
 	Nonce           int            `gorm:"nonce"`
 	TokenURL        string         `gorm:"token_url"`
 	GenreID         uuid.UUID      `gorm:"genre_id"`
 	To              sql.NullString `gorm:"to"`
-	Price           float64        `gorm:"price"` // Value
+	Price           Amount         `gorm:"price"` // ミント料
 	Insentive       int            `gorm:"insentive"`
-	Cost            int            `gorm:"cost"`
+	Cost            Amount         `gorm:"cost"` // 送金額 + ガス代の上限
 	Sale            bool           `gorm:"sale"`
 	Status          string         `gorm:"status"`
-	CreatorAddress  string         `gorm:"creator_address"`
+	RevertReason    sql.NullString `gorm:"revert_reason"`
+	CreatorAddress  string         `gorm:"creator_address"` // クリエイターのウォレットで署名する場合の送信元
 	CreatedAt       time.Time      `gorm:"created_at"`
 	UpdatedAt       time.Time      `gorm:"updated_at"`
 }
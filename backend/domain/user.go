@@ -8,6 +8,22 @@ import (
 	"github.com/google/uuid"
 )
 
+// ユーザーの権限（users.role）
+const (
+	RoleMember  = "member"
+	RoleCreator = "creator"
+	RoleAdmin   = "admin"
+)
+
+// ValidRole は users.role に登録できる権限か判定します
+func ValidRole(role string) bool {
+	switch role {
+	case RoleMember, RoleCreator, RoleAdmin:
+		return true
+	}
+	return false
+}
+
 // User はユーザーの構造体です
 type User struct {
 	ID         uuid.UUID      `gorm:"id"`
@@ -0,0 +1,55 @@
+// Package domain は、ドメインモデルとビジネスルールを定義します。
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 遅延ミントのバウチャーのステータス（prepared → listed → redeeming → redeemed / cancelled）
+const (
+	VoucherStatusPrepared  = "prepared"  // クリエイターの署名待ち
+	VoucherStatusListed    = "listed"    // 署名を検証して出品した
+	VoucherStatusRedeeming = "redeeming" // 購入者が送信した redeem のトランザクションを追跡している
+	VoucherStatusRedeemed  = "redeemed"  // 購入者にミントした
+	VoucherStatusCancelled = "cancelled" // クリエイターが取り消した
+)
+
+// 検索結果の出品の状態
+const (
+	ListingStateMinted = "minted" // ミント済みのNFT
+	ListingStateLazy   = "lazy"   // 購入時にミントするバウチャー
+)
+
+// MintVoucher はクリエイターが EIP-712 で署名した遅延ミントのバウチャーです
+// 最初の購入者がコレクションのコントラクトの redeem を呼び出すとミントされ、代金はクリエイターに送金されます
+type MintVoucher struct {
+	ID              uuid.UUID      `gorm:"id"` // 署名するバウチャーIDは bytes32 の下位16バイトに入れる
+	CollectionID    uuid.UUID      `gorm:"collection_id"`
+	ChainID         int            `gorm:"chain_id"`
+	ContractAddress string         `gorm:"contract_address"`
+	UserID          uuid.UUID      `gorm:"user_id"`
+	Signer          string         `gorm:"signer"` // 署名するクリエイターのウォレット（代金の受取人）
+	TokenURL        string         `gorm:"token_url"`
+	GenreID         uuid.UUID      `gorm:"genre_id"`
+	Price           Amount         `gorm:"price"`
+	RoyaltyBps      int            `gorm:"royalty_bps"` // 0 の場合はコレクションの既定値
+	RoyaltyReceiver string         `gorm:"royalty_receiver"`
+	Expiry          int64          `gorm:"expiry"` // 有効期限（UNIX時間の秒。署名したデータと同じ値）
+	Signature       sql.NullString `gorm:"signature"`
+	Status          string         `gorm:"status"`
+	Issued          bool           `gorm:"issued"` // 購入者に redeem のトランザクションを返した（以降の取り消しはコントラクトにも記録する）
+	Buyer           sql.NullString `gorm:"buyer"`
+	TxHash          sql.NullString `gorm:"tx_hash"`
+	BlockNumber     sql.NullInt64  `gorm:"block_number"`
+	TokenID         sql.NullString `gorm:"token_id"`
+	CreatedAt       time.Time      `gorm:"created_at"`
+	UpdatedAt       time.Time      `gorm:"updated_at"`
+}
+
+// Expired はバウチャーの有効期限が過ぎているか返します（コントラクトと同じく有効期限の時刻までは有効）
+func (voucher *MintVoucher) Expired(now time.Time) bool {
+	return now.Unix() > voucher.Expiry
+}
@@ -0,0 +1,219 @@
+// Package ethereum は、イーサリアムネットワークとの通信を管理します。
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"nft-music/usecases/gateways"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// 署名方式（SIGNER_TYPE）
+const (
+	SignerTypeKeystore = "keystore"
+	SignerTypeKeyFile  = "keyfile"
+	SignerTypeRemote   = "remote"
+)
+
+// NewSigner は環境変数 SIGNER_TYPE に応じた署名者を作成します。
+//
+//	keystore: SIGNER_KEYSTORE_FILE の暗号化keystoreを SIGNER_KEYSTORE_PASSPHRASE で復号する
+//	keyfile : SIGNER_KEY_FILE に保存した16進数の秘密鍵を読み込む
+//	remote  : SIGNER_REMOTE_URL の eth_signTransaction（Clef / web3signer など）で署名する
+func NewSigner(ctx context.Context) (gateways.Signer, error) {
+	signerType := os.Getenv("SIGNER_TYPE")
+	if signerType == "" {
+		signerType = SignerTypeKeyFile
+	}
+
+	switch signerType {
+	case SignerTypeKeystore:
+		return NewKeystoreSigner(os.Getenv("SIGNER_KEYSTORE_FILE"), os.Getenv("SIGNER_KEYSTORE_PASSPHRASE"))
+	case SignerTypeKeyFile:
+		path := os.Getenv("SIGNER_KEY_FILE")
+		if path == "" {
+			path = "config/signer.key"
+		}
+		return NewKeyFileSigner(path)
+	case SignerTypeRemote:
+		return NewRemoteSigner(ctx, os.Getenv("SIGNER_REMOTE_URL"), os.Getenv("SIGNER_ADDRESS"))
+	}
+	return nil, fmt.Errorf("unknown SIGNER_TYPE: %s", signerType)
+}
+
+// PrivateKeySigner はメモリ上の秘密鍵で署名する
+type PrivateKeySigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+}
+
+// NewPrivateKeySigner は秘密鍵から署名者を作成します。
+func NewPrivateKeySigner(privateKey *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+	}
+}
+
+// NewKeystoreSigner はgo-ethereumの暗号化keystoreファイルから署名者を作成します。
+func NewKeystoreSigner(path string, passphrase string) (*PrivateKeySigner, error) {
+	if path == "" {
+		return nil, errors.New("SIGNER_KEYSTORE_FILE is not set")
+	}
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+	return NewPrivateKeySigner(key.PrivateKey), nil
+}
+
+// NewKeyFileSigner は16進数の秘密鍵を保存したファイルから署名者を作成します。
+func NewKeyFileSigner(path string) (*PrivateKeySigner, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(string(raw)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key in %s: %w", path, err)
+	}
+	return NewPrivateKeySigner(privateKey), nil
+}
+
+func (signer *PrivateKeySigner) Address() common.Address {
+	return signer.address
+}
+
+func (signer *PrivateKeySigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), signer.privateKey)
+}
+
+// RemoteSigner は eth_signTransaction を話す外部の署名サーバーで署名する
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// NewRemoteSigner はリモート署名サーバーの署名者を作成します。
+// address が空の場合は eth_accounts の先頭のアカウントを使います。
+func NewRemoteSigner(ctx context.Context, url string, address string) (*RemoteSigner, error) {
+	if url == "" {
+		return nil, errors.New("SIGNER_REMOTE_URL is not set")
+	}
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	if address == "" {
+		var accounts []common.Address
+		if err := client.CallContext(ctx, &accounts, "eth_accounts"); err != nil {
+			return nil, fmt.Errorf("failed to get accounts from remote signer: %w", err)
+		}
+		if len(accounts) == 0 {
+			return nil, errors.New("remote signer has no accounts")
+		}
+		return &RemoteSigner{client: client, address: accounts[0]}, nil
+	}
+
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid SIGNER_ADDRESS: %s", address)
+	}
+	return &RemoteSigner{client: client, address: common.HexToAddress(address)}, nil
+}
+
+func (signer *RemoteSigner) Address() common.Address {
+	return signer.address
+}
+
+// signTxArgs は eth_signTransaction に渡すトランザクション
+type signTxArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+func (signer *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	args := signTxArgs{
+		From:    signer.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+
+	var result json.RawMessage
+	if err := signer.client.CallContext(ctx, &result, "eth_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("remote signer failed to sign transaction: %w", err)
+	}
+
+	raw, err := decodeSignedTx(result)
+	if err != nil {
+		return nil, err
+	}
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("remote signer returned invalid transaction: %w", err)
+	}
+
+	// 依頼した内容と異なるトランザクションに署名されていないか確認する
+	// 署名対象のハッシュは種類・チェーンID・ノンス・ガス・手数料・宛先・金額・データ・アクセスリストをすべて含む
+	chainSigner := types.LatestSignerForChainID(chainID)
+	sender, err := types.Sender(chainSigner, signed)
+	if err != nil {
+		return nil, err
+	}
+	if sender != signer.address || signed.Type() != tx.Type() || chainSigner.Hash(signed) != chainSigner.Hash(tx) {
+		return nil, errors.New("remote signer returned a transaction that does not match the request")
+	}
+	return signed, nil
+}
+
+// decodeSignedTx は署名サーバーの応答からRLPエンコードされたトランザクションを取り出す
+// （web3signer は16進数の文字列、Clef / geth は {"raw": ..., "tx": ...} を返す）
+func decodeSignedTx(result json.RawMessage) ([]byte, error) {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+
+	var signed struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &signed); err != nil || len(signed.Raw) == 0 {
+		return nil, fmt.Errorf("unexpected eth_signTransaction response: %s", string(result))
+	}
+	return signed.Raw, nil
+}
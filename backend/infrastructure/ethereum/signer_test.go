@@ -0,0 +1,167 @@
+// Package ethereum は、イーサリアムネットワークとの通信を管理します。
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRemoteSigner はClefやweb3signerの代わりに eth_signTransaction に応答するテスト用の署名サーバー
+type stubRemoteSigner struct {
+	privateKey *ecdsa.PrivateKey
+	tamper     func(tx *types.LegacyTx) // 依頼と異なる内容に署名する署名サーバー
+}
+
+func (stub *stubRemoteSigner) Accounts() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(stub.privateKey.PublicKey)}
+}
+
+func (stub *stubRemoteSigner) SignTransaction(args signTxArgs) (hexutil.Bytes, error) {
+	legacy := &types.LegacyTx{
+		Nonce:    uint64(args.Nonce),
+		To:       args.To,
+		Gas:      uint64(args.Gas),
+		GasPrice: args.GasPrice.ToInt(),
+		Value:    args.Value.ToInt(),
+		Data:     args.Data,
+	}
+	if stub.tamper != nil {
+		stub.tamper(legacy)
+	}
+	signed, err := types.SignTx(types.NewTx(legacy), types.LatestSignerForChainID(args.ChainID.ToInt()), stub.privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
+
+func newUnsignedTx() *types.Transaction {
+	to := common.HexToAddress("0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50")
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    7,
+		To:       &to,
+		Gas:      21000,
+		GasPrice: big.NewInt(20000000000),
+		Value:    big.NewInt(1000),
+	})
+}
+
+func assertSignedBy(t *testing.T, tx *types.Transaction, chainID *big.Int, address common.Address) {
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	require.NoError(t, err)
+	assert.Equal(t, address, sender)
+}
+
+func TestKeyFileSigner(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "signer.key")
+	require.NoError(t, os.WriteFile(path, []byte(hexutil.Encode(crypto.FromECDSA(privateKey))+"\n"), 0o600))
+
+	signer, err := NewKeyFileSigner(path)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(privateKey.PublicKey), signer.Address())
+
+	chainID := big.NewInt(1337)
+	signed, err := signer.SignTx(context.Background(), newUnsignedTx(), chainID)
+	require.NoError(t, err)
+	assertSignedBy(t, signed, chainID, signer.Address())
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+	keyJSON, err := keystore.EncryptKey(key, "passphrase", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	require.NoError(t, os.WriteFile(path, keyJSON, 0o600))
+
+	t.Run("正常系: パスフレーズで復号して署名できる", func(t *testing.T) {
+		signer, err := NewKeystoreSigner(path, "passphrase")
+		require.NoError(t, err)
+		assert.Equal(t, key.Address, signer.Address())
+
+		chainID := big.NewInt(11155111)
+		signed, err := signer.SignTx(context.Background(), newUnsignedTx(), chainID)
+		require.NoError(t, err)
+		assertSignedBy(t, signed, chainID, key.Address)
+	})
+
+	t.Run("異常系: パスフレーズが違う", func(t *testing.T) {
+		_, err := NewKeystoreSigner(path, "wrong")
+		assert.Error(t, err)
+	})
+}
+
+func TestRemoteSigner(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	server := rpc.NewServer()
+	stub := &stubRemoteSigner{privateKey: privateKey}
+	require.NoError(t, server.RegisterName("eth", stub))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	ctx := context.Background()
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	t.Run("正常系: eth_accountsのアカウントで署名できる", func(t *testing.T) {
+		signer, err := NewRemoteSigner(ctx, httpServer.URL, "")
+		require.NoError(t, err)
+		assert.Equal(t, address, signer.Address())
+
+		chainID := big.NewInt(1337)
+		unsigned := newUnsignedTx()
+		signed, err := signer.SignTx(ctx, unsigned, chainID)
+		require.NoError(t, err)
+		assertSignedBy(t, signed, chainID, address)
+		assert.Equal(t, unsigned.Nonce(), signed.Nonce())
+	})
+
+	t.Run("異常系: 署名サーバーのアカウントと指定したアドレスが異なる", func(t *testing.T) {
+		signer, err := NewRemoteSigner(ctx, httpServer.URL, "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50")
+		require.NoError(t, err)
+
+		_, err = signer.SignTx(ctx, newUnsignedTx(), big.NewInt(1337))
+		assert.Error(t, err)
+	})
+
+	t.Run("異常系: 依頼と異なる宛先・データ・ガス・手数料に署名された", func(t *testing.T) {
+		defer func() { stub.tamper = nil }()
+		signer, err := NewRemoteSigner(ctx, httpServer.URL, "")
+		require.NoError(t, err)
+
+		other := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+		for name, tamper := range map[string]func(tx *types.LegacyTx){
+			"to":       func(tx *types.LegacyTx) { tx.To = &other },
+			"data":     func(tx *types.LegacyTx) { tx.Data = []byte{0x01} },
+			"gas":      func(tx *types.LegacyTx) { tx.Gas = 500000 },
+			"gasPrice": func(tx *types.LegacyTx) { tx.GasPrice = big.NewInt(1) },
+		} {
+			stub.tamper = tamper
+			_, err = signer.SignTx(ctx, newUnsignedTx(), big.NewInt(1337))
+			assert.ErrorContains(t, err, "does not match", name)
+		}
+	})
+}
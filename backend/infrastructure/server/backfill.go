@@ -0,0 +1,39 @@
+// Package server は、HTTPサーバーのセットアップとルーティングを定義します。
+package server
+
+import (
+	"context"
+
+	"nft-music/adapters/gateways"
+	"nft-music/usecases/interactor"
+	"nft-music/usecases/logging"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"gorm.io/gorm"
+)
+
+// Backfill は接続中のマーケットプレイスのイベントを fromBlock から取り込み直し、トークンIDの無いミントをレシートから埋めます。
+// サーバーのインデクサーと同時に取り込まないよう、サーバーを止めてから実行してください。
+func Backfill(db *gorm.DB, etherClient *ethclient.Client, chainRegistry *interactor.ChainRegistry, logging logging.Logging, fromBlock uint64) error {
+	ctx := context.Background()
+
+	marketplace := interactor.NewMarketplaceContract()
+	deploymentGateway := gateways.NewDeploymentGateway(db)
+	evmInteractor := interactor.NewEvmInteractor(etherClient, nil, nil, deploymentGateway, chainRegistry, marketplace, logging)
+	if err := evmInteractor.BindDeployed(ctx); err != nil {
+		return err
+	}
+
+	eventIndexer := interactor.NewEventIndexer(gateways.NewMarketEventGateway(db), deploymentGateway, etherClient, marketplace, indexerConfig(chainRegistry.Active()), logging)
+	if err := eventIndexer.Backfill(ctx, fromBlock); err != nil {
+		return err
+	}
+	logging.Info("backfilled market events")
+
+	mintWorker := interactor.NewMintWorker(gateways.NewTransactionGateway(db), nil, etherClient, nil, nil, nil, marketplace, mintWorkerConfig(chainRegistry.Active()), logging)
+	if err := mintWorker.BackfillTokenIDs(ctx); err != nil {
+		return err
+	}
+	logging.Info("backfilled token ids of minted transactions")
+	return nil
+}
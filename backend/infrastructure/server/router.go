@@ -2,16 +2,22 @@
 package server
 
 import (
+	"context"
+	"math/big"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"nft-music/adapters/controllers"
 	"nft-music/adapters/gateways"
-	"nft-music/contracts"
+	"nft-music/adapters/middlewares"
+	"nft-music/domain"
+	usecasesGateways "nft-music/usecases/gateways"
 	"nft-music/usecases/interactor"
 	"nft-music/usecases/logging"
+	"nft-music/util/audio"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -24,8 +30,8 @@ import (
 func Run(
 	db *gorm.DB,
 	etherClient *ethclient.Client,
-	etherAuth *bind.TransactOpts,
-	contracts *contracts.Contracts,
+	chainRegistry *interactor.ChainRegistry,
+	signer usecasesGateways.Signer,
 	logging logging.Logging,
 	validate *validator.Validate,
 ) {
@@ -42,7 +48,11 @@ func Run(
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"https://music.threenext.com", "http://music.threenext.com"},
-		AllowMethods: []string{echo.GET, echo.PUT, echo.POST, echo.DELETE},
+		AllowMethods: []string{echo.GET, echo.PUT, echo.POST, echo.DELETE, echo.HEAD, echo.PATCH},
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization,
+			"X-Upload-ID", "Tus-Resumable", "Upload-Length", "Upload-Metadata", "Upload-Offset"},
+		// tus のクライアントがレスポンスヘッダーを読めるようにする
+		ExposeHeaders: []string{echo.HeaderLocation, "Tus-Resumable", "Tus-Version", "Tus-Extension", "Tus-Max-Size", "Upload-Offset", "Upload-Length", "Upload-Expires"},
 	}))
 
 	e.GET("/", func(c echo.Context) error {
@@ -51,69 +61,183 @@ func Run(
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
+	// 登録簿にあるデプロイ済みのコントラクトに接続する（起動のたびにデプロイしない）
+	marketplace := interactor.NewMarketplaceContract()
+	// プラットフォームのウォレットから送信する処理は同じノンス管理とガス代の設定を共有する
+	feePolicy := interactor.NewFeePolicy(etherClient, feeConfig())
+	nonceManager := interactor.NewNonceManager(etherClient, feePolicy)
+	deploymentGateway := gateways.NewDeploymentGateway(db)
+	evmInteractor := interactor.NewEvmInteractor(etherClient, signer, nonceManager, deploymentGateway, chainRegistry, marketplace, logging)
+	if err := evmInteractor.BindDeployed(context.Background()); err != nil {
+		logging.Warning("マーケットプレイスのコントラクトに接続できません。POST /api/v1/deployments でデプロイしてください: " + err.Error())
+	}
+	// 他のウォレットからの販売・再出品も含めてチェーンのイベントを取り込む
+	marketEventGateway := gateways.NewMarketEventGateway(db)
+	eventIndexer := interactor.NewEventIndexer(marketEventGateway, deploymentGateway, etherClient, marketplace, indexerConfig(chainRegistry.Active()), logging)
+	go eventIndexer.Run(context.Background())
+	// コントラクトの一時停止中と運用者の設定中は書き込みのAPIを止める
+	maintenanceInteractor := interactor.NewMaintenanceInteractor(marketplace, maintenanceConfig(), logging)
+	go maintenanceInteractor.Run(context.Background())
+
 	v1 := e.Group("/api/v1")
 	{
+		authGateway := gateways.NewAuthGateway(db)
+		userGateway := gateways.NewUserGateway(db)
+		authInteractor := interactor.NewAuthInteractor(authGateway, userGateway, authConfig(), logging)
+		authController := controllers.NewAuthController(authInteractor, logging, validate)
+		requireAuth := middlewares.Auth(authInteractor)
+		requireWritable := middlewares.Maintenance(maintenanceInteractor)
+		v1.GET("/auth/nonce", authController.Nonce)
+		v1.POST("/auth/verify", authController.Verify)
+		v1.GET("/auth/me", authController.Me, requireAuth)
+		v1.POST("/auth/logout", authController.Logout, requireAuth)
+
 		walletGateway := gateways.NewWalletGateway(db)
 		walletInteractor := interactor.NewWalletInteractor(walletGateway)
 		walletController := controllers.NewWalletController(walletInteractor, logging, validate)
 		v1.GET("/wallets", walletController.List)
-		v1.POST("/wallets", walletController.Create)
+		v1.POST("/wallets", walletController.Create, requireWritable, requireAuth)
 
 		businessGateway := gateways.NewBusinessGateway(db)
 		businessInteractor := interactor.NewBusinessInteractor(businessGateway)
 		businessController := controllers.NewBusinessController(businessInteractor, logging, validate)
-		v1.POST("/businesses", businessController.Create)
+		v1.POST("/businesses", businessController.Create, requireWritable, requireAuth)
 		v1.GET("/businesses/:id", businessController.Get)
 		v1.GET("/businesses", businessController.List)
-		v1.PUT("/businesses/:id", businessController.Update)
-		v1.DELETE("/businesses/:id", businessController.Delete)
+		v1.PUT("/businesses/:id", businessController.Update, requireWritable, requireAuth)
+		v1.DELETE("/businesses/:id", businessController.Delete, requireWritable, requireAuth)
 
 		genreGateway := gateways.NewGenreGateway(db)
 		genreInteractor := interactor.NewGenreInteractor(genreGateway)
 		genreController := controllers.NewGenreController(genreInteractor, logging, validate)
-		v1.POST("/genres", genreController.Create)
+		v1.POST("/genres", genreController.Create, requireWritable, requireAuth)
 		v1.GET("/genres/:id", genreController.Get)
 		v1.GET("/genres", genreController.List)
-		v1.PUT("/genres/:id", genreController.Update)
-		v1.DELETE("/genres/:id", genreController.Delete)
+		v1.PUT("/genres/:id", genreController.Update, requireWritable, requireAuth)
+		v1.DELETE("/genres/:id", genreController.Delete, requireWritable, requireAuth)
 
-		ipfsGateway := gateways.NewIpfsGateway(db)
-		userGateway := gateways.NewUserGateway(db)
-		ipfsInteractor := interactor.NewIpfsInteractor(ipfsGateway, userGateway)
+		ipfsGateway := ipfsStorage(logging)
+		mediaGateway := gateways.NewMediaGateway(db)
+		ipfsInteractor := interactor.NewIpfsInteractor(ipfsGateway, userGateway, mediaGateway, uploadConfig())
 		ipfsController := controllers.NewIpfsController(ipfsInteractor, logging, validate)
-		v1.POST("/ipfs", ipfsController.Upload)
-		v1.POST("/ipfs/meta", ipfsController.MetaUpload)
+		v1.POST("/ipfs", ipfsController.Upload, requireWritable, requireAuth)
+		v1.POST("/ipfs/meta", ipfsController.MetaUpload, requireWritable, requireAuth)
+		v1.GET("/ipfs/uploads/:id", ipfsController.Progress, requireAuth)
+		v1.DELETE("/ipfs/uploads/:id", ipfsController.Cancel, requireAuth)
+		v1.GET("/ipfs/:cid", ipfsController.Content)
+		// 接続が切れても再開できる tus プロトコルの分割アップロード
+		resumableUploadInteractor := interactor.NewResumableUploadInteractor(gateways.NewResumableUploadGateway(resumableUploadDir()), ipfsInteractor, resumableUploadConfig(), logging)
+		go resumableUploadInteractor.Run(context.Background())
+		resumableUploadController := controllers.NewResumableUploadController(resumableUploadInteractor, logging)
+		v1.OPTIONS("/ipfs/tus", resumableUploadController.Options)
+		v1.POST("/ipfs/tus", resumableUploadController.Create, requireWritable, requireAuth)
+		v1.HEAD("/ipfs/tus/:id", resumableUploadController.Head, requireAuth)
+		v1.GET("/ipfs/tus/:id", resumableUploadController.Get, requireAuth)
+		v1.PATCH("/ipfs/tus/:id", resumableUploadController.Patch, requireWritable, requireAuth)
+		v1.DELETE("/ipfs/tus/:id", resumableUploadController.Delete, requireAuth)
 
 		collectionGateway := gateways.NewCollectionGateway(db)
-		collectionInteractor := interactor.NewCollectionInteractor(collectionGateway)
+		tokenRoyaltyGateway := gateways.NewTokenRoyaltyGateway(db)
+		collectionInteractor := interactor.NewCollectionInteractor(collectionGateway, tokenRoyaltyGateway, etherClient, signer, nonceManager, logging)
 		collectionController := controllers.NewCollectionController(collectionInteractor, logging, validate)
-		v1.POST("/collections", collectionController.Create)
+		v1.POST("/collections", collectionController.Create, requireWritable, requireAuth)
 		v1.GET("/collections/:id", collectionController.Get)
 		v1.GET("/collections", collectionController.List)
-		v1.PUT("/collections/:id", collectionController.Update)
-		v1.DELETE("/collections/:id", collectionController.Delete)
+		v1.PUT("/collections/:id", collectionController.Update, requireWritable, requireAuth)
+		v1.DELETE("/collections/:id", collectionController.Delete, requireWritable, requireAuth)
+		v1.POST("/collections/:id/mint", collectionController.Mint, requireWritable, requireAuth)
+		v1.POST("/collections/:id/tokens/:token_id/burn", collectionController.Burn, requireWritable, requireAuth)
+		v1.PUT("/collections/:id/tokens/:token_id/royalty", collectionController.SetTokenRoyalty, requireWritable, requireAuth)
+		v1.DELETE("/collections/:id/tokens/:token_id/royalty", collectionController.ResetTokenRoyalty, requireWritable, requireAuth)
+
+		// 遅延ミントのバウチャーはコレクションのコントラクトで引き換える
+		voucherGateway := gateways.NewVoucherGateway(db)
+		voucherInteractor := interactor.NewVoucherInteractor(voucherGateway, ipfsGateway, collectionInteractor, chainRegistry, logging)
+		voucherInteractor.Resume(context.Background())
+		voucherController := controllers.NewVoucherController(voucherInteractor, ipfsInteractor, logging, validate)
+		v1.POST("/collections/:id/vouchers", voucherController.Create, requireWritable, requireAuth)
+		v1.GET("/collections/:id/vouchers", voucherController.ListByCollection)
+		v1.GET("/vouchers", voucherController.List)
+		v1.GET("/vouchers/:id", voucherController.Get)
+		v1.POST("/vouchers/:id/sign", voucherController.Sign, requireWritable, requireAuth)
+		v1.POST("/vouchers/:id/purchase", voucherController.Purchase, requireWritable, requireAuth)
+		v1.POST("/vouchers/:id/submit", voucherController.Submit, requireAuth) // 送信済みのトランザクションの記録はメンテナンス中も受け付ける
+		v1.DELETE("/vouchers/:id", voucherController.Cancel, requireWritable, requireAuth)
+
+		royaltyInteractor := interactor.NewRoyaltyInteractor(collectionGateway, tokenRoyaltyGateway, chainRegistry, marketplace)
+		royaltyController := controllers.NewRoyaltyController(royaltyInteractor, logging)
+		v1.GET("/royalty/:chain_id/:contract/:token_id", royaltyController.Info)
 
 		transactionGateway := gateways.NewTransactionGateway(db)
-		nftInteractor := interactor.NewNftInteractor(userGateway, transactionGateway, ipfsGateway, etherClient, etherAuth, contracts, logging, validate)
+		// 取り込まれないまま詰まったミントのトランザクションは同じノンスでガス代を上げて送り直すか取り消す
+		stuckTxWatcher := interactor.NewStuckTxWatcher(gateways.NewReplacementGateway(db), etherClient, signer, feePolicy, stuckTxConfig(), logging)
+		mintWorker := interactor.NewMintWorker(transactionGateway, ipfsGateway, etherClient, signer, nonceManager, stuckTxWatcher, marketplace, mintWorkerConfig(chainRegistry.Active()), logging)
+		go mintWorker.Run(context.Background())
+		// DBとチェーンの食い違いを定期的に照合する
+		reconciler := interactor.NewReconciler(transactionGateway, marketEventGateway, etherClient, marketplace, reconcilerConfig(chainRegistry.Active()), logging)
+		go reconciler.Run(context.Background())
+		nftInteractor := interactor.NewNftInteractor(userGateway, transactionGateway, voucherGateway, ipfsGateway, mediaGateway, etherClient, marketplace, mintWorker, chainRegistry, feePolicy, os.Getenv("MINT_MODE"), logging, validate)
 		nftController := controllers.NewNftController(nftInteractor, ipfsInteractor)
 		v1.GET("/nfts/search", nftController.Search)
 		v1.GET("/nfts", nftController.List)
 		v1.GET("/nfts/:wallet", nftController.ListByWallet)
 		v1.GET("/nfts/detail/:transaction_id", nftController.GetByTransactionid)
-		v1.POST("/nfts", nftController.Mint)
+		v1.GET("/nfts/detail/:transaction_id/master", nftController.Master, requireAuth)
+		v1.GET("/nfts/detail/:transaction_id/master/content", nftController.MasterContent, requireAuth)
+		v1.GET("/nfts/token/:chain_id/:contract/:token_id", nftController.GetByToken)
+		v1.GET("/nfts/jobs/:id", nftController.GetJob)
+		v1.GET("/nfts/mint/preview", nftController.PreviewMint, requireAuth)
+		v1.POST("/nfts", nftController.Mint, requireWritable, requireAuth)
+		v1.POST("/nfts/jobs/:id/submit", nftController.SubmitMint, requireAuth) // 送信済みのトランザクションの記録はメンテナンス中も受け付ける
+		replacementController := controllers.NewReplacementController(mintWorker, logging)
+		v1.GET("/nfts/jobs/:id/replacements", replacementController.List, requireAuth)
+		v1.POST("/nfts/jobs/:id/speed-up", replacementController.SpeedUp, requireAuth) // 詰まったトランザクションの置き換えはメンテナンス中も受け付ける
+		v1.POST("/nfts/jobs/:id/cancel", replacementController.Cancel, requireAuth)
+
+		tradeInteractor := interactor.NewTradeInteractor(gateways.NewTradeGateway(db), etherClient, marketplace, logging)
+		tradeInteractor.Resume(context.Background())
+		tradeController := controllers.NewTradeController(tradeInteractor, logging, validate)
+		v1.POST("/nfts/:token_id/purchase", tradeController.Purchase, requireWritable, requireAuth)
+		v1.POST("/nfts/:token_id/resell", tradeController.Resell, requireWritable, requireAuth)
+		v1.GET("/trades/:id", tradeController.Get)
+		v1.POST("/trades/:id/submit", tradeController.Submit, requireAuth) // 送信済みのトランザクションの記録はメンテナンス中も受け付ける
 
 		userInteractor := interactor.NewUserInteractor(userGateway, logging)
 		userController := controllers.NewUserController(userInteractor)
 		v1.GET("/users", userController.List)
 		v1.GET("/users/:id", userController.Get)
 		v1.GET("/users/wallet/:wallet", userController.GetByWallet) // ウォレットアドレスで取得するための明確なパス
-		v1.POST("/users", userController.Create)
-		v1.PUT("/users/:id", userController.Update)
-		v1.DELETE("/users/:id", userController.Delete)
+		v1.POST("/users", userController.Create, requireWritable)
+		v1.PUT("/users/:id", userController.Update, requireWritable, requireAuth)
+		v1.DELETE("/users/:id", userController.Delete, requireWritable, requireAuth)
+		v1.PUT("/users/:id/role", userController.UpdateRole, requireWritable, requireAuth)
+
+		chainController := controllers.NewChainController(chainRegistry, logging)
+		v1.GET("/chains", chainController.List)
+
+		blockChainController := controllers.NewBlockChainController(evmInteractor, logging, validate)
+		v1.GET("/deployments", blockChainController.ListDeployments)
+		v1.POST("/deployments", blockChainController.Deploy, requireAuth)
+
+		// コントラクトのオーナー操作はプラットフォームのウォレットから送信し、監査ログを残す
+		contractAdminInteractor := interactor.NewContractAdminInteractor(gateways.NewContractAuditGateway(db), etherClient, signer, nonceManager, chainRegistry, marketplace, logging)
+		contractAdminController := controllers.NewContractAdminController(contractAdminInteractor, logging, validate)
+		v1.GET("/contract", contractAdminController.State)
+		v1.GET("/contract/audits", contractAdminController.ListAudits, requireAuth)
+		v1.POST("/contract/pause", contractAdminController.Pause, requireAuth)
+		v1.POST("/contract/unpause", contractAdminController.Unpause, requireAuth)
+		v1.PUT("/contract/royalty-fee", contractAdminController.UpdateRoyaltyFee, requireAuth)
+		v1.PUT("/contract/listing-price", contractAdminController.UpdateListingPrice, requireAuth)
+		v1.POST("/contract/renounce-ownership", contractAdminController.RenounceOwnership, requireAuth)
+
+		reconcileController := controllers.NewReconcileController(reconciler, logging, validate)
+		v1.GET("/reconciliation", reconcileController.LastReport, requireAuth)
+		v1.POST("/reconciliation", reconcileController.Reconcile, requireAuth)
 
-		evmInteractor := interactor.NewEvmInteractor(logging)
-		blockChainController := controllers.NewBlockChainController(evmInteractor, etherAuth, contracts, logging)
-		v1.POST("/evm", blockChainController.Signer)
+		// フロントエンドがポーリングする状態と、運用者の切り替え（管理者のAPIはメンテナンス中も止めない）
+		maintenanceController := controllers.NewMaintenanceController(maintenanceInteractor, logging, validate)
+		v1.GET("/maintenance", maintenanceController.Status)
+		v1.PUT("/maintenance", maintenanceController.SetOperator, requireAuth)
 	}
 	port := os.Getenv("PORT")
 	if len(port) == 0 {
@@ -123,3 +247,298 @@ func Run(
 	// サーバー起動
 	e.Logger.Fatal(e.Start("0.0.0.0:" + port))
 }
+
+// LoadChainRegistry はチェーンの登録簿を読み込み、環境変数 CHAIN_ID のチェーンを接続先に選びます（既定はGanacheの1337）。
+func LoadChainRegistry(db *gorm.DB) (*interactor.ChainRegistry, error) {
+	chainID, err := strconv.Atoi(os.Getenv("CHAIN_ID"))
+	if err != nil {
+		chainID = 1337
+	}
+
+	chainRegistry := interactor.NewChainRegistry(gateways.NewChainGateway(db))
+	if err := chainRegistry.Load(context.Background(), chainID); err != nil {
+		return nil, err
+	}
+	return chainRegistry, nil
+}
+
+// authConfig は環境変数からSIWEログインの設定を読み込みます。
+func authConfig() interactor.AuthConfig {
+	domain := os.Getenv("SIWE_DOMAIN")
+	if domain == "" {
+		domain = "music.threenext.com"
+	}
+	uri := os.Getenv("SIWE_URI")
+	if uri == "" {
+		uri = "https://" + domain
+	}
+	sessionTTL, err := time.ParseDuration(os.Getenv("SESSION_TTL"))
+	if err != nil || sessionTTL <= 0 {
+		sessionTTL = 24 * time.Hour
+	}
+
+	return interactor.AuthConfig{
+		Domain:     domain,
+		URI:        uri,
+		NonceTTL:   10 * time.Minute,
+		SessionTTL: sessionTTL,
+	}
+}
+
+// mintWorkerConfig は環境変数からミントジョブの設定を読み込みます。承認数の既定値はチェーンの登録簿の値です。
+func mintWorkerConfig(chain *domain.Chain) interactor.MintWorkerConfig {
+	confirmations, err := strconv.ParseUint(os.Getenv("MINT_CONFIRMATIONS"), 10, 64)
+	if err != nil || confirmations == 0 {
+		confirmations = max(chain.Confirmations, 1)
+	}
+	pollInterval, err := time.ParseDuration(os.Getenv("MINT_POLL_INTERVAL"))
+	if err != nil || pollInterval <= 0 {
+		pollInterval = 2 * time.Second
+	}
+
+	return interactor.MintWorkerConfig{
+		Confirmations: confirmations,
+		PollInterval:  pollInterval,
+	}
+}
+
+// stuckTxConfig は環境変数から詰まったトランザクションの監視の設定を読み込みます。
+func stuckTxConfig() interactor.StuckTxConfig {
+	stuckAfter, err := time.ParseDuration(os.Getenv("STUCK_TX_AFTER"))
+	if err != nil || stuckAfter <= 0 {
+		stuckAfter = 5 * time.Minute
+	}
+	action := os.Getenv("STUCK_TX_ACTION")
+	if action != domain.ReplacementKindCancel && action != interactor.StuckActionNone {
+		action = domain.ReplacementKindSpeedUp
+	}
+	feeBumpPercent, err := strconv.Atoi(os.Getenv("STUCK_TX_FEE_BUMP_PERCENT"))
+	if err != nil || feeBumpPercent < 10 {
+		feeBumpPercent = 15
+	}
+	maxReplacements, err := strconv.Atoi(os.Getenv("STUCK_TX_MAX_REPLACEMENTS"))
+	if err != nil || maxReplacements <= 0 {
+		maxReplacements = 3
+	}
+
+	return interactor.StuckTxConfig{
+		StuckAfter:      stuckAfter,
+		Action:          action,
+		FeeBumpPercent:  feeBumpPercent,
+		MaxReplacements: maxReplacements,
+	}
+}
+
+// indexerConfig は環境変数からイベントのインデクサーの設定を読み込みます。承認数の既定値はチェーンの登録簿の値です。
+func indexerConfig(chain *domain.Chain) interactor.EventIndexerConfig {
+	confirmations, err := strconv.ParseUint(os.Getenv("INDEXER_CONFIRMATIONS"), 10, 64)
+	if err != nil {
+		confirmations = chain.Confirmations
+	}
+	batchSize, err := strconv.ParseUint(os.Getenv("INDEXER_BATCH_SIZE"), 10, 64)
+	if err != nil || batchSize == 0 {
+		batchSize = 1000
+	}
+	pollInterval, err := time.ParseDuration(os.Getenv("INDEXER_POLL_INTERVAL"))
+	if err != nil || pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+
+	return interactor.EventIndexerConfig{
+		Confirmations: confirmations,
+		BatchSize:     batchSize,
+		PollInterval:  pollInterval,
+	}
+}
+
+// reconcilerConfig は環境変数からDBとチェーンの照合の設定を読み込みます。承認数はミントジョブと同じ値です。
+func reconcilerConfig(chain *domain.Chain) interactor.ReconcilerConfig {
+	interval, err := time.ParseDuration(os.Getenv("RECONCILE_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = time.Hour
+	}
+	staleAfter, err := time.ParseDuration(os.Getenv("RECONCILE_STALE_AFTER"))
+	if err != nil || staleAfter <= 0 {
+		staleAfter = 10 * time.Minute
+	}
+
+	return interactor.ReconcilerConfig{
+		Interval:      interval,
+		Repair:        os.Getenv("RECONCILE_REPAIR") != "false",
+		StaleAfter:    staleAfter,
+		Confirmations: mintWorkerConfig(chain).Confirmations,
+	}
+}
+
+// maintenanceConfig は環境変数からメンテナンスモードの設定を読み込みます。
+func maintenanceConfig() interactor.MaintenanceConfig {
+	pollInterval, err := time.ParseDuration(os.Getenv("MAINTENANCE_POLL_INTERVAL"))
+	if err != nil || pollInterval <= 0 {
+		pollInterval = 10 * time.Second
+	}
+	retryAfter, err := time.ParseDuration(os.Getenv("MAINTENANCE_RETRY_AFTER"))
+	if err != nil || retryAfter <= 0 {
+		retryAfter = time.Minute
+	}
+
+	return interactor.MaintenanceConfig{
+		Enabled:      os.Getenv("MAINTENANCE_MODE") == "true",
+		Reason:       os.Getenv("MAINTENANCE_REASON"),
+		PollInterval: pollInterval,
+		RetryAfter:   retryAfter,
+	}
+}
+
+// uploadConfig は環境変数からアップロードするファイルの上限（バイト）を読み込みます。
+// 種類ごとの上限を指定しない場合は音声 500MiB・画像 20MiB・動画 2GiB です。
+// 音声から切り出すプレビューは、指定しない場合は先頭から30秒・フェード2秒です。
+func uploadConfig() interactor.UploadConfig {
+	maxSize, err := strconv.ParseInt(os.Getenv("UPLOAD_MAX_SIZE"), 10, 64)
+	if err != nil || maxSize <= 0 {
+		maxSize = 2 << 30
+	}
+	typeLimits := map[string]int64{"audio": 500 << 20, "image": 20 << 20, "video": 2 << 30}
+	for kind, key := range map[string]string{"audio": "UPLOAD_MAX_AUDIO_SIZE", "image": "UPLOAD_MAX_IMAGE_SIZE", "video": "UPLOAD_MAX_VIDEO_SIZE"} {
+		if limit, err := strconv.ParseInt(os.Getenv(key), 10, 64); err == nil && limit > 0 {
+			typeLimits[kind] = limit
+		}
+	}
+
+	preview := audio.ClipOptions{Length: audio.DefaultClipLength, Fade: 2 * time.Second}
+	if start, err := time.ParseDuration(os.Getenv("PREVIEW_START")); err == nil && start >= 0 {
+		preview.Start = start
+	}
+	if length, err := time.ParseDuration(os.Getenv("PREVIEW_LENGTH")); err == nil && length > 0 {
+		preview.Length = length
+	}
+	if fade, err := time.ParseDuration(os.Getenv("PREVIEW_FADE")); err == nil && fade >= 0 {
+		preview.Fade = fade
+	}
+
+	return interactor.UploadConfig{
+		MaxSize:    maxSize,
+		TypeLimits: typeLimits,
+		Preview:    preview,
+	}
+}
+
+// resumableUploadDir は tus の分割アップロードで受信した内容を置くディレクトリです（既定は storage/uploads）。
+func resumableUploadDir() string {
+	dir := os.Getenv("TUS_UPLOAD_DIR")
+	if dir == "" {
+		dir = "storage/uploads"
+	}
+	return dir
+}
+
+// resumableUploadConfig は環境変数から tus の分割アップロードの期限を読み込みます。
+func resumableUploadConfig() interactor.ResumableUploadConfig {
+	expiry, err := time.ParseDuration(os.Getenv("TUS_UPLOAD_EXPIRY"))
+	if err != nil || expiry <= 0 {
+		expiry = 24 * time.Hour
+	}
+	sweepInterval, err := time.ParseDuration(os.Getenv("TUS_UPLOAD_SWEEP_INTERVAL"))
+	if err != nil || sweepInterval <= 0 {
+		sweepInterval = time.Hour
+	}
+
+	return interactor.ResumableUploadConfig{
+		Expiry:        expiry,
+		SweepInterval: sweepInterval,
+	}
+}
+
+// ipfsStorage は環境変数 IPFS_DRIVER でコンテンツの保存先を選びます（既定は kubo）。
+// IPFS_PUBLIC_URL はトークンURIや画面で使うコンテンツの公開URLで、/ipfs/<cid> の前に付けます。
+//
+//	kubo    IPFS_HOST + IPFS_API_PORT の API で追加・固定し、IPFS_HOST + IPFS_GATEWAY_PORT のゲートウェイから取得する（公開URLの既定は https://ipfs.io）
+//	pinning kubo と同じノードに追加し、PINNING_SERVICE_URL の IPFS Pinning Service API で固定する（公開URLの既定は https://ipfs.io）
+//	s3      S3_ENDPOINT の S3_BUCKET に CID をキーにして保存する（ローカルでは MinIO）
+//	local   LOCAL_STORAGE_DIR に CID をファイル名にして保存する（開発・テスト用）
+//
+// s3 と local はIPFSのネットワークに公開しないため、バックエンドの GET /api/v1/ipfs/:cid から配信する（公開URLの既定は http://localhost:1323/api/v1）
+func ipfsStorage(logging logging.Logging) usecasesGateways.IpfsGateway {
+	host := os.Getenv("IPFS_HOST")
+	if host == "" {
+		host = "http://ipfs"
+	}
+	apiPort := os.Getenv("IPFS_API_PORT")
+	if apiPort == "" {
+		apiPort = ":5001"
+	}
+	gatewayPort := os.Getenv("IPFS_GATEWAY_PORT")
+	if gatewayPort == "" {
+		gatewayPort = ":8080"
+	}
+	publicURL := os.Getenv("IPFS_PUBLIC_URL")
+	// backendURL は s3 と local の公開URL（バックエンドが配信する）
+	backendURL := func() string {
+		if publicURL == "" {
+			logging.Warning("IPFS_PUBLIC_URL が設定されていないため、コンテンツの公開URLは http://localhost:1323/api/v1 になります")
+			return "http://localhost:1323/api/v1"
+		}
+		return publicURL
+	}
+	kuboURL := publicURL
+	if kuboURL == "" {
+		kuboURL = "https://ipfs.io"
+	}
+	kubo := gateways.NewKuboIpfsGateway(host+apiPort, host+gatewayPort, kuboURL)
+
+	switch driver := os.Getenv("IPFS_DRIVER"); driver {
+	case "", "kubo":
+		return kubo
+	case "pinning":
+		return gateways.NewPinningIpfsGateway(kubo, os.Getenv("PINNING_SERVICE_URL"), os.Getenv("PINNING_SERVICE_TOKEN"))
+	case "s3":
+		s3 := gateways.NewS3IpfsGateway(os.Getenv("S3_ENDPOINT"), os.Getenv("S3_REGION"), os.Getenv("S3_BUCKET"), os.Getenv("S3_PREFIX"), os.Getenv("S3_ACCESS_KEY_ID"), os.Getenv("S3_SECRET_ACCESS_KEY"), backendURL())
+		if err := s3.EnsureBucket(context.Background()); err != nil {
+			logging.Warning("S3 のバケットを確認できません: " + err.Error())
+		}
+		return s3
+	case "local":
+		dir := os.Getenv("LOCAL_STORAGE_DIR")
+		if dir == "" {
+			dir = "storage/ipfs"
+		}
+		return gateways.NewLocalIpfsGateway(dir, backendURL())
+	default:
+		logging.Warning("IPFS_DRIVER " + driver + " には対応していないため kubo を使います")
+		return kubo
+	}
+}
+
+// feeConfig は環境変数からガス代の設定を読み込みます。上限はweiで指定し、未設定の場合は制限しません。
+func feeConfig() interactor.FeeConfig {
+	mode := os.Getenv("FEE_MODE")
+	if mode != interactor.FeeModeLegacy && mode != interactor.FeeModeDynamic {
+		mode = interactor.FeeModeAuto
+	}
+
+	return interactor.FeeConfig{
+		Mode:                 mode,
+		GasPriceMultiplier:   envFloat("FEE_GAS_PRICE_MULTIPLIER", 1),
+		TipMultiplier:        envFloat("FEE_TIP_MULTIPLIER", 1),
+		BaseFeeMultiplier:    envFloat("FEE_BASE_FEE_MULTIPLIER", 2),
+		MaxGasPrice:          envWei("FEE_MAX_GAS_PRICE"),
+		MaxPriorityFeePerGas: envWei("FEE_MAX_PRIORITY_FEE_PER_GAS"),
+		MaxFeePerGas:         envWei("FEE_MAX_FEE_PER_GAS"),
+		GasLimitMultiplier:   envFloat("GAS_LIMIT_MULTIPLIER", 1.2),
+	}
+}
+
+func envFloat(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func envWei(key string) *big.Int {
+	value, ok := new(big.Int).SetString(os.Getenv(key), 10)
+	if !ok || value.Sign() <= 0 {
+		return nil
+	}
+	return value
+}
@@ -2,6 +2,8 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"os"
 
 	"nft-music/docs"
@@ -22,6 +24,7 @@ import (
 // @securityDefinitions.apikey ApiKeyAuth
 // @in header
 // @name Authorization
+// @description POST /auth/verify で発行したセッショントークンを "Bearer <セッショントークン>" の形式で指定します
 func main() {
 	swaggerSet()
 
@@ -32,13 +35,35 @@ func main() {
 
 	validate := validator.New()
 
+	// 接続するチェーンは環境変数 CHAIN_ID で選び、接続先は登録簿から取得する
+	chainRegistry, err := server.LoadChainRegistry(client)
+	if err != nil {
+		panic(err)
+	}
+
 	newEthereumVirtualMachine := ethereum.NewEthereumVirtualMachine()
-	etherClient, transactOpts, contracts, err := newEthereumVirtualMachine.Auth()
+	etherClient, err := newEthereumVirtualMachine.DialChain(context.Background(), chainRegistry.Active())
+	if err != nil {
+		panic(err)
+	}
+
+	// go run . backfill -from <ブロック番号> でマーケットのイベントとミントのトークンIDを取り込み直す
+	if len(os.Args) > 1 && os.Args[1] == "backfill" {
+		flags := flag.NewFlagSet("backfill", flag.ExitOnError)
+		from := flags.Uint64("from", 1, "取り込み直す最初のブロック番号")
+		_ = flags.Parse(os.Args[2:])
+		if err := server.Backfill(client, etherClient, chainRegistry, logging, *from); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	signer, err := ethereum.NewSigner(context.Background())
 	if err != nil {
 		panic(err)
 	}
 
-	server.Run(client, etherClient, transactOpts, contracts, logging, validate)
+	server.Run(client, etherClient, chainRegistry, signer, logging, validate)
 }
 
 func swaggerSet() {
@@ -0,0 +1,21 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+	"time"
+
+	"nft-music/domain"
+)
+
+//go:generate mockgen -package mock -source $GOFILE -destination mock/$GOFILE
+
+// AuthGateway はSIWEのノンスとセッションを管理するインターフェース
+type AuthGateway interface {
+	CreateNonce(ctx context.Context, nonce *domain.AuthNonce) error
+	GetNonce(ctx context.Context, nonce string) (*domain.AuthNonce, error)
+	UseNonce(ctx context.Context, nonce string, usedAt time.Time) error
+	CreateSession(ctx context.Context, session *domain.Session) error
+	GetSessionByTokenHash(ctx context.Context, tokenHash string) (*domain.Session, error)
+	DeleteSessionByTokenHash(ctx context.Context, tokenHash string) error
+}
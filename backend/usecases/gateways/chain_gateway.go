@@ -0,0 +1,16 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+)
+
+//go:generate mockgen -package mock -source $GOFILE -destination mock/$GOFILE
+
+// ChainGateway は対応しているチェーンの登録簿を管理するインターフェース
+type ChainGateway interface {
+	List(ctx context.Context) ([]domain.Chain, error)
+	UpdateMarketplace(ctx context.Context, chainID int, address string) error
+}
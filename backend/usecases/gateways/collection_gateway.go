@@ -14,8 +14,10 @@ import (
 // CollectionGateway はコレクションのトランザクション処理インターフェース
 type CollectionGateway interface {
 	Get(ctx context.Context, id uuid.UUID) (*domain.Collection, error)
+	GetByContract(ctx context.Context, chainID int, contractAddress string) (*domain.Collection, error)
 	List(ctx context.Context) ([]domain.Collection, error)
 	Create(ctx context.Context, collection *domain.Collection) error
 	Update(ctx context.Context, collection *domain.Collection) error
+	UpdateRoyalty(ctx context.Context, id uuid.UUID, royaltyBps int, receiver string) error
 	Delete(ctx context.Context, collection *domain.Collection) error
 }
@@ -0,0 +1,18 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+)
+
+//go:generate mockgen -package mock -source $GOFILE -destination mock/$GOFILE
+
+// ContractAuditGateway はコントラクトのオーナー操作の監査ログを管理するインターフェース
+type ContractAuditGateway interface {
+	Create(ctx context.Context, audit *domain.ContractAudit) error
+	// Update は取り込みを待っていた操作の結果（ステータス・ブロック番号・失敗した理由）を更新する
+	Update(ctx context.Context, audit *domain.ContractAudit) error
+	List(ctx context.Context, chainID int, contractAddress string, limit int) ([]domain.ContractAudit, error)
+}
@@ -0,0 +1,17 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+)
+
+//go:generate mockgen -package mock -source $GOFILE -destination mock/$GOFILE
+
+// DeploymentGateway はデプロイ済みコントラクトの登録簿を管理するインターフェース
+type DeploymentGateway interface {
+	Create(ctx context.Context, deployment *domain.Deployment) error
+	Latest(ctx context.Context, chainID int, contractName string) (*domain.Deployment, error)
+	List(ctx context.Context, chainID int) ([]domain.Deployment, error)
+}
@@ -2,19 +2,25 @@
 package gateways
 
 import (
-	"bytes"
 	"context"
+	"io"
 
 	"nft-music/domain"
 )
 
-// IpfsGateway はIPFSノードにアクセスする処理です
+// IpfsGateway はCIDで参照するコンテンツの保存先です
+// （Kubo ノード / Pinning Service / S3 互換のオブジェクトストレージ / ローカルのファイルを設定で切り替える）
 //
 //go:generate mockgen -package mock -source $GOFILE -destination mock/$GOFILE
 type IpfsGateway interface {
-	Get(ctx context.Context, cid string) (*domain.IpfsJSON, error)
-	Add(ctx context.Context, body *bytes.Buffer, contentType string) (*domain.IpfsAdd, error)
-	Publish(ctx context.Context, cid string) (*domain.IpfsPublish, error)
-	Localpin(ctx context.Context, cid string) (*domain.IpfsPins, error)
-	Resolve(ctx context.Context, name string) (*domain.IpfsResolve, error)
+	// Get はトークンのメタJSONを取得する（path は /ipfs/<cid>）
+	Get(ctx context.Context, path string) (*domain.IpfsJSON, error)
+	// Add はコンテンツを保存してCIDを返す
+	Add(ctx context.Context, filename string, content io.Reader) (*domain.IpfsAdd, error)
+	// Pin はコンテンツが消えないように固定し、参照するパスを返す
+	Pin(ctx context.Context, cid string) (*domain.IpfsResolve, error)
+	// Cat はコンテンツを取得する（path は /ipfs/<cid> または CID）
+	Cat(ctx context.Context, path string) (io.ReadCloser, error)
+	// URL は参照するパス（/ipfs/<cid>）をトークンURIや画面で使う公開URLにする（保存先ごとに公開するURLが異なる）
+	URL(path string) string
 }
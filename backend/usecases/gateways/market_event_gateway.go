@@ -0,0 +1,22 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+)
+
+//go:generate mockgen -package mock -source $GOFILE -destination mock/$GOFILE
+
+// MarketEventGateway はチェーンから取り込んだイベントとチェックポイントを管理するインターフェース
+type MarketEventGateway interface {
+	// GetCheckpoint はコントラクトのチェックポイントを取得する（まだ無い場合は nil を返す）
+	GetCheckpoint(ctx context.Context, chainID int, contractAddress string) (*domain.IndexerCheckpoint, error)
+	// Save はブロック範囲のイベントとチェックポイントを1つのトランザクションで保存する
+	Save(ctx context.Context, events []domain.MarketEvent, transfers []domain.TokenTransfer, checkpoint *domain.IndexerCheckpoint) error
+	// Rollback はチェックポイントより後のブロックのイベントを削除し、チェックポイントを戻す
+	Rollback(ctx context.Context, checkpoint *domain.IndexerCheckpoint) error
+	// ListOwners は取り込んだTransferから、トークンIDごとの最後の移転先を取得する
+	ListOwners(ctx context.Context, chainID int, contractAddress string) (map[string]string, error)
+}
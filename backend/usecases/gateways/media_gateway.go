@@ -0,0 +1,18 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+)
+
+//go:generate mockgen -package mock -source $GOFILE -destination mock/$GOFILE
+
+// MediaGateway はアップロードしたファイルの記録のトランザクション処理インターフェース
+type MediaGateway interface {
+	Create(ctx context.Context, media *domain.MediaFile) error
+	GetByCid(ctx context.Context, cid string) (*domain.MediaFile, error)
+	// GetBySha256 は同じ内容のファイルのうち最初に記録したものを取得する
+	GetBySha256(ctx context.Context, sha256 string) (*domain.MediaFile, error)
+}
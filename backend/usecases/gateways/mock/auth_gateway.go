@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: auth_gateway.go
+//
+// Generated by this command:
+//
+//	mockgen -package mock -source auth_gateway.go -destination mock/auth_gateway.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	domain "nft-music/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthGateway is a mock of AuthGateway interface.
+type MockAuthGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthGatewayMockRecorder is the mock recorder for MockAuthGateway.
+type MockAuthGatewayMockRecorder struct {
+	mock *MockAuthGateway
+}
+
+// NewMockAuthGateway creates a new mock instance.
+func NewMockAuthGateway(ctrl *gomock.Controller) *MockAuthGateway {
+	mock := &MockAuthGateway{ctrl: ctrl}
+	mock.recorder = &MockAuthGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthGateway) EXPECT() *MockAuthGatewayMockRecorder {
+	return m.recorder
+}
+
+// CreateNonce mocks base method.
+func (m *MockAuthGateway) CreateNonce(ctx context.Context, nonce *domain.AuthNonce) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNonce", ctx, nonce)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNonce indicates an expected call of CreateNonce.
+func (mr *MockAuthGatewayMockRecorder) CreateNonce(ctx, nonce any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNonce", reflect.TypeOf((*MockAuthGateway)(nil).CreateNonce), ctx, nonce)
+}
+
+// CreateSession mocks base method.
+func (m *MockAuthGateway) CreateSession(ctx context.Context, session *domain.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockAuthGatewayMockRecorder) CreateSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockAuthGateway)(nil).CreateSession), ctx, session)
+}
+
+// DeleteSessionByTokenHash mocks base method.
+func (m *MockAuthGateway) DeleteSessionByTokenHash(ctx context.Context, tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionByTokenHash indicates an expected call of DeleteSessionByTokenHash.
+func (mr *MockAuthGatewayMockRecorder) DeleteSessionByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionByTokenHash", reflect.TypeOf((*MockAuthGateway)(nil).DeleteSessionByTokenHash), ctx, tokenHash)
+}
+
+// GetNonce mocks base method.
+func (m *MockAuthGateway) GetNonce(ctx context.Context, nonce string) (*domain.AuthNonce, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNonce", ctx, nonce)
+	ret0, _ := ret[0].(*domain.AuthNonce)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNonce indicates an expected call of GetNonce.
+func (mr *MockAuthGatewayMockRecorder) GetNonce(ctx, nonce any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNonce", reflect.TypeOf((*MockAuthGateway)(nil).GetNonce), ctx, nonce)
+}
+
+// GetSessionByTokenHash mocks base method.
+func (m *MockAuthGateway) GetSessionByTokenHash(ctx context.Context, tokenHash string) (*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByTokenHash indicates an expected call of GetSessionByTokenHash.
+func (mr *MockAuthGatewayMockRecorder) GetSessionByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByTokenHash", reflect.TypeOf((*MockAuthGateway)(nil).GetSessionByTokenHash), ctx, tokenHash)
+}
+
+// UseNonce mocks base method.
+func (m *MockAuthGateway) UseNonce(ctx context.Context, nonce string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseNonce", ctx, nonce, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseNonce indicates an expected call of UseNonce.
+func (mr *MockAuthGatewayMockRecorder) UseNonce(ctx, nonce, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseNonce", reflect.TypeOf((*MockAuthGateway)(nil).UseNonce), ctx, nonce, usedAt)
+}
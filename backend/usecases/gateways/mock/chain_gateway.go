@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: chain_gateway.go
+//
+// Generated by this command:
+//
+//	mockgen -package mock -source chain_gateway.go -destination mock/chain_gateway.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	domain "nft-music/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChainGateway is a mock of ChainGateway interface.
+type MockChainGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockChainGatewayMockRecorder is the mock recorder for MockChainGateway.
+type MockChainGatewayMockRecorder struct {
+	mock *MockChainGateway
+}
+
+// NewMockChainGateway creates a new mock instance.
+func NewMockChainGateway(ctrl *gomock.Controller) *MockChainGateway {
+	mock := &MockChainGateway{ctrl: ctrl}
+	mock.recorder = &MockChainGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainGateway) EXPECT() *MockChainGatewayMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockChainGateway) List(ctx context.Context) ([]domain.Chain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]domain.Chain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockChainGatewayMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockChainGateway)(nil).List), ctx)
+}
+
+// UpdateMarketplace mocks base method.
+func (m *MockChainGateway) UpdateMarketplace(ctx context.Context, chainID int, address string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMarketplace", ctx, chainID, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMarketplace indicates an expected call of UpdateMarketplace.
+func (mr *MockChainGatewayMockRecorder) UpdateMarketplace(ctx, chainID, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMarketplace", reflect.TypeOf((*MockChainGateway)(nil).UpdateMarketplace), ctx, chainID, address)
+}
@@ -85,6 +85,21 @@ func (mr *MockCollectionGatewayMockRecorder) Get(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCollectionGateway)(nil).Get), ctx, id)
 }
 
+// GetByContract mocks base method.
+func (m *MockCollectionGateway) GetByContract(ctx context.Context, chainID int, contractAddress string) (*domain.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByContract", ctx, chainID, contractAddress)
+	ret0, _ := ret[0].(*domain.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByContract indicates an expected call of GetByContract.
+func (mr *MockCollectionGatewayMockRecorder) GetByContract(ctx, chainID, contractAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByContract", reflect.TypeOf((*MockCollectionGateway)(nil).GetByContract), ctx, chainID, contractAddress)
+}
+
 // List mocks base method.
 func (m *MockCollectionGateway) List(ctx context.Context) ([]domain.Collection, error) {
 	m.ctrl.T.Helper()
@@ -113,3 +128,17 @@ func (mr *MockCollectionGatewayMockRecorder) Update(ctx, collection any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCollectionGateway)(nil).Update), ctx, collection)
 }
+
+// UpdateRoyalty mocks base method.
+func (m *MockCollectionGateway) UpdateRoyalty(ctx context.Context, id uuid.UUID, royaltyBps int, receiver string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRoyalty", ctx, id, royaltyBps, receiver)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRoyalty indicates an expected call of UpdateRoyalty.
+func (mr *MockCollectionGatewayMockRecorder) UpdateRoyalty(ctx, id, royaltyBps, receiver any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoyalty", reflect.TypeOf((*MockCollectionGateway)(nil).UpdateRoyalty), ctx, id, royaltyBps, receiver)
+}
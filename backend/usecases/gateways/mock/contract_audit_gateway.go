@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract_audit_gateway.go
+//
+// Generated by this command:
+//
+//	mockgen -package mock -source contract_audit_gateway.go -destination mock/contract_audit_gateway.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	domain "nft-music/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockContractAuditGateway is a mock of ContractAuditGateway interface.
+type MockContractAuditGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockContractAuditGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockContractAuditGatewayMockRecorder is the mock recorder for MockContractAuditGateway.
+type MockContractAuditGatewayMockRecorder struct {
+	mock *MockContractAuditGateway
+}
+
+// NewMockContractAuditGateway creates a new mock instance.
+func NewMockContractAuditGateway(ctrl *gomock.Controller) *MockContractAuditGateway {
+	mock := &MockContractAuditGateway{ctrl: ctrl}
+	mock.recorder = &MockContractAuditGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContractAuditGateway) EXPECT() *MockContractAuditGatewayMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockContractAuditGateway) Create(ctx context.Context, audit *domain.ContractAudit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, audit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockContractAuditGatewayMockRecorder) Create(ctx, audit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockContractAuditGateway)(nil).Create), ctx, audit)
+}
+
+// List mocks base method.
+func (m *MockContractAuditGateway) List(ctx context.Context, chainID int, contractAddress string, limit int) ([]domain.ContractAudit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, chainID, contractAddress, limit)
+	ret0, _ := ret[0].([]domain.ContractAudit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockContractAuditGatewayMockRecorder) List(ctx, chainID, contractAddress, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockContractAuditGateway)(nil).List), ctx, chainID, contractAddress, limit)
+}
+
+// Update mocks base method.
+func (m *MockContractAuditGateway) Update(ctx context.Context, audit *domain.ContractAudit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, audit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockContractAuditGatewayMockRecorder) Update(ctx, audit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockContractAuditGateway)(nil).Update), ctx, audit)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: deployment_gateway.go
+//
+// Generated by this command:
+//
+//	mockgen -package mock -source deployment_gateway.go -destination mock/deployment_gateway.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	domain "nft-music/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeploymentGateway is a mock of DeploymentGateway interface.
+type MockDeploymentGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeploymentGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockDeploymentGatewayMockRecorder is the mock recorder for MockDeploymentGateway.
+type MockDeploymentGatewayMockRecorder struct {
+	mock *MockDeploymentGateway
+}
+
+// NewMockDeploymentGateway creates a new mock instance.
+func NewMockDeploymentGateway(ctrl *gomock.Controller) *MockDeploymentGateway {
+	mock := &MockDeploymentGateway{ctrl: ctrl}
+	mock.recorder = &MockDeploymentGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeploymentGateway) EXPECT() *MockDeploymentGatewayMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDeploymentGateway) Create(ctx context.Context, deployment *domain.Deployment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, deployment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDeploymentGatewayMockRecorder) Create(ctx, deployment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDeploymentGateway)(nil).Create), ctx, deployment)
+}
+
+// Latest mocks base method.
+func (m *MockDeploymentGateway) Latest(ctx context.Context, chainID int, contractName string) (*domain.Deployment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Latest", ctx, chainID, contractName)
+	ret0, _ := ret[0].(*domain.Deployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Latest indicates an expected call of Latest.
+func (mr *MockDeploymentGatewayMockRecorder) Latest(ctx, chainID, contractName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Latest", reflect.TypeOf((*MockDeploymentGateway)(nil).Latest), ctx, chainID, contractName)
+}
+
+// List mocks base method.
+func (m *MockDeploymentGateway) List(ctx context.Context, chainID int) ([]domain.Deployment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, chainID)
+	ret0, _ := ret[0].([]domain.Deployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDeploymentGatewayMockRecorder) List(ctx, chainID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDeploymentGateway)(nil).List), ctx, chainID)
+}
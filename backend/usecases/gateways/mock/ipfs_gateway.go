@@ -1,15 +1,19 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: ipfs_gateway.go
+//
+// Generated by this command:
+//
+//	mockgen -package mock -source ipfs_gateway.go -destination mock/ipfs_gateway.go
+//
 
 // Package mock is a generated GoMock package.
 package mock
 
 import (
-	bytes "bytes"
 	context "context"
-	reflect "reflect"
-
+	io "io"
 	domain "nft-music/domain"
+	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -18,6 +22,7 @@ import (
 type MockIpfsGateway struct {
 	ctrl     *gomock.Controller
 	recorder *MockIpfsGatewayMockRecorder
+	isgomock struct{}
 }
 
 // MockIpfsGatewayMockRecorder is the mock recorder for MockIpfsGateway.
@@ -38,76 +43,75 @@ func (m *MockIpfsGateway) EXPECT() *MockIpfsGatewayMockRecorder {
 }
 
 // Add mocks base method.
-func (m *MockIpfsGateway) Add(ctx context.Context, body *bytes.Buffer, contentType string) (*domain.IpfsAdd, error) {
+func (m *MockIpfsGateway) Add(ctx context.Context, filename string, content io.Reader) (*domain.IpfsAdd, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Add", ctx, body, contentType)
+	ret := m.ctrl.Call(m, "Add", ctx, filename, content)
 	ret0, _ := ret[0].(*domain.IpfsAdd)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Add indicates an expected call of Add.
-func (mr *MockIpfsGatewayMockRecorder) Add(ctx, body, contentType interface{}) *gomock.Call {
+func (mr *MockIpfsGatewayMockRecorder) Add(ctx, filename, content any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockIpfsGateway)(nil).Add), ctx, body, contentType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockIpfsGateway)(nil).Add), ctx, filename, content)
 }
 
-// Get mocks base method.
-func (m *MockIpfsGateway) Get(ctx context.Context, cid string) (*domain.IpfsJSON, error) {
+// Cat mocks base method.
+func (m *MockIpfsGateway) Cat(ctx context.Context, path string) (io.ReadCloser, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, cid)
-	ret0, _ := ret[0].(*domain.IpfsJSON)
+	ret := m.ctrl.Call(m, "Cat", ctx, path)
+	ret0, _ := ret[0].(io.ReadCloser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Get indicates an expected call of Get.
-func (mr *MockIpfsGatewayMockRecorder) Get(ctx, cid interface{}) *gomock.Call {
+// Cat indicates an expected call of Cat.
+func (mr *MockIpfsGatewayMockRecorder) Cat(ctx, path any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIpfsGateway)(nil).Get), ctx, cid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cat", reflect.TypeOf((*MockIpfsGateway)(nil).Cat), ctx, path)
 }
 
-// Localpin mocks base method.
-func (m *MockIpfsGateway) Localpin(ctx context.Context, cid string) (*domain.IpfsPins, error) {
+// Get mocks base method.
+func (m *MockIpfsGateway) Get(ctx context.Context, path string) (*domain.IpfsJSON, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Localpin", ctx, cid)
-	ret0, _ := ret[0].(*domain.IpfsPins)
+	ret := m.ctrl.Call(m, "Get", ctx, path)
+	ret0, _ := ret[0].(*domain.IpfsJSON)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Localpin indicates an expected call of Localpin.
-func (mr *MockIpfsGatewayMockRecorder) Localpin(ctx, cid interface{}) *gomock.Call {
+// Get indicates an expected call of Get.
+func (mr *MockIpfsGatewayMockRecorder) Get(ctx, path any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Localpin", reflect.TypeOf((*MockIpfsGateway)(nil).Localpin), ctx, cid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIpfsGateway)(nil).Get), ctx, path)
 }
 
-// Publish mocks base method.
-func (m *MockIpfsGateway) Publish(ctx context.Context, cid string) (*domain.IpfsPublish, error) {
+// Pin mocks base method.
+func (m *MockIpfsGateway) Pin(ctx context.Context, cid string) (*domain.IpfsResolve, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Publish", ctx, cid)
-	ret0, _ := ret[0].(*domain.IpfsPublish)
+	ret := m.ctrl.Call(m, "Pin", ctx, cid)
+	ret0, _ := ret[0].(*domain.IpfsResolve)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Publish indicates an expected call of Publish.
-func (mr *MockIpfsGatewayMockRecorder) Publish(ctx, cid interface{}) *gomock.Call {
+// Pin indicates an expected call of Pin.
+func (mr *MockIpfsGatewayMockRecorder) Pin(ctx, cid any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIpfsGateway)(nil).Publish), ctx, cid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pin", reflect.TypeOf((*MockIpfsGateway)(nil).Pin), ctx, cid)
 }
 
-// Resolve mocks base method.
-func (m *MockIpfsGateway) Resolve(ctx context.Context, name string) (*domain.IpfsResolve, error) {
+// URL mocks base method.
+func (m *MockIpfsGateway) URL(path string) string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Resolve", ctx, name)
-	ret0, _ := ret[0].(*domain.IpfsResolve)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "URL", path)
+	ret0, _ := ret[0].(string)
+	return ret0
 }
 
-// Resolve indicates an expected call of Resolve.
-func (mr *MockIpfsGatewayMockRecorder) Resolve(ctx, name interface{}) *gomock.Call {
+// URL indicates an expected call of URL.
+func (mr *MockIpfsGatewayMockRecorder) URL(path any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockIpfsGateway)(nil).Resolve), ctx, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URL", reflect.TypeOf((*MockIpfsGateway)(nil).URL), path)
 }
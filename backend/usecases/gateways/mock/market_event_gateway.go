@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: market_event_gateway.go
+//
+// Generated by this command:
+//
+//	mockgen -package mock -source market_event_gateway.go -destination mock/market_event_gateway.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	domain "nft-music/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMarketEventGateway is a mock of MarketEventGateway interface.
+type MockMarketEventGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockMarketEventGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockMarketEventGatewayMockRecorder is the mock recorder for MockMarketEventGateway.
+type MockMarketEventGatewayMockRecorder struct {
+	mock *MockMarketEventGateway
+}
+
+// NewMockMarketEventGateway creates a new mock instance.
+func NewMockMarketEventGateway(ctrl *gomock.Controller) *MockMarketEventGateway {
+	mock := &MockMarketEventGateway{ctrl: ctrl}
+	mock.recorder = &MockMarketEventGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMarketEventGateway) EXPECT() *MockMarketEventGatewayMockRecorder {
+	return m.recorder
+}
+
+// GetCheckpoint mocks base method.
+func (m *MockMarketEventGateway) GetCheckpoint(ctx context.Context, chainID int, contractAddress string) (*domain.IndexerCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckpoint", ctx, chainID, contractAddress)
+	ret0, _ := ret[0].(*domain.IndexerCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckpoint indicates an expected call of GetCheckpoint.
+func (mr *MockMarketEventGatewayMockRecorder) GetCheckpoint(ctx, chainID, contractAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckpoint", reflect.TypeOf((*MockMarketEventGateway)(nil).GetCheckpoint), ctx, chainID, contractAddress)
+}
+
+// ListOwners mocks base method.
+func (m *MockMarketEventGateway) ListOwners(ctx context.Context, chainID int, contractAddress string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOwners", ctx, chainID, contractAddress)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOwners indicates an expected call of ListOwners.
+func (mr *MockMarketEventGatewayMockRecorder) ListOwners(ctx, chainID, contractAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwners", reflect.TypeOf((*MockMarketEventGateway)(nil).ListOwners), ctx, chainID, contractAddress)
+}
+
+// Rollback mocks base method.
+func (m *MockMarketEventGateway) Rollback(ctx context.Context, checkpoint *domain.IndexerCheckpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", ctx, checkpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockMarketEventGatewayMockRecorder) Rollback(ctx, checkpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockMarketEventGateway)(nil).Rollback), ctx, checkpoint)
+}
+
+// Save mocks base method.
+func (m *MockMarketEventGateway) Save(ctx context.Context, events []domain.MarketEvent, transfers []domain.TokenTransfer, checkpoint *domain.IndexerCheckpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, events, transfers, checkpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockMarketEventGatewayMockRecorder) Save(ctx, events, transfers, checkpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockMarketEventGateway)(nil).Save), ctx, events, transfers, checkpoint)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: media_gateway.go
+//
+// Generated by this command:
+//
+//	mockgen -package mock -source media_gateway.go -destination mock/media_gateway.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	domain "nft-music/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMediaGateway is a mock of MediaGateway interface.
+type MockMediaGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockMediaGatewayMockRecorder is the mock recorder for MockMediaGateway.
+type MockMediaGatewayMockRecorder struct {
+	mock *MockMediaGateway
+}
+
+// NewMockMediaGateway creates a new mock instance.
+func NewMockMediaGateway(ctrl *gomock.Controller) *MockMediaGateway {
+	mock := &MockMediaGateway{ctrl: ctrl}
+	mock.recorder = &MockMediaGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaGateway) EXPECT() *MockMediaGatewayMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMediaGateway) Create(ctx context.Context, media *domain.MediaFile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, media)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMediaGatewayMockRecorder) Create(ctx, media any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMediaGateway)(nil).Create), ctx, media)
+}
+
+// GetByCid mocks base method.
+func (m *MockMediaGateway) GetByCid(ctx context.Context, cid string) (*domain.MediaFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCid", ctx, cid)
+	ret0, _ := ret[0].(*domain.MediaFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCid indicates an expected call of GetByCid.
+func (mr *MockMediaGatewayMockRecorder) GetByCid(ctx, cid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCid", reflect.TypeOf((*MockMediaGateway)(nil).GetByCid), ctx, cid)
+}
+
+// GetBySha256 mocks base method.
+func (m *MockMediaGateway) GetBySha256(ctx context.Context, sha256 string) (*domain.MediaFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySha256", ctx, sha256)
+	ret0, _ := ret[0].(*domain.MediaFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySha256 indicates an expected call of GetBySha256.
+func (mr *MockMediaGatewayMockRecorder) GetBySha256(ctx, sha256 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySha256", reflect.TypeOf((*MockMediaGateway)(nil).GetBySha256), ctx, sha256)
+}
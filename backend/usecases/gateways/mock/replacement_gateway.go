@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: replacement_gateway.go
+//
+// Generated by this command:
+//
+//	mockgen -package mock -source replacement_gateway.go -destination mock/replacement_gateway.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	domain "nft-music/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReplacementGateway is a mock of ReplacementGateway interface.
+type MockReplacementGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockReplacementGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockReplacementGatewayMockRecorder is the mock recorder for MockReplacementGateway.
+type MockReplacementGatewayMockRecorder struct {
+	mock *MockReplacementGateway
+}
+
+// NewMockReplacementGateway creates a new mock instance.
+func NewMockReplacementGateway(ctrl *gomock.Controller) *MockReplacementGateway {
+	mock := &MockReplacementGateway{ctrl: ctrl}
+	mock.recorder = &MockReplacementGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReplacementGateway) EXPECT() *MockReplacementGatewayMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReplacementGateway) Create(ctx context.Context, replacement *domain.TransactionReplacement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, replacement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReplacementGatewayMockRecorder) Create(ctx, replacement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReplacementGateway)(nil).Create), ctx, replacement)
+}
+
+// ListByTransaction mocks base method.
+func (m *MockReplacementGateway) ListByTransaction(ctx context.Context, transactionID string) ([]*domain.TransactionReplacement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTransaction", ctx, transactionID)
+	ret0, _ := ret[0].([]*domain.TransactionReplacement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTransaction indicates an expected call of ListByTransaction.
+func (mr *MockReplacementGatewayMockRecorder) ListByTransaction(ctx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTransaction", reflect.TypeOf((*MockReplacementGateway)(nil).ListByTransaction), ctx, transactionID)
+}
+
+// Update mocks base method.
+func (m *MockReplacementGateway) Update(ctx context.Context, replacement *domain.TransactionReplacement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, replacement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReplacementGatewayMockRecorder) Update(ctx, replacement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReplacementGateway)(nil).Update), ctx, replacement)
+}
@@ -0,0 +1,161 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: resumable_upload_gateway.go
+//
+// Generated by this command:
+//
+//	mockgen -package mock -source resumable_upload_gateway.go -destination mock/resumable_upload_gateway.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	domain "nft-music/domain"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockResumableUploadGateway is a mock of ResumableUploadGateway interface.
+type MockResumableUploadGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockResumableUploadGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockResumableUploadGatewayMockRecorder is the mock recorder for MockResumableUploadGateway.
+type MockResumableUploadGatewayMockRecorder struct {
+	mock *MockResumableUploadGateway
+}
+
+// NewMockResumableUploadGateway creates a new mock instance.
+func NewMockResumableUploadGateway(ctrl *gomock.Controller) *MockResumableUploadGateway {
+	mock := &MockResumableUploadGateway{ctrl: ctrl}
+	mock.recorder = &MockResumableUploadGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResumableUploadGateway) EXPECT() *MockResumableUploadGatewayMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockResumableUploadGateway) Append(ctx context.Context, id uuid.UUID, offset int64, content io.Reader) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, id, offset, content)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockResumableUploadGatewayMockRecorder) Append(ctx, id, offset, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockResumableUploadGateway)(nil).Append), ctx, id, offset, content)
+}
+
+// Create mocks base method.
+func (m *MockResumableUploadGateway) Create(ctx context.Context, upload *domain.ResumableUpload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, upload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockResumableUploadGatewayMockRecorder) Create(ctx, upload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockResumableUploadGateway)(nil).Create), ctx, upload)
+}
+
+// Delete mocks base method.
+func (m *MockResumableUploadGateway) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockResumableUploadGatewayMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockResumableUploadGateway)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockResumableUploadGateway) Get(ctx context.Context, id uuid.UUID) (*domain.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*domain.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockResumableUploadGatewayMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockResumableUploadGateway)(nil).Get), ctx, id)
+}
+
+// ListExpired mocks base method.
+func (m *MockResumableUploadGateway) ListExpired(ctx context.Context, now time.Time) ([]*domain.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpired", ctx, now)
+	ret0, _ := ret[0].([]*domain.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpired indicates an expected call of ListExpired.
+func (mr *MockResumableUploadGatewayMockRecorder) ListExpired(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpired", reflect.TypeOf((*MockResumableUploadGateway)(nil).ListExpired), ctx, now)
+}
+
+// Open mocks base method.
+func (m *MockResumableUploadGateway) Open(ctx context.Context, id uuid.UUID) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, id)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockResumableUploadGatewayMockRecorder) Open(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockResumableUploadGateway)(nil).Open), ctx, id)
+}
+
+// RemoveData mocks base method.
+func (m *MockResumableUploadGateway) RemoveData(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveData", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveData indicates an expected call of RemoveData.
+func (mr *MockResumableUploadGatewayMockRecorder) RemoveData(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveData", reflect.TypeOf((*MockResumableUploadGateway)(nil).RemoveData), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockResumableUploadGateway) Update(ctx context.Context, upload *domain.ResumableUpload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, upload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockResumableUploadGatewayMockRecorder) Update(ctx, upload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockResumableUploadGateway)(nil).Update), ctx, upload)
+}
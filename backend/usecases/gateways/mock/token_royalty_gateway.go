@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: token_royalty_gateway.go
+//
+// Generated by this command:
+//
+//	mockgen -package mock -source token_royalty_gateway.go -destination mock/token_royalty_gateway.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	domain "nft-music/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTokenRoyaltyGateway is a mock of TokenRoyaltyGateway interface.
+type MockTokenRoyaltyGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRoyaltyGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenRoyaltyGatewayMockRecorder is the mock recorder for MockTokenRoyaltyGateway.
+type MockTokenRoyaltyGatewayMockRecorder struct {
+	mock *MockTokenRoyaltyGateway
+}
+
+// NewMockTokenRoyaltyGateway creates a new mock instance.
+func NewMockTokenRoyaltyGateway(ctrl *gomock.Controller) *MockTokenRoyaltyGateway {
+	mock := &MockTokenRoyaltyGateway{ctrl: ctrl}
+	mock.recorder = &MockTokenRoyaltyGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRoyaltyGateway) EXPECT() *MockTokenRoyaltyGatewayMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockTokenRoyaltyGateway) Delete(ctx context.Context, chainID int, contractAddress, tokenID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, chainID, contractAddress, tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTokenRoyaltyGatewayMockRecorder) Delete(ctx, chainID, contractAddress, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTokenRoyaltyGateway)(nil).Delete), ctx, chainID, contractAddress, tokenID)
+}
+
+// Get mocks base method.
+func (m *MockTokenRoyaltyGateway) Get(ctx context.Context, chainID int, contractAddress, tokenID string) (*domain.TokenRoyalty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, chainID, contractAddress, tokenID)
+	ret0, _ := ret[0].(*domain.TokenRoyalty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTokenRoyaltyGatewayMockRecorder) Get(ctx, chainID, contractAddress, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTokenRoyaltyGateway)(nil).Get), ctx, chainID, contractAddress, tokenID)
+}
+
+// Save mocks base method.
+func (m *MockTokenRoyaltyGateway) Save(ctx context.Context, royalty *domain.TokenRoyalty) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, royalty)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockTokenRoyaltyGatewayMockRecorder) Save(ctx, royalty any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTokenRoyaltyGateway)(nil).Save), ctx, royalty)
+}
@@ -0,0 +1,106 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: trade_gateway.go
+//
+// Generated by this command:
+//
+//	mockgen -package mock -source trade_gateway.go -destination mock/trade_gateway.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	domain "nft-music/domain"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTradeGateway is a mock of TradeGateway interface.
+type MockTradeGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockTradeGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockTradeGatewayMockRecorder is the mock recorder for MockTradeGateway.
+type MockTradeGatewayMockRecorder struct {
+	mock *MockTradeGateway
+}
+
+// NewMockTradeGateway creates a new mock instance.
+func NewMockTradeGateway(ctrl *gomock.Controller) *MockTradeGateway {
+	mock := &MockTradeGateway{ctrl: ctrl}
+	mock.recorder = &MockTradeGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTradeGateway) EXPECT() *MockTradeGatewayMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTradeGateway) Create(ctx context.Context, trade *domain.Trade) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, trade)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTradeGatewayMockRecorder) Create(ctx, trade any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTradeGateway)(nil).Create), ctx, trade)
+}
+
+// Get mocks base method.
+func (m *MockTradeGateway) Get(ctx context.Context, id uuid.UUID) (*domain.Trade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*domain.Trade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTradeGatewayMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTradeGateway)(nil).Get), ctx, id)
+}
+
+// ListByStatus mocks base method.
+func (m *MockTradeGateway) ListByStatus(ctx context.Context, statuses ...string) ([]*domain.Trade, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range statuses {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListByStatus", varargs...)
+	ret0, _ := ret[0].([]*domain.Trade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByStatus indicates an expected call of ListByStatus.
+func (mr *MockTradeGatewayMockRecorder) ListByStatus(ctx any, statuses ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, statuses...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStatus", reflect.TypeOf((*MockTradeGateway)(nil).ListByStatus), varargs...)
+}
+
+// Update mocks base method.
+func (m *MockTradeGateway) Update(ctx context.Context, trade *domain.Trade) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, trade)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTradeGatewayMockRecorder) Update(ctx, trade any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTradeGateway)(nil).Update), ctx, trade)
+}
@@ -55,6 +55,21 @@ func (mr *MockTransactionGatewayMockRecorder) Create(ctx, transaction any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransactionGateway)(nil).Create), ctx, transaction)
 }
 
+// GetByToken mocks base method.
+func (m *MockTransactionGateway) GetByToken(ctx context.Context, chainID int, contractAddress, tokenID string) (*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByToken", ctx, chainID, contractAddress, tokenID)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByToken indicates an expected call of GetByToken.
+func (mr *MockTransactionGatewayMockRecorder) GetByToken(ctx, chainID, contractAddress, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByToken", reflect.TypeOf((*MockTransactionGateway)(nil).GetByToken), ctx, chainID, contractAddress, tokenID)
+}
+
 // GetByTransactionid mocks base method.
 func (m *MockTransactionGateway) GetByTransactionid(ctx context.Context, transactionID string) (*domain.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +100,41 @@ func (mr *MockTransactionGatewayMockRecorder) List(ctx, limit any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTransactionGateway)(nil).List), ctx, limit)
 }
 
+// ListByContract mocks base method.
+func (m *MockTransactionGateway) ListByContract(ctx context.Context, chainID int, contractAddress string) ([]*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByContract", ctx, chainID, contractAddress)
+	ret0, _ := ret[0].([]*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByContract indicates an expected call of ListByContract.
+func (mr *MockTransactionGatewayMockRecorder) ListByContract(ctx, chainID, contractAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByContract", reflect.TypeOf((*MockTransactionGateway)(nil).ListByContract), ctx, chainID, contractAddress)
+}
+
+// ListByStatus mocks base method.
+func (m *MockTransactionGateway) ListByStatus(ctx context.Context, statuses ...string) ([]*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range statuses {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListByStatus", varargs...)
+	ret0, _ := ret[0].([]*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByStatus indicates an expected call of ListByStatus.
+func (mr *MockTransactionGatewayMockRecorder) ListByStatus(ctx any, statuses ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, statuses...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStatus", reflect.TypeOf((*MockTransactionGateway)(nil).ListByStatus), varargs...)
+}
+
 // ListByWallet mocks base method.
 func (m *MockTransactionGateway) ListByWallet(ctx context.Context, wallet string) ([]*domain.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -101,7 +151,7 @@ func (mr *MockTransactionGatewayMockRecorder) ListByWallet(ctx, wallet any) *gom
 }
 
 // Search mocks base method.
-func (m *MockTransactionGateway) Search(ctx context.Context, genre string, minPrice, maxPrice int, sort string) ([]*domain.Transaction, error) {
+func (m *MockTransactionGateway) Search(ctx context.Context, genre string, minPrice, maxPrice domain.Amount, sort string) ([]*domain.Transaction, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Search", ctx, genre, minPrice, maxPrice, sort)
 	ret0, _ := ret[0].([]*domain.Transaction)
@@ -114,3 +164,17 @@ func (mr *MockTransactionGatewayMockRecorder) Search(ctx, genre, minPrice, maxPr
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTransactionGateway)(nil).Search), ctx, genre, minPrice, maxPrice, sort)
 }
+
+// Update mocks base method.
+func (m *MockTransactionGateway) Update(ctx context.Context, transaction *domain.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, transaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTransactionGatewayMockRecorder) Update(ctx, transaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTransactionGateway)(nil).Update), ctx, transaction)
+}
@@ -0,0 +1,136 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: voucher_gateway.go
+//
+// Generated by this command:
+//
+//	mockgen -package mock -source voucher_gateway.go -destination mock/voucher_gateway.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	domain "nft-music/domain"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVoucherGateway is a mock of VoucherGateway interface.
+type MockVoucherGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockVoucherGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockVoucherGatewayMockRecorder is the mock recorder for MockVoucherGateway.
+type MockVoucherGatewayMockRecorder struct {
+	mock *MockVoucherGateway
+}
+
+// NewMockVoucherGateway creates a new mock instance.
+func NewMockVoucherGateway(ctrl *gomock.Controller) *MockVoucherGateway {
+	mock := &MockVoucherGateway{ctrl: ctrl}
+	mock.recorder = &MockVoucherGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVoucherGateway) EXPECT() *MockVoucherGatewayMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockVoucherGateway) Create(ctx context.Context, voucher *domain.MintVoucher) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, voucher)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVoucherGatewayMockRecorder) Create(ctx, voucher any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVoucherGateway)(nil).Create), ctx, voucher)
+}
+
+// Get mocks base method.
+func (m *MockVoucherGateway) Get(ctx context.Context, id uuid.UUID) (*domain.MintVoucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*domain.MintVoucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockVoucherGatewayMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVoucherGateway)(nil).Get), ctx, id)
+}
+
+// ListByCollection mocks base method.
+func (m *MockVoucherGateway) ListByCollection(ctx context.Context, collectionID uuid.UUID) ([]*domain.MintVoucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCollection", ctx, collectionID)
+	ret0, _ := ret[0].([]*domain.MintVoucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCollection indicates an expected call of ListByCollection.
+func (mr *MockVoucherGatewayMockRecorder) ListByCollection(ctx, collectionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCollection", reflect.TypeOf((*MockVoucherGateway)(nil).ListByCollection), ctx, collectionID)
+}
+
+// ListByStatus mocks base method.
+func (m *MockVoucherGateway) ListByStatus(ctx context.Context, statuses ...string) ([]*domain.MintVoucher, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range statuses {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListByStatus", varargs...)
+	ret0, _ := ret[0].([]*domain.MintVoucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByStatus indicates an expected call of ListByStatus.
+func (mr *MockVoucherGatewayMockRecorder) ListByStatus(ctx any, statuses ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, statuses...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStatus", reflect.TypeOf((*MockVoucherGateway)(nil).ListByStatus), varargs...)
+}
+
+// Search mocks base method.
+func (m *MockVoucherGateway) Search(ctx context.Context, genre string, minPrice, maxPrice domain.Amount, after int64) ([]*domain.MintVoucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, genre, minPrice, maxPrice, after)
+	ret0, _ := ret[0].([]*domain.MintVoucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockVoucherGatewayMockRecorder) Search(ctx, genre, minPrice, maxPrice, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockVoucherGateway)(nil).Search), ctx, genre, minPrice, maxPrice, after)
+}
+
+// Update mocks base method.
+func (m *MockVoucherGateway) Update(ctx context.Context, voucher *domain.MintVoucher) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, voucher)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVoucherGatewayMockRecorder) Update(ctx, voucher any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVoucherGateway)(nil).Update), ctx, voucher)
+}
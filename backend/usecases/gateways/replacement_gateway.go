@@ -0,0 +1,17 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+)
+
+//go:generate mockgen -package mock -source $GOFILE -destination mock/$GOFILE
+
+// ReplacementGateway は詰まったトランザクションの置き換えのトランザクション処理インターフェース
+type ReplacementGateway interface {
+	Create(ctx context.Context, replacement *domain.TransactionReplacement) error
+	ListByTransaction(ctx context.Context, transactionID string) ([]*domain.TransactionReplacement, error)
+	Update(ctx context.Context, replacement *domain.TransactionReplacement) error
+}
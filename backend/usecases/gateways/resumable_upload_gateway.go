@@ -0,0 +1,29 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"nft-music/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -package mock -source $GOFILE -destination mock/$GOFILE
+
+// ResumableUploadGateway は tus プロトコルで分割して送信中のファイルの保存インターフェース
+type ResumableUploadGateway interface {
+	Create(ctx context.Context, upload *domain.ResumableUpload) error
+	Get(ctx context.Context, id uuid.UUID) (*domain.ResumableUpload, error)
+	Update(ctx context.Context, upload *domain.ResumableUpload) error
+	// Append は offset の位置から content を書き込み、書き込めたバイト数を返す（エラーの場合も途中まで書き込んだ分を返す）
+	Append(ctx context.Context, id uuid.UUID, offset int64, content io.Reader) (int64, error)
+	// Open は受信した内容を読み取る
+	Open(ctx context.Context, id uuid.UUID) (io.ReadCloser, error)
+	// RemoveData は受信した内容だけを削除する（完了後は結果を返すために記録を残す）
+	RemoveData(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListExpired(ctx context.Context, now time.Time) ([]*domain.ResumableUpload, error)
+}
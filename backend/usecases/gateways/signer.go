@@ -0,0 +1,19 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Signer はプラットフォームのウォレットでトランザクションに署名する処理です
+// （keystore / 秘密鍵ファイル / リモート署名サーバーを切り替えられる）
+//
+//go:generate mockgen -package mock -source $GOFILE -destination mock/$GOFILE
+type Signer interface {
+	Address() common.Address
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
@@ -0,0 +1,17 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+)
+
+//go:generate mockgen -package mock -source $GOFILE -destination mock/$GOFILE
+
+// TokenRoyaltyGateway はトークンごとのロイヤリティを管理するインターフェース（Get は設定が無い場合に nil を返す）
+type TokenRoyaltyGateway interface {
+	Get(ctx context.Context, chainID int, contractAddress string, tokenID string) (*domain.TokenRoyalty, error)
+	Save(ctx context.Context, royalty *domain.TokenRoyalty) error
+	Delete(ctx context.Context, chainID int, contractAddress string, tokenID string) error
+}
@@ -0,0 +1,20 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -package mock -source $GOFILE -destination mock/$GOFILE
+
+// TradeGateway は二次流通の取引を管理するインターフェース
+type TradeGateway interface {
+	Create(ctx context.Context, trade *domain.Trade) error
+	Get(ctx context.Context, id uuid.UUID) (*domain.Trade, error)
+	ListByStatus(ctx context.Context, statuses ...string) ([]*domain.Trade, error)
+	Update(ctx context.Context, trade *domain.Trade) error
+}
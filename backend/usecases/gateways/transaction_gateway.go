@@ -13,7 +13,11 @@ import (
 type TransactionGateway interface {
 	List(ctx context.Context, limit int) ([]*domain.Transaction, error)
 	ListByWallet(ctx context.Context, wallet string) ([]*domain.Transaction, error)
-	Search(ctx context.Context, genre string, minPrice int, maxPrice int, sort string) ([]*domain.Transaction, error)
+	Search(ctx context.Context, genre string, minPrice domain.Amount, maxPrice domain.Amount, sort string) ([]*domain.Transaction, error)
 	GetByTransactionid(ctx context.Context, transactionID string) (*domain.Transaction, error)
+	GetByToken(ctx context.Context, chainID int, contractAddress string, tokenID string) (*domain.Transaction, error)
+	ListByStatus(ctx context.Context, statuses ...string) ([]*domain.Transaction, error)
+	ListByContract(ctx context.Context, chainID int, contractAddress string) ([]*domain.Transaction, error)
 	Create(ctx context.Context, transaction *domain.Transaction) error
+	Update(ctx context.Context, transaction *domain.Transaction) error
 }
@@ -0,0 +1,23 @@
+// Package gateways は、データベースや外部サービスへのアクセスを実装します。
+package gateways
+
+import (
+	"context"
+
+	"nft-music/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -package mock -source $GOFILE -destination mock/$GOFILE
+
+// VoucherGateway は遅延ミントのバウチャーを管理するインターフェース
+// Search は出品中で有効期限（UNIX時間）が after 以降のバウチャーを検索する
+type VoucherGateway interface {
+	Create(ctx context.Context, voucher *domain.MintVoucher) error
+	Get(ctx context.Context, id uuid.UUID) (*domain.MintVoucher, error)
+	ListByCollection(ctx context.Context, collectionID uuid.UUID) ([]*domain.MintVoucher, error)
+	ListByStatus(ctx context.Context, statuses ...string) ([]*domain.MintVoucher, error)
+	Search(ctx context.Context, genre string, minPrice domain.Amount, maxPrice domain.Amount, after int64) ([]*domain.MintVoucher, error)
+	Update(ctx context.Context, voucher *domain.MintVoucher) error
+}
@@ -0,0 +1,239 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"nft-music/adapters/presenters"
+	"nft-music/domain"
+	"nft-music/usecases/gateways"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+	"nft-music/util"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+const siweStatement = "NFT Musicにサインインします。"
+
+// AuthConfig はSIWEログインの設定
+type AuthConfig struct {
+	Domain     string
+	URI        string
+	NonceTTL   time.Duration
+	SessionTTL time.Duration
+}
+
+// AuthInteractor はSign-In With Ethereumによるログインのユースケース
+type AuthInteractor struct {
+	AuthGateway gateways.AuthGateway
+	UserGateway gateways.UserGateway
+	Config      AuthConfig
+	Logging     logging.Logging
+	Error       *presenters.ErrorPresenter
+}
+
+func NewAuthInteractor(authGateway gateways.AuthGateway, userGateway gateways.UserGateway, config AuthConfig, logging logging.Logging) *AuthInteractor {
+	return &AuthInteractor{
+		AuthGateway: authGateway,
+		UserGateway: userGateway,
+		Config:      config,
+		Logging:     logging,
+		Error:       presenters.NewErrorPresenter(logging),
+	}
+}
+
+// Nonce はノンスを発行し、ウォレットで署名するSIWEメッセージを返す
+func (interactor *AuthInteractor) Nonce(ctx context.Context, wallet string, chainID int) (*ports.AuthNonceOutput, error) {
+	if !common.IsHexAddress(wallet) {
+		return nil, errors.New("BadRequest: ウォレットアドレスが不正です")
+	}
+	if chainID <= 0 {
+		chainID = 1
+	}
+
+	nonce, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+
+	now := util.JapaneseNowTime()
+	authNonce := &domain.AuthNonce{
+		Nonce:     nonce,
+		Wallet:    common.HexToAddress(wallet).Hex(),
+		ChainID:   chainID,
+		ExpiresAt: now.Add(interactor.Config.NonceTTL),
+		CreatedAt: now,
+	}
+	if err := interactor.AuthGateway.CreateNonce(ctx, authNonce); err != nil {
+		return nil, err
+	}
+
+	message := domain.SiweMessage{
+		Domain:         interactor.Config.Domain,
+		Address:        authNonce.Wallet,
+		Statement:      siweStatement,
+		URI:            interactor.Config.URI,
+		Version:        "1",
+		ChainID:        chainID,
+		Nonce:          nonce,
+		IssuedAt:       now,
+		ExpirationTime: authNonce.ExpiresAt,
+	}
+
+	return &ports.AuthNonceOutput{
+		Nonce:     nonce,
+		Message:   message.String(),
+		ExpiresAt: authNonce.ExpiresAt,
+	}, nil
+}
+
+// Verify は署名済みのSIWEメッセージを検証し、セッショントークンを発行する
+func (interactor *AuthInteractor) Verify(ctx context.Context, input *ports.AuthVerifyInput) (*ports.AuthSessionOutput, error) {
+	message, err := domain.ParseSiweMessage(input.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	now := util.JapaneseNowTime()
+	if message.Domain != interactor.Config.Domain {
+		return nil, errors.New("Unauthorized: SIWEメッセージのドメインが一致しません")
+	}
+	if !message.ExpirationTime.IsZero() && now.After(message.ExpirationTime) {
+		return nil, errors.New("Unauthorized: SIWEメッセージの有効期限が切れています")
+	}
+
+	authNonce, err := interactor.AuthGateway.GetNonce(ctx, message.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("Unauthorized: ノンスが見つかりません: %w", err)
+	}
+	if authNonce.UsedAt.Valid || now.After(authNonce.ExpiresAt) {
+		return nil, errors.New("Unauthorized: ノンスは使用済みか有効期限が切れています")
+	}
+	if !util.SameAddress(authNonce.Wallet, message.Address) || authNonce.ChainID != message.ChainID {
+		return nil, errors.New("Unauthorized: ノンスを発行したウォレットと一致しません")
+	}
+
+	signer, err := util.RecoverPersonalSign(input.Message, input.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if !util.SameAddress(signer.Hex(), message.Address) {
+		return nil, errors.New("Unauthorized: 署名者とSIWEメッセージのアドレスが一致しません")
+	}
+
+	// 署名を検証したウォレットが users.wallet に登録されていること
+	user, err := interactor.UserGateway.GetByWallet(ctx, &domain.User{Wallet: message.Address})
+	if err != nil {
+		return nil, fmt.Errorf("Unauthorized: ウォレットに紐づくユーザーが存在しません: %w", err)
+	}
+
+	if err := interactor.AuthGateway.UseNonce(ctx, authNonce.Nonce, now); err != nil {
+		return nil, err
+	}
+
+	token, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	sessionID, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &domain.Session{
+		ID:        sessionID,
+		TokenHash: hashToken(token),
+		UserID:    user.ID,
+		Wallet:    user.Wallet,
+		ExpiresAt: now.Add(interactor.Config.SessionTTL),
+		CreatedAt: now,
+	}
+	if err := interactor.AuthGateway.CreateSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return &ports.AuthSessionOutput{
+		Token:     token,
+		UserID:    session.UserID,
+		Wallet:    session.Wallet,
+		ExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+// Authenticate はセッショントークンを検証し、認証済みユーザーを返す
+func (interactor *AuthInteractor) Authenticate(ctx context.Context, token string) (*ports.AuthUser, error) {
+	if token == "" {
+		return nil, errors.New("Unauthorized: セッショントークンがありません")
+	}
+
+	session, err := interactor.AuthGateway.GetSessionByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, errors.New("Unauthorized: セッショントークンが無効です")
+	}
+	if util.JapaneseNowTime().After(session.ExpiresAt) {
+		return nil, errors.New("Unauthorized: セッションの有効期限が切れています")
+	}
+
+	// 権限の変更がすぐに反映されるよう、ロールは毎回ユーザーから取得する
+	user, err := interactor.UserGateway.Get(ctx, &domain.User{ID: session.UserID})
+	if err != nil {
+		return nil, errors.New("Unauthorized: セッションのユーザーが存在しません")
+	}
+
+	return &ports.AuthUser{
+		UserID: session.UserID,
+		Wallet: session.Wallet,
+		Role:   user.Role,
+	}, nil
+}
+
+// Logout はセッションを破棄する
+func (interactor *AuthInteractor) Logout(ctx context.Context, token string) error {
+	return interactor.AuthGateway.DeleteSessionByTokenHash(ctx, hashToken(token))
+}
+
+// authorizeWallet はリクエストしたユーザーのウォレットと対象のウォレットが一致するか確認する
+func authorizeWallet(ctx context.Context, wallet string) error {
+	authUser, ok := ports.AuthUserFrom(ctx)
+	if !ok {
+		return errors.New("Unauthorized: ログインが必要です")
+	}
+	if !util.SameAddress(authUser.Wallet, wallet) {
+		return errors.New("Forbidden: 他のウォレットの操作はできません")
+	}
+	return nil
+}
+
+// authorizeUser はリクエストしたユーザーと対象のユーザーIDが一致するか確認する
+func authorizeUser(ctx context.Context, userID uuid.UUID) error {
+	authUser, ok := ports.AuthUserFrom(ctx)
+	if !ok {
+		return errors.New("Unauthorized: ログインが必要です")
+	}
+	if authUser.UserID != userID {
+		return errors.New("Forbidden: 他のユーザーのデータは操作できません")
+	}
+	return nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
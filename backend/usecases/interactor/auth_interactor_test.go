@@ -0,0 +1,203 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"nft-music/domain"
+	"nft-music/usecases/gateways/mock"
+	"nft-music/usecases/ports"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthInteractor(t *testing.T) {
+	config := AuthConfig{
+		Domain:     "music.threenext.com",
+		URI:        "https://music.threenext.com",
+		NonceTTL:   10 * time.Minute,
+		SessionTTL: time.Hour,
+	}
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	wallet := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+
+	sign := func(t *testing.T, message string) string {
+		sig, err := crypto.Sign(accounts.TextHash([]byte(message)), privateKey)
+		require.NoError(t, err)
+		sig[crypto.RecoveryIDOffset] += 27
+		return hexutil.Encode(sig)
+	}
+
+	// issue はノンスを発行し、登録されたノンスとメッセージを返す
+	issue := func(t *testing.T, interactor *AuthInteractor, mockAuthGateway *mock.MockAuthGateway) (*domain.AuthNonce, string) {
+		var stored *domain.AuthNonce
+		mockAuthGateway.EXPECT().
+			CreateNonce(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, nonce *domain.AuthNonce) error {
+				stored = nonce
+				return nil
+			})
+
+		output, err := interactor.Nonce(context.Background(), wallet, 1)
+		require.NoError(t, err)
+		return stored, output.Message
+	}
+
+	t.Run("Nonce", func(t *testing.T) {
+		t.Run("正常系: ノンスとSIWEメッセージを発行できる", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAuthGateway := mock.NewMockAuthGateway(ctrl)
+			interactor := NewAuthInteractor(mockAuthGateway, mock.NewMockUserGateway(ctrl), config, &NullLogging{})
+
+			stored, message := issue(t, interactor, mockAuthGateway)
+
+			parsed, err := domain.ParseSiweMessage(message)
+			assert.NoError(t, err)
+			assert.Equal(t, config.Domain, parsed.Domain)
+			assert.Equal(t, wallet, parsed.Address)
+			assert.Equal(t, stored.Nonce, parsed.Nonce)
+			assert.Equal(t, 1, parsed.ChainID)
+		})
+
+		t.Run("異常系: ウォレットアドレスが不正", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			interactor := NewAuthInteractor(mock.NewMockAuthGateway(ctrl), mock.NewMockUserGateway(ctrl), config, &NullLogging{})
+
+			output, err := interactor.Nonce(context.Background(), "0x1234", 1)
+
+			assert.ErrorContains(t, err, "BadRequest")
+			assert.Nil(t, output)
+		})
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		t.Run("正常系: 署名を検証してセッションを発行し、トークンで認証できる", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAuthGateway := mock.NewMockAuthGateway(ctrl)
+			mockUserGateway := mock.NewMockUserGateway(ctrl)
+			interactor := NewAuthInteractor(mockAuthGateway, mockUserGateway, config, &NullLogging{})
+
+			stored, message := issue(t, interactor, mockAuthGateway)
+			user := &domain.User{ID: uuid.New(), Wallet: wallet}
+
+			var session *domain.Session
+			mockAuthGateway.EXPECT().GetNonce(gomock.Any(), stored.Nonce).Return(stored, nil)
+			mockUserGateway.EXPECT().GetByWallet(gomock.Any(), gomock.Any()).Return(user, nil)
+			mockAuthGateway.EXPECT().UseNonce(gomock.Any(), stored.Nonce, gomock.Any()).Return(nil)
+			mockAuthGateway.EXPECT().
+				CreateSession(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, s *domain.Session) error {
+					session = s
+					return nil
+				})
+
+			output, err := interactor.Verify(context.Background(), &ports.AuthVerifyInput{
+				Message:   message,
+				Signature: sign(t, message),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, user.ID, output.UserID)
+			assert.NotEqual(t, output.Token, session.TokenHash) // トークンそのものは保存しない
+
+			mockAuthGateway.EXPECT().GetSessionByTokenHash(gomock.Any(), session.TokenHash).Return(session, nil)
+			mockUserGateway.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&domain.User{ID: user.ID, Wallet: wallet, Role: domain.RoleCreator}, nil)
+
+			authUser, err := interactor.Authenticate(context.Background(), output.Token)
+			assert.NoError(t, err)
+			assert.Equal(t, user.ID, authUser.UserID)
+			assert.Equal(t, wallet, authUser.Wallet)
+			assert.Equal(t, domain.RoleCreator, authUser.Role)
+		})
+
+		t.Run("異常系: 別のウォレットで署名されている", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAuthGateway := mock.NewMockAuthGateway(ctrl)
+			interactor := NewAuthInteractor(mockAuthGateway, mock.NewMockUserGateway(ctrl), config, &NullLogging{})
+
+			stored, message := issue(t, interactor, mockAuthGateway)
+			mockAuthGateway.EXPECT().GetNonce(gomock.Any(), stored.Nonce).Return(stored, nil)
+
+			otherKey, err := crypto.GenerateKey()
+			require.NoError(t, err)
+			sig, err := crypto.Sign(accounts.TextHash([]byte(message)), otherKey)
+			require.NoError(t, err)
+
+			output, err := interactor.Verify(context.Background(), &ports.AuthVerifyInput{
+				Message:   message,
+				Signature: hexutil.Encode(sig),
+			})
+
+			assert.ErrorContains(t, err, "Unauthorized")
+			assert.Nil(t, output)
+		})
+
+		t.Run("異常系: 使用済みのノンス", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAuthGateway := mock.NewMockAuthGateway(ctrl)
+			interactor := NewAuthInteractor(mockAuthGateway, mock.NewMockUserGateway(ctrl), config, &NullLogging{})
+
+			stored, message := issue(t, interactor, mockAuthGateway)
+			stored.UsedAt.Time = time.Now()
+			stored.UsedAt.Valid = true
+			mockAuthGateway.EXPECT().GetNonce(gomock.Any(), stored.Nonce).Return(stored, nil)
+
+			output, err := interactor.Verify(context.Background(), &ports.AuthVerifyInput{
+				Message:   message,
+				Signature: sign(t, message),
+			})
+
+			assert.ErrorContains(t, err, "Unauthorized")
+			assert.Nil(t, output)
+		})
+	})
+
+	t.Run("Authenticate", func(t *testing.T) {
+		t.Run("異常系: セッションが存在しない", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAuthGateway := mock.NewMockAuthGateway(ctrl)
+			interactor := NewAuthInteractor(mockAuthGateway, mock.NewMockUserGateway(ctrl), config, &NullLogging{})
+
+			mockAuthGateway.EXPECT().
+				GetSessionByTokenHash(gomock.Any(), gomock.Any()).
+				Return(nil, errors.New("record not found"))
+
+			authUser, err := interactor.Authenticate(context.Background(), "invalid")
+
+			assert.ErrorContains(t, err, "Unauthorized")
+			assert.Nil(t, authUser)
+		})
+
+		t.Run("異常系: セッションの有効期限切れ", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAuthGateway := mock.NewMockAuthGateway(ctrl)
+			interactor := NewAuthInteractor(mockAuthGateway, mock.NewMockUserGateway(ctrl), config, &NullLogging{})
+
+			mockAuthGateway.EXPECT().
+				GetSessionByTokenHash(gomock.Any(), gomock.Any()).
+				Return(&domain.Session{ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+
+			authUser, err := interactor.Authenticate(context.Background(), "expired")
+
+			assert.ErrorContains(t, err, "Unauthorized")
+			assert.Nil(t, authUser)
+		})
+	})
+}
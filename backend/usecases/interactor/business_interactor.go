@@ -26,6 +26,10 @@ func NewBusinessInteractor(gateway gateways.BusinessGateway) *BusinessInteractor
 
 // Create は職種マスターを追加する
 func (interactor *BusinessInteractor) Create(ctx context.Context, input *ports.BusinessMasterInput) (*ports.BusinessMasterOutput, error) {
+	if err := authorize(ctx, ActionWriteMaster); err != nil {
+		return nil, err
+	}
+
 	uuidV7, err := uuid.NewV7()
 	if err != nil {
 		return nil, err
@@ -92,6 +96,10 @@ func (interactor *BusinessInteractor) List(ctx context.Context) ([]ports.Busines
 
 // Update は職種マスターを修正する
 func (interactor *BusinessInteractor) Update(ctx context.Context, id uuid.UUID, input *ports.BusinessMasterInput) (*ports.BusinessMasterOutput, error) {
+	if err := authorize(ctx, ActionWriteMaster); err != nil {
+		return nil, err
+	}
+
 	now := util.JapaneseNowTime()
 	business := &domain.BusinessMaster{
 		ID:        id,
@@ -115,6 +123,10 @@ func (interactor *BusinessInteractor) Update(ctx context.Context, id uuid.UUID,
 
 // Delete は職種マスターを削除する
 func (interactor *BusinessInteractor) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := authorize(ctx, ActionWriteMaster); err != nil {
+		return err
+	}
+
 	business := domain.BusinessMaster{
 		ID: id,
 	}
@@ -17,6 +17,8 @@ import (
 )
 
 func TestBusinessInteractor(t *testing.T) {
+	adminCtx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: uuid.New(), Role: domain.RoleAdmin})
+
 	t.Run("Create", func(t *testing.T) {
 		t.Run("正常系: ビジネス情報を追加できる", func(t *testing.T) {
 			ctrl := gomock.NewController(t)
@@ -30,7 +32,7 @@ func TestBusinessInteractor(t *testing.T) {
 				Create(gomock.Any(), gomock.Any()).
 				Return(nil)
 
-			output, err := interactor.Create(context.Background(), input)
+			output, err := interactor.Create(adminCtx, input)
 
 			assert.NoError(t, err)
 			assert.NotNil(t, output)
@@ -49,12 +51,25 @@ func TestBusinessInteractor(t *testing.T) {
 				Create(gomock.Any(), gomock.Any()).
 				Return(errors.New("db error"))
 
-			output, err := interactor.Create(context.Background(), input)
+			output, err := interactor.Create(adminCtx, input)
 
 			assert.Error(t, err)
 			assert.Nil(t, output)
 			assert.Equal(t, "db error", err.Error())
 		})
+
+		t.Run("異常系: 管理者以外はマスターを追加できない", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockBusinessGateway(ctrl)
+			interactor := NewBusinessInteractor(mockGateway)
+
+			creatorCtx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: uuid.New(), Role: domain.RoleCreator})
+			output, err := interactor.Create(creatorCtx, &ports.BusinessMasterInput{Name: "Composer"})
+
+			assert.ErrorContains(t, err, "Forbidden")
+			assert.Nil(t, output)
+		})
 	})
 
 	t.Run("Get", func(t *testing.T) {
@@ -141,7 +156,7 @@ func TestBusinessInteractor(t *testing.T) {
 				Update(gomock.Any(), gomock.Any()).
 				Return(nil)
 
-			output, err := interactor.Update(context.Background(), id, input)
+			output, err := interactor.Update(adminCtx, id, input)
 
 			assert.NoError(t, err)
 			assert.NotNil(t, output)
@@ -162,7 +177,7 @@ func TestBusinessInteractor(t *testing.T) {
 				Delete(gomock.Any(), gomock.Any()).
 				Return(nil)
 
-			err := interactor.Delete(context.Background(), id)
+			err := interactor.Delete(adminCtx, id)
 
 			assert.NoError(t, err)
 		})
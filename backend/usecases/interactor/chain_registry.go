@@ -0,0 +1,114 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"nft-music/domain"
+	"nft-music/usecases/gateways"
+	"nft-music/usecases/ports"
+)
+
+// ChainRegistry は対応しているチェーンの登録簿
+// 価格の換算・接続するノード・承認数はすべてここから取得する
+type ChainRegistry struct {
+	ChainGateway gateways.ChainGateway
+
+	mu     sync.RWMutex
+	chains map[int]domain.Chain
+	active int // バックエンドが接続中のチェーンID
+}
+
+func NewChainRegistry(chainGateway gateways.ChainGateway) *ChainRegistry {
+	return &ChainRegistry{
+		ChainGateway: chainGateway,
+		chains:       map[int]domain.Chain{},
+	}
+}
+
+// Load はデータベースから登録簿を読み込み、接続するチェーンを選ぶ（起動時に呼び出す）
+func (registry *ChainRegistry) Load(ctx context.Context, activeChainID int) error {
+	chains, err := registry.ChainGateway.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.chains = make(map[int]domain.Chain, len(chains))
+	for _, chain := range chains {
+		registry.chains[chain.ChainID] = chain
+	}
+	if _, ok := registry.chains[activeChainID]; !ok {
+		return fmt.Errorf("chain %d is not registered in the chains table", activeChainID)
+	}
+	registry.active = activeChainID
+	return nil
+}
+
+// Get はチェーンIDのチェーンを取得する
+func (registry *ChainRegistry) Get(chainID int) (*domain.Chain, error) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	chain, ok := registry.chains[chainID]
+	if !ok {
+		return nil, fmt.Errorf("BadRequest: チェーン %d には対応していません", chainID)
+	}
+	return &chain, nil
+}
+
+// Active はバックエンドが接続中のチェーンを取得する
+func (registry *ChainRegistry) Active() *domain.Chain {
+	chain, _ := registry.Get(registry.active)
+	return chain
+}
+
+// SetMarketplace は接続中のチェーンで使うマーケットプレイスのアドレスを記録する
+func (registry *ChainRegistry) SetMarketplace(ctx context.Context, address string) error {
+	if err := registry.ChainGateway.UpdateMarketplace(ctx, registry.active, address); err != nil {
+		return err
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	chain := registry.chains[registry.active]
+	chain.MarketplaceAddress = sql.NullString{String: address, Valid: true}
+	registry.chains[registry.active] = chain
+	return nil
+}
+
+// List は対応しているチェーンを一覧で取得する
+func (registry *ChainRegistry) List(ctx context.Context) ([]ports.ChainOutput, error) {
+	chains, err := registry.ChainGateway.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]ports.ChainOutput, 0, len(chains))
+	for _, chain := range chains {
+		outputs = append(outputs, ports.ChainOutput{
+			ChainID:            chain.ChainID,
+			Name:               chain.Name,
+			Symbol:             chain.Symbol,
+			Decimals:           chain.Decimals,
+			RPCURL:             chain.RPCURL,
+			ExplorerURL:        chain.ExplorerURL,
+			MarketplaceAddress: chain.MarketplaceAddress.String,
+			Confirmations:      chain.Confirmations,
+			Active:             chain.ChainID == registry.active,
+		})
+	}
+	return outputs, nil
+}
+
+// format は金額をチェーンのネイティブ通貨の単位で表示する（登録簿に無いチェーンはweiで表示する）
+func (registry *ChainRegistry) format(chainID int, amount domain.Amount) string {
+	chain, err := registry.Get(chainID)
+	if err != nil {
+		return amount.String() + " wei"
+	}
+	return chain.FormatAmount(amount)
+}
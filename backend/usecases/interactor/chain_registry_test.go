@@ -0,0 +1,74 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"nft-music/domain"
+	"nft-music/usecases/gateways/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newTestChainRegistry はGanache（1337）とBSC（56）を登録したテスト用の登録簿を作成する
+func newTestChainRegistry(t *testing.T) (*ChainRegistry, *mock.MockChainGateway) {
+	chainGateway := mock.NewMockChainGateway(gomock.NewController(t))
+	chainGateway.EXPECT().List(gomock.Any()).Return([]domain.Chain{
+		{ChainID: 56, Name: "BNB Smart Chain", Symbol: "BNB", Decimals: 18, Confirmations: 15},
+		{ChainID: 1337, Name: "Ganache", Symbol: "ETH", Decimals: 18, RPCURL: "http://ganache:8545", Confirmations: 1},
+	}, nil).AnyTimes()
+
+	registry := NewChainRegistry(chainGateway)
+	require.NoError(t, registry.Load(context.Background(), 1337))
+	return registry, chainGateway
+}
+
+func TestChainRegistry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 登録簿のチェーンのネイティブ通貨の単位で金額を読み取って表示する", func(t *testing.T) {
+		registry, _ := newTestChainRegistry(t)
+
+		chain, err := registry.Get(56)
+		require.NoError(t, err)
+		amount, err := chain.ParsePrice("1000.11 BNB")
+		require.NoError(t, err)
+		assert.Equal(t, "1000110000000000000000", amount.String())
+		assert.Equal(t, "1000.11 BNB", registry.format(56, amount))
+	})
+
+	t.Run("正常系: 登録簿に無いチェーンの金額はweiで表示する", func(t *testing.T) {
+		registry, _ := newTestChainRegistry(t)
+		assert.Equal(t, "1234 wei", registry.format(222, domain.NewAmount(big.NewInt(1234))))
+	})
+
+	t.Run("異常系: 登録簿に無いチェーンは扱えない", func(t *testing.T) {
+		registry, _ := newTestChainRegistry(t)
+		_, err := registry.Get(222)
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+
+	t.Run("異常系: 接続するチェーンが登録簿に無い場合は起動しない", func(t *testing.T) {
+		registry, _ := newTestChainRegistry(t)
+		assert.Error(t, registry.Load(ctx, 222))
+	})
+
+	t.Run("正常系: マーケットプレイスのアドレスを接続中のチェーンに記録する", func(t *testing.T) {
+		registry, chainGateway := newTestChainRegistry(t)
+		address := "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+		chainGateway.EXPECT().UpdateMarketplace(gomock.Any(), 1337, address).Return(nil)
+
+		require.NoError(t, registry.SetMarketplace(ctx, address))
+		assert.Equal(t, address, registry.Active().MarketplaceAddress.String)
+
+		outputs, err := registry.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, outputs, 2)
+		assert.False(t, outputs[0].Active)
+		assert.True(t, outputs[1].Active)
+	})
+}
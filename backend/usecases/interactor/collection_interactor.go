@@ -4,23 +4,57 @@ package interactor
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
 
+	"nft-music/contracts"
 	"nft-music/domain"
 	"nft-music/usecases/gateways"
+	"nft-music/usecases/logging"
 	"nft-music/usecases/ports"
 	"nft-music/util"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/google/uuid"
 )
 
+// erc721InterfaceID は ERC-721 の ERC-165 インターフェースID
+var erc721InterfaceID = [4]byte{0x80, 0xac, 0x58, 0xcd}
+
+// errRoyaltyNotEnforced はロイヤリティをコントラクト（ERC-2981）に設定できないコレクションへの設定を拒否するエラー
+// 販売時に支払われるのは royaltyInfo の値だけのため、データベースだけに保存しても支払われない
+var errRoyaltyNotEnforced = errors.New("BadRequest: プラットフォームでデプロイしていないコレクションにはロイヤリティを設定できません（販売時に royaltyInfo で支払われないため）")
+
+// CollectionBackend はコレクションのコントラクトのデプロイ・送信・確認に使うノードの機能（*ethclient.Client が実装している）
+type CollectionBackend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+}
+
 // CollectionInteractor コレクションインストラクタの構造体
+// コレクションごとに Collection.sol をプラットフォームのウォレットでデプロイし、ミントとバーンもプラットフォームのウォレットから送信する
 type CollectionInteractor struct {
-	Gateway gateways.CollectionGateway
+	Gateway             gateways.CollectionGateway
+	TokenRoyaltyGateway gateways.TokenRoyaltyGateway
+	Client              CollectionBackend
+	TxSigner            gateways.Signer
+	NonceManager        *NonceManager
+	Logging             logging.Logging
 }
 
-func NewCollectionInteractor(gateway gateways.CollectionGateway) *CollectionInteractor {
+func NewCollectionInteractor(gateway gateways.CollectionGateway, tokenRoyaltyGateway gateways.TokenRoyaltyGateway, client CollectionBackend, signer gateways.Signer, nonceManager *NonceManager, logging logging.Logging) *CollectionInteractor {
 	return &CollectionInteractor{
-		Gateway: gateway,
+		Gateway:             gateway,
+		TokenRoyaltyGateway: tokenRoyaltyGateway,
+		Client:              client,
+		TxSigner:            signer,
+		NonceManager:        nonceManager,
+		Logging:             logging,
 	}
 }
 
@@ -47,7 +81,43 @@ func (interactor *CollectionInteractor) List(ctx context.Context) ([]ports.Colle
 }
 
 // Create コレクションを作成する
+// コントラクトアドレスが空の場合はコレクションのコントラクトをデプロイし、指定された場合はデプロイ済みのERC-721か確認する
+// デプロイする場合は既定のロイヤリティもコントラクト（ERC-2981）に設定する（外部のコントラクトにはロイヤリティを設定できない）
 func (interactor *CollectionInteractor) Create(ctx context.Context, input *ports.CollectionInput) (*ports.CollectionOutput, error) {
+	if err := authorize(ctx, ActionCreateCollection); err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, input.UserID); err != nil {
+		return nil, err
+	}
+	legacyRoyalty(input)
+	if err := validateRoyalty(input.RoyaltyBps, input.RoyaltyReceiver); err != nil {
+		return nil, err
+	}
+
+	chainID, err := interactor.chainID(ctx, input.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	contractAddress := input.ContractAddress
+	var deployTx sql.NullString
+	if contractAddress == "" {
+		address, tx, err := interactor.deploy(ctx, chainID, input)
+		if err != nil {
+			return nil, err
+		}
+		contractAddress = address.Hex()
+		deployTx = sql.NullString{String: tx.Hash().Hex(), Valid: true}
+	} else {
+		if input.RoyaltyBps != 0 {
+			return nil, errRoyaltyNotEnforced
+		}
+		if err := interactor.verifyContract(ctx, contractAddress); err != nil {
+			return nil, err
+		}
+		contractAddress = common.HexToAddress(contractAddress).Hex()
+	}
+
 	uuidV7, err := uuid.NewV7()
 	if err != nil {
 		return nil, err
@@ -55,39 +125,78 @@ func (interactor *CollectionInteractor) Create(ctx context.Context, input *ports
 	collection := &domain.Collection{
 		ID:              uuidV7,
 		UserID:          input.UserID,
-		ChainID:         input.ChainID,
+		ChainID:         int(chainID.Int64()),
 		Name:            input.Name,
-		ContractAddress: input.ContractAddress,
+		ContractAddress: contractAddress,
+		DeployTx:        deployTx,
 		Description:     sql.NullString{String: input.Description, Valid: true},
 		ImageURL:        sql.NullString{String: input.ImageURL, Valid: true},
 		BannerImageURL:  sql.NullString{String: input.BannerImageURL, Valid: true},
 		ExternalURL:     sql.NullString{String: input.ExternalURL, Valid: true},
-		Royalty:         input.Royalty,
+		RoyaltyBps:      input.RoyaltyBps,
 		RoyaltyReceiver: input.RoyaltyReceiver,
 		CreatedAt:       util.JapaneseNowTime(),
 		UpdatedAt:       util.JapaneseNowTime(),
 	}
 
 	if err := interactor.Gateway.Create(ctx, collection); err != nil {
+		if deployTx.Valid {
+			interactor.Logging.Error(fmt.Sprintf("deployed collection %s (tx %s) but failed to record it: %s", contractAddress, deployTx.String, err.Error()))
+		}
 		return nil, err
 	}
 
 	return output(collection), nil
 }
 
+// Update コレクションを更新する
+// プラットフォームでデプロイしたコントラクトは差し替えられず、コントラクトアドレスを指定した場合はデプロイ済みのERC-721か確認する
+// 既定のロイヤリティは入力の値に置き換え、先にチェーンに設定する（外部のコントラクトのコレクションはロイヤリティなしにする）
 func (interactor *CollectionInteractor) Update(ctx context.Context, id uuid.UUID, input *ports.CollectionInput) (*ports.CollectionOutput, error) {
+	current, err := interactor.authorizeOwner(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, input.UserID); err != nil {
+		return nil, err
+	}
+	legacyRoyalty(input)
+	if !current.DeployTx.Valid {
+		// 外部のコントラクトには設定できないため、これまでの画面から送られる値は使わずロイヤリティなしにする
+		input.RoyaltyBps, input.RoyaltyReceiver = 0, ""
+	}
+	if err := validateRoyalty(input.RoyaltyBps, input.RoyaltyReceiver); err != nil {
+		return nil, err
+	}
+	contractAddress := input.ContractAddress
+	if contractAddress != "" && !util.SameAddress(contractAddress, current.ContractAddress) {
+		if current.DeployTx.Valid {
+			return nil, fmt.Errorf("BadRequest: プラットフォームでデプロイしたコレクションのコントラクトアドレスは変更できません")
+		}
+		if err := interactor.verifyContract(ctx, contractAddress); err != nil {
+			return nil, err
+		}
+		contractAddress = common.HexToAddress(contractAddress).Hex()
+	}
+	royaltyChanged := input.RoyaltyBps != current.RoyaltyBps || input.RoyaltyReceiver != current.RoyaltyReceiver
+	if royaltyChanged && current.DeployTx.Valid {
+		if err := interactor.setDefaultRoyalty(ctx, current, input.RoyaltyBps, input.RoyaltyReceiver); err != nil {
+			return nil, err
+		}
+	}
+
 	now := util.JapaneseNowTime()
 	collection := &domain.Collection{
 		ID:              id,
 		UserID:          input.UserID,
 		ChainID:         input.ChainID,
 		Name:            input.Name,
-		ContractAddress: input.ContractAddress,
+		ContractAddress: contractAddress,
 		Description:     sql.NullString{String: input.Description, Valid: true},
 		ImageURL:        sql.NullString{String: input.ImageURL, Valid: true},
 		BannerImageURL:  sql.NullString{String: input.BannerImageURL, Valid: true},
 		ExternalURL:     sql.NullString{String: input.ExternalURL, Valid: true},
-		Royalty:         input.Royalty,
+		RoyaltyBps:      input.RoyaltyBps,
 		RoyaltyReceiver: input.RoyaltyReceiver,
 		UpdatedAt:       now,
 	}
@@ -95,17 +204,396 @@ func (interactor *CollectionInteractor) Update(ctx context.Context, id uuid.UUID
 	if err := interactor.Gateway.Update(ctx, collection); err != nil {
 		return nil, err
 	}
+	if royaltyChanged {
+		if err := interactor.Gateway.UpdateRoyalty(ctx, id, input.RoyaltyBps, input.RoyaltyReceiver); err != nil {
+			return nil, err
+		}
+	}
 
+	// 空の項目は更新しないため、出力は更新前の値で補う
+	if collection.ContractAddress == "" {
+		collection.ContractAddress = current.ContractAddress
+	}
+	collection.DeployTx = current.DeployTx
 	return output(collection), nil
 }
 
 func (interactor *CollectionInteractor) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := interactor.authorizeOwner(ctx, id); err != nil {
+		return err
+	}
+
 	collection := &domain.Collection{
 		ID: id,
 	}
 	return interactor.Gateway.Delete(ctx, collection)
 }
 
+// Mint はコレクションのコントラクトでミントする（コレクションの作成者のみ）
+// 送信先が空の場合はリクエストしたユーザーのウォレットにミントする
+func (interactor *CollectionInteractor) Mint(ctx context.Context, id uuid.UUID, input *ports.CollectionMintInput) (*ports.CollectionTokenOutput, error) {
+	collection, err := interactor.authorizeOwner(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	authUser, _ := ports.AuthUserFrom(ctx)
+	to := input.To
+	if to == "" {
+		to = authUser.Wallet
+	}
+	if !common.IsHexAddress(to) {
+		return nil, fmt.Errorf("BadRequest: 送信先 %s が正しくありません", to)
+	}
+
+	contract, chainID, err := interactor.bind(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := contract.Owner(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, err
+	}
+	if owner != interactor.TxSigner.Address() {
+		return nil, fmt.Errorf("BadRequest: コレクション %s のオーナーがプラットフォームのウォレットではないためミントできません", collection.ContractAddress)
+	}
+
+	tx, receipt, err := interactor.send(ctx, chainID, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return contract.SafeMint(opts, common.HexToAddress(to))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	address := common.HexToAddress(collection.ContractAddress)
+	for _, log := range receipt.Logs {
+		if log.Address != address {
+			continue
+		}
+		minted, err := contract.ParseMinted(*log)
+		if err != nil {
+			continue
+		}
+		interactor.Logging.Info(fmt.Sprintf("minted token %s of collection %s to %s (tx %s)", minted.TokenId, collection.ContractAddress, minted.To.Hex(), tx.Hash().Hex()))
+		return collectionTokenOutput(collection, minted.TokenId, minted.To.Hex(), tx, receipt), nil
+	}
+	return nil, fmt.Errorf("no Minted event in the receipt of %s", tx.Hash().Hex())
+}
+
+// Burn はコレクションのトークンをバーンする（トークンの所有者のみ）
+// コントラクトの burn は所有者か承認されたアカウントのみ呼び出せるため、所有者がプラットフォームのウォレットを承認している必要がある
+func (interactor *CollectionInteractor) Burn(ctx context.Context, id uuid.UUID, tokenID string) (*ports.CollectionTokenOutput, error) {
+	token, err := parseCollectionTokenID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := interactor.Gateway.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	contract, chainID, err := interactor.bind(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	owner, err := contract.OwnerOf(opts, token)
+	if err != nil {
+		return nil, fmt.Errorf("BadRequest: トークン %s はコレクション %s に存在しません", tokenID, collection.ContractAddress)
+	}
+	if err := authorizeWallet(ctx, owner.Hex()); err != nil {
+		return nil, err
+	}
+	approved, err := interactor.approved(opts, contract, owner, token)
+	if err != nil {
+		return nil, err
+	}
+	if !approved {
+		return nil, fmt.Errorf("BadRequest: バーンするにはプラットフォームのウォレット %s をトークン %s の操作者に承認してください", interactor.TxSigner.Address().Hex(), tokenID)
+	}
+
+	tx, receipt, err := interactor.send(ctx, chainID, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return contract.Burn(opts, token)
+	})
+	if err != nil {
+		return nil, err
+	}
+	interactor.Logging.Info(fmt.Sprintf("burned token %s of collection %s owned by %s (tx %s)", tokenID, collection.ContractAddress, owner.Hex(), tx.Hash().Hex()))
+	// コントラクトはバーンしたトークンのロイヤリティを解除するため、DBの設定も削除する
+	if err := interactor.TokenRoyaltyGateway.Delete(ctx, collection.ChainID, collection.ContractAddress, token.String()); err != nil {
+		interactor.Logging.Warning(fmt.Sprintf("failed to delete the royalty of burned token %s of collection %s: %s", token, collection.ContractAddress, err.Error()))
+	}
+	return collectionTokenOutput(collection, token, "", tx, receipt), nil
+}
+
+// SetTokenRoyalty はトークンごとのロイヤリティを設定する（コレクションの作成者のみ）
+// 先にコントラクト（ERC-2981）に設定するため、プラットフォームでデプロイしたコレクションのみ設定できる
+func (interactor *CollectionInteractor) SetTokenRoyalty(ctx context.Context, id uuid.UUID, tokenID string, input *ports.TokenRoyaltyInput) (*ports.TokenRoyaltyOutput, error) {
+	collection, err := interactor.authorizeOwner(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	token, err := parseCollectionTokenID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRoyalty(input.RoyaltyBps, input.Receiver); err != nil {
+		return nil, err
+	}
+	if input.Receiver == "" {
+		return nil, errors.New("BadRequest: ロイヤリティの受取人を指定してください")
+	}
+	if !collection.DeployTx.Valid {
+		return nil, errRoyaltyNotEnforced
+	}
+
+	tx, err := interactor.sendAsOwner(ctx, collection, func(contract *contracts.Collection, opts *bind.TransactOpts) (*types.Transaction, error) {
+		return contract.SetTokenRoyalty(opts, token, common.HexToAddress(input.Receiver), big.NewInt(int64(input.RoyaltyBps)))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	authUser, _ := ports.AuthUserFrom(ctx)
+	royaltyID, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	now := util.JapaneseNowTime()
+	royalty := &domain.TokenRoyalty{
+		ID:              royaltyID,
+		ChainID:         collection.ChainID,
+		ContractAddress: collection.ContractAddress,
+		TokenID:         token.String(),
+		RoyaltyBps:      input.RoyaltyBps,
+		Receiver:        input.Receiver,
+		UserID:          authUser.UserID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := interactor.TokenRoyaltyGateway.Save(ctx, royalty); err != nil {
+		return nil, err
+	}
+	return tokenRoyaltyOutput(collection, royalty, tx.Hash().Hex()), nil
+}
+
+// ResetTokenRoyalty はトークンごとのロイヤリティを解除してコレクションの既定値に戻す（コレクションの作成者のみ）
+func (interactor *CollectionInteractor) ResetTokenRoyalty(ctx context.Context, id uuid.UUID, tokenID string) error {
+	collection, err := interactor.authorizeOwner(ctx, id)
+	if err != nil {
+		return err
+	}
+	token, err := parseCollectionTokenID(tokenID)
+	if err != nil {
+		return err
+	}
+
+	if collection.DeployTx.Valid {
+		_, err := interactor.sendAsOwner(ctx, collection, func(contract *contracts.Collection, opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contract.ResetTokenRoyalty(opts, token)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return interactor.TokenRoyaltyGateway.Delete(ctx, collection.ChainID, collection.ContractAddress, token.String())
+}
+
+// authorizeOwner はリクエストしたユーザーがコレクションの作成者であるか確認する
+func (interactor *CollectionInteractor) authorizeOwner(ctx context.Context, id uuid.UUID) (*domain.Collection, error) {
+	collection, err := interactor.Gateway.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, collection.UserID); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// chainID はコレクションのチェーンが接続中のチェーンか確認する（0 の場合は接続中のチェーン）
+// デプロイと確認は接続中のノードで行うため、他のチェーンのコレクションは作成できない
+func (interactor *CollectionInteractor) chainID(ctx context.Context, chainID int) (*big.Int, error) {
+	connected, err := interactor.Client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if chainID != 0 && int64(chainID) != connected.Int64() {
+		return nil, fmt.Errorf("BadRequest: チェーン %d には接続していません（接続中のチェーンは %d）", chainID, connected)
+	}
+	return connected, nil
+}
+
+// deploy はプラットフォームのウォレットをオーナーとしてコレクションのコントラクトをデプロイし、取り込まれるまで待つ
+// バイトコードは make sol で abigen が生成するバインディング（contracts.CollectionMetaData）に含まれるものを使う
+func (interactor *CollectionInteractor) deploy(ctx context.Context, chainID *big.Int, input *ports.CollectionInput) (common.Address, *types.Transaction, error) {
+	if len(common.FromHex(contracts.CollectionMetaData.Bin)) == 0 {
+		return common.Address{}, nil, fmt.Errorf("ServiceUnavailable: Collection のバインディングにバイトコードが含まれていないためデプロイできません（make sol で生成してください）")
+	}
+	if input.Name == "" || input.Symbol == "" {
+		return common.Address{}, nil, fmt.Errorf("BadRequest: デプロイするには name と symbol を指定してください")
+	}
+	parsed, err := contracts.CollectionMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	var address common.Address
+	tx, receipt, err := interactor.send(ctx, chainID, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		var tx *types.Transaction
+		var err error
+		address, tx, _, err = bind.DeployContract(opts, *parsed, common.FromHex(contracts.CollectionMetaData.Bin), interactor.Client,
+			input.Name, input.Symbol, interactor.TxSigner.Address(), common.HexToAddress(input.RoyaltyReceiver), big.NewInt(int64(input.RoyaltyBps)))
+		return tx, err
+	})
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	interactor.Logging.Info(fmt.Sprintf("deployed collection %s at %s (tx %s, block %d)", input.Name, address.Hex(), tx.Hash().Hex(), receipt.BlockNumber))
+	return address, tx, nil
+}
+
+// verifyContract はコントラクトアドレスにERC-721のコントラクトがデプロイされているか確認する
+func (interactor *CollectionInteractor) verifyContract(ctx context.Context, contractAddress string) error {
+	if !common.IsHexAddress(contractAddress) {
+		return fmt.Errorf("BadRequest: コントラクトアドレス %s が正しくありません", contractAddress)
+	}
+	address := common.HexToAddress(contractAddress)
+	code, err := interactor.Client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return err
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("BadRequest: %s にコントラクトがデプロイされていません", contractAddress)
+	}
+
+	caller, err := contracts.NewCollectionCaller(address, interactor.Client)
+	if err != nil {
+		return err
+	}
+	supported, err := caller.SupportsInterface(&bind.CallOpts{Context: ctx}, erc721InterfaceID)
+	if err != nil || !supported {
+		return fmt.Errorf("BadRequest: %s はERC-721のコントラクトではありません", contractAddress)
+	}
+	return nil
+}
+
+// bind はコレクションのコントラクトに接続する
+func (interactor *CollectionInteractor) bind(ctx context.Context, collection *domain.Collection) (*contracts.Collection, *big.Int, error) {
+	if collection.ContractAddress == "" {
+		return nil, nil, fmt.Errorf("BadRequest: コレクション %s にはコントラクトがありません", collection.ID)
+	}
+	chainID, err := interactor.chainID(ctx, collection.ChainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	contract, err := contracts.NewCollection(common.HexToAddress(collection.ContractAddress), interactor.Client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return contract, chainID, nil
+}
+
+// setDefaultRoyalty はコレクションのコントラクトの既定のロイヤリティを変更する（0の場合は削除する）
+func (interactor *CollectionInteractor) setDefaultRoyalty(ctx context.Context, collection *domain.Collection, royaltyBps int, receiver string) error {
+	_, err := interactor.sendAsOwner(ctx, collection, func(contract *contracts.Collection, opts *bind.TransactOpts) (*types.Transaction, error) {
+		return contract.SetDefaultRoyalty(opts, common.HexToAddress(receiver), big.NewInt(int64(royaltyBps)))
+	})
+	return err
+}
+
+// sendAsOwner はコレクションのコントラクトのオーナー操作をプラットフォームのウォレットから送信する
+func (interactor *CollectionInteractor) sendAsOwner(ctx context.Context, collection *domain.Collection, send func(contract *contracts.Collection, opts *bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	contract, chainID, err := interactor.bind(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := contract.Owner(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, err
+	}
+	if owner != interactor.TxSigner.Address() {
+		return nil, fmt.Errorf("BadRequest: コレクション %s のオーナーがプラットフォームのウォレットではないため操作できません", collection.ContractAddress)
+	}
+	tx, _, err := interactor.send(ctx, chainID, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return send(contract, opts)
+	})
+	return tx, err
+}
+
+// approved はプラットフォームのウォレットがトークンの操作を承認されているか確認する
+func (interactor *CollectionInteractor) approved(opts *bind.CallOpts, contract *contracts.Collection, owner common.Address, token *big.Int) (bool, error) {
+	platform := interactor.TxSigner.Address()
+	if owner == platform {
+		return true, nil
+	}
+	approved, err := contract.GetApproved(opts, token)
+	if err != nil {
+		return false, err
+	}
+	if approved == platform {
+		return true, nil
+	}
+	return contract.IsApprovedForAll(opts, owner, platform)
+}
+
+// send はプラットフォームのウォレットからトランザクションを送信し、取り込まれるまで待つ
+func (interactor *CollectionInteractor) send(ctx context.Context, chainID *big.Int, send func(opts *bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, *types.Receipt, error) {
+	tx, err := interactor.NonceManager.Send(ctx, interactor.TxSigner, chainID, big.NewInt(0), send)
+	if err != nil {
+		return nil, nil, err
+	}
+	receipt, err := bind.WaitMined(ctx, interactor.Client, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, nil, fmt.Errorf("transaction %s reverted: %s", tx.Hash().Hex(), revertReason(ctx, interactor.Client, tx.Hash(), receipt.BlockNumber))
+	}
+	return tx, receipt, nil
+}
+
+// legacyRoyalty は royalty_bps が指定されていない場合に非推奨の royalty（%）を bps に読み替える
+func legacyRoyalty(input *ports.CollectionInput) {
+	if input.RoyaltyBps == 0 && input.Royalty != 0 {
+		input.RoyaltyBps = input.Royalty * 100
+	}
+}
+
+// parseCollectionTokenID はコレクションのトークンIDを読み取る（Collection.sol のトークンIDは0から始まる）
+func parseCollectionTokenID(tokenID string) (*big.Int, error) {
+	token, ok := new(big.Int).SetString(tokenID, 10)
+	if !ok || token.Sign() < 0 {
+		return nil, fmt.Errorf("BadRequest: トークンID %s が正しくありません", tokenID)
+	}
+	return token, nil
+}
+
+func tokenRoyaltyOutput(collection *domain.Collection, royalty *domain.TokenRoyalty, txHash string) *ports.TokenRoyaltyOutput {
+	return &ports.TokenRoyaltyOutput{
+		CollectionID:    collection.ID,
+		ChainID:         royalty.ChainID,
+		ContractAddress: royalty.ContractAddress,
+		TokenID:         royalty.TokenID,
+		RoyaltyBps:      royalty.RoyaltyBps,
+		Receiver:        royalty.Receiver,
+		TxHash:          txHash,
+		UpdatedAt:       royalty.UpdatedAt,
+	}
+}
+
+func collectionTokenOutput(collection *domain.Collection, tokenID *big.Int, owner string, tx *types.Transaction, receipt *types.Receipt) *ports.CollectionTokenOutput {
+	return &ports.CollectionTokenOutput{
+		CollectionID:    collection.ID,
+		ChainID:         collection.ChainID,
+		ContractAddress: collection.ContractAddress,
+		TokenID:         tokenID.String(),
+		Owner:           owner,
+		TxHash:          tx.Hash().Hex(),
+		BlockNumber:     receipt.BlockNumber.Uint64(),
+	}
+}
+
 func output(collection *domain.Collection) *ports.CollectionOutput {
 	return &ports.CollectionOutput{
 		ID:              collection.ID,
@@ -113,11 +601,13 @@ func output(collection *domain.Collection) *ports.CollectionOutput {
 		ChainID:         collection.ChainID,
 		Name:            collection.Name,
 		ContractAddress: collection.ContractAddress,
+		DeployTx:        collection.DeployTx.String,
 		Description:     collection.Description.String,
 		ImageURL:        collection.ImageURL.String,
 		BannerImageURL:  collection.BannerImageURL.String,
 		ExternalURL:     collection.ExternalURL.String,
-		Royalty:         collection.Royalty,
+		RoyaltyBps:      collection.RoyaltyBps,
+		Royalty:         collection.RoyaltyBps / 100,
 		RoyaltyReceiver: collection.RoyaltyReceiver,
 		CreatedAt:       collection.CreatedAt,
 		UpdatedAt:       collection.UpdatedAt,
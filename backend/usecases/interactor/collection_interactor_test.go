@@ -5,24 +5,225 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"math/big"
+	"slices"
+	"strings"
 	"testing"
 
+	"nft-music/contracts"
 	"nft-music/domain"
+	"nft-music/usecases/gateways"
 	"nft-music/usecases/gateways/mock"
 	"nft-music/usecases/ports"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
+// fakeCollectionBackend はコレクションのコントラクトの状態を返すテスト用のノード
+type fakeCollectionBackend struct {
+	*fakeMarketBackend
+
+	contracts      map[common.Address]bool // コントラクトがあるアドレス（値はERC-721かどうか）
+	owners         map[int64]common.Address
+	approved       map[int64]common.Address
+	nextTokenID    int64
+	defaultRoyalty *big.Int
+	tokenRoyalties map[int64]*big.Int
+	minters        map[common.Address]bool
+	vouchersUsed   map[[32]byte]bool
+}
+
+func newFakeCollectionBackend(owner common.Address) *fakeCollectionBackend {
+	return &fakeCollectionBackend{
+		fakeMarketBackend: &fakeMarketBackend{
+			owner:    owner,
+			txs:      map[common.Hash]*types.Transaction{},
+			receipts: map[common.Hash]*types.Receipt{},
+		},
+		contracts:      map[common.Address]bool{},
+		owners:         map[int64]common.Address{},
+		approved:       map[int64]common.Address{},
+		tokenRoyalties: map[int64]*big.Int{},
+		minters:        map[common.Address]bool{},
+		vouchersUsed:   map[[32]byte]bool{},
+	}
+}
+
+func (fake *fakeCollectionBackend) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	if _, ok := fake.contracts[account]; !ok {
+		return nil, nil
+	}
+	return []byte{0x60}, nil
+}
+
+func (fake *fakeCollectionBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	parsed, err := contracts.CollectionMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	method, err := parsed.MethodById(msg.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "supportsInterface":
+		return method.Outputs.Pack(fake.contracts[*msg.To] && args[0].([4]byte) == erc721InterfaceID)
+	case "owner":
+		return method.Outputs.Pack(fake.owner)
+	case "ownerOf":
+		owner, ok := fake.owners[args[0].(*big.Int).Int64()]
+		if !ok {
+			return nil, errors.New("execution reverted")
+		}
+		return method.Outputs.Pack(owner)
+	case "getApproved":
+		return method.Outputs.Pack(fake.approved[args[0].(*big.Int).Int64()])
+	case "isApprovedForAll":
+		return method.Outputs.Pack(false)
+	case "minters":
+		return method.Outputs.Pack(fake.minters[args[0].(common.Address)])
+	case "voucherUsed":
+		return method.Outputs.Pack(fake.vouchersUsed[args[0].([32]byte)])
+	}
+	return nil, errors.New("execution reverted")
+}
+
+// SendTransaction はデプロイ・safeMint・burn・ロイヤリティとバウチャーの操作をコントラクトの状態に反映してブロック1に取り込む
+// redeem は使用済みのバウチャーの場合にrevertする（署名は検証しない）
+func (fake *fakeCollectionBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := fake.fakeMarketBackend.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	receipt := fake.receipts[tx.Hash()]
+	if fake.revertSent {
+		return nil
+	}
+	if tx.To() == nil {
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return err
+		}
+		receipt.ContractAddress = crypto.CreateAddress(from, tx.Nonce())
+		fake.contracts[receipt.ContractAddress] = true
+		return nil
+	}
+
+	parsed, err := contracts.CollectionMetaData.GetAbi()
+	if err != nil {
+		return err
+	}
+	method, err := parsed.MethodById(tx.Data()[:4])
+	if err != nil {
+		return err
+	}
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		return err
+	}
+	switch method.Name {
+	case "safeMint":
+		to := args[0].(common.Address)
+		tokenID := fake.nextTokenID
+		fake.nextTokenID++
+		fake.owners[tokenID] = to
+		receipt.Logs = append(receipt.Logs, &types.Log{
+			Address: *tx.To(),
+			Topics:  []common.Hash{parsed.Events["Minted"].ID, common.BytesToHash(to.Bytes()), common.BigToHash(big.NewInt(tokenID))},
+		})
+	case "burn":
+		delete(fake.owners, args[0].(*big.Int).Int64())
+	case "setDefaultRoyalty":
+		fake.defaultRoyalty = args[1].(*big.Int)
+	case "setTokenRoyalty":
+		fake.tokenRoyalties[args[0].(*big.Int).Int64()] = args[2].(*big.Int)
+	case "resetTokenRoyalty":
+		delete(fake.tokenRoyalties, args[0].(*big.Int).Int64())
+	case "setMinter":
+		fake.minters[args[0].(common.Address)] = args[1].(bool)
+	case "cancelVoucher":
+		fake.vouchersUsed[args[0].([32]byte)] = true
+	case "redeem":
+		to := args[0].(common.Address)
+		id := abi.ConvertType(args[1], new(contracts.CollectionMintVoucher)).(*contracts.CollectionMintVoucher).Id
+		if fake.vouchersUsed[id] {
+			receipt.Status = types.ReceiptStatusFailed
+			return nil
+		}
+		fake.vouchersUsed[id] = true
+		tokenID := fake.nextTokenID
+		fake.nextTokenID++
+		fake.owners[tokenID] = to
+		receipt.Logs = append(receipt.Logs, &types.Log{
+			Address:     *tx.To(),
+			Topics:      []common.Hash{parsed.Events["VoucherRedeemed"].ID, id, common.BigToHash(big.NewInt(tokenID)), common.BytesToHash(fake.owner.Bytes())},
+			Data:        common.LeftPadBytes(to.Bytes(), 32),
+			TxHash:      tx.Hash(),
+			BlockNumber: receipt.BlockNumber.Uint64(),
+		})
+	}
+	return nil
+}
+
+// FilterLogs は取り込んだトランザクションのログからアドレスとトピックが一致するものを返す
+func (fake *fakeCollectionBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	for _, receipt := range fake.receipts {
+		for _, log := range receipt.Logs {
+			if len(query.Addresses) > 0 && !slices.Contains(query.Addresses, log.Address) {
+				continue
+			}
+			matched := true
+			for i, topics := range query.Topics {
+				if len(topics) > 0 && (i >= len(log.Topics) || !slices.Contains(topics, log.Topics[i])) {
+					matched = false
+				}
+			}
+			if matched {
+				logs = append(logs, *log)
+			}
+		}
+	}
+	return logs, nil
+}
+
+// requireCollectionBytecode は make sol で生成したバインディングにバイトコードが無い場合はデプロイするテストを飛ばす
+func requireCollectionBytecode(t *testing.T) {
+	t.Helper()
+	if !hasCollectionBytecode() {
+		t.Skip("Collection のバインディングにバイトコードがありません（make sol で生成してください）")
+	}
+}
+
+func hasCollectionBytecode() bool {
+	return len(common.FromHex(contracts.CollectionMetaData.Bin)) > 0
+}
+
 func TestCollectionInteractor(t *testing.T) {
+	signer := newTestSigner(t)
+	// トークンごとのロイヤリティを使うテストは TokenRoyaltyGateway を設定する
+	newInteractor := func(gateway gateways.CollectionGateway) (*CollectionInteractor, *fakeCollectionBackend) {
+		backend := newFakeCollectionBackend(signer.Address())
+		return NewCollectionInteractor(gateway, nil, backend, signer, NewNonceManager(backend, nil), &NullLogging{}), backend
+	}
+
 	t.Run("Get", func(t *testing.T) {
 		t.Run("正常系: IDでコレクションを取得できる", func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			mockGateway := mock.NewMockCollectionGateway(ctrl)
-			interactor := NewCollectionInteractor(mockGateway)
+			interactor, _ := newInteractor(mockGateway)
 
 			id := uuid.New()
 			expectedDomain := &domain.Collection{
@@ -48,7 +249,7 @@ func TestCollectionInteractor(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			mockGateway := mock.NewMockCollectionGateway(ctrl)
-			interactor := NewCollectionInteractor(mockGateway)
+			interactor, _ := newInteractor(mockGateway)
 
 			id := uuid.New()
 			mockGateway.EXPECT().
@@ -67,7 +268,7 @@ func TestCollectionInteractor(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			mockGateway := mock.NewMockCollectionGateway(ctrl)
-			interactor := NewCollectionInteractor(mockGateway)
+			interactor, _ := newInteractor(mockGateway)
 
 			expectedDomains := []domain.Collection{
 				{ID: uuid.New(), Name: "Col 1"},
@@ -85,26 +286,214 @@ func TestCollectionInteractor(t *testing.T) {
 		})
 	})
 
+	userID := uuid.New()
+	userWallet := common.HexToAddress("0xc5309Ef694C81C4a8e946F2810e09516436daeB5")
+	authCtx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: userID, Wallet: userWallet.Hex(), Role: domain.RoleCreator})
+	contractAddress := common.HexToAddress("0x495f947276749ce646f68ac8c248420045075b34")
+
 	t.Run("Create", func(t *testing.T) {
-		t.Run("正常系: コレクションを作成できる", func(t *testing.T) {
+		t.Run("正常系: コントラクトをデプロイしてコレクションを作成できる", func(t *testing.T) {
+			requireCollectionBytecode(t)
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			mockGateway := mock.NewMockCollectionGateway(ctrl)
-			interactor := NewCollectionInteractor(mockGateway)
+			interactor, backend := newInteractor(mockGateway)
 
 			input := &ports.CollectionInput{
-				Name: "New Collection",
+				UserID: userID,
+				Name:   "New Collection",
+				Symbol: "NEW",
 			}
 
+			var created *domain.Collection
 			mockGateway.EXPECT().
 				Create(gomock.Any(), gomock.Any()).
-				Return(nil)
+				DoAndReturn(func(ctx context.Context, collection *domain.Collection) error {
+					created = collection
+					return nil
+				})
 
-			output, err := interactor.Create(context.Background(), input)
+			output, err := interactor.Create(authCtx, input)
 
-			assert.NoError(t, err)
-			assert.NotNil(t, output)
+			require.NoError(t, err)
 			assert.Equal(t, "New Collection", output.Name)
+			assert.Equal(t, 1337, output.ChainID)
+			require.Len(t, backend.txs, 1)
+			for hash, tx := range backend.txs {
+				assert.Nil(t, tx.To())
+				assert.Equal(t, hash.Hex(), output.DeployTx)
+				assert.Equal(t, backend.receipts[hash].ContractAddress.Hex(), output.ContractAddress)
+			}
+			assert.Equal(t, output.ContractAddress, created.ContractAddress)
+			assert.True(t, created.DeployTx.Valid)
+		})
+
+		t.Run("正常系: 既定のロイヤリティを設定してデプロイできる", func(t *testing.T) {
+			requireCollectionBytecode(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, _ := newInteractor(mockGateway)
+
+			var created *domain.Collection
+			mockGateway.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, collection *domain.Collection) error {
+					created = collection
+					return nil
+				})
+
+			output, err := interactor.Create(authCtx, &ports.CollectionInput{UserID: userID, Name: "New Collection", Symbol: "NEW", RoyaltyBps: 750, RoyaltyReceiver: userWallet.Hex()})
+
+			require.NoError(t, err)
+			assert.Equal(t, 750, output.RoyaltyBps)
+			assert.Equal(t, userWallet.Hex(), output.RoyaltyReceiver)
+			assert.Equal(t, 750, created.RoyaltyBps)
+		})
+
+		t.Run("正常系: 非推奨の royalty（%）はbpsに読み替える", func(t *testing.T) {
+			requireCollectionBytecode(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, _ := newInteractor(mockGateway)
+
+			mockGateway.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				Return(nil)
+
+			output, err := interactor.Create(authCtx, &ports.CollectionInput{UserID: userID, Name: "New Collection", Symbol: "NEW", Royalty: 10, RoyaltyReceiver: userWallet.Hex()})
+
+			require.NoError(t, err)
+			assert.Equal(t, 1000, output.RoyaltyBps)
+			assert.Equal(t, 10, output.Royalty)
+		})
+
+		t.Run("異常系: ロイヤリティ率が範囲外か受取人がチェックサム付きでない場合", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+
+			_, err := interactor.Create(authCtx, &ports.CollectionInput{UserID: userID, Name: "New Collection", Symbol: "NEW", RoyaltyBps: 10001, RoyaltyReceiver: userWallet.Hex()})
+			assert.ErrorContains(t, err, "BadRequest")
+
+			_, err = interactor.Create(authCtx, &ports.CollectionInput{UserID: userID, Name: "New Collection", Symbol: "NEW", RoyaltyBps: 500, RoyaltyReceiver: strings.ToLower(userWallet.Hex())})
+			assert.ErrorContains(t, err, "チェックサム")
+
+			_, err = interactor.Create(authCtx, &ports.CollectionInput{UserID: userID, Name: "New Collection", Symbol: "NEW", RoyaltyBps: 500})
+			assert.ErrorContains(t, err, "BadRequest")
+			assert.Empty(t, backend.txs)
+		})
+
+		t.Run("正常系: デプロイ済みのERC-721のコントラクトを登録できる", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+			backend.contracts[contractAddress] = true
+
+			mockGateway.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				Return(nil)
+
+			output, err := interactor.Create(authCtx, &ports.CollectionInput{UserID: userID, Name: "New Collection", ContractAddress: contractAddress.Hex()})
+
+			require.NoError(t, err)
+			assert.Equal(t, contractAddress.Hex(), output.ContractAddress)
+			assert.Empty(t, output.DeployTx)
+			assert.Empty(t, backend.txs)
+		})
+
+		t.Run("異常系: 外部のコントラクトには販売時に支払われないロイヤリティを設定できない", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+			backend.contracts[contractAddress] = true
+
+			_, err := interactor.Create(authCtx, &ports.CollectionInput{UserID: userID, Name: "New Collection", ContractAddress: contractAddress.Hex(), RoyaltyBps: 500, RoyaltyReceiver: userWallet.Hex()})
+
+			assert.ErrorContains(t, err, "BadRequest")
+		})
+
+		t.Run("異常系: コントラクトが無いアドレスやERC-721でないコントラクトは登録できない", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+
+			_, err := interactor.Create(authCtx, &ports.CollectionInput{UserID: userID, Name: "New Collection", ContractAddress: contractAddress.Hex()})
+			assert.ErrorContains(t, err, "BadRequest")
+
+			backend.contracts[contractAddress] = false
+			_, err = interactor.Create(authCtx, &ports.CollectionInput{UserID: userID, Name: "New Collection", ContractAddress: contractAddress.Hex()})
+			assert.ErrorContains(t, err, "ERC-721")
+
+			_, err = interactor.Create(authCtx, &ports.CollectionInput{UserID: userID, Name: "New Collection", ContractAddress: "0x1234"})
+			assert.ErrorContains(t, err, "BadRequest")
+		})
+
+		t.Run("異常系: 接続していないチェーンにはデプロイできない", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+
+			_, err := interactor.Create(authCtx, &ports.CollectionInput{UserID: userID, ChainID: 1, Name: "New Collection", Symbol: "NEW"})
+			assert.ErrorContains(t, err, "BadRequest")
+			assert.Empty(t, backend.txs)
+		})
+
+		t.Run("異常系: バインディングにバイトコードが無い場合はデプロイできない", func(t *testing.T) {
+			if hasCollectionBytecode() {
+				t.Skip("Collection のバインディングにバイトコードがあります")
+			}
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+
+			_, err := interactor.Create(authCtx, &ports.CollectionInput{UserID: userID, Name: "New Collection", Symbol: "NEW"})
+			assert.ErrorContains(t, err, "ServiceUnavailable")
+			assert.Empty(t, backend.txs)
+		})
+
+		t.Run("異常系: ログインしていない場合", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, _ := newInteractor(mockGateway)
+
+			output, err := interactor.Create(context.Background(), &ports.CollectionInput{UserID: userID, Name: "New Collection"})
+
+			assert.ErrorContains(t, err, "Unauthorized")
+			assert.Nil(t, output)
+		})
+
+		t.Run("異常系: クリエイター以外はコレクションを作成できない", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, _ := newInteractor(mockGateway)
+
+			memberCtx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: userID, Role: domain.RoleMember})
+			output, err := interactor.Create(memberCtx, &ports.CollectionInput{UserID: userID, Name: "New Collection"})
+
+			assert.ErrorContains(t, err, "Forbidden")
+			assert.Nil(t, output)
+		})
+
+		t.Run("異常系: 他のユーザーのコレクションは作成できない", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, _ := newInteractor(mockGateway)
+
+			output, err := interactor.Create(authCtx, &ports.CollectionInput{UserID: uuid.New(), Name: "New Collection"})
+
+			assert.ErrorContains(t, err, "Forbidden")
+			assert.Nil(t, output)
 		})
 	})
 
@@ -113,23 +502,316 @@ func TestCollectionInteractor(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			mockGateway := mock.NewMockCollectionGateway(ctrl)
-			interactor := NewCollectionInteractor(mockGateway)
+			interactor, _ := newInteractor(mockGateway)
 
 			id := uuid.New()
 			input := &ports.CollectionInput{
-				Name: "Updated Name",
+				UserID: userID,
+				Name:   "Updated Name",
 			}
 
+			mockGateway.EXPECT().
+				Get(gomock.Any(), id).
+				Return(&domain.Collection{ID: id, UserID: userID}, nil)
 			mockGateway.EXPECT().
 				Update(gomock.Any(), gomock.Any()).
 				Return(nil)
 
-			output, err := interactor.Update(context.Background(), id, input)
+			output, err := interactor.Update(authCtx, id, input)
 
 			assert.NoError(t, err)
 			assert.NotNil(t, output)
 			assert.Equal(t, "Updated Name", output.Name)
 		})
+
+		t.Run("正常系: デプロイしたコレクションのロイヤリティはコントラクトにも設定する", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+			backend.contracts[contractAddress] = true
+
+			id := uuid.New()
+			mockGateway.EXPECT().
+				Get(gomock.Any(), id).
+				Return(&domain.Collection{
+					ID:              id,
+					UserID:          userID,
+					ChainID:         1337,
+					ContractAddress: contractAddress.Hex(),
+					DeployTx:        sql.NullString{String: "0x01", Valid: true},
+					RoyaltyBps:      500,
+					RoyaltyReceiver: userWallet.Hex(),
+				}, nil)
+			mockGateway.EXPECT().
+				Update(gomock.Any(), gomock.Any()).
+				Return(nil)
+			mockGateway.EXPECT().
+				UpdateRoyalty(gomock.Any(), id, 0, "").
+				Return(nil)
+
+			output, err := interactor.Update(authCtx, id, &ports.CollectionInput{UserID: userID, Name: "Updated Name"})
+
+			require.NoError(t, err)
+			assert.Zero(t, output.RoyaltyBps)
+			require.NotNil(t, backend.defaultRoyalty)
+			assert.Zero(t, backend.defaultRoyalty.Sign())
+		})
+
+		t.Run("正常系: 外部のコントラクトのコレクションは送られたロイヤリティを使わずに更新する", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+
+			id := uuid.New()
+			mockGateway.EXPECT().
+				Get(gomock.Any(), id).
+				Return(&domain.Collection{ID: id, UserID: userID, ChainID: 1337, ContractAddress: contractAddress.Hex()}, nil)
+			mockGateway.EXPECT().
+				Update(gomock.Any(), gomock.Any()).
+				Return(nil)
+
+			output, err := interactor.Update(authCtx, id, &ports.CollectionInput{UserID: userID, Name: "Updated Name", Royalty: 5, RoyaltyReceiver: userWallet.Hex()})
+
+			require.NoError(t, err)
+			assert.Equal(t, "Updated Name", output.Name)
+			assert.Zero(t, output.RoyaltyBps)
+			assert.Empty(t, output.RoyaltyReceiver)
+			assert.Nil(t, backend.defaultRoyalty)
+		})
+
+		t.Run("異常系: デプロイしたコレクションのコントラクトアドレスは変更できない", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+			backend.contracts[contractAddress] = true
+
+			id := uuid.New()
+			mockGateway.EXPECT().
+				Get(gomock.Any(), id).
+				Return(&domain.Collection{
+					ID:              id,
+					UserID:          userID,
+					ContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+					DeployTx:        sql.NullString{String: "0x01", Valid: true},
+				}, nil)
+
+			_, err := interactor.Update(authCtx, id, &ports.CollectionInput{UserID: userID, Name: "Updated Name", ContractAddress: contractAddress.Hex()})
+
+			assert.ErrorContains(t, err, "BadRequest")
+		})
+	})
+
+	t.Run("Mint", func(t *testing.T) {
+		t.Run("正常系: 作成者のウォレットにミントしてトークンIDを返す", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+			backend.contracts[contractAddress] = true
+			backend.nextTokenID = 3
+
+			id := uuid.New()
+			mockGateway.EXPECT().
+				Get(gomock.Any(), id).
+				Return(&domain.Collection{ID: id, UserID: userID, ChainID: 1337, ContractAddress: contractAddress.Hex()}, nil)
+
+			output, err := interactor.Mint(authCtx, id, &ports.CollectionMintInput{})
+
+			require.NoError(t, err)
+			assert.Equal(t, "3", output.TokenID)
+			assert.Equal(t, userWallet.Hex(), output.Owner)
+			assert.Equal(t, userWallet, backend.owners[3])
+			assert.Contains(t, backend.txs, common.HexToHash(output.TxHash))
+		})
+
+		t.Run("異常系: オーナーがプラットフォームのウォレットでないコントラクトではミントできない", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+			backend.contracts[contractAddress] = true
+			backend.owner = userWallet
+
+			id := uuid.New()
+			mockGateway.EXPECT().
+				Get(gomock.Any(), id).
+				Return(&domain.Collection{ID: id, UserID: userID, ContractAddress: contractAddress.Hex()}, nil)
+
+			_, err := interactor.Mint(authCtx, id, &ports.CollectionMintInput{})
+
+			assert.ErrorContains(t, err, "BadRequest")
+			assert.Empty(t, backend.txs)
+		})
+	})
+
+	t.Run("Burn", func(t *testing.T) {
+		collection := func(mockGateway *mock.MockCollectionGateway) uuid.UUID {
+			id := uuid.New()
+			mockGateway.EXPECT().
+				Get(gomock.Any(), id).
+				Return(&domain.Collection{ID: id, UserID: uuid.New(), ContractAddress: contractAddress.Hex()}, nil)
+			return id
+		}
+
+		t.Run("正常系: プラットフォームのウォレットを承認したトークンをバーンできる", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+			mockRoyaltyGateway := mock.NewMockTokenRoyaltyGateway(ctrl)
+			interactor.TokenRoyaltyGateway = mockRoyaltyGateway
+			mockRoyaltyGateway.EXPECT().
+				Delete(gomock.Any(), 0, contractAddress.Hex(), "0").
+				Return(nil)
+			backend.contracts[contractAddress] = true
+			backend.owners[0] = userWallet
+			backend.approved[0] = signer.Address()
+
+			output, err := interactor.Burn(authCtx, collection(mockGateway), "0")
+
+			require.NoError(t, err)
+			assert.Equal(t, "0", output.TokenID)
+			assert.NotContains(t, backend.owners, int64(0))
+		})
+
+		t.Run("異常系: 承認していない場合はバーンできない", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+			backend.contracts[contractAddress] = true
+			backend.owners[0] = userWallet
+
+			_, err := interactor.Burn(authCtx, collection(mockGateway), "0")
+
+			assert.ErrorContains(t, err, "BadRequest")
+			assert.Empty(t, backend.txs)
+		})
+
+		t.Run("異常系: 他のウォレットのトークンはバーンできない", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+			backend.contracts[contractAddress] = true
+			backend.owners[0] = common.HexToAddress("0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50")
+			backend.approved[0] = signer.Address()
+
+			_, err := interactor.Burn(authCtx, collection(mockGateway), "0")
+
+			assert.ErrorContains(t, err, "Forbidden")
+			assert.Empty(t, backend.txs)
+		})
+	})
+
+	t.Run("TokenRoyalty", func(t *testing.T) {
+		deployed := func(mockGateway *mock.MockCollectionGateway, deployTx string) uuid.UUID {
+			id := uuid.New()
+			mockGateway.EXPECT().
+				Get(gomock.Any(), id).
+				Return(&domain.Collection{
+					ID:              id,
+					UserID:          userID,
+					ChainID:         1337,
+					ContractAddress: contractAddress.Hex(),
+					DeployTx:        sql.NullString{String: deployTx, Valid: deployTx != ""},
+				}, nil)
+			return id
+		}
+
+		t.Run("正常系: デプロイしたコレクションはコントラクトとDBに設定する", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			mockRoyaltyGateway := mock.NewMockTokenRoyaltyGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+			interactor.TokenRoyaltyGateway = mockRoyaltyGateway
+			backend.contracts[contractAddress] = true
+
+			var saved *domain.TokenRoyalty
+			mockRoyaltyGateway.EXPECT().
+				Save(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, royalty *domain.TokenRoyalty) error {
+					saved = royalty
+					return nil
+				})
+
+			output, err := interactor.SetTokenRoyalty(authCtx, deployed(mockGateway, "0x01"), "4", &ports.TokenRoyaltyInput{RoyaltyBps: 1000, Receiver: userWallet.Hex()})
+
+			require.NoError(t, err)
+			assert.Equal(t, 1000, output.RoyaltyBps)
+			assert.NotEmpty(t, output.TxHash)
+			assert.Equal(t, big.NewInt(1000), backend.tokenRoyalties[4])
+			assert.Equal(t, "4", saved.TokenID)
+			assert.Equal(t, userID, saved.UserID)
+		})
+
+		t.Run("異常系: 外部のコントラクトには販売時に支払われないため設定できない", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			mockRoyaltyGateway := mock.NewMockTokenRoyaltyGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+			interactor.TokenRoyaltyGateway = mockRoyaltyGateway
+
+			_, err := interactor.SetTokenRoyalty(authCtx, deployed(mockGateway, ""), "4", &ports.TokenRoyaltyInput{RoyaltyBps: 1000, Receiver: userWallet.Hex()})
+
+			assert.ErrorContains(t, err, "BadRequest")
+			assert.Empty(t, backend.txs)
+		})
+
+		t.Run("正常系: 解除するとコントラクトとDBから削除する", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			mockRoyaltyGateway := mock.NewMockTokenRoyaltyGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+			interactor.TokenRoyaltyGateway = mockRoyaltyGateway
+			backend.contracts[contractAddress] = true
+			backend.tokenRoyalties[4] = big.NewInt(1000)
+
+			mockRoyaltyGateway.EXPECT().
+				Delete(gomock.Any(), 1337, contractAddress.Hex(), "4").
+				Return(nil)
+
+			err := interactor.ResetTokenRoyalty(authCtx, deployed(mockGateway, "0x01"), "4")
+
+			require.NoError(t, err)
+			assert.NotContains(t, backend.tokenRoyalties, int64(4))
+		})
+
+		t.Run("異常系: ロイヤリティ率が範囲外か受取人が正しくない場合", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, backend := newInteractor(mockGateway)
+
+			_, err := interactor.SetTokenRoyalty(authCtx, deployed(mockGateway, "0x01"), "4", &ports.TokenRoyaltyInput{RoyaltyBps: -1, Receiver: userWallet.Hex()})
+			assert.ErrorContains(t, err, "BadRequest")
+
+			_, err = interactor.SetTokenRoyalty(authCtx, deployed(mockGateway, "0x01"), "4", &ports.TokenRoyaltyInput{RoyaltyBps: 1000, Receiver: common.Address{}.Hex()})
+			assert.ErrorContains(t, err, "BadRequest")
+			assert.Empty(t, backend.txs)
+		})
+
+		t.Run("異常系: 他のユーザーのコレクションには設定できない", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, _ := newInteractor(mockGateway)
+
+			id := uuid.New()
+			mockGateway.EXPECT().
+				Get(gomock.Any(), id).
+				Return(&domain.Collection{ID: id, UserID: uuid.New()}, nil)
+
+			_, err := interactor.SetTokenRoyalty(authCtx, id, "4", &ports.TokenRoyaltyInput{RoyaltyBps: 1000, Receiver: userWallet.Hex()})
+
+			assert.ErrorContains(t, err, "Forbidden")
+		})
 	})
 
 	t.Run("Delete", func(t *testing.T) {
@@ -137,17 +819,37 @@ func TestCollectionInteractor(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			mockGateway := mock.NewMockCollectionGateway(ctrl)
-			interactor := NewCollectionInteractor(mockGateway)
+			interactor, _ := newInteractor(mockGateway)
 
 			id := uuid.New()
 
+			mockGateway.EXPECT().
+				Get(gomock.Any(), id).
+				Return(&domain.Collection{ID: id, UserID: userID}, nil)
 			mockGateway.EXPECT().
 				Delete(gomock.Any(), gomock.Any()).
 				Return(nil)
 
-			err := interactor.Delete(context.Background(), id)
+			err := interactor.Delete(authCtx, id)
 
 			assert.NoError(t, err)
 		})
+
+		t.Run("異常系: 他のユーザーのコレクションは削除できない", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGateway := mock.NewMockCollectionGateway(ctrl)
+			interactor, _ := newInteractor(mockGateway)
+
+			id := uuid.New()
+
+			mockGateway.EXPECT().
+				Get(gomock.Any(), id).
+				Return(&domain.Collection{ID: id, UserID: uuid.New()}, nil)
+
+			err := interactor.Delete(authCtx, id)
+
+			assert.ErrorContains(t, err, "Forbidden")
+		})
 	})
 }
@@ -0,0 +1,323 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/usecases/gateways"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+	"nft-music/util"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+)
+
+// ContractAdminInteractor はマーケットプレイスのコントラクトのオーナー操作を行う
+// オーナーはプラットフォームのウォレットのため、管理者の操作をプラットフォームのウォレットで送信し、操作ごとに監査ログを記録する
+type ContractAdminInteractor struct {
+	ContractAuditGateway gateways.ContractAuditGateway
+	Client               MintBackend
+	TxSigner             gateways.Signer
+	NonceManager         *NonceManager
+	ChainRegistry        *ChainRegistry
+	Marketplace          *MarketplaceContract
+	Logging              logging.Logging
+}
+
+func NewContractAdminInteractor(contractAuditGateway gateways.ContractAuditGateway, client MintBackend, signer gateways.Signer, nonceManager *NonceManager, chainRegistry *ChainRegistry, marketplace *MarketplaceContract, logging logging.Logging) *ContractAdminInteractor {
+	return &ContractAdminInteractor{
+		ContractAuditGateway: contractAuditGateway,
+		Client:               client,
+		TxSigner:             signer,
+		NonceManager:         nonceManager,
+		ChainRegistry:        chainRegistry,
+		Marketplace:          marketplace,
+		Logging:              logging,
+	}
+}
+
+// State は接続中のコントラクトのオーナー・一時停止・ロイヤリティ・ミント料を取得する
+func (interactor *ContractAdminInteractor) State(ctx context.Context) (*ports.ContractStateOutput, error) {
+	contract, address, err := interactor.Marketplace.Get()
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := interactor.Client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	owner, err := contract.Owner(opts)
+	if err != nil {
+		return nil, err
+	}
+	paused, err := contract.Paused(opts)
+	if err != nil {
+		return nil, err
+	}
+	royaltyFeeBps, err := contract.RoyaltyFeeBps(opts)
+	if err != nil {
+		return nil, err
+	}
+	listingPrice, err := contract.GetListingPrice(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ports.ContractStateOutput{
+		ChainID:               int(chainID.Int64()),
+		Address:               address.Hex(),
+		Owner:                 owner.Hex(),
+		Paused:                paused,
+		RoyaltyFeeBps:         royaltyFeeBps.Int64(),
+		ListingPrice:          domain.NewAmount(listingPrice),
+		ListingPriceFormatted: interactor.ChainRegistry.format(int(chainID.Int64()), domain.NewAmount(listingPrice)),
+	}, nil
+}
+
+// Pause はミントと売買を一時停止する（管理者のみ）
+func (interactor *ContractAdminInteractor) Pause(ctx context.Context) (*ports.ContractAuditOutput, error) {
+	return interactor.operate(ctx, domain.ContractOperationPause, strconv.FormatBool(true), currentPaused,
+		func(contract *contracts.Contracts, opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contract.Pause(opts)
+		})
+}
+
+// Unpause は一時停止を解除する（管理者のみ）
+func (interactor *ContractAdminInteractor) Unpause(ctx context.Context) (*ports.ContractAuditOutput, error) {
+	return interactor.operate(ctx, domain.ContractOperationUnpause, strconv.FormatBool(false), currentPaused,
+		func(contract *contracts.Contracts, opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contract.Unpause(opts)
+		})
+}
+
+// UpdateRoyaltyFee は二次流通でクリエイターに支払うロイヤリティを変更する（管理者のみ）
+func (interactor *ContractAdminInteractor) UpdateRoyaltyFee(ctx context.Context, input *ports.RoyaltyFeeInput) (*ports.ContractAuditOutput, error) {
+	royaltyFeeBps := big.NewInt(input.RoyaltyFeeBps)
+	return interactor.operate(ctx, domain.ContractOperationUpdateRoyaltyFee, royaltyFeeBps.String(),
+		func(contract *contracts.Contracts, opts *bind.CallOpts) (string, error) {
+			current, err := contract.RoyaltyFeeBps(opts)
+			if err != nil {
+				return "", err
+			}
+			return current.String(), nil
+		},
+		func(contract *contracts.Contracts, opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contract.UpdateRoyaltyFee(opts, royaltyFeeBps)
+		})
+}
+
+// UpdateListingPrice はミント料を変更する（管理者のみ）。以降のミントは変更後のミント料で送信する
+func (interactor *ContractAdminInteractor) UpdateListingPrice(ctx context.Context, input *ports.ListingPriceInput) (*ports.ContractAuditOutput, error) {
+	listingPrice, err := interactor.ChainRegistry.Active().ParsePrice(input.ListingPrice)
+	if err != nil {
+		return nil, err
+	}
+	return interactor.operate(ctx, domain.ContractOperationUpdateListingPrice, listingPrice.String(),
+		func(contract *contracts.Contracts, opts *bind.CallOpts) (string, error) {
+			current, err := contract.GetListingPrice(opts)
+			if err != nil {
+				return "", err
+			}
+			return current.String(), nil
+		},
+		func(contract *contracts.Contracts, opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contract.UpdateListingPrice(opts, listingPrice.Wei())
+		})
+}
+
+// RenounceOwnership はコントラクトの所有権を放棄する（管理者のみ）
+// 放棄すると一時停止の解除やミント料の変更ができなくなるため、確認として接続中のコントラクトアドレスの指定が必要
+func (interactor *ContractAdminInteractor) RenounceOwnership(ctx context.Context, input *ports.RenounceOwnershipInput) (*ports.ContractAuditOutput, error) {
+	if err := authorize(ctx, ActionOperateContract); err != nil {
+		return nil, err
+	}
+	_, address, err := interactor.Marketplace.Get()
+	if err != nil {
+		return nil, err
+	}
+	if !util.SameAddress(input.Confirm, address.Hex()) {
+		return nil, fmt.Errorf("BadRequest: confirm に接続中のコントラクトアドレス %s を指定してください", address.Hex())
+	}
+
+	return interactor.operate(ctx, domain.ContractOperationRenounceOwnership, common.Address{}.Hex(),
+		func(contract *contracts.Contracts, opts *bind.CallOpts) (string, error) {
+			owner, err := contract.Owner(opts)
+			if err != nil {
+				return "", err
+			}
+			return owner.Hex(), nil
+		},
+		func(contract *contracts.Contracts, opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contract.RenounceOwnership(opts)
+		})
+}
+
+// ListAudits は接続中のコントラクトのオーナー操作の監査ログを新しい順に取得する（管理者のみ）
+func (interactor *ContractAdminInteractor) ListAudits(ctx context.Context, limit int) ([]ports.ContractAuditOutput, error) {
+	if err := authorize(ctx, ActionOperateContract); err != nil {
+		return nil, err
+	}
+	_, address, err := interactor.Marketplace.Get()
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := interactor.Client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	audits, err := interactor.ContractAuditGateway.List(ctx, int(chainID.Int64()), address.Hex(), limit)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]ports.ContractAuditOutput, 0, len(audits))
+	for i := range audits {
+		outputs = append(outputs, *contractAuditOutput(&audits[i]))
+	}
+	return outputs, nil
+}
+
+// operate はオーナー操作を送信し、取り込まれるまで待って監査ログを記録する
+// 送信前の確認（権限・オーナー・変更の有無）で断った操作は記録せず、送信以降の失敗は failed として記録する
+// 送信したトランザクションは取り込みを待つ前に pending として記録し、クライアントが切断しても取り込まれるまで待って結果を更新する
+func (interactor *ContractAdminInteractor) operate(
+	ctx context.Context,
+	operation string,
+	newValue string,
+	current func(contract *contracts.Contracts, opts *bind.CallOpts) (string, error),
+	send func(contract *contracts.Contracts, opts *bind.TransactOpts) (*types.Transaction, error),
+) (*ports.ContractAuditOutput, error) {
+	if err := authorize(ctx, ActionOperateContract); err != nil {
+		return nil, err
+	}
+	authUser, _ := ports.AuthUserFrom(ctx)
+
+	contract, address, err := interactor.Marketplace.Get()
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := interactor.Client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	owner, err := contract.Owner(opts)
+	if err != nil {
+		return nil, err
+	}
+	if owner != interactor.TxSigner.Address() {
+		return nil, fmt.Errorf("Forbidden: プラットフォームのウォレット %s は %s のオーナーではありません", interactor.TxSigner.Address().Hex(), address.Hex())
+	}
+	oldValue, err := current(contract, opts)
+	if err != nil {
+		return nil, err
+	}
+	if oldValue == newValue {
+		return nil, fmt.Errorf("BadRequest: %s は既に %s です", operation, newValue)
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	audit := &domain.ContractAudit{
+		ID:              id,
+		ChainID:         int(chainID.Int64()),
+		ContractAddress: address.Hex(),
+		Operation:       operation,
+		OldValue:        oldValue,
+		NewValue:        newValue,
+		Status:          domain.ContractAuditStatusPending,
+		UserID:          authUser.UserID,
+		Wallet:          authUser.Wallet,
+		CreatedAt:       util.JapaneseNowTime(),
+	}
+
+	tx, sendErr := interactor.NonceManager.Send(ctx, interactor.TxSigner, chainID, big.NewInt(0), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return send(contract, opts)
+	})
+	if sendErr != nil {
+		audit.Status = domain.ContractAuditStatusFailed
+		audit.Error = sql.NullString{String: truncateRevertReason(sendErr.Error()), Valid: true}
+	} else {
+		audit.TxHash = sql.NullString{String: tx.Hash().Hex(), Valid: true}
+	}
+	if err := interactor.ContractAuditGateway.Create(ctx, audit); err != nil {
+		interactor.Logging.Error(fmt.Sprintf("%s on %s (tx %s) but failed to record the audit: %s", operation, address.Hex(), audit.TxHash.String, err.Error()))
+		return nil, err
+	}
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	// 送信済みの操作はチェーン上で実行されるため、リクエストが取り消されても結果を記録する
+	ctx = context.WithoutCancel(ctx)
+	waitErr := interactor.wait(ctx, audit, tx)
+	if waitErr != nil {
+		audit.Status = domain.ContractAuditStatusFailed
+		audit.Error = sql.NullString{String: truncateRevertReason(waitErr.Error()), Valid: true}
+	} else {
+		audit.Status = domain.ContractAuditStatusMined
+	}
+	if err := interactor.ContractAuditGateway.Update(ctx, audit); err != nil {
+		interactor.Logging.Error(fmt.Sprintf("%s on %s (tx %s) but failed to update the audit: %s", operation, address.Hex(), audit.TxHash.String, err.Error()))
+		return nil, err
+	}
+	if waitErr != nil {
+		return nil, waitErr
+	}
+	interactor.Logging.Info(fmt.Sprintf("%s on %s by %s: %s -> %s (tx %s)", operation, address.Hex(), authUser.Wallet, oldValue, newValue, audit.TxHash.String))
+	return contractAuditOutput(audit), nil
+}
+
+// wait はオーナー操作のトランザクションが取り込まれるまで待ち、ブロック番号を監査ログに設定する
+func (interactor *ContractAdminInteractor) wait(ctx context.Context, audit *domain.ContractAudit, tx *types.Transaction) error {
+	receipt, err := bind.WaitMined(ctx, interactor.Client, tx)
+	if err != nil {
+		return err
+	}
+	audit.BlockNumber = sql.NullInt64{Int64: receipt.BlockNumber.Int64(), Valid: true}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("%s reverted: %s", audit.Operation, revertReason(ctx, interactor.Client, tx.Hash(), receipt.BlockNumber))
+	}
+	return nil
+}
+
+// currentPaused は一時停止中かどうかを監査ログの値にする
+func currentPaused(contract *contracts.Contracts, opts *bind.CallOpts) (string, error) {
+	paused, err := contract.Paused(opts)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatBool(paused), nil
+}
+
+func contractAuditOutput(audit *domain.ContractAudit) *ports.ContractAuditOutput {
+	return &ports.ContractAuditOutput{
+		ID:              audit.ID,
+		ChainID:         audit.ChainID,
+		ContractAddress: audit.ContractAddress,
+		Operation:       audit.Operation,
+		OldValue:        audit.OldValue,
+		NewValue:        audit.NewValue,
+		TxHash:          audit.TxHash.String,
+		BlockNumber:     uint64(audit.BlockNumber.Int64),
+		Status:          audit.Status,
+		Error:           audit.Error.String,
+		UserID:          audit.UserID,
+		Wallet:          audit.Wallet,
+		CreatedAt:       audit.CreatedAt,
+	}
+}
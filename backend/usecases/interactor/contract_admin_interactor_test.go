@@ -0,0 +1,179 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/infrastructure/ethereum"
+	"nft-music/usecases/gateways/mock"
+	"nft-music/usecases/ports"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newTestSigner は使い捨ての秘密鍵で署名するプラットフォームのウォレット
+func newTestSigner(t *testing.T) *ethereum.PrivateKeySigner {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return ethereum.NewPrivateKeySigner(privateKey)
+}
+
+func TestContractAdminInteractor(t *testing.T) {
+	contract := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	signer := newTestSigner(t)
+	adminID := uuid.New()
+	admin := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: adminID, Wallet: "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50", Role: domain.RoleAdmin})
+
+	newInteractor := func(t *testing.T) (*ContractAdminInteractor, *fakeMarketBackend, *mock.MockContractAuditGateway) {
+		backend := &fakeMarketBackend{
+			owner:        signer.Address(),
+			listingPrice: big.NewInt(1000),
+			royaltyBps:   big.NewInt(500),
+			txs:          map[common.Hash]*types.Transaction{},
+			receipts:     map[common.Hash]*types.Receipt{},
+		}
+		marketplace := NewMarketplaceContract()
+		bound, err := contracts.NewContracts(contract, backend)
+		require.NoError(t, err)
+		marketplace.Set(contract, bound)
+
+		chainRegistry, _ := newTestChainRegistry(t)
+		auditGateway := mock.NewMockContractAuditGateway(gomock.NewController(t))
+		return NewContractAdminInteractor(auditGateway, backend, signer, NewNonceManager(backend, nil), chainRegistry, marketplace, &NullLogging{}), backend, auditGateway
+	}
+	// recorded は取り込みを待つ前に pending で記録し、取り込まれた後に結果を更新した監査ログを返す
+	recorded := func(auditGateway *mock.MockContractAuditGateway) *domain.ContractAudit {
+		audit := &domain.ContractAudit{}
+		auditGateway.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, created *domain.ContractAudit) error {
+			assert.Equal(t, domain.ContractAuditStatusPending, created.Status)
+			assert.True(t, created.TxHash.Valid)
+			*audit = *created
+			return nil
+		})
+		auditGateway.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, updated *domain.ContractAudit) error {
+			assert.Equal(t, audit.ID, updated.ID)
+			*audit = *updated
+			return nil
+		})
+		return audit
+	}
+
+	t.Run("正常系: コントラクトの設定を読み取る", func(t *testing.T) {
+		interactor, _, _ := newInteractor(t)
+
+		output, err := interactor.State(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, signer.Address().Hex(), output.Owner)
+		assert.False(t, output.Paused)
+		assert.Equal(t, int64(500), output.RoyaltyFeeBps)
+		assert.Equal(t, "1000", output.ListingPrice.String())
+	})
+
+	t.Run("正常系: ミント料を変更して変更前後の値とトランザクションを記録する", func(t *testing.T) {
+		interactor, backend, auditGateway := newInteractor(t)
+		audit := recorded(auditGateway)
+
+		output, err := interactor.UpdateListingPrice(admin, &ports.ListingPriceInput{ListingPrice: "2000 wei"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContractAuditStatusMined, output.Status)
+		assert.Equal(t, domain.ContractOperationUpdateListingPrice, audit.Operation)
+		assert.Equal(t, "1000", audit.OldValue)
+		assert.Equal(t, "2000", audit.NewValue)
+		assert.Equal(t, adminID, audit.UserID)
+		assert.Equal(t, contract.Hex(), audit.ContractAddress)
+		require.Len(t, backend.txs, 1)
+		assert.Equal(t, common.HexToHash(output.TxHash), common.HexToHash(audit.TxHash.String))
+		assert.Contains(t, backend.txs, common.HexToHash(output.TxHash))
+	})
+
+	t.Run("正常系: 一時停止する", func(t *testing.T) {
+		interactor, _, auditGateway := newInteractor(t)
+		audit := recorded(auditGateway)
+
+		_, err := interactor.Pause(admin)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContractOperationPause, audit.Operation)
+		assert.Equal(t, "false", audit.OldValue)
+		assert.Equal(t, "true", audit.NewValue)
+	})
+
+	t.Run("異常系: revertした場合もfailedとして記録する", func(t *testing.T) {
+		interactor, backend, auditGateway := newInteractor(t)
+		backend.revertSent = true
+		audit := recorded(auditGateway)
+
+		_, err := interactor.UpdateRoyaltyFee(admin, &ports.RoyaltyFeeInput{RoyaltyFeeBps: 700})
+		assert.ErrorContains(t, err, "reverted")
+		assert.Equal(t, domain.ContractAuditStatusFailed, audit.Status)
+		assert.Equal(t, "500", audit.OldValue)
+		assert.Equal(t, "700", audit.NewValue)
+		assert.True(t, audit.TxHash.Valid)
+		assert.True(t, audit.Error.Valid)
+	})
+
+	t.Run("正常系: 送信した後にリクエストが取り消されても結果を記録する", func(t *testing.T) {
+		interactor, _, auditGateway := newInteractor(t)
+		ctx, cancel := context.WithCancel(admin)
+		defer cancel()
+		auditGateway.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, created *domain.ContractAudit) error {
+			cancel()
+			return nil
+		})
+		auditGateway.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, updated *domain.ContractAudit) error {
+			assert.NoError(t, ctx.Err())
+			assert.Equal(t, domain.ContractAuditStatusMined, updated.Status)
+			assert.True(t, updated.BlockNumber.Valid)
+			return nil
+		})
+
+		_, err := interactor.Pause(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("異常系: 値が変わらない操作は送信しない", func(t *testing.T) {
+		interactor, backend, _ := newInteractor(t)
+		backend.paused = true
+
+		_, err := interactor.Pause(admin)
+		assert.ErrorContains(t, err, "BadRequest")
+		assert.Empty(t, backend.txs)
+	})
+
+	t.Run("異常系: プラットフォームのウォレットがオーナーでない場合は送信しない", func(t *testing.T) {
+		interactor, backend, _ := newInteractor(t)
+		backend.owner = common.HexToAddress("0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0")
+
+		_, err := interactor.UpdateRoyaltyFee(admin, &ports.RoyaltyFeeInput{RoyaltyFeeBps: 700})
+		assert.ErrorContains(t, err, "Forbidden")
+		assert.Empty(t, backend.txs)
+	})
+
+	t.Run("異常系: 所有権の放棄はコントラクトアドレスの確認が必要", func(t *testing.T) {
+		interactor, backend, _ := newInteractor(t)
+
+		_, err := interactor.RenounceOwnership(admin, &ports.RenounceOwnershipInput{Confirm: "yes"})
+		assert.ErrorContains(t, err, "BadRequest")
+		assert.Empty(t, backend.txs)
+	})
+
+	t.Run("異常系: 管理者以外は操作できない", func(t *testing.T) {
+		interactor, backend, _ := newInteractor(t)
+		creator := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: uuid.New(), Role: domain.RoleCreator})
+
+		_, err := interactor.Unpause(creator)
+		assert.ErrorContains(t, err, "Forbidden")
+		_, err = interactor.ListAudits(creator, 10)
+		assert.ErrorContains(t, err, "Forbidden")
+		assert.Empty(t, backend.txs)
+	})
+}
@@ -0,0 +1,350 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/usecases/gateways"
+	"nft-music/usecases/logging"
+	"nft-music/util"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+)
+
+// IndexerBackend はログの取得とブロックの確認に使うノードの機能（*ethclient.Client が実装している）
+type IndexerBackend interface {
+	bind.ContractFilterer
+	ChainID(ctx context.Context) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+}
+
+// EventIndexerConfig はインデクサーの設定
+type EventIndexerConfig struct {
+	Confirmations uint64        // これより新しいブロックは未確定として扱い、reorgの際に取り込み直す
+	BatchSize     uint64        // 1回のeth_getLogsで取得するブロック数
+	PollInterval  time.Duration // 新しいブロックを確認する間隔
+}
+
+// EventIndexer はマーケットプレイスのコントラクトのログをチェックポイントから順に取り込む
+//
+//	MarketItemCreated          → market_events（mint）
+//	MarketItemSold             → market_events（sale）
+//	所有者からコントラクトへのTransfer → market_events（resale）
+//	Transfer                   → token_transfers
+type EventIndexer struct {
+	MarketEventGateway gateways.MarketEventGateway
+	DeploymentGateway  gateways.DeploymentGateway
+	Client             IndexerBackend
+	Marketplace        *MarketplaceContract
+	Config             EventIndexerConfig
+	Logging            logging.Logging
+}
+
+func NewEventIndexer(marketEventGateway gateways.MarketEventGateway, deploymentGateway gateways.DeploymentGateway, client IndexerBackend, marketplace *MarketplaceContract, config EventIndexerConfig, logging logging.Logging) *EventIndexer {
+	if config.BatchSize == 0 {
+		config.BatchSize = 1000
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = 5 * time.Second
+	}
+	return &EventIndexer{
+		MarketEventGateway: marketEventGateway,
+		DeploymentGateway:  deploymentGateway,
+		Client:             client,
+		Marketplace:        marketplace,
+		Config:             config,
+		Logging:            logging,
+	}
+}
+
+// indexTarget は取り込み対象のコントラクト
+type indexTarget struct {
+	chainID  int
+	address  common.Address
+	filterer *contracts.ContractsFilterer
+}
+
+// Run は PollInterval ごとに Sync を呼び出す（ctx が終了するまで戻らない）
+func (indexer *EventIndexer) Run(ctx context.Context) {
+	ticker := time.NewTicker(indexer.Config.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := indexer.Sync(ctx); err != nil {
+			indexer.Logging.Warning(fmt.Sprintf("failed to index market events: %s", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync はチェックポイントの次のブロックから最新のブロックまでのイベントを取り込む
+func (indexer *EventIndexer) Sync(ctx context.Context) error {
+	target, err := indexer.target(ctx)
+	if err != nil {
+		return err
+	}
+
+	checkpoint, err := indexer.MarketEventGateway.GetCheckpoint(ctx, target.chainID, target.address.Hex())
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil {
+		checkpoint = &domain.IndexerCheckpoint{
+			ChainID:         target.chainID,
+			ContractAddress: target.address.Hex(),
+			BlockNumber:     indexer.startBlock(ctx, target) - 1,
+		}
+	} else if checkpoint, err = indexer.rollbackOnReorg(ctx, checkpoint); err != nil {
+		return err
+	}
+
+	head, err := indexer.Client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	return indexer.index(ctx, target, checkpoint, head)
+}
+
+// Backfill は fromBlock 以降に取り込んだイベントを削除し、最新のブロックまで取り込み直す
+func (indexer *EventIndexer) Backfill(ctx context.Context, fromBlock uint64) error {
+	if fromBlock == 0 {
+		return errors.New("BadRequest: fromBlock は1以上を指定してください")
+	}
+	target, err := indexer.target(ctx)
+	if err != nil {
+		return err
+	}
+
+	checkpoint, err := indexer.checkpointAt(ctx, target, fromBlock-1)
+	if err != nil {
+		return err
+	}
+	if err := indexer.MarketEventGateway.Rollback(ctx, checkpoint); err != nil {
+		return err
+	}
+
+	head, err := indexer.Client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	return indexer.index(ctx, target, checkpoint, head)
+}
+
+func (indexer *EventIndexer) target(ctx context.Context) (*indexTarget, error) {
+	_, address, err := indexer.Marketplace.Get()
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := indexer.Client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filterer, err := contracts.NewContractsFilterer(address, indexer.Client)
+	if err != nil {
+		return nil, err
+	}
+	return &indexTarget{chainID: int(chainID.Int64()), address: address, filterer: filterer}, nil
+}
+
+// startBlock は初めて取り込む場合の開始ブロック（登録簿にあるデプロイしたブロック）
+func (indexer *EventIndexer) startBlock(ctx context.Context, target *indexTarget) uint64 {
+	deployment, err := indexer.DeploymentGateway.Latest(ctx, target.chainID, domain.ContractNFTMarketplace)
+	if err != nil || common.HexToAddress(deployment.Address) != target.address || deployment.BlockNumber == 0 {
+		return 1
+	}
+	return deployment.BlockNumber
+}
+
+func (indexer *EventIndexer) checkpointAt(ctx context.Context, target *indexTarget, blockNumber uint64) (*domain.IndexerCheckpoint, error) {
+	header, err := indexer.Client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	return &domain.IndexerCheckpoint{
+		ChainID:         target.chainID,
+		ContractAddress: target.address.Hex(),
+		BlockNumber:     blockNumber,
+		BlockHash:       header.Hash().Hex(),
+		UpdatedAt:       util.JapaneseNowTime(),
+	}, nil
+}
+
+// rollbackOnReorg はチェックポイントのブロックがチェーンから外れていた場合、未確定のブロックのイベントを削除する
+// Confirmations が0でも、チェーンから外れたチェックポイントのブロックは取り込み直す
+func (indexer *EventIndexer) rollbackOnReorg(ctx context.Context, checkpoint *domain.IndexerCheckpoint) (*domain.IndexerCheckpoint, error) {
+	header, err := indexer.Client.HeaderByNumber(ctx, new(big.Int).SetUint64(checkpoint.BlockNumber))
+	if err != nil {
+		return nil, err
+	}
+	if header.Hash().Hex() == checkpoint.BlockHash {
+		return checkpoint, nil
+	}
+
+	target := &indexTarget{chainID: checkpoint.ChainID, address: common.HexToAddress(checkpoint.ContractAddress)}
+	rollbackTo := checkpoint.BlockNumber - min(max(indexer.Config.Confirmations, 1), checkpoint.BlockNumber)
+	rolledBack, err := indexer.checkpointAt(ctx, target, rollbackTo)
+	if err != nil {
+		return nil, err
+	}
+	if err := indexer.MarketEventGateway.Rollback(ctx, rolledBack); err != nil {
+		return nil, err
+	}
+	indexer.Logging.Warning(fmt.Sprintf("reorg detected at block %d, rolled back market events to block %d", checkpoint.BlockNumber, rollbackTo))
+	return rolledBack, nil
+}
+
+// index はチェックポイントの次のブロックから head までを BatchSize ずつ取り込む
+func (indexer *EventIndexer) index(ctx context.Context, target *indexTarget, checkpoint *domain.IndexerCheckpoint, head uint64) error {
+	for checkpoint.BlockNumber < head {
+		from := checkpoint.BlockNumber + 1
+		to := min(from+indexer.Config.BatchSize-1, head)
+
+		events, transfers, err := indexer.collect(ctx, target, from, to)
+		if err != nil {
+			return err
+		}
+		next, err := indexer.checkpointAt(ctx, target, to)
+		if err != nil {
+			return err
+		}
+		if err := indexer.MarketEventGateway.Save(ctx, events, transfers, next); err != nil {
+			return err
+		}
+		checkpoint = next
+	}
+	return nil
+}
+
+// collect はブロック範囲のログをイベントとTransferに変換する
+func (indexer *EventIndexer) collect(ctx context.Context, target *indexTarget, from uint64, to uint64) ([]domain.MarketEvent, []domain.TokenTransfer, error) {
+	opts := &bind.FilterOpts{Start: from, End: &to, Context: ctx}
+	var events []domain.MarketEvent
+	var transfers []domain.TokenTransfer
+	minted := map[common.Hash]bool{}
+
+	created, err := target.filterer.FilterMarketItemCreated(opts, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer created.Close()
+	for created.Next() {
+		event := created.Event
+		minted[event.Raw.TxHash] = true
+		events = append(events, newMarketEvent(target, event.Raw, domain.MarketEventMint, event.TokenId, event.Seller, common.Address{}, event.Price))
+	}
+	if err := created.Error(); err != nil {
+		return nil, nil, err
+	}
+
+	sold, err := target.filterer.FilterMarketItemSold(opts, nil, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer sold.Close()
+	for sold.Next() {
+		event := sold.Event
+		events = append(events, newMarketEvent(target, event.Raw, domain.MarketEventSale, event.TokenId, event.Seller, event.Buyer, event.Price))
+	}
+	if err := sold.Error(); err != nil {
+		return nil, nil, err
+	}
+
+	transferred, err := target.filterer.FilterTransfer(opts, nil, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer transferred.Close()
+	for transferred.Next() {
+		event := transferred.Event
+		transfers = append(transfers, domain.TokenTransfer{
+			ID:              uuid.Must(uuid.NewV7()),
+			ChainID:         target.chainID,
+			ContractAddress: target.address.Hex(),
+			BlockNumber:     event.Raw.BlockNumber,
+			BlockHash:       event.Raw.BlockHash.Hex(),
+			TxHash:          event.Raw.TxHash.Hex(),
+			LogIndex:        event.Raw.Index,
+			TokenID:         event.TokenId.String(),
+			FromAddress:     event.From.Hex(),
+			ToAddress:       event.To.Hex(),
+			CreatedAt:       util.JapaneseNowTime(),
+		})
+
+		// resellToken はイベントを出さないため、ミント以外でコントラクトに預けたTransferを再出品とする
+		if event.To != target.address || event.From == (common.Address{}) || minted[event.Raw.TxHash] {
+			continue
+		}
+		price := indexer.resalePrice(ctx, event.Raw.TxHash)
+		events = append(events, newMarketEvent(target, event.Raw, domain.MarketEventResale, event.TokenId, event.From, common.Address{}, price))
+	}
+	if err := transferred.Error(); err != nil {
+		return nil, nil, err
+	}
+
+	return events, transfers, nil
+}
+
+// resalePrice は resellToken の呼び出しデータから出品価格を取り出す（取得できない場合は nil）
+func (indexer *EventIndexer) resalePrice(ctx context.Context, hash common.Hash) *big.Int {
+	tx, _, err := indexer.Client.TransactionByHash(ctx, hash)
+	if err != nil {
+		indexer.Logging.Warning(fmt.Sprintf("failed to get resale transaction %s: %s", hash.Hex(), err.Error()))
+		return nil
+	}
+	parsed, err := contracts.ContractsMetaData.GetAbi()
+	if err != nil {
+		return nil
+	}
+	method := parsed.Methods["resellToken"]
+	data := tx.Data()
+	if len(data) < 4 || !bytes.Equal(data[:4], method.ID) {
+		return nil
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil || len(args) != 2 {
+		return nil
+	}
+	price, _ := args[1].(*big.Int)
+	return price
+}
+
+func newMarketEvent(target *indexTarget, raw types.Log, eventType string, tokenID *big.Int, seller common.Address, buyer common.Address, price *big.Int) domain.MarketEvent {
+	event := domain.MarketEvent{
+		ID:              uuid.Must(uuid.NewV7()),
+		ChainID:         target.chainID,
+		ContractAddress: target.address.Hex(),
+		BlockNumber:     raw.BlockNumber,
+		BlockHash:       raw.BlockHash.Hex(),
+		TxHash:          raw.TxHash.Hex(),
+		LogIndex:        raw.Index,
+		EventType:       eventType,
+		TokenID:         tokenID.String(),
+		CreatedAt:       util.JapaneseNowTime(),
+	}
+	if seller != (common.Address{}) {
+		event.Seller = seller.Hex()
+	}
+	if buyer != (common.Address{}) {
+		event.Buyer = buyer.Hex()
+	}
+	if price != nil {
+		amount := domain.NewAmount(price)
+		event.Price = &amount
+	}
+	return event
+}
@@ -0,0 +1,282 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/usecases/gateways/mock"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeIndexerBackend はブロックごとのログを返すテスト用のノード
+// fork を書き換えるとそのブロックのハッシュが変わり、reorgを再現できる
+type fakeIndexerBackend struct {
+	head uint64
+	fork map[uint64]byte
+	logs []types.Log
+	txs  map[common.Hash]*types.Transaction
+}
+
+func newFakeIndexerBackend() *fakeIndexerBackend {
+	return &fakeIndexerBackend{
+		fork: map[uint64]byte{},
+		txs:  map[common.Hash]*types.Transaction{},
+	}
+}
+
+func (fake *fakeIndexerBackend) header(number uint64) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(number), Extra: []byte{fake.fork[number]}}
+}
+
+func (fake *fakeIndexerBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	for _, log := range fake.logs {
+		if log.BlockNumber < query.FromBlock.Uint64() || log.BlockNumber > query.ToBlock.Uint64() {
+			continue
+		}
+		if !slices.Contains(query.Addresses, log.Address) {
+			continue
+		}
+		if len(query.Topics) > 0 && len(query.Topics[0]) > 0 && !slices.Contains(query.Topics[0], log.Topics[0]) {
+			continue
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
+
+func (fake *fakeIndexerBackend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func (fake *fakeIndexerBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1337), nil
+}
+
+func (fake *fakeIndexerBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	return fake.head, nil
+}
+
+func (fake *fakeIndexerBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return fake.header(number.Uint64()), nil
+}
+
+func (fake *fakeIndexerBackend) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	tx, ok := fake.txs[hash]
+	if !ok {
+		return nil, false, ethereum.NotFound
+	}
+	return tx, false, nil
+}
+
+// emit はコントラクトのイベントをブロックに追加する
+func (fake *fakeIndexerBackend) emit(t *testing.T, contract common.Address, blockNumber uint64, txHash common.Hash, name string, args ...any) {
+	log := marketLog(t, contract, name, args...)
+	log.BlockNumber = blockNumber
+	log.BlockHash = fake.header(blockNumber).Hash()
+	log.TxHash = txHash
+	log.Index = uint(len(fake.logs))
+	fake.logs = append(fake.logs, log)
+}
+
+// marketLog はマーケットプレイスのコントラクトのイベントのログを作成する
+func marketLog(t *testing.T, contract common.Address, name string, args ...any) types.Log {
+	parsed, err := contracts.ContractsMetaData.GetAbi()
+	require.NoError(t, err)
+	event := parsed.Events[name]
+
+	topics := []common.Hash{event.ID}
+	var data []any
+	for i, input := range event.Inputs {
+		if !input.Indexed {
+			data = append(data, args[i])
+			continue
+		}
+		switch value := args[i].(type) {
+		case common.Address:
+			topics = append(topics, common.BytesToHash(value.Bytes()))
+		case *big.Int:
+			topics = append(topics, common.BigToHash(value))
+		}
+	}
+	packed, err := event.Inputs.NonIndexed().Pack(data...)
+	require.NoError(t, err)
+
+	return types.Log{Address: contract, Topics: topics, Data: packed}
+}
+
+// fakeMarketEventGateway はメモリ上にイベントを保存するテスト用のリポジトリ
+type fakeMarketEventGateway struct {
+	checkpoint *domain.IndexerCheckpoint
+	events     []domain.MarketEvent
+	transfers  []domain.TokenTransfer
+}
+
+func (fake *fakeMarketEventGateway) GetCheckpoint(ctx context.Context, chainID int, contractAddress string) (*domain.IndexerCheckpoint, error) {
+	return fake.checkpoint, nil
+}
+
+func (fake *fakeMarketEventGateway) Save(ctx context.Context, events []domain.MarketEvent, transfers []domain.TokenTransfer, checkpoint *domain.IndexerCheckpoint) error {
+	for _, event := range events {
+		if !slices.ContainsFunc(fake.events, func(saved domain.MarketEvent) bool {
+			return saved.TxHash == event.TxHash && saved.LogIndex == event.LogIndex
+		}) {
+			fake.events = append(fake.events, event)
+		}
+	}
+	fake.transfers = append(fake.transfers, transfers...)
+	fake.checkpoint = checkpoint
+	return nil
+}
+
+func (fake *fakeMarketEventGateway) Rollback(ctx context.Context, checkpoint *domain.IndexerCheckpoint) error {
+	fake.events = slices.DeleteFunc(fake.events, func(event domain.MarketEvent) bool { return event.BlockNumber > checkpoint.BlockNumber })
+	fake.transfers = slices.DeleteFunc(fake.transfers, func(transfer domain.TokenTransfer) bool { return transfer.BlockNumber > checkpoint.BlockNumber })
+	fake.checkpoint = checkpoint
+	return nil
+}
+
+func (fake *fakeMarketEventGateway) ListOwners(ctx context.Context, chainID int, contractAddress string) (map[string]string, error) {
+	owners := map[string]string{}
+	for _, transfer := range fake.transfers {
+		owners[transfer.TokenID] = transfer.ToAddress
+	}
+	return owners, nil
+}
+
+func (fake *fakeMarketEventGateway) eventTypes() []string {
+	var eventTypes []string
+	for _, event := range fake.events {
+		eventTypes = append(eventTypes, event.EventType)
+	}
+	return eventTypes
+}
+
+func TestEventIndexer(t *testing.T) {
+	ctx := context.Background()
+	contract := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	creator := common.HexToAddress("0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50")
+	buyer := common.HexToAddress("0xc5309Ef694C81C4a8e946F2810e09516436daeB5")
+	tokenID := big.NewInt(1)
+
+	// resellToken(1, 250) の呼び出し
+	parsed, err := contracts.ContractsMetaData.GetAbi()
+	require.NoError(t, err)
+	resellData, err := parsed.Pack("resellToken", tokenID, big.NewInt(250))
+	require.NoError(t, err)
+	resellTx := types.NewTx(&types.LegacyTx{To: &contract, Data: resellData})
+
+	newIndexer := func(t *testing.T) (*EventIndexer, *fakeIndexerBackend, *fakeMarketEventGateway) {
+		backend := newFakeIndexerBackend()
+		mintTx := common.HexToHash("0x01")
+		backend.emit(t, contract, 3, mintTx, "Transfer", common.Address{}, creator, tokenID)
+		backend.emit(t, contract, 3, mintTx, "Transfer", creator, contract, tokenID)
+		backend.emit(t, contract, 3, mintTx, "MarketItemCreated", tokenID, creator, contract, big.NewInt(100), false, creator)
+		saleTx := common.HexToHash("0x02")
+		backend.emit(t, contract, 5, saleTx, "MarketItemSold", tokenID, creator, buyer, big.NewInt(100))
+		backend.emit(t, contract, 5, saleTx, "Transfer", contract, buyer, tokenID)
+		backend.txs[resellTx.Hash()] = resellTx
+		backend.emit(t, contract, 7, resellTx.Hash(), "Transfer", buyer, contract, tokenID)
+		backend.head = 8
+
+		ctrl := gomock.NewController(t)
+		deploymentGateway := mock.NewMockDeploymentGateway(ctrl)
+		deploymentGateway.EXPECT().Latest(gomock.Any(), 1337, domain.ContractNFTMarketplace).
+			Return(&domain.Deployment{Address: contract.Hex(), BlockNumber: 2}, nil).AnyTimes()
+
+		marketplace := NewMarketplaceContract()
+		bound, err := contracts.NewContracts(contract, nil)
+		require.NoError(t, err)
+		marketplace.Set(contract, bound)
+
+		gateway := &fakeMarketEventGateway{}
+		indexer := NewEventIndexer(gateway, deploymentGateway, backend, marketplace, EventIndexerConfig{Confirmations: 3, BatchSize: 4}, &NullLogging{})
+		return indexer, backend, gateway
+	}
+
+	t.Run("正常系: ミント・販売・再出品とTransferを取り込む", func(t *testing.T) {
+		indexer, backend, gateway := newIndexer(t)
+
+		require.NoError(t, indexer.Sync(ctx))
+
+		assert.ElementsMatch(t, []string{domain.MarketEventMint, domain.MarketEventSale, domain.MarketEventResale}, gateway.eventTypes())
+		assert.Len(t, gateway.transfers, 4)
+		for _, event := range gateway.events {
+			switch event.EventType {
+			case domain.MarketEventSale:
+				assert.Equal(t, creator.Hex(), event.Seller)
+				assert.Equal(t, buyer.Hex(), event.Buyer)
+				assert.Equal(t, "100", event.Price.String())
+			case domain.MarketEventResale:
+				assert.Equal(t, buyer.Hex(), event.Seller)
+				assert.Equal(t, "250", event.Price.String())
+			}
+		}
+		assert.Equal(t, uint64(8), gateway.checkpoint.BlockNumber)
+		assert.Equal(t, backend.header(8).Hash().Hex(), gateway.checkpoint.BlockHash)
+	})
+
+	t.Run("正常系: reorgした場合は未確定のブロックを取り込み直す", func(t *testing.T) {
+		indexer, backend, gateway := newIndexer(t)
+		require.NoError(t, indexer.Sync(ctx))
+
+		// ブロック7以降が別のチェーンに置き換わり、再出品が無かったことになる
+		backend.fork[7], backend.fork[8] = 1, 1
+		backend.logs = slices.DeleteFunc(backend.logs, func(log types.Log) bool { return log.BlockNumber == 7 })
+		backend.head = 9
+
+		require.NoError(t, indexer.Sync(ctx))
+
+		assert.ElementsMatch(t, []string{domain.MarketEventMint, domain.MarketEventSale}, gateway.eventTypes())
+		assert.Len(t, gateway.transfers, 3)
+		assert.Equal(t, uint64(9), gateway.checkpoint.BlockNumber)
+	})
+
+	t.Run("正常系: Confirmationsが0でもチェーンから外れたチェックポイントのブロックは取り込み直す", func(t *testing.T) {
+		indexer, backend, gateway := newIndexer(t)
+		indexer.Config.Confirmations = 0
+		backend.head = 7
+		require.NoError(t, indexer.Sync(ctx))
+		require.Contains(t, gateway.eventTypes(), domain.MarketEventResale)
+
+		// ブロック7が別のチェーンに置き換わり、再出品が無かったことになる
+		backend.fork[7] = 1
+		backend.logs = slices.DeleteFunc(backend.logs, func(log types.Log) bool { return log.BlockNumber == 7 })
+		backend.head = 8
+
+		require.NoError(t, indexer.Sync(ctx))
+
+		assert.ElementsMatch(t, []string{domain.MarketEventMint, domain.MarketEventSale}, gateway.eventTypes())
+		assert.Len(t, gateway.transfers, 3)
+		assert.Equal(t, uint64(8), gateway.checkpoint.BlockNumber)
+		assert.Equal(t, backend.header(8).Hash().Hex(), gateway.checkpoint.BlockHash)
+	})
+
+	t.Run("正常系: 指定したブロックから取り込み直す", func(t *testing.T) {
+		indexer, _, gateway := newIndexer(t)
+		require.NoError(t, indexer.Sync(ctx))
+
+		require.NoError(t, indexer.Backfill(ctx, 4))
+
+		assert.Len(t, gateway.events, 3)
+		assert.Len(t, gateway.transfers, 4)
+		assert.Equal(t, uint64(8), gateway.checkpoint.BlockNumber)
+	})
+
+	t.Run("異常系: 0ブロック目からは取り込み直せない", func(t *testing.T) {
+		indexer, _, _ := newIndexer(t)
+		assert.ErrorContains(t, indexer.Backfill(ctx, 0), "BadRequest")
+	})
+}
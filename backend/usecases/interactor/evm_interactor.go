@@ -3,97 +3,200 @@ package interactor
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
-	"log"
 	"math/big"
-	"os"
 
 	"nft-music/contracts"
 	"nft-music/domain"
+	"nft-music/usecases/gateways"
 	"nft-music/usecases/logging"
 	"nft-music/usecases/ports"
+	"nft-music/util"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/google/uuid"
 )
 
 type EvmInteractor struct {
-	Logging logging.Logging
+	EtherClient       *ethclient.Client
+	TxSigner          gateways.Signer
+	NonceManager      *NonceManager
+	DeploymentGateway gateways.DeploymentGateway
+	ChainRegistry     *ChainRegistry
+	Marketplace       *MarketplaceContract
+	Logging           logging.Logging
 }
 
-func NewEvmInteractor(logging logging.Logging) *EvmInteractor {
+func NewEvmInteractor(ethClient *ethclient.Client, signer gateways.Signer, nonceManager *NonceManager, deploymentGateway gateways.DeploymentGateway, chainRegistry *ChainRegistry, marketplace *MarketplaceContract, logging logging.Logging) *EvmInteractor {
 	return &EvmInteractor{
-		Logging: logging,
+		EtherClient:       ethClient,
+		TxSigner:          signer,
+		NonceManager:      nonceManager,
+		DeploymentGateway: deploymentGateway,
+		ChainRegistry:     chainRegistry,
+		Marketplace:       marketplace,
+		Logging:           logging,
 	}
 }
 
-func (interactor *EvmInteractor) Signer(ctx context.Context) (*ports.SignerOutput, error) {
-	// 環境変数からGanacheのURLを取得。なければデフォルト値を使用
-	ganacheURL := os.Getenv("GANACHE_URL")
-	if ganacheURL == "" {
-		ganacheURL = "http://ganache:8545" // GanacheのデフォルトRPCサーバー
+// BindDeployed はチェーンの登録簿で指定したコントラクトに接続する（起動時に呼び出す）
+// 指定が無い場合はデプロイの登録簿にある最新のコントラクトに接続する
+func (interactor *EvmInteractor) BindDeployed(ctx context.Context) error {
+	chainID, err := interactor.EtherClient.ChainID(ctx)
+	if err != nil {
+		return err
+	}
+
+	var deployment *domain.Deployment
+	if pinned := interactor.ChainRegistry.Active().MarketplaceAddress; pinned.Valid {
+		deployment, err = interactor.pinnedDeployment(ctx, int(chainID.Int64()), pinned.String)
+	} else {
+		deployment, err = interactor.DeploymentGateway.Latest(ctx, int(chainID.Int64()), domain.ContractNFTMarketplace)
 	}
-	client, err := ethclient.Dial(ganacheURL)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("no %s deployment for chain %d: %w", domain.ContractNFTMarketplace, chainID, err)
 	}
 
-	privateKey, err := crypto.HexToECDSA("01a3c379a8b07fb8f6ae7735fd7de35c5156c1cb8c3ac76a9d5157e3eedd2c4a")
+	// 登録簿のアドレスにコントラクトが存在しない場合（チェーンのリセットなど）は接続しない
+	address := common.HexToAddress(deployment.Address)
+	code, err := interactor.EtherClient.CodeAt(ctx, address, nil)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("no contract code at %s on chain %d", deployment.Address, chainID)
 	}
 
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		log.Fatal("cannot assert type: publicKey is not of type *ecdsa.PublicKey")
+	if deployment.AbiVersion != MarketplaceAbiVersion {
+		interactor.Logging.Warning(fmt.Sprintf("ABI version of %s (%s) differs from the current binding (%s)", deployment.Address, deployment.AbiVersion, MarketplaceAbiVersion))
 	}
 
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-	nonce, err := client.PendingNonceAt(ctx, fromAddress)
+	contract, err := contracts.NewContracts(address, interactor.EtherClient)
 	if err != nil {
+		return err
+	}
+	interactor.Marketplace.Set(address, contract)
+	interactor.Logging.Info(fmt.Sprintf("bound to %s at %s (chain %d)", domain.ContractNFTMarketplace, deployment.Address, chainID))
+	return nil
+}
+
+// Deploy はマーケットプレイスのコントラクトをデプロイして登録簿に記録し、接続先を切り替える（管理者のみ）
+func (interactor *EvmInteractor) Deploy(ctx context.Context, input *ports.DeploymentInput) (*ports.DeploymentOutput, error) {
+	if err := authorize(ctx, ActionDeployContract); err != nil {
 		return nil, err
 	}
 
-	gasPrice := big.NewInt(20000000000) // 20 Gwei
+	listingPrice, err := interactor.ChainRegistry.Active().ParsePrice(input.ListingPrice)
+	if err != nil {
+		return nil, err
+	}
 
+	client := interactor.EtherClient
 	chainID, err := client.ChainID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	var address common.Address
+	var contract *contracts.Contracts
+	tx, err := interactor.NonceManager.Send(ctx, interactor.TxSigner, chainID, big.NewInt(0), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		var tx *types.Transaction
+		var err error
+		address, tx, contract, err = contracts.DeployContracts(opts, client, input.Name, input.Symbol, listingPrice.Wei(), big.NewInt(input.RoyaltyFeeBps))
+		return tx, err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = big.NewInt(0)      // in wei
-	auth.GasLimit = uint64(3000000) // in units
-	auth.GasPrice = gasPrice
-	name := "test"
-	symbol := "tst"
-	initialListingPrice := big.NewInt(domain.OneEther)
-	initialRoyaltyFeeBps := big.NewInt(1000)
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != 1 {
+		return nil, fmt.Errorf("deploy transaction %s reverted", tx.Hash().Hex())
+	}
 
-	address, tx, instance, err := contracts.DeployContracts(auth, client, name, symbol, initialListingPrice, initialRoyaltyFeeBps)
+	id, err := uuid.NewV7()
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+	deployment := &domain.Deployment{
+		ID:           id,
+		ChainID:      int(chainID.Int64()),
+		ContractName: domain.ContractNFTMarketplace,
+		Address:      address.Hex(),
+		DeployTx:     tx.Hash().Hex(),
+		BlockNumber:  receipt.BlockNumber.Uint64(),
+		AbiVersion:   MarketplaceAbiVersion,
+		CreatedAt:    util.JapaneseNowTime(),
+	}
+	if authUser, ok := ports.AuthUserFrom(ctx); ok {
+		deployment.DeployedBy = uuid.NullUUID{UUID: authUser.UserID, Valid: true}
 	}
+	if err := interactor.DeploymentGateway.Create(ctx, deployment); err != nil {
+		interactor.Logging.Error(fmt.Sprintf("deployed %s but failed to record it: %s", address.Hex(), err.Error()))
+		return nil, err
+	}
+
+	if err := interactor.ChainRegistry.SetMarketplace(ctx, address.Hex()); err != nil {
+		// 登録簿に前のアドレスが残っていると次回の起動で前のコントラクトに接続するため、手動で更新する
+		interactor.Logging.Error(fmt.Sprintf("deployed %s but failed to update the chain registry: %s", address.Hex(), err.Error()))
+	}
+
+	interactor.Marketplace.Set(address, contract)
+	return deploymentOutput(deployment, true), nil
+}
+
+// pinnedDeployment はチェーンの登録簿で指定したアドレスのデプロイを探す
+// 外部でデプロイしたコントラクトはデプロイの登録簿に無いため、ABIのバージョンを確認できない
+func (interactor *EvmInteractor) pinnedDeployment(ctx context.Context, chainID int, address string) (*domain.Deployment, error) {
+	deployments, err := interactor.DeploymentGateway.List(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range deployments {
+		if util.SameAddress(deployments[i].Address, address) {
+			return &deployments[i], nil
+		}
+	}
+	return &domain.Deployment{Address: address, AbiVersion: MarketplaceAbiVersion}, nil
+}
 
-	fmt.Printf("instance: %v\n", instance)
-	signerOutput := ports.SignerOutput{
-		AddressHex:      address.Hex(),
-		TransactionHash: tx.Hash().Hex(),
+// ListDeployments は接続中のチェーンにデプロイしたコントラクトの一覧を取得する
+func (interactor *EvmInteractor) ListDeployments(ctx context.Context) ([]ports.DeploymentOutput, error) {
+	chainID, err := interactor.EtherClient.ChainID(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	contractDial, err := contracts.NewContracts(address, client)
+	deployments, err := interactor.DeploymentGateway.List(ctx, int(chainID.Int64()))
 	if err != nil {
 		return nil, err
 	}
 
-	log.Printf("contractDial : %v", &contractDial.ContractsCaller)
-	return &signerOutput, nil
+	_, active, _ := interactor.Marketplace.Get()
+	outputs := make([]ports.DeploymentOutput, 0, len(deployments))
+	for i := range deployments {
+		isActive := common.HexToAddress(deployments[i].Address) == active
+		outputs = append(outputs, *deploymentOutput(&deployments[i], isActive))
+	}
+	return outputs, nil
+}
+
+func deploymentOutput(deployment *domain.Deployment, active bool) *ports.DeploymentOutput {
+	return &ports.DeploymentOutput{
+		ID:           deployment.ID,
+		ChainID:      deployment.ChainID,
+		ContractName: deployment.ContractName,
+		Address:      deployment.Address,
+		DeployTx:     deployment.DeployTx,
+		BlockNumber:  deployment.BlockNumber,
+		AbiVersion:   deployment.AbiVersion,
+		Active:       active,
+		CreatedAt:    deployment.CreatedAt,
+	}
 }
@@ -5,24 +5,82 @@ import (
 	"context"
 	"testing"
 
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/infrastructure/ethereum"
+	"nft-music/usecases/gateways/mock"
+	"nft-music/usecases/ports"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
-func TestEvmInteractor_Signer(t *testing.T) {
+func TestEvmInteractor_Deploy(t *testing.T) {
 	// This test requires a running Ganache instance, as defined in the docker-compose.yml file.
+	chainRegistry, chainGateway := newTestChainRegistry(t)
+	client, err := ethereum.NewEthereumVirtualMachine().DialChain(context.Background(), chainRegistry.Active())
+	require.NoError(t, err)
+
+	// docker-compose.yml のニーモニックから生成されるGanacheの先頭アカウント
+	privateKey, err := crypto.HexToECDSA("01a3c379a8b07fb8f6ae7735fd7de35c5156c1cb8c3ac76a9d5157e3eedd2c4a")
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDeploymentGateway := mock.NewMockDeploymentGateway(ctrl)
+	marketplace := NewMarketplaceContract()
+	interactor := NewEvmInteractor(client, ethereum.NewPrivateKeySigner(privateKey), NewNonceManager(client, nil), mockDeploymentGateway, chainRegistry, marketplace, &NullLogging{})
 
-	// Create an EvmInteractor
-	interactor := NewEvmInteractor(nil) // Logging is not used in the test
+	mockDeploymentGateway.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	chainGateway.EXPECT().UpdateMarketplace(gomock.Any(), 1337, gomock.Any()).Return(nil)
 
-	// Call the Signer method
-	ctx := context.Background()
-	output, err := interactor.Signer(ctx)
+	ctx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: uuid.New(), Role: domain.RoleAdmin})
+	output, err := interactor.Deploy(ctx, &ports.DeploymentInput{
+		Name:          "test",
+		Symbol:        "tst",
+		ListingPrice:  "1000000000000000 wei",
+		RoyaltyFeeBps: 1000,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, output.Address)
+	assert.NotEmpty(t, output.DeployTx)
+	assert.Equal(t, MarketplaceAbiVersion, output.AbiVersion)
 
-	// Assert that no error occurred
+	// デプロイしたコントラクトに接続先が切り替わっている
+	_, address, err := marketplace.Get()
 	assert.NoError(t, err)
+	assert.Equal(t, common.HexToAddress(output.Address), address)
+}
+
+func TestEvmInteractor_DeployForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	interactor := NewEvmInteractor(nil, nil, nil, mock.NewMockDeploymentGateway(ctrl), nil, NewMarketplaceContract(), &NullLogging{})
+
+	ctx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: uuid.New(), Role: domain.RoleCreator})
+	output, err := interactor.Deploy(ctx, &ports.DeploymentInput{Name: "test", Symbol: "tst", ListingPrice: "1 wei"})
 
-	// Assert that the returned values are not empty
-	assert.NotNil(t, output)
-	assert.NotEmpty(t, output.AddressHex)
-	assert.NotEmpty(t, output.TransactionHash)
+	assert.ErrorContains(t, err, "Forbidden")
+	assert.Nil(t, output)
+}
+
+func TestMarketplaceContract(t *testing.T) {
+	marketplace := NewMarketplaceContract()
+
+	_, _, err := marketplace.Get()
+	assert.ErrorContains(t, err, "ServiceUnavailable")
+
+	address := common.HexToAddress("0x47CD2D0873833Ba015e0C31AB94D30313eF07942")
+	contract, err := contracts.NewContracts(address, nil)
+	require.NoError(t, err)
+	marketplace.Set(address, contract)
+
+	got, gotAddress, err := marketplace.Get()
+	assert.NoError(t, err)
+	assert.Same(t, contract, got)
+	assert.Equal(t, address, gotAddress)
 }
@@ -0,0 +1,289 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ガス代の種類
+const (
+	FeeModeAuto    = "auto"    // ブロックにbaseFeeがあれば dynamic、無ければ legacy
+	FeeModeLegacy  = "legacy"  // gasPrice を指定する従来のトランザクション
+	FeeModeDynamic = "dynamic" // EIP-1559 の maxFeePerGas / maxPriorityFeePerGas を指定する
+)
+
+// FeeBackend はガス代とガスリミットの見積もりに使うノードの機能（*ethclient.Client が実装している）
+type FeeBackend interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+}
+
+// FeeConfig はガス代の設定。上限が nil の場合は制限しない
+type FeeConfig struct {
+	Mode                 string
+	GasPriceMultiplier   float64  // legacy: ノードの推奨gasPriceに掛ける倍率
+	TipMultiplier        float64  // dynamic: ノードの推奨チップに掛ける倍率
+	BaseFeeMultiplier    float64  // dynamic: 直近のbaseFeeに掛ける倍率（baseFeeの上昇に備える）
+	MaxGasPrice          *big.Int // legacy: gasPrice の上限（wei）
+	MaxPriorityFeePerGas *big.Int // dynamic: チップの上限（wei）
+	MaxFeePerGas         *big.Int // dynamic: maxFeePerGas の上限（wei）
+	GasLimitMultiplier   float64  // EstimateGas の結果に掛ける安全係数
+}
+
+// Fees はトランザクションに設定するガス代
+type Fees struct {
+	Dynamic   bool
+	BaseFee   *big.Int // dynamic のみ
+	GasPrice  *big.Int // legacy のみ
+	GasTipCap *big.Int // dynamic のみ
+	GasFeeCap *big.Int // dynamic のみ
+}
+
+// FeeEstimate はトランザクションのガス代の見積もり
+type FeeEstimate struct {
+	Fees     *Fees
+	GasLimit uint64   // 安全係数を掛けたガスリミットの合計
+	Cost     *big.Int // 現在のbaseFeeで取り込まれた場合のガス代（wei）
+	MaxCost  *big.Int // ガスリミットを使い切った場合の最大のガス代（wei）
+}
+
+// FeePolicy はプラットフォームのウォレットから送信するトランザクションのガス代とガスリミットを決める
+type FeePolicy struct {
+	client FeeBackend
+	config FeeConfig
+}
+
+func NewFeePolicy(client FeeBackend, config FeeConfig) *FeePolicy {
+	if config.Mode == "" {
+		config.Mode = FeeModeAuto
+	}
+	if config.GasPriceMultiplier <= 0 {
+		config.GasPriceMultiplier = 1
+	}
+	if config.TipMultiplier <= 0 {
+		config.TipMultiplier = 1
+	}
+	if config.BaseFeeMultiplier <= 0 {
+		config.BaseFeeMultiplier = 2
+	}
+	if config.GasLimitMultiplier < 1 {
+		config.GasLimitMultiplier = 1.2
+	}
+	return &FeePolicy{client: client, config: config}
+}
+
+// Fees は現在のネットワークの状態からガス代を決める
+func (policy *FeePolicy) Fees(ctx context.Context) (*Fees, error) {
+	var baseFee *big.Int
+	if policy.config.Mode != FeeModeLegacy {
+		header, err := policy.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		baseFee = header.BaseFee
+		if baseFee == nil && policy.config.Mode == FeeModeDynamic {
+			return nil, errors.New("ServiceUnavailable: 接続中のチェーンはEIP-1559に対応していません")
+		}
+	}
+
+	if baseFee == nil {
+		gasPrice, err := policy.client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &Fees{
+			GasPrice: capFee(multiplyFee(gasPrice, policy.config.GasPriceMultiplier), policy.config.MaxGasPrice),
+		}, nil
+	}
+
+	tip, err := policy.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tip = capFee(multiplyFee(tip, policy.config.TipMultiplier), policy.config.MaxPriorityFeePerGas)
+	feeCap := new(big.Int).Add(multiplyFee(baseFee, policy.config.BaseFeeMultiplier), tip)
+	feeCap = capFee(feeCap, policy.config.MaxFeePerGas)
+	// 上限で切り詰めた maxFeePerGas をチップが超えるとノードに拒否される
+	if tip.Cmp(feeCap) > 0 {
+		tip = new(big.Int).Set(feeCap)
+	}
+	return &Fees{
+		Dynamic:   true,
+		BaseFee:   baseFee,
+		GasTipCap: tip,
+		GasFeeCap: feeCap,
+	}, nil
+}
+
+// GasLimit は見積もったガスに安全係数を掛ける
+func (policy *FeePolicy) GasLimit(estimated uint64) uint64 {
+	return uint64(math.Ceil(float64(estimated) * policy.config.GasLimitMultiplier))
+}
+
+// Apply は送信するトランザクションにガス代を設定する
+// ガスリミットが未設定の場合は bind が EstimateGas で見積もった値に安全係数を掛けてから署名する
+func (policy *FeePolicy) Apply(ctx context.Context, opts *bind.TransactOpts) error {
+	fees, err := policy.Fees(ctx)
+	if err != nil {
+		return err
+	}
+	if fees.Dynamic {
+		opts.GasTipCap = fees.GasTipCap
+		opts.GasFeeCap = fees.GasFeeCap
+	} else {
+		opts.GasPrice = fees.GasPrice
+	}
+
+	if opts.GasLimit == 0 {
+		sign := opts.Signer
+		opts.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return sign(address, withGas(tx, policy.GasLimit(tx.Gas())))
+		}
+	}
+	return nil
+}
+
+// Estimate は続けて送信するトランザクションのガス代を見積もる
+func (policy *FeePolicy) Estimate(ctx context.Context, msgs ...ethereum.CallMsg) (*FeeEstimate, error) {
+	fees, err := policy.Fees(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var gasLimit uint64
+	for _, msg := range msgs {
+		estimated, err := policy.client.EstimateGas(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+		gasLimit += policy.GasLimit(estimated)
+	}
+
+	gas := new(big.Int).SetUint64(gasLimit)
+	if !fees.Dynamic {
+		cost := new(big.Int).Mul(gas, fees.GasPrice)
+		return &FeeEstimate{Fees: fees, GasLimit: gasLimit, Cost: cost, MaxCost: cost}, nil
+	}
+	// 実際に支払うのは baseFee + チップ（maxFeePerGas を超えない）
+	effective := new(big.Int).Add(fees.BaseFee, fees.GasTipCap)
+	if effective.Cmp(fees.GasFeeCap) > 0 {
+		effective = fees.GasFeeCap
+	}
+	return &FeeEstimate{
+		Fees:     fees,
+		GasLimit: gasLimit,
+		Cost:     new(big.Int).Mul(gas, effective),
+		MaxCost:  new(big.Int).Mul(gas, fees.GasFeeCap),
+	}, nil
+}
+
+// Bump は同じノンスでトランザクションを置き換えるときのガス代を決める
+// ノードは元のガス代より一定の割合以上高くないと置き換えを受け付けないため、元のガス代に bumpPercent % を上乗せした値と
+// 現在のネットワークのガス代の高い方を元のトランザクションと同じ種類で使う（元が分からない場合は現在のガス代）
+// 上乗せした値が上限を超える場合は置き換えてもノードに拒否されるため、エラーを返す
+func (policy *FeePolicy) Bump(ctx context.Context, tx *types.Transaction, bumpPercent int) (*Fees, error) {
+	current, err := policy.Fees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return current, nil
+	}
+
+	// 現在のガス代を元のトランザクションの種類に合わせる
+	currentPrice, currentTip := current.GasPrice, current.GasPrice
+	if current.Dynamic {
+		currentPrice, currentTip = current.GasFeeCap, current.GasTipCap
+	}
+
+	if tx.Type() != types.DynamicFeeTxType {
+		gasPrice := maxFee(bumpFee(tx.GasPrice(), bumpPercent), currentPrice)
+		if maximum := policy.config.MaxGasPrice; maximum != nil && gasPrice.Cmp(maximum) > 0 {
+			return nil, fmt.Errorf("ServiceUnavailable: 置き換えに必要な gasPrice %s wei がガス代の上限 %s wei を超えています", gasPrice, maximum)
+		}
+		return &Fees{GasPrice: gasPrice}, nil
+	}
+
+	tip := maxFee(bumpFee(tx.GasTipCap(), bumpPercent), currentTip)
+	feeCap := maxFee(maxFee(bumpFee(tx.GasFeeCap(), bumpPercent), currentPrice), tip)
+	if maximum := policy.config.MaxPriorityFeePerGas; maximum != nil && tip.Cmp(maximum) > 0 {
+		return nil, fmt.Errorf("ServiceUnavailable: 置き換えに必要な maxPriorityFeePerGas %s wei がガス代の上限 %s wei を超えています", tip, maximum)
+	}
+	if maximum := policy.config.MaxFeePerGas; maximum != nil && feeCap.Cmp(maximum) > 0 {
+		return nil, fmt.Errorf("ServiceUnavailable: 置き換えに必要な maxFeePerGas %s wei がガス代の上限 %s wei を超えています", feeCap, maximum)
+	}
+	return &Fees{
+		Dynamic:   true,
+		BaseFee:   current.BaseFee,
+		GasTipCap: tip,
+		GasFeeCap: feeCap,
+	}, nil
+}
+
+func multiplyFee(fee *big.Int, multiplier float64) *big.Int {
+	if multiplier == 1 {
+		return new(big.Int).Set(fee)
+	}
+	result, _ := new(big.Float).Mul(new(big.Float).SetInt(fee), big.NewFloat(multiplier)).Int(nil)
+	return result
+}
+
+func capFee(fee *big.Int, maximum *big.Int) *big.Int {
+	if maximum != nil && fee.Cmp(maximum) > 0 {
+		return new(big.Int).Set(maximum)
+	}
+	return fee
+}
+
+// bumpFee はガス代に percent % を上乗せする（1wei未満は切り上げる）
+func bumpFee(fee *big.Int, percent int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(int64(100+percent)))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+func maxFee(a *big.Int, b *big.Int) *big.Int {
+	if b != nil && b.Cmp(a) > 0 {
+		return new(big.Int).Set(b)
+	}
+	return a
+}
+
+// withGas はガスリミットだけを差し替えた署名前のトランザクションを作成する
+func withGas(tx *types.Transaction, gas uint64) *types.Transaction {
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  tx.GasTipCap(),
+			GasFeeCap:  tx.GasFeeCap(),
+			Gas:        gas,
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		})
+	case types.LegacyTxType:
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: tx.GasPrice(),
+			Gas:      gas,
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		})
+	}
+	return tx
+}
@@ -0,0 +1,176 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFeeBackend はガス代の推奨値と見積もりを返すテスト用のノード
+type fakeFeeBackend struct {
+	baseFee  *big.Int // nil の場合はEIP-1559以前のチェーン
+	gasPrice *big.Int
+	tip      *big.Int
+	gas      uint64
+}
+
+func (fake *fakeFeeBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return fake.gasPrice, nil
+}
+
+func (fake *fakeFeeBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return fake.tip, nil
+}
+
+func (fake *fakeFeeBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(10), BaseFee: fake.baseFee}, nil
+}
+
+func (fake *fakeFeeBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return fake.gas, nil
+}
+
+func TestFeePolicy(t *testing.T) {
+	ctx := context.Background()
+	gwei := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(1_000_000_000)) }
+
+	t.Run("正常系: baseFeeの無いチェーンでは倍率を掛けたgasPriceを使う", func(t *testing.T) {
+		policy := NewFeePolicy(&fakeFeeBackend{gasPrice: gwei(10)}, FeeConfig{GasPriceMultiplier: 1.5})
+
+		fees, err := policy.Fees(ctx)
+		require.NoError(t, err)
+		assert.False(t, fees.Dynamic)
+		assert.Equal(t, gwei(15), fees.GasPrice)
+	})
+
+	t.Run("正常系: gasPriceは上限で切り詰める", func(t *testing.T) {
+		policy := NewFeePolicy(&fakeFeeBackend{baseFee: gwei(1), gasPrice: gwei(50)}, FeeConfig{Mode: FeeModeLegacy, MaxGasPrice: gwei(20)})
+
+		fees, err := policy.Fees(ctx)
+		require.NoError(t, err)
+		assert.False(t, fees.Dynamic)
+		assert.Equal(t, gwei(20), fees.GasPrice)
+	})
+
+	t.Run("正常系: maxFeePerGasはbaseFeeの倍率にチップを足す", func(t *testing.T) {
+		policy := NewFeePolicy(&fakeFeeBackend{baseFee: gwei(30), tip: gwei(2)}, FeeConfig{})
+
+		fees, err := policy.Fees(ctx)
+		require.NoError(t, err)
+		assert.True(t, fees.Dynamic)
+		assert.Equal(t, gwei(2), fees.GasTipCap)
+		assert.Equal(t, gwei(62), fees.GasFeeCap)
+	})
+
+	t.Run("正常系: 上限で切り詰めたmaxFeePerGasをチップが超えない", func(t *testing.T) {
+		policy := NewFeePolicy(&fakeFeeBackend{baseFee: gwei(30), tip: gwei(8)}, FeeConfig{
+			MaxPriorityFeePerGas: gwei(5),
+			MaxFeePerGas:         gwei(4),
+		})
+
+		fees, err := policy.Fees(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, gwei(4), fees.GasFeeCap)
+		assert.Equal(t, gwei(4), fees.GasTipCap)
+	})
+
+	t.Run("異常系: dynamicを指定してもbaseFeeが無いチェーンでは送信しない", func(t *testing.T) {
+		policy := NewFeePolicy(&fakeFeeBackend{gasPrice: gwei(10)}, FeeConfig{Mode: FeeModeDynamic})
+
+		_, err := policy.Fees(ctx)
+		assert.ErrorContains(t, err, "ServiceUnavailable")
+	})
+
+	t.Run("正常系: 見積もったガスリミットに安全係数を掛けて署名する", func(t *testing.T) {
+		policy := NewFeePolicy(&fakeFeeBackend{baseFee: gwei(30), tip: gwei(2)}, FeeConfig{GasLimitMultiplier: 1.25})
+
+		var signed *types.Transaction
+		opts := &bind.TransactOpts{
+			Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				signed = tx
+				return tx, nil
+			},
+		}
+		require.NoError(t, policy.Apply(ctx, opts))
+		assert.Equal(t, gwei(62), opts.GasFeeCap)
+		assert.Equal(t, gwei(2), opts.GasTipCap)
+		assert.Nil(t, opts.GasPrice)
+
+		// bind が EstimateGas の結果で作成したトランザクション
+		to := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+		_, err := opts.Signer(common.Address{}, types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(1337),
+			Nonce:     3,
+			GasTipCap: opts.GasTipCap,
+			GasFeeCap: opts.GasFeeCap,
+			Gas:       100000,
+			To:        &to,
+			Value:     big.NewInt(7),
+			Data:      []byte{0x01},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(125000), signed.Gas())
+		assert.Equal(t, uint64(3), signed.Nonce())
+		assert.Equal(t, gwei(62), signed.GasFeeCap())
+		assert.Equal(t, big.NewInt(7), signed.Value())
+	})
+
+	t.Run("正常系: 複数のトランザクションのガス代を合計して見積もる", func(t *testing.T) {
+		policy := NewFeePolicy(&fakeFeeBackend{baseFee: gwei(30), tip: gwei(2), gas: 100000}, FeeConfig{})
+
+		estimate, err := policy.Estimate(ctx, ethereum.CallMsg{}, ethereum.CallMsg{})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(240000), estimate.GasLimit)
+		assert.Equal(t, new(big.Int).Mul(big.NewInt(240000), gwei(32)), estimate.Cost)
+		assert.Equal(t, new(big.Int).Mul(big.NewInt(240000), gwei(62)), estimate.MaxCost)
+	})
+}
+
+func TestFeePolicy_Bump(t *testing.T) {
+	ctx := context.Background()
+	gwei := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(1_000_000_000)) }
+	to := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+
+	t.Run("正常系: legacy は元のgasPriceに上乗せした値と現在のgasPriceの高い方を使う", func(t *testing.T) {
+		policy := NewFeePolicy(&fakeFeeBackend{gasPrice: gwei(5)}, FeeConfig{})
+		tx := types.NewTx(&types.LegacyTx{Nonce: 3, GasPrice: gwei(10), Gas: 21000, To: &to})
+
+		fees, err := policy.Bump(ctx, tx, 15)
+		require.NoError(t, err)
+		assert.False(t, fees.Dynamic)
+		assert.Equal(t, new(big.Int).Add(gwei(11), big.NewInt(500_000_000)), fees.GasPrice)
+
+		// ネットワークのガス代が上がっていれば現在の値を使う
+		policy = NewFeePolicy(&fakeFeeBackend{gasPrice: gwei(30)}, FeeConfig{})
+		fees, err = policy.Bump(ctx, tx, 15)
+		require.NoError(t, err)
+		assert.Equal(t, gwei(30), fees.GasPrice)
+	})
+
+	t.Run("正常系: dynamic はチップとmaxFeePerGasの両方を上乗せする", func(t *testing.T) {
+		policy := NewFeePolicy(&fakeFeeBackend{baseFee: gwei(1), tip: gwei(1)}, FeeConfig{})
+		tx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1337), Nonce: 3, GasTipCap: gwei(2), GasFeeCap: gwei(40), Gas: 21000, To: &to})
+
+		fees, err := policy.Bump(ctx, tx, 10)
+		require.NoError(t, err)
+		assert.True(t, fees.Dynamic)
+		assert.Equal(t, new(big.Int).Add(gwei(2), big.NewInt(200_000_000)), fees.GasTipCap)
+		assert.Equal(t, gwei(44), fees.GasFeeCap)
+	})
+
+	t.Run("異常系: 上乗せした値が上限を超える場合は置き換えない", func(t *testing.T) {
+		policy := NewFeePolicy(&fakeFeeBackend{gasPrice: gwei(5)}, FeeConfig{MaxGasPrice: gwei(11)})
+		tx := types.NewTx(&types.LegacyTx{Nonce: 3, GasPrice: gwei(10), Gas: 21000, To: &to})
+
+		_, err := policy.Bump(ctx, tx, 15)
+		assert.ErrorContains(t, err, "ServiceUnavailable")
+	})
+}
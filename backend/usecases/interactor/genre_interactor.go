@@ -25,6 +25,10 @@ func NewGenreInteractor(gateway gateways.GenreGateway) *GenreInteractor {
 
 // Create はジャンルの追加をする
 func (interactor *GenreInteractor) Create(ctx context.Context, input *ports.GenreMasterInput) (*ports.GenreMasterOutput, error) {
+	if err := authorize(ctx, ActionWriteMaster); err != nil {
+		return nil, err
+	}
+
 	uuidV7, err := uuid.NewV7()
 	if err != nil {
 		return nil, err
@@ -91,6 +95,10 @@ func (interactor *GenreInteractor) List(ctx context.Context) ([]ports.GenreMaste
 
 // Update はジャンルの情報を変更する
 func (interactor *GenreInteractor) Update(ctx context.Context, id uuid.UUID, input *ports.GenreMasterInput) (*ports.GenreMasterOutput, error) {
+	if err := authorize(ctx, ActionWriteMaster); err != nil {
+		return nil, err
+	}
+
 	now := util.JapaneseNowTime()
 	genre := &domain.GenreMaster{
 		ID:        id,
@@ -114,6 +122,10 @@ func (interactor *GenreInteractor) Update(ctx context.Context, id uuid.UUID, inp
 
 // Delete は指定した一つのジャンルを削除する
 func (interactor *GenreInteractor) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := authorize(ctx, ActionWriteMaster); err != nil {
+		return err
+	}
+
 	genre := domain.GenreMaster{
 		ID: id,
 	}
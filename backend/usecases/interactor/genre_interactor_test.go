@@ -6,9 +6,11 @@ import (
 	"testing"
 
 	"nft-music/adapters/gateways"
+	"nft-music/domain"
 	"nft-music/infrastructure/mysql"
 	"nft-music/usecases/ports"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,7 +27,7 @@ func TestGenreInteractor_CreateAndGet(t *testing.T) {
 	}
 
 	// Call Create method
-	ctx := context.Background()
+	ctx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: uuid.New(), Role: domain.RoleAdmin})
 	createdGenre, err := interactor.Create(ctx, input)
 	assert.NoError(t, err)
 	assert.NotNil(t, createdGenre)
@@ -4,30 +4,65 @@ package interactor
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
-	"mime/multipart"
+	"strings"
+	"sync"
 
 	"nft-music/domain"
 	"nft-music/usecases/gateways"
 	"nft-music/usecases/ports"
+	"nft-music/util"
+	"nft-music/util/audio"
+
+	"github.com/google/uuid"
 )
 
 // IpfsInteractor はIPFSのユースケースです
 type IpfsInteractor struct {
-	IpfsGateway gateways.IpfsGateway
-	UserGateway gateways.UserGateway
+	IpfsGateway  gateways.IpfsGateway
+	UserGateway  gateways.UserGateway
+	MediaGateway gateways.MediaGateway
+	Config       UploadConfig
+
+	mu      sync.Mutex
+	uploads map[string]*trackedUpload // UploadID を指定したアップロード中のファイル
+}
+
+// trackedUpload はアップロード中のファイルの進捗と取り消し
+type trackedUpload struct {
+	userID   uuid.UUID
+	filename string
+	size     int64
+	reader   *uploadReader
+	cancel   context.CancelCauseFunc
 }
 
-func NewIpfsInteractor(ipfsGateway gateways.IpfsGateway, userGateway gateways.UserGateway) *IpfsInteractor {
+func NewIpfsInteractor(ipfsGateway gateways.IpfsGateway, userGateway gateways.UserGateway, mediaGateway gateways.MediaGateway, config UploadConfig) *IpfsInteractor {
 	return &IpfsInteractor{
-		IpfsGateway: ipfsGateway,
-		UserGateway: userGateway,
+		IpfsGateway:  ipfsGateway,
+		UserGateway:  userGateway,
+		MediaGateway: mediaGateway,
+		Config:       config,
+		uploads:      map[string]*trackedUpload{},
 	}
 }
 
-// Upload はIpfsにデータをアップロードする
-func (interactor *IpfsInteractor) Upload(ctx context.Context, header *multipart.FileHeader, form ports.IpfsInput) (ipfsOutput *ports.IpfsOutput, err error) {
+// Upload はリクエストのファイルを読み取りながらIpfsにアップロードする
+// ファイル全体をメモリに載せないため、上限の確認と SHA-256 の計算は読み取りながら行う
+// 音声ファイルは内容を確認し、対応していない形式や壊れているファイルは保存せずにエラーを返す
+// wallet を省略した場合はログイン中のウォレットを使う
+func (interactor *IpfsInteractor) Upload(ctx context.Context, upload ports.IpfsUpload, form ports.IpfsInput) (ipfsOutput *ports.IpfsOutput, err error) {
+	if authUser, ok := ports.AuthUserFrom(ctx); ok && form.Wallet == "" {
+		form.Wallet = authUser.Wallet
+	}
+	if err := authorizeWallet(ctx, form.Wallet); err != nil {
+		return nil, err
+	}
+
 	user := &domain.User{
 		Wallet: form.Wallet,
 	}
@@ -35,90 +70,225 @@ func (interactor *IpfsInteractor) Upload(ctx context.Context, header *multipart.
 	if err != nil {
 		return nil, err
 	}
-	file, err := header.Open()
+
+	// クライアントの切断または Cancel で保存先への送信を止める
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	reader := newUploadReader(ctx, upload.Content, interactor.Config.limit(upload.Filename))
+	if upload.UploadID != "" {
+		if err := interactor.track(upload, &trackedUpload{userID: user.ID, filename: upload.Filename, size: upload.Size, reader: reader, cancel: cancel}); err != nil {
+			return nil, err
+		}
+		defer interactor.untrack(upload.UploadID)
+	}
+
+	content, spooled, err := inspectAudio(upload.Filename, reader)
 	if err != nil {
+		if cause := context.Cause(ctx); cause != nil {
+			return nil, cause
+		}
 		return nil, err
 	}
-	defer func() {
-		if closeErr := file.Close(); closeErr != nil && err == nil {
-			err = closeErr
-		}
-	}()
-
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
+	defer spooled.Close()
 
-	part, err := writer.CreateFormFile("file", header.Filename)
+	ipfsAdd, err := interactor.IpfsGateway.Add(ctx, upload.Filename, content)
 	if err != nil {
+		// 取り消された場合は保存先の通信のエラーではなく取り消しの理由を返す
+		if cause := context.Cause(ctx); cause != nil {
+			return nil, cause
+		}
 		return nil, err
 	}
 
-	if _, err := io.Copy(part, file); err != nil {
+	// public
+	ipfsOutput, err = public(ctx, interactor.IpfsGateway, ipfsAdd.Hash)
+	if err != nil {
 		return nil, err
 	}
 
-	if err := writer.Close(); err != nil {
-		return nil, err
+	var metadata *domain.AudioMetadata
+	var preview *ports.IpfsOutput
+	if spooled != nil {
+		metadata = spooled.metadata
+		if audio.CanClip(metadata.Format) {
+			if preview, err = interactor.preview(ctx, upload.Filename, spooled); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	ipfsAdd, err := interactor.IpfsGateway.Add(ctx, body, writer.FormDataContentType())
+	id, err := uuid.NewV7()
 	if err != nil {
 		return nil, err
 	}
-
-	// public
-	ipfsOutput, err = public(ctx, interactor.IpfsGateway, ipfsAdd.Hash)
-	if err != nil {
+	now := util.JapaneseNowTime()
+	media := &domain.MediaFile{
+		ID:        id,
+		Cid:       ipfsOutput.Cid,
+		UserID:    user.ID,
+		Filename:  upload.Filename,
+		Kind:      mediaKind(upload.Filename, metadata),
+		Size:      reader.received.Load(),
+		Sha256:    reader.sha256(),
+		Audio:     metadata,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if preview != nil {
+		media.PreviewCid = sql.NullString{String: preview.Cid, Valid: true}
+	}
+	if err := interactor.MediaGateway.Create(ctx, media); err != nil {
 		return nil, err
 	}
 
 	ipfsOutput.UserID = user.ID
+	ipfsOutput.Sha256 = media.Sha256
+	ipfsOutput.Size = media.Size
+	ipfsOutput.FileType = media.Kind
+	ipfsOutput.Audio = metadata
+	if preview != nil {
+		ipfsOutput.PreviewCid = preview.Cid
+		ipfsOutput.PreviewPath = preview.Path
+	}
 
 	return ipfsOutput, nil
 }
 
-func (interactor *IpfsInteractor) MetaJSON(ctx context.Context, input ports.IpfsMetaInput) (*ports.IpfsOutput, error) {
-	metaJSON, err := json.Marshal(input)
+// preview は一時ファイルの音声からプレビューを切り出してIpfsにアップロードする
+// 切り出した内容はメモリに載せず、そのまま保存先に送る
+func (interactor *IpfsInteractor) preview(ctx context.Context, filename string, spooled *spooledAudio) (*ports.IpfsOutput, error) {
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(audio.Clip(spooled.file, spooled.size, writer, interactor.Config.Preview))
+	}()
+	defer reader.Close()
+
+	ipfsAdd, err := interactor.IpfsGateway.Add(ctx, previewFilename(filename), reader)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("プレビューをアップロードできません: %w", err)
 	}
+	return public(ctx, interactor.IpfsGateway, ipfsAdd.Hash)
+}
 
-	var body bytes.Buffer
-	writer := multipart.NewWriter(&body)
-
-	part, err := writer.CreateFormFile("file", "meta.json") // "file" は必須、"data.json" は任意のファイル名
+// Progress はアップロード中のファイルの進捗を返す（アップロードしたユーザーのみ）
+func (interactor *IpfsInteractor) Progress(ctx context.Context, uploadID string) (*ports.IpfsUploadProgress, error) {
+	tracked, err := interactor.tracked(ctx, uploadID)
 	if err != nil {
 		return nil, err
 	}
+	return &ports.IpfsUploadProgress{
+		UploadID: uploadID,
+		Filename: tracked.filename,
+		Received: tracked.reader.received.Load(),
+		Size:     tracked.size,
+		Limit:    tracked.reader.limit,
+	}, nil
+}
 
-	_, err = part.Write(metaJSON)
+// Cancel はアップロード中のファイルの送信を止める（アップロードしたユーザーのみ）
+// Upload は取り消しのエラーを返す
+func (interactor *IpfsInteractor) Cancel(ctx context.Context, uploadID string) error {
+	tracked, err := interactor.tracked(ctx, uploadID)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	tracked.cancel(errors.New("BadRequest: アップロードが取り消されました"))
+	return nil
+}
 
-	if err := writer.Close(); err != nil {
-		return nil, err
+func (interactor *IpfsInteractor) track(upload ports.IpfsUpload, tracked *trackedUpload) error {
+	interactor.mu.Lock()
+	defer interactor.mu.Unlock()
+	if _, ok := interactor.uploads[upload.UploadID]; ok {
+		return fmt.Errorf("Already Exist: アップロード %s は実行中です", upload.UploadID)
 	}
+	interactor.uploads[upload.UploadID] = tracked
+	return nil
+}
 
-	ipfsAdd, err := interactor.IpfsGateway.Add(ctx, &body, writer.FormDataContentType())
-	if err != nil {
+func (interactor *IpfsInteractor) untrack(uploadID string) {
+	interactor.mu.Lock()
+	defer interactor.mu.Unlock()
+	delete(interactor.uploads, uploadID)
+}
+
+func (interactor *IpfsInteractor) tracked(ctx context.Context, uploadID string) (*trackedUpload, error) {
+	interactor.mu.Lock()
+	tracked, ok := interactor.uploads[uploadID]
+	interactor.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("record not found: アップロード %s は実行中ではありません", uploadID)
+	}
+	if err := authorizeUser(ctx, tracked.userID); err != nil {
 		return nil, err
 	}
+	return tracked, nil
+}
 
-	return public(ctx, interactor.IpfsGateway, ipfsAdd.Hash)
+// Content はCIDのコンテンツを返す（IPFSのネットワークに公開しない保存先のコンテンツはバックエンドから配信する）
+// フル音源は公開するメタデータに含めないため返さず、クリエイターとトークンの所有者は NftInteractor.MasterContent で取得する
+func (interactor *IpfsInteractor) Content(ctx context.Context, cid string) (io.ReadCloser, error) {
+	media, err := interactor.MediaGateway.GetByCid(ctx, cid)
+	if err != nil && !strings.Contains(err.Error(), "record not found") {
+		return nil, err
+	}
+	if err == nil && media.Kind == domain.MediaKindAudio {
+		return nil, fmt.Errorf("Forbidden: %s はフル音源のため公開していません", cid)
+	}
+	return interactor.IpfsGateway.Cat(ctx, cid)
 }
 
-func public(ctx context.Context, gateways gateways.IpfsGateway, hash string) (*ports.IpfsOutput, error) {
-	ipfsPublish, err := gateways.Publish(ctx, hash)
+// MetaJSON はNFTのメタデータをIpfsにアップロードする
+// file_type はアップロードしたファイルの記録から決め、音声の場合はアップロード時に読み取った技術的なメタデータを含める
+// 公開するメタデータにはフル音源の audio_cid を含めず、プレビューの preview_cid とフル音源を検索する audio_sha256 を含める
+func (interactor *IpfsInteractor) MetaJSON(ctx context.Context, input ports.IpfsMetaInput) (*ports.IpfsOutput, error) {
+	ipfsJSON := &domain.IpfsJSON{
+		Name:        input.Name,
+		Description: input.Description,
+		FileType:    input.FileType,
+		ImageCid:    input.ImageCid,
+		AudioCid:    input.AudioCid,
+		VideoCid:    input.VideoCid,
+		Insentive:   input.Insentive,
+	}
+	switch {
+	case input.AudioCid != "":
+		media, err := interactor.MediaGateway.GetByCid(ctx, input.AudioCid)
+		if err != nil {
+			if strings.Contains(err.Error(), "record not found") {
+				return nil, fmt.Errorf("BadRequest: audio_cid %s はアップロードされた音声ファイルではありません", input.AudioCid)
+			}
+			return nil, err
+		}
+		if media.Kind != domain.MediaKindAudio || media.Audio == nil {
+			return nil, fmt.Errorf("BadRequest: audio_cid %s は音声ファイルではありません", input.AudioCid)
+		}
+		// フル音源は公開のメタデータに含めない（プレビューを作成できない形式は音声の無いメタデータでミントする）
+		ipfsJSON.FileType = domain.MediaKindAudio
+		ipfsJSON.AudioCid = ""
+		ipfsJSON.AudioSha256 = media.Sha256
+		ipfsJSON.PreviewCid = media.PreviewCid.String
+		ipfsJSON.Audio = media.Audio
+	case input.VideoCid != "":
+		ipfsJSON.FileType = domain.MediaKindVideo
+	}
+
+	metaJSON, err := json.Marshal(ipfsJSON)
 	if err != nil {
 		return nil, err
 	}
-	_, err = gateways.Localpin(ctx, hash)
+
+	ipfsAdd, err := interactor.IpfsGateway.Add(ctx, "meta.json", bytes.NewReader(metaJSON))
 	if err != nil {
 		return nil, err
 	}
 
-	ipfsResolve, err := gateways.Resolve(ctx, ipfsPublish.Name)
+	return public(ctx, interactor.IpfsGateway, ipfsAdd.Hash)
+}
+
+// public はコンテンツを固定して参照するパスを返す
+func public(ctx context.Context, gateways gateways.IpfsGateway, hash string) (*ports.IpfsOutput, error) {
+	ipfsResolve, err := gateways.Pin(ctx, hash)
 	if err != nil {
 		return nil, err
 	}
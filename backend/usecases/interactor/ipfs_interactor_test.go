@@ -2,24 +2,72 @@
 package interactor
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"nft-music/domain"
 	"nft-music/usecases/gateways/mock"
 	"nft-music/usecases/ports"
+	"nft-music/util/audio"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
+// testWAV は 8kHz・16ビット・モノラルの無音の WAV を作る
+func testWAV(samples int) []byte {
+	var buf bytes.Buffer
+	write := func(v any) { binary.Write(&buf, binary.LittleEndian, v) }
+	buf.WriteString("RIFF")
+	write(uint32(36 + samples*2))
+	buf.WriteString("WAVEfmt ")
+	write([]uint32{16})
+	write([]uint16{1, 1})
+	write([]uint32{8000, 16000})
+	write([]uint16{2, 16})
+	buf.WriteString("data")
+	write(uint32(samples * 2))
+	buf.Write(make([]byte, samples*2))
+	return buf.Bytes()
+}
+
+// expectPublicURL は保存先のモックに https://ipfs.io から公開するURLを返させる
+func expectPublicURL(ipfsGateway *mock.MockIpfsGateway) *mock.MockIpfsGateway {
+	ipfsGateway.EXPECT().URL(gomock.Any()).DoAndReturn(func(path string) string {
+		if path == "" {
+			return ""
+		}
+		if !strings.HasPrefix(path, "/") {
+			path = "/ipfs/" + path
+		}
+		return "https://ipfs.io" + path
+	}).AnyTimes()
+	return ipfsGateway
+}
+
+// newTestIpfsGateway は https://ipfs.io から公開するURLを返す保存先のモックを作成する
+func newTestIpfsGateway(t *testing.T) *mock.MockIpfsGateway {
+	return expectPublicURL(mock.NewMockIpfsGateway(gomock.NewController(t)))
+}
+
 func TestIpfsInteractor_MetaJSON(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockIpfsGateway := mock.NewMockIpfsGateway(ctrl)
 	mockUserGateway := mock.NewMockUserGateway(ctrl)
-	interactor := NewIpfsInteractor(mockIpfsGateway, mockUserGateway)
+	mockMediaGateway := mock.NewMockMediaGateway(ctrl)
+	interactor := NewIpfsInteractor(mockIpfsGateway, mockUserGateway, mockMediaGateway, UploadConfig{})
 
 	t.Run("正常系: メタデータをアップロードできる", func(t *testing.T) {
 		input := ports.IpfsMetaInput{
@@ -27,19 +75,11 @@ func TestIpfsInteractor_MetaJSON(t *testing.T) {
 		}
 
 		mockIpfsGateway.EXPECT().
-			Add(gomock.Any(), gomock.Any(), gomock.Any()).
+			Add(gomock.Any(), "meta.json", gomock.Any()).
 			Return(&domain.IpfsAdd{Hash: "QmMetaHash"}, nil)
 
 		mockIpfsGateway.EXPECT().
-			Publish(gomock.Any(), "QmMetaHash").
-			Return(&domain.IpfsPublish{Name: "QmMetaName"}, nil)
-
-		mockIpfsGateway.EXPECT().
-			Localpin(gomock.Any(), "QmMetaHash").
-			Return(&domain.IpfsPins{Pins: []string{"QmMetaHash"}}, nil)
-
-		mockIpfsGateway.EXPECT().
-			Resolve(gomock.Any(), "QmMetaName").
+			Pin(gomock.Any(), "QmMetaHash").
 			Return(&domain.IpfsResolve{Path: "/ipfs/QmMetaHash"}, nil)
 
 		output, err := interactor.MetaJSON(context.Background(), input)
@@ -48,4 +88,251 @@ func TestIpfsInteractor_MetaJSON(t *testing.T) {
 		assert.NotNil(t, output)
 		assert.Equal(t, "QmMetaHash", output.Cid)
 	})
+
+	t.Run("正常系: 音声ファイルの技術的なメタデータとプレビューを含め、フル音源は含めない", func(t *testing.T) {
+		metadata := &domain.AudioMetadata{Format: "flac", Codec: "flac", Duration: 215.48, SampleRate: 96000, BitDepth: 24, Channels: 2, Bitrate: 2304000, Tags: map[string]string{"title": "Song"}}
+		mockMediaGateway.EXPECT().
+			GetByCid(gomock.Any(), "QmAudio").
+			Return(&domain.MediaFile{Cid: "QmAudio", Kind: domain.MediaKindAudio, Sha256: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", Audio: metadata, PreviewCid: sql.NullString{String: "QmPreview", Valid: true}}, nil)
+		mockIpfsGateway.EXPECT().
+			Add(gomock.Any(), "meta.json", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, filename string, content io.Reader) (*domain.IpfsAdd, error) {
+				var ipfsJSON domain.IpfsJSON
+				require.NoError(t, json.NewDecoder(content).Decode(&ipfsJSON))
+				assert.Equal(t, "audio", ipfsJSON.FileType)
+				assert.Equal(t, metadata, ipfsJSON.Audio)
+				assert.Empty(t, ipfsJSON.AudioCid)
+				assert.Equal(t, "QmPreview", ipfsJSON.PreviewCid)
+				assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", ipfsJSON.AudioSha256)
+				return &domain.IpfsAdd{Hash: "QmMetaHash"}, nil
+			})
+		mockIpfsGateway.EXPECT().
+			Pin(gomock.Any(), "QmMetaHash").
+			Return(&domain.IpfsResolve{Path: "/ipfs/QmMetaHash"}, nil)
+
+		_, err := interactor.MetaJSON(context.Background(), ports.IpfsMetaInput{Name: "NFT Name", FileType: "video", ImageCid: "QmImage", AudioCid: "QmAudio"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系: audio_cid が音声ファイルではない・アップロードされていない", func(t *testing.T) {
+		mockMediaGateway.EXPECT().
+			GetByCid(gomock.Any(), "QmImage").
+			Return(&domain.MediaFile{Cid: "QmImage", Kind: domain.MediaKindImage}, nil)
+		_, err := interactor.MetaJSON(context.Background(), ports.IpfsMetaInput{Name: "NFT Name", AudioCid: "QmImage"})
+		assert.ErrorContains(t, err, "BadRequest")
+
+		mockMediaGateway.EXPECT().
+			GetByCid(gomock.Any(), "QmUnknown").
+			Return(nil, errors.New("record not found"))
+		_, err = interactor.MetaJSON(context.Background(), ports.IpfsMetaInput{Name: "NFT Name", AudioCid: "QmUnknown"})
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+
+	t.Run("正常系: プレビューを作成できない形式の音声はフル音源を公開せずにプレビュー無しでミントできる", func(t *testing.T) {
+		for _, format := range []string{audio.FormatFLAC, audio.FormatOgg, audio.FormatM4A} {
+			mockMediaGateway.EXPECT().
+				GetByCid(gomock.Any(), "QmMaster").
+				Return(&domain.MediaFile{Cid: "QmMaster", Kind: domain.MediaKindAudio, Audio: &domain.AudioMetadata{Format: format}}, nil)
+			mockIpfsGateway.EXPECT().
+				Add(gomock.Any(), "meta.json", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, filename string, content io.Reader) (*domain.IpfsAdd, error) {
+					var ipfsJSON domain.IpfsJSON
+					require.NoError(t, json.NewDecoder(content).Decode(&ipfsJSON))
+					assert.Equal(t, "audio", ipfsJSON.FileType, format)
+					assert.Empty(t, ipfsJSON.AudioCid, format)
+					assert.Empty(t, ipfsJSON.PreviewCid, format)
+					return &domain.IpfsAdd{Hash: "QmMetaHash"}, nil
+				})
+			mockIpfsGateway.EXPECT().
+				Pin(gomock.Any(), "QmMetaHash").
+				Return(&domain.IpfsResolve{Path: "/ipfs/QmMetaHash"}, nil)
+
+			_, err := interactor.MetaJSON(context.Background(), ports.IpfsMetaInput{Name: "NFT Name", FileType: "audio", ImageCid: "QmImage", AudioCid: "QmMaster"})
+			assert.NoError(t, err, format)
+		}
+	})
+}
+
+func TestIpfsInteractor_Upload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	wallet := "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"
+	user := &domain.User{ID: uuid.New(), Wallet: wallet}
+	ctx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: user.ID, Wallet: wallet, Role: domain.RoleCreator})
+
+	mockIpfsGateway := mock.NewMockIpfsGateway(ctrl)
+	mockUserGateway := mock.NewMockUserGateway(ctrl)
+	mockUserGateway.EXPECT().GetByWallet(gomock.Any(), gomock.Any()).Return(user, nil).AnyTimes()
+	mockMediaGateway := mock.NewMockMediaGateway(ctrl)
+	interactor := NewIpfsInteractor(mockIpfsGateway, mockUserGateway, mockMediaGateway, UploadConfig{MaxSize: 1024, TypeLimits: map[string]int64{"audio": 256}, Preview: audio.ClipOptions{Length: 5 * time.Millisecond}})
+
+	t.Run("正常系: 読み取りながら SHA-256 とサイズを計算する", func(t *testing.T) {
+		mockIpfsGateway.EXPECT().
+			Add(gomock.Any(), "cover.png", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, filename string, content io.Reader) (*domain.IpfsAdd, error) {
+				_, err := io.Copy(io.Discard, content)
+				return &domain.IpfsAdd{Hash: "QmCover"}, err
+			})
+		mockIpfsGateway.EXPECT().
+			Pin(gomock.Any(), "QmCover").
+			Return(&domain.IpfsResolve{Path: "/ipfs/QmCover"}, nil)
+		mockMediaGateway.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, media *domain.MediaFile) error {
+				assert.Equal(t, "QmCover", media.Cid)
+				assert.Equal(t, domain.MediaKindImage, media.Kind)
+				assert.Nil(t, media.Audio)
+				return nil
+			})
+
+		output, err := interactor.Upload(ctx, ports.IpfsUpload{Filename: "cover.png", Size: -1, Content: strings.NewReader("test")}, ports.IpfsInput{})
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, output.UserID)
+		assert.Equal(t, "QmCover", output.Cid)
+		assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", output.Sha256)
+		assert.Equal(t, int64(4), output.Size)
+		assert.Equal(t, "image", output.FileType)
+	})
+
+	t.Run("正常系: 音声ファイルの内容を確認してメタデータとプレビューを保存する", func(t *testing.T) {
+		wav := testWAV(100)
+		mockIpfsGateway.EXPECT().
+			Add(gomock.Any(), "track.wav", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, filename string, content io.Reader) (*domain.IpfsAdd, error) {
+				received, err := io.ReadAll(content)
+				assert.Equal(t, wav, received)
+				return &domain.IpfsAdd{Hash: "QmTrack"}, err
+			})
+		mockIpfsGateway.EXPECT().
+			Pin(gomock.Any(), "QmTrack").
+			Return(&domain.IpfsResolve{Path: "/ipfs/QmTrack"}, nil)
+		// 5ms は 40 サンプル
+		mockIpfsGateway.EXPECT().
+			Add(gomock.Any(), "track.preview.wav", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, filename string, content io.Reader) (*domain.IpfsAdd, error) {
+				received, err := io.ReadAll(content)
+				assert.Len(t, received, 44+40*2)
+				return &domain.IpfsAdd{Hash: "QmPreview"}, err
+			})
+		mockIpfsGateway.EXPECT().
+			Pin(gomock.Any(), "QmPreview").
+			Return(&domain.IpfsResolve{Path: "/ipfs/QmPreview"}, nil)
+		want := &domain.AudioMetadata{Format: "wav", Codec: "pcm", Duration: 0.013, SampleRate: 8000, BitDepth: 16, Channels: 1, Bitrate: 128000}
+		mockMediaGateway.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, media *domain.MediaFile) error {
+				assert.Equal(t, user.ID, media.UserID)
+				assert.Equal(t, domain.MediaKindAudio, media.Kind)
+				assert.Equal(t, int64(len(wav)), media.Size)
+				assert.Equal(t, want, media.Audio)
+				assert.Equal(t, sql.NullString{String: "QmPreview", Valid: true}, media.PreviewCid)
+				return nil
+			})
+
+		output, err := interactor.Upload(ctx, ports.IpfsUpload{Filename: "track.wav", Size: -1, Content: bytes.NewReader(wav)}, ports.IpfsInput{})
+		require.NoError(t, err)
+		assert.Equal(t, "audio", output.FileType)
+		assert.Equal(t, want, output.Audio)
+		assert.Equal(t, "QmPreview", output.PreviewCid)
+		assert.Equal(t, "/ipfs/QmPreview", output.PreviewPath)
+	})
+
+	t.Run("異常系: 対応していない・壊れている音声ファイルは保存しない", func(t *testing.T) {
+		_, err := interactor.Upload(ctx, ports.IpfsUpload{Filename: "track.mp3", Size: -1, Content: strings.NewReader("not an audio file")}, ports.IpfsInput{})
+		assert.ErrorContains(t, err, "BadRequest")
+
+		// data チャンクが途中で切れている
+		wav := testWAV(100)
+		_, err = interactor.Upload(ctx, ports.IpfsUpload{Filename: "track.wav", Size: -1, Content: bytes.NewReader(wav[:len(wav)-10])}, ports.IpfsInput{})
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+
+	t.Run("異常系: ファイルの種類ごとの上限を超えた", func(t *testing.T) {
+		_, err := interactor.Upload(ctx, ports.IpfsUpload{Filename: "track.wav", Size: -1, Content: bytes.NewReader(testWAV(200))}, ports.IpfsInput{Wallet: wallet})
+		assert.ErrorContains(t, err, "PayloadTooLarge")
+	})
+
+	t.Run("異常系: 他のウォレットではアップロードできない", func(t *testing.T) {
+		_, err := interactor.Upload(ctx, ports.IpfsUpload{Filename: "cover.png", Content: strings.NewReader("test")}, ports.IpfsInput{Wallet: "0x0000000000000000000000000000000000000001"})
+		assert.ErrorContains(t, err, "Forbidden")
+	})
+
+	t.Run("正常系: アップロード中の進捗を確認して取り消せる", func(t *testing.T) {
+		body, writer := io.Pipe()
+		received := make(chan struct{})
+		mockIpfsGateway.EXPECT().
+			Add(gomock.Any(), "cover.png", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, filename string, content io.Reader) (*domain.IpfsAdd, error) {
+				buf := make([]byte, 4)
+				if _, err := io.ReadFull(content, buf); err != nil {
+					return nil, err
+				}
+				close(received)
+				// 取り消されるまで読み取りを続ける
+				_, err := io.Copy(io.Discard, content)
+				return nil, err
+			})
+
+		errs := make(chan error, 1)
+		go func() {
+			_, err := interactor.Upload(ctx, ports.IpfsUpload{UploadID: "upload-1", Filename: "cover.png", Size: 100, Content: body}, ports.IpfsInput{})
+			errs <- err
+		}()
+		go writer.Write([]byte("test"))
+		<-received
+
+		other := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: uuid.New(), Role: domain.RoleCreator})
+		_, err := interactor.Progress(other, "upload-1")
+		assert.ErrorContains(t, err, "Forbidden")
+
+		progress, err := interactor.Progress(ctx, "upload-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), progress.Received)
+		assert.Equal(t, int64(100), progress.Size)
+		assert.Equal(t, int64(1024), progress.Limit)
+
+		require.NoError(t, interactor.Cancel(ctx, "upload-1"))
+		// 次の読み取りで取り消しに気付く
+		go writer.Write([]byte("more"))
+		assert.ErrorContains(t, <-errs, "取り消されました")
+		writer.Close()
+
+		_, err = interactor.Progress(ctx, "upload-1")
+		assert.ErrorContains(t, err, "record not found")
+	})
+}
+
+func TestIpfsInteractor_Content(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	mockIpfsGateway := mock.NewMockIpfsGateway(ctrl)
+	mockMediaGateway := mock.NewMockMediaGateway(ctrl)
+	interactor := NewIpfsInteractor(mockIpfsGateway, mock.NewMockUserGateway(ctrl), mockMediaGateway, UploadConfig{})
+
+	t.Run("正常系: メタJSONやプレビューのコンテンツを返す", func(t *testing.T) {
+		mockMediaGateway.EXPECT().GetByCid(gomock.Any(), "QmPreview").Return(nil, errors.New("record not found"))
+		mockIpfsGateway.EXPECT().Cat(gomock.Any(), "QmPreview").Return(io.NopCloser(strings.NewReader("preview")), nil)
+
+		body, err := interactor.Content(ctx, "QmPreview")
+		require.NoError(t, err)
+		defer body.Close()
+		content, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, "preview", string(content))
+	})
+
+	t.Run("正常系: 画像のコンテンツを返す", func(t *testing.T) {
+		mockMediaGateway.EXPECT().GetByCid(gomock.Any(), "QmImage").Return(&domain.MediaFile{Cid: "QmImage", Kind: domain.MediaKindImage}, nil)
+		mockIpfsGateway.EXPECT().Cat(gomock.Any(), "QmImage").Return(io.NopCloser(strings.NewReader("image")), nil)
+
+		body, err := interactor.Content(ctx, "QmImage")
+		require.NoError(t, err)
+		body.Close()
+	})
+
+	t.Run("異常系: フル音源は公開しない", func(t *testing.T) {
+		mockMediaGateway.EXPECT().GetByCid(gomock.Any(), "QmMaster").Return(&domain.MediaFile{Cid: "QmMaster", Kind: domain.MediaKindAudio}, nil)
+
+		_, err := interactor.Content(ctx, "QmMaster")
+		assert.ErrorContains(t, err, "Forbidden")
+	})
 }
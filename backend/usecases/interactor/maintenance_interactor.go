@@ -0,0 +1,165 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+	"nft-music/util"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// MaintenanceConfig はメンテナンスモードの設定
+type MaintenanceConfig struct {
+	Enabled      bool          // 起動時から運用者のメンテナンスモードにする
+	Reason       string        // Enabled の場合の理由
+	PollInterval time.Duration // コントラクトの Paused() を確認する間隔
+	RetryAfter   time.Duration // 終了時刻が分からない場合の再試行の目安
+}
+
+// MaintenanceInteractor はメンテナンスモードを管理する
+// コントラクトの一時停止（Paused）か運用者の設定のどちらかが有効な間、書き込みのAPIを止める
+type MaintenanceInteractor struct {
+	Marketplace *MarketplaceContract
+	Config      MaintenanceConfig
+	Logging     logging.Logging
+
+	mu             sync.RWMutex
+	contractPaused bool
+	operator       bool
+	reason         string
+	until          time.Time // 運用者が設定した終了時刻（過ぎると運用者のメンテナンスモードを終える。ゼロ値の場合は解除するまで続ける）
+	checkedAt      time.Time
+}
+
+func NewMaintenanceInteractor(marketplace *MarketplaceContract, config MaintenanceConfig, logging logging.Logging) *MaintenanceInteractor {
+	if config.PollInterval <= 0 {
+		config.PollInterval = 10 * time.Second
+	}
+	if config.RetryAfter <= 0 {
+		config.RetryAfter = time.Minute
+	}
+	return &MaintenanceInteractor{
+		Marketplace: marketplace,
+		Config:      config,
+		Logging:     logging,
+		operator:    config.Enabled,
+		reason:      config.Reason,
+	}
+}
+
+// Run は PollInterval ごとにコントラクトが一時停止中か確認する（ctx が終了するまで戻らない）
+func (interactor *MaintenanceInteractor) Run(ctx context.Context) {
+	ticker := time.NewTicker(interactor.Config.PollInterval)
+	defer ticker.Stop()
+	for {
+		interactor.Refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh はコントラクトの Paused() を読み直す
+// コントラクトに接続していない場合は一時停止していないものとし、読めなかった場合は前回の状態を使う
+func (interactor *MaintenanceInteractor) Refresh(ctx context.Context) {
+	paused := false
+	if contract, _, err := interactor.Marketplace.Get(); err == nil {
+		paused, err = contract.Paused(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			interactor.Logging.Warning(fmt.Sprintf("failed to check if the marketplace is paused: %s", err.Error()))
+			return
+		}
+	}
+
+	interactor.mu.Lock()
+	defer interactor.mu.Unlock()
+	now := util.JapaneseNowTime()
+	if interactor.operator && !interactor.operatorActive(now) {
+		interactor.Logging.Info("operator maintenance mode ended")
+		interactor.operator = false
+		interactor.reason = ""
+		interactor.until = time.Time{}
+	}
+	if paused != interactor.contractPaused {
+		interactor.Logging.Info(fmt.Sprintf("marketplace paused changed to %t", paused))
+	}
+	interactor.contractPaused = paused
+	interactor.checkedAt = now
+}
+
+// operatorActive は運用者のメンテナンスモードが now の時点で有効か（終了時刻を過ぎた場合は Refresh を待たずに終わったものとする）
+func (interactor *MaintenanceInteractor) operatorActive(now time.Time) bool {
+	return interactor.operator && (interactor.until.IsZero() || now.Before(interactor.until))
+}
+
+// Status はメンテナンスモードの状態を返す
+func (interactor *MaintenanceInteractor) Status(ctx context.Context) *ports.MaintenanceOutput {
+	interactor.mu.RLock()
+	defer interactor.mu.RUnlock()
+
+	now := util.JapaneseNowTime()
+	operator := interactor.operatorActive(now)
+	output := &ports.MaintenanceOutput{
+		Active:         interactor.contractPaused || operator,
+		ContractPaused: interactor.contractPaused,
+		Operator:       operator,
+		CheckedAt:      interactor.checkedAt,
+	}
+	if !output.Active {
+		return output
+	}
+
+	output.Reason = "マーケットプレイスのコントラクトが一時停止されています"
+	retryAfter := interactor.Config.RetryAfter
+	if operator {
+		output.Reason = interactor.reason
+		if output.Reason == "" {
+			output.Reason = "メンテナンス中です"
+		}
+		if !interactor.until.IsZero() {
+			until := interactor.until
+			output.Until = &until
+			retryAfter = until.Sub(now)
+		}
+	}
+	output.RetryAfter = int(math.Ceil(retryAfter.Seconds()))
+	return output
+}
+
+// SetOperator は運用者のメンテナンスモードを切り替える（管理者のみ）
+func (interactor *MaintenanceInteractor) SetOperator(ctx context.Context, input *ports.MaintenanceInput) (*ports.MaintenanceOutput, error) {
+	if err := authorize(ctx, ActionMaintenance); err != nil {
+		return nil, err
+	}
+
+	var until time.Time
+	if input.Enabled && input.Duration != "" {
+		duration, err := time.ParseDuration(input.Duration)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("BadRequest: duration %s が正しくありません（例: 30m）", input.Duration)
+		}
+		until = util.JapaneseNowTime().Add(duration)
+	}
+
+	interactor.mu.Lock()
+	interactor.operator = input.Enabled
+	interactor.reason = ""
+	interactor.until = until
+	if input.Enabled {
+		interactor.reason = input.Reason
+	}
+	interactor.mu.Unlock()
+
+	authUser, _ := ports.AuthUserFrom(ctx)
+	interactor.Logging.Info(fmt.Sprintf("maintenance mode set to %t by %s: %s", input.Enabled, authUser.Wallet, input.Reason))
+	return interactor.Status(ctx), nil
+}
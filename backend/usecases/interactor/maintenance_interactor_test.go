@@ -0,0 +1,108 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/usecases/ports"
+	"nft-music/util"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceInteractor(t *testing.T) {
+	ctx := context.Background()
+	admin := ports.WithAuthUser(ctx, &ports.AuthUser{UserID: uuid.New(), Role: domain.RoleAdmin})
+
+	newInteractor := func(t *testing.T, config MaintenanceConfig) (*MaintenanceInteractor, *fakeMarketBackend) {
+		backend := &fakeMarketBackend{}
+		address := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+		contract, err := contracts.NewContracts(address, backend)
+		require.NoError(t, err)
+		marketplace := NewMarketplaceContract()
+		marketplace.Set(address, contract)
+		return NewMaintenanceInteractor(marketplace, config, &NullLogging{}), backend
+	}
+
+	t.Run("正常系: コントラクトが一時停止中の間はメンテナンス中になる", func(t *testing.T) {
+		interactor, backend := newInteractor(t, MaintenanceConfig{RetryAfter: 30 * time.Second})
+
+		interactor.Refresh(ctx)
+		assert.False(t, interactor.Status(ctx).Active)
+
+		backend.paused = true
+		interactor.Refresh(ctx)
+		status := interactor.Status(ctx)
+		assert.True(t, status.Active)
+		assert.True(t, status.ContractPaused)
+		assert.False(t, status.Operator)
+		assert.Equal(t, 30, status.RetryAfter)
+		assert.NotEmpty(t, status.Reason)
+
+		backend.paused = false
+		interactor.Refresh(ctx)
+		assert.False(t, interactor.Status(ctx).Active)
+	})
+
+	t.Run("正常系: 運用者の設定は終了時刻までを再試行の目安にする", func(t *testing.T) {
+		interactor, _ := newInteractor(t, MaintenanceConfig{})
+
+		output, err := interactor.SetOperator(admin, &ports.MaintenanceInput{Enabled: true, Reason: "DB移行中", Duration: "30m"})
+		require.NoError(t, err)
+		assert.True(t, output.Active)
+		assert.True(t, output.Operator)
+		assert.Equal(t, "DB移行中", output.Reason)
+		require.NotNil(t, output.Until)
+		assert.InDelta(t, 1800, output.RetryAfter, 2)
+
+		output, err = interactor.SetOperator(admin, &ports.MaintenanceInput{Enabled: false})
+		require.NoError(t, err)
+		assert.False(t, output.Active)
+		assert.Zero(t, output.RetryAfter)
+	})
+
+	t.Run("正常系: 終了時刻を過ぎると運用者のメンテナンスモードを終える", func(t *testing.T) {
+		interactor, _ := newInteractor(t, MaintenanceConfig{})
+
+		_, err := interactor.SetOperator(admin, &ports.MaintenanceInput{Enabled: true, Reason: "DB移行中", Duration: "30m"})
+		require.NoError(t, err)
+		interactor.until = util.JapaneseNowTime().Add(-time.Second)
+		assert.False(t, interactor.Status(ctx).Active)
+
+		interactor.Refresh(ctx)
+		assert.False(t, interactor.operator)
+		assert.True(t, interactor.until.IsZero())
+	})
+
+	t.Run("正常系: 起動時の設定でメンテナンス中にできる", func(t *testing.T) {
+		interactor, _ := newInteractor(t, MaintenanceConfig{Enabled: true, Reason: "リリース作業中"})
+
+		status := interactor.Status(ctx)
+		assert.True(t, status.Active)
+		assert.Equal(t, "リリース作業中", status.Reason)
+		assert.Equal(t, 60, status.RetryAfter)
+	})
+
+	t.Run("異常系: 管理者以外は切り替えられない", func(t *testing.T) {
+		interactor, _ := newInteractor(t, MaintenanceConfig{})
+		creator := ports.WithAuthUser(ctx, &ports.AuthUser{UserID: uuid.New(), Role: domain.RoleCreator})
+
+		_, err := interactor.SetOperator(creator, &ports.MaintenanceInput{Enabled: true})
+		assert.ErrorContains(t, err, "Forbidden")
+		assert.False(t, interactor.Status(ctx).Active)
+	})
+
+	t.Run("異常系: 終了までの時間が正しくない", func(t *testing.T) {
+		interactor, _ := newInteractor(t, MaintenanceConfig{})
+
+		_, err := interactor.SetOperator(admin, &ports.MaintenanceInput{Enabled: true, Duration: "soon"})
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+}
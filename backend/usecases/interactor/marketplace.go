@@ -0,0 +1,45 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"errors"
+	"sync"
+
+	"nft-music/contracts"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MarketplaceAbiVersion は現在のバインディングのABIのハッシュ（デプロイ済みコントラクトとの互換性確認に使う）
+var MarketplaceAbiVersion = crypto.Keccak256Hash([]byte(contracts.ContractsMetaData.ABI)).Hex()
+
+// MarketplaceContract は接続中のNFTマーケットプレイスのコントラクト
+// 管理者が新しくデプロイした場合に差し替えるため、ロックで保護する
+type MarketplaceContract struct {
+	mu       sync.RWMutex
+	address  common.Address
+	contract *contracts.Contracts
+}
+
+func NewMarketplaceContract() *MarketplaceContract {
+	return &MarketplaceContract{}
+}
+
+// Set は接続するコントラクトを差し替える
+func (marketplace *MarketplaceContract) Set(address common.Address, contract *contracts.Contracts) {
+	marketplace.mu.Lock()
+	defer marketplace.mu.Unlock()
+	marketplace.address = address
+	marketplace.contract = contract
+}
+
+// Get は接続中のコントラクトを返す
+func (marketplace *MarketplaceContract) Get() (*contracts.Contracts, common.Address, error) {
+	marketplace.mu.RLock()
+	defer marketplace.mu.RUnlock()
+	if marketplace.contract == nil {
+		return nil, common.Address{}, errors.New("ServiceUnavailable: マーケットプレイスのコントラクトがデプロイされていません")
+	}
+	return marketplace.contract, marketplace.address, nil
+}
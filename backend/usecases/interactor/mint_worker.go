@@ -0,0 +1,530 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/usecases/gateways"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+	"nft-music/util"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+)
+
+// MintBackend はミントの送信と確認に使うノードの機能（*ethclient.Client が実装している）
+type MintBackend interface {
+	bind.DeployBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// MintWorkerConfig はミントジョブの設定
+type MintWorkerConfig struct {
+	Confirmations uint64        // confirmed にするのに必要な承認数（取り込まれたブロックを含む）
+	PollInterval  time.Duration // レシートを確認する間隔
+}
+
+// MintWorker はキューに入ったミントジョブを送信し、レシートを追跡してステータスを進める
+//
+//	queued → submitted → mined → confirmed
+//	         └──────────┴──→ failed（送信失敗・revert）
+//
+// ジョブはキューに入った順に1件ずつ送信し、送信する前にノンスとハッシュを queued のまま記録する
+// クリエイターのウォレットで署名するジョブ（prepared）は送信せず、送信されたものを Track で追跡する
+// プラットフォームのウォレットから送信したジョブが取り込まれない場合は Watcher が同じノンスで置き換える
+type MintWorker struct {
+	TransactionGateway gateways.TransactionGateway
+	IpfsGateway        gateways.IpfsGateway // トークンURIの公開URLを作成する
+	Client             MintBackend
+	TxSigner           gateways.Signer
+	NonceManager       *NonceManager
+	Watcher            *StuckTxWatcher // nil の場合は置き換えない
+	Marketplace        *MarketplaceContract
+	Config             MintWorkerConfig
+	Logging            logging.Logging
+
+	jobs     chan string
+	mu       sync.Mutex
+	tracking map[string]*trackedJob // レシートを追跡中のジョブ
+}
+
+// trackedJob は追跡中のミントジョブ（レシートの確認と管理者の置き換えが同時に行を更新しないようにロックする）
+type trackedJob struct {
+	mu          sync.Mutex
+	transaction *domain.Transaction
+}
+
+func NewMintWorker(transactionGateway gateways.TransactionGateway, ipfsGateway gateways.IpfsGateway, client MintBackend, signer gateways.Signer, nonceManager *NonceManager, watcher *StuckTxWatcher, marketplace *MarketplaceContract, config MintWorkerConfig, logging logging.Logging) *MintWorker {
+	if config.Confirmations == 0 {
+		config.Confirmations = 1
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	return &MintWorker{
+		TransactionGateway: transactionGateway,
+		IpfsGateway:        ipfsGateway,
+		Client:             client,
+		TxSigner:           signer,
+		NonceManager:       nonceManager,
+		Watcher:            watcher,
+		Marketplace:        marketplace,
+		Config:             config,
+		Logging:            logging,
+		jobs:               make(chan string, 1024),
+		tracking:           map[string]*trackedJob{},
+	}
+}
+
+// Enqueue はミントジョブを送信待ちに追加する
+func (worker *MintWorker) Enqueue(id string) {
+	select {
+	case worker.jobs <- id:
+	default:
+		// DBには queued のまま残るため、次回の起動時に再開される
+		worker.Logging.Warning(fmt.Sprintf("mint queue is full, job %s is deferred until restart", id))
+	}
+}
+
+// Run は前回終わらなかったジョブを再開し、キューのジョブを順番に送信する（ctx が終了するまで戻らない）
+func (worker *MintWorker) Run(ctx context.Context) {
+	worker.resume(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case id := <-worker.jobs:
+			worker.process(ctx, id)
+		}
+	}
+}
+
+// resume は queued のジョブをキューに戻し、送信済みのジョブのレシートの追跡を再開する
+// ハッシュを記録した queued のジョブは送信した後に終了した可能性があるため、送り直さずに submitted として追跡する
+func (worker *MintWorker) resume(ctx context.Context) {
+	transactions, err := worker.TransactionGateway.ListByStatus(ctx, domain.TransactionStatusQueued, domain.TransactionStatusSubmitted, domain.TransactionStatusMined)
+	if err != nil {
+		worker.Logging.Error(fmt.Sprintf("failed to resume mint jobs: %s", err.Error()))
+		return
+	}
+	for _, transaction := range transactions {
+		if transaction.Status == domain.TransactionStatusQueued && !transaction.TxHash.Valid {
+			worker.Enqueue(transaction.ID)
+			continue
+		}
+		if err := worker.submitted(ctx, transaction); err != nil {
+			worker.Logging.Error(fmt.Sprintf("failed to update mint job %s: %s", transaction.ID, err.Error()))
+		}
+		worker.startTracking(ctx, transaction)
+	}
+}
+
+// process は queued のジョブを送信して submitted にする
+func (worker *MintWorker) process(ctx context.Context, id string) {
+	transaction, err := worker.TransactionGateway.GetByTransactionid(ctx, id)
+	if err != nil {
+		worker.Logging.Error(fmt.Sprintf("failed to get mint job %s: %s", id, err.Error()))
+		return
+	}
+	if transaction.Status != domain.TransactionStatusQueued {
+		return
+	}
+	if transaction.TxHash.Valid {
+		// 前回の起動で送信する前に記録したジョブ（resume と同じく送り直さない）
+		if err := worker.submitted(ctx, transaction); err != nil {
+			worker.Logging.Error(fmt.Sprintf("failed to update mint job %s: %s", id, err.Error()))
+		}
+		worker.startTracking(ctx, transaction)
+		return
+	}
+
+	deferred, err := worker.submit(ctx, transaction)
+	if err != nil {
+		if deferred {
+			// 送信していないため queued のまま残し、次回の起動時に再開する
+			worker.Logging.Error(fmt.Sprintf("failed to record mint job %s before sending: %s", id, err.Error()))
+			return
+		}
+		worker.Logging.Error(fmt.Sprintf("failed to submit mint job %s: %s", id, err.Error()))
+		if err := worker.fail(ctx, transaction, err.Error()); err != nil {
+			worker.Logging.Error(fmt.Sprintf("failed to update mint job %s: %s", id, err.Error()))
+		}
+		return
+	}
+
+	if err := worker.submitted(ctx, transaction); err != nil {
+		// 送信は済んでいてハッシュも記録してあるため、追跡は続けて次の更新で記録する
+		worker.Logging.Error(fmt.Sprintf("failed to update mint job %s: %s", id, err.Error()))
+	}
+	worker.startTracking(ctx, transaction)
+}
+
+// submit は現在のミント料を送金する CreateToken に署名し、ノンスとハッシュを記録してから送信する
+// 送信した後に記録できずに同じミントを送り直すことが無いよう、記録できなかった場合は送信せずに deferred を true で返す
+// ミント料は管理者が変更できるため、登録時から変わっていた場合は送信時の値を記録する
+func (worker *MintWorker) submit(ctx context.Context, transaction *domain.Transaction) (deferred bool, err error) {
+	contract, contractAddress, err := worker.Marketplace.Get()
+	if err != nil {
+		return false, err
+	}
+	transaction.ContractAddress = contractAddress.Hex()
+
+	chainID, err := worker.Client.ChainID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	listingPrice, err := contract.GetListingPrice(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return false, fmt.Errorf("failed to get listing price: %w", err)
+	}
+	transaction.Price = domain.NewAmount(listingPrice)
+
+	address := worker.TxSigner.Address()
+	for attempt := 0; ; attempt++ {
+		tx, err := worker.NonceManager.Sign(ctx, worker.TxSigner, chainID, listingPrice, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contract.CreateToken(opts, tokenURI(worker.IpfsGateway, transaction))
+		})
+		if err != nil {
+			return false, err
+		}
+
+		transaction.TxHash = sql.NullString{String: tx.Hash().Hex(), Valid: true}
+		transaction.Nonce = int(tx.Nonce())
+		transaction.To = sql.NullString{String: tx.To().Hex(), Valid: true}
+		transaction.Cost = domain.NewAmount(tx.Cost())
+		if err := worker.update(ctx, transaction); err != nil {
+			worker.NonceManager.Release(address, tx.Nonce())
+			transaction.TxHash = sql.NullString{}
+			return true, err
+		}
+
+		err = worker.Client.SendTransaction(ctx, tx)
+		if err == nil {
+			return false, nil
+		}
+		worker.NonceManager.Abandon(address, tx.Nonce(), err)
+		// ノードが受け付けなかったトランザクションは取り込まれないため、記録したハッシュは残さない
+		transaction.TxHash = sql.NullString{}
+		if isNonceError(err) && attempt == 0 {
+			continue
+		}
+		return false, err
+	}
+}
+
+// submitted はハッシュを記録したジョブを submitted にする
+func (worker *MintWorker) submitted(ctx context.Context, transaction *domain.Transaction) error {
+	if transaction.Status != domain.TransactionStatusQueued {
+		return nil
+	}
+	transaction.Status = domain.TransactionStatusSubmitted
+	return worker.update(ctx, transaction)
+}
+
+// Track はクリエイターのウォレットから送信されたミントジョブのレシートの追跡を始める
+func (worker *MintWorker) Track(ctx context.Context, transaction *domain.Transaction) {
+	worker.startTracking(ctx, transaction)
+}
+
+func (worker *MintWorker) startTracking(ctx context.Context, transaction *domain.Transaction) {
+	worker.mu.Lock()
+	defer worker.mu.Unlock()
+	if _, ok := worker.tracking[transaction.ID]; ok {
+		return
+	}
+	job := &trackedJob{transaction: transaction}
+	worker.tracking[transaction.ID] = job
+	go worker.track(ctx, job)
+}
+
+// track はジョブが confirmed か failed になるまでレシートを確認する
+func (worker *MintWorker) track(ctx context.Context, job *trackedJob) {
+	transaction := job.transaction
+	defer func() {
+		worker.mu.Lock()
+		delete(worker.tracking, transaction.ID)
+		worker.mu.Unlock()
+		if worker.Watcher != nil {
+			worker.Watcher.Forget(transaction.ID)
+		}
+	}()
+
+	ticker := time.NewTicker(worker.Config.PollInterval)
+	defer ticker.Stop()
+	for {
+		job.mu.Lock()
+		done, err := worker.checkReceipt(ctx, transaction)
+		job.mu.Unlock()
+		if err != nil {
+			worker.Logging.Warning(fmt.Sprintf("failed to check receipt of mint job %s: %s", transaction.ID, err.Error()))
+		}
+		if done {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkReceipt はレシートと承認数を確認してステータスを進める。confirmed か failed になったら true を返す
+func (worker *MintWorker) checkReceipt(ctx context.Context, transaction *domain.Transaction) (bool, error) {
+	receipt, replacement, err := worker.receipt(ctx, transaction)
+	if errors.Is(err, ethereum.NotFound) {
+		// reorgでブロックから外れた場合は submitted に戻して取り込まれるのを待ち直す
+		if transaction.Status == domain.TransactionStatusMined {
+			transaction.Status = domain.TransactionStatusSubmitted
+			transaction.BlockNumber = sql.NullInt64{}
+			transaction.TokenID = sql.NullString{} // 取り込み直されると別のトークンIDになることがある
+			return false, worker.update(ctx, transaction)
+		}
+		return false, worker.replaceIfStuck(ctx, transaction)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if worker.Watcher != nil && transaction.Status == domain.TransactionStatusSubmitted {
+		if err := worker.Watcher.Settle(ctx, transaction, receipt.TxHash.Hex()); err != nil {
+			return false, err
+		}
+	}
+	// 取り消しが取り込まれた場合、tx_hash は取り消したミントのトランザクションのまま failed にする
+	if replacement != nil && replacement.Kind == domain.ReplacementKindCancel {
+		if err := worker.fail(ctx, transaction, fmt.Sprintf("cancelled by %s", replacement.TxHash)); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	// 置き換える前のトランザクションが取り込まれることもあるため、取り込まれたものを記録する
+	if worker.Watcher != nil && receipt.TxHash.Hex() != transaction.TxHash.String {
+		transaction.TxHash = sql.NullString{String: receipt.TxHash.Hex(), Valid: true}
+	}
+	hash := common.HexToHash(transaction.TxHash.String)
+
+	transaction.BlockNumber = sql.NullInt64{Int64: receipt.BlockNumber.Int64(), Valid: true}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		if err := worker.fail(ctx, transaction, revertReason(ctx, worker.Client, hash, receipt.BlockNumber)); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if transaction.Status != domain.TransactionStatusMined {
+		transaction.Status = domain.TransactionStatusMined
+		worker.setTokenID(transaction, receipt)
+		if err := worker.update(ctx, transaction); err != nil {
+			return false, err
+		}
+	}
+
+	head, err := worker.Client.BlockNumber(ctx)
+	if err != nil {
+		return false, err
+	}
+	if head+1 < receipt.BlockNumber.Uint64()+worker.Config.Confirmations {
+		return false, nil
+	}
+
+	transaction.Status = domain.TransactionStatusConfirmed
+	if err := worker.update(ctx, transaction); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// receipt はミントのトランザクションのレシートを取得する（置き換えた場合は同じノンスで取り込まれたもの）
+func (worker *MintWorker) receipt(ctx context.Context, transaction *domain.Transaction) (*types.Receipt, *domain.TransactionReplacement, error) {
+	if worker.Watcher != nil {
+		return worker.Watcher.Receipt(ctx, transaction)
+	}
+	receipt, err := worker.Client.TransactionReceipt(ctx, common.HexToHash(transaction.TxHash.String))
+	return receipt, nil, err
+}
+
+// replaceIfStuck は取り込まれないまま StuckAfter を過ぎたジョブを自動で置き換えて記録する
+func (worker *MintWorker) replaceIfStuck(ctx context.Context, transaction *domain.Transaction) error {
+	if worker.Watcher == nil {
+		return nil
+	}
+	replacement, err := worker.Watcher.ReplaceIfStuck(ctx, transaction)
+	if err != nil || replacement == nil {
+		return err
+	}
+	return worker.update(ctx, transaction)
+}
+
+// Replace は取り込まれていないミントジョブを管理者が同じノンスで置き換える（speed_up / cancel）
+func (worker *MintWorker) Replace(ctx context.Context, id string, kind string) (*ports.ReplacementOutput, error) {
+	if err := authorize(ctx, ActionReplaceTx); err != nil {
+		return nil, err
+	}
+	if worker.Watcher == nil {
+		return nil, errors.New("ServiceUnavailable: トランザクションの置き換えは無効です")
+	}
+	transaction, err := worker.TransactionGateway.GetByTransactionid(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	worker.mu.Lock()
+	job, ok := worker.tracking[transaction.ID]
+	worker.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("BadRequest: ミントジョブ %s は取り込み待ちではありません（%s）", id, transaction.Status)
+	}
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	if job.transaction.Status != domain.TransactionStatusSubmitted {
+		return nil, fmt.Errorf("BadRequest: ミントジョブ %s は取り込み待ちではありません（%s）", id, job.transaction.Status)
+	}
+
+	authUser, _ := ports.AuthUserFrom(ctx)
+	replacement, err := worker.Watcher.Replace(ctx, job.transaction, kind, uuid.NullUUID{UUID: authUser.UserID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	if err := worker.update(ctx, job.transaction); err != nil {
+		// 送信は済んでいるため、追跡は続けて次の更新で記録する
+		worker.Logging.Error(fmt.Sprintf("failed to update mint job %s: %s", id, err.Error()))
+	}
+	output := replacementOutput(replacement)
+	return &output, nil
+}
+
+// ListReplacements はミントジョブを置き換えた記録を古い順に返す（管理者のみ）
+func (worker *MintWorker) ListReplacements(ctx context.Context, id string) ([]ports.ReplacementOutput, error) {
+	if err := authorize(ctx, ActionReplaceTx); err != nil {
+		return nil, err
+	}
+	if worker.Watcher == nil {
+		return nil, errors.New("ServiceUnavailable: トランザクションの置き換えは無効です")
+	}
+	transaction, err := worker.TransactionGateway.GetByTransactionid(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	replacements, err := worker.Watcher.List(ctx, transaction.ID)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]ports.ReplacementOutput, 0, len(replacements))
+	for _, replacement := range replacements {
+		outputs = append(outputs, replacementOutput(replacement))
+	}
+	return outputs, nil
+}
+
+// BackfillTokenIDs はトークンIDを記録していないミント済みのジョブをレシートのログから埋める
+// ミントジョブを導入する前の created のミントはレシートを確認していないため、取り込まれていれば mined にしてから埋める（以降の承認は Run が確認する）
+func (worker *MintWorker) BackfillTokenIDs(ctx context.Context) error {
+	transactions, err := worker.TransactionGateway.ListByStatus(ctx, domain.TransactionStatusCreated, domain.TransactionStatusMined, domain.TransactionStatusConfirmed)
+	if err != nil {
+		return err
+	}
+	for _, transaction := range transactions {
+		if transaction.TokenID.Valid || !transaction.TxHash.Valid {
+			continue
+		}
+		hash := common.HexToHash(transaction.TxHash.String)
+		receipt, err := worker.Client.TransactionReceipt(ctx, hash)
+		if err != nil {
+			worker.Logging.Warning(fmt.Sprintf("failed to get receipt of mint job %s: %s", transaction.ID, err.Error()))
+			continue
+		}
+		if transaction.Status == domain.TransactionStatusCreated {
+			transaction.BlockNumber = sql.NullInt64{Int64: receipt.BlockNumber.Int64(), Valid: true}
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				if err := worker.fail(ctx, transaction, revertReason(ctx, worker.Client, hash, receipt.BlockNumber)); err != nil {
+					return err
+				}
+				continue
+			}
+			transaction.Status = domain.TransactionStatusMined
+			// 以前のミントはコントラクトアドレスを記録せず、送信先（to）に記録していた
+			if transaction.ContractAddress == "" && transaction.To.Valid {
+				transaction.ContractAddress = common.HexToAddress(transaction.To.String).Hex()
+			}
+		}
+		if !worker.setTokenID(transaction, receipt) {
+			continue
+		}
+		if err := worker.update(ctx, transaction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setTokenID はレシートのログからトークンIDを取り出して記録する。取り出せた場合は true を返す
+func (worker *MintWorker) setTokenID(transaction *domain.Transaction, receipt *types.Receipt) bool {
+	item, err := mintedItem(transaction.ContractAddress, receipt)
+	if err != nil {
+		worker.Logging.Warning(fmt.Sprintf("failed to get token id of mint job %s: %s", transaction.ID, err.Error()))
+		return false
+	}
+	transaction.TokenID = sql.NullString{String: item.TokenId.String(), Valid: true}
+	return true
+}
+
+// mintedItem はミントしたコントラクトの MarketItemCreated からトークンIDと価格を取り出す
+// MarketItemCreated が無い場合は新規発行（from がゼロアドレス）の Transfer からトークンIDだけを取り出す（Price は nil）
+func mintedItem(contractAddress string, receipt *types.Receipt) (*contracts.ContractsMarketItemCreated, error) {
+	address := common.HexToAddress(contractAddress)
+	filterer, err := contracts.NewContractsFilterer(address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var minted *contracts.ContractsMarketItemCreated
+	for _, log := range receipt.Logs {
+		if log.Address != address {
+			continue
+		}
+		if event, err := filterer.ParseMarketItemCreated(*log); err == nil {
+			return event, nil
+		}
+		if event, err := filterer.ParseTransfer(*log); err == nil && event.From == (common.Address{}) && minted == nil {
+			minted = &contracts.ContractsMarketItemCreated{TokenId: event.TokenId, Raw: event.Raw}
+		}
+	}
+	if minted == nil {
+		return nil, fmt.Errorf("no MarketItemCreated or Transfer log from %s in %s", address.Hex(), receipt.TxHash.Hex())
+	}
+	return minted, nil
+}
+
+// tokenURI は createToken に渡すトークンのメタデータのURL
+func tokenURI(ipfsGateway gateways.IpfsGateway, transaction *domain.Transaction) string {
+	return ipfsGateway.URL(transaction.TokenURL)
+}
+
+func (worker *MintWorker) fail(ctx context.Context, transaction *domain.Transaction, reason string) error {
+	transaction.Status = domain.TransactionStatusFailed
+	transaction.RevertReason = sql.NullString{String: truncateRevertReason(reason), Valid: true}
+	return worker.update(ctx, transaction)
+}
+
+func (worker *MintWorker) update(ctx context.Context, transaction *domain.Transaction) error {
+	transaction.UpdatedAt = util.JapaneseNowTime()
+	return worker.TransactionGateway.Update(ctx, transaction)
+}
@@ -0,0 +1,428 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/usecases/gateways/mock"
+	"nft-music/usecases/ports"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeMintBackend はレシートと最新ブロックを返すテスト用のノード
+type fakeMintBackend struct {
+	mu       sync.Mutex
+	head     uint64
+	receipts map[common.Hash]*types.Receipt
+	txs      map[common.Hash]*types.Transaction
+	callErr  error
+}
+
+func newFakeMintBackend() *fakeMintBackend {
+	return &fakeMintBackend{
+		receipts: map[common.Hash]*types.Receipt{},
+		txs:      map[common.Hash]*types.Transaction{},
+	}
+}
+
+func (fake *fakeMintBackend) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x60}, nil
+}
+
+func (fake *fakeMintBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	receipt, ok := fake.receipts[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (fake *fakeMintBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1337), nil
+}
+
+func (fake *fakeMintBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	return fake.head, nil
+}
+
+func (fake *fakeMintBackend) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	tx, ok := fake.txs[hash]
+	if !ok {
+		return nil, false, ethereum.NotFound
+	}
+	return tx, false, nil
+}
+
+func (fake *fakeMintBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return nil, fake.callErr
+}
+
+func (fake *fakeMintBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	fake.txs[tx.Hash()] = tx
+	return nil
+}
+
+func (fake *fakeMintBackend) mine(hash common.Hash, blockNumber uint64, status uint64, logs ...types.Log) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	receipt := &types.Receipt{TxHash: hash, Status: status, BlockNumber: new(big.Int).SetUint64(blockNumber)}
+	for i := range logs {
+		receipt.Logs = append(receipt.Logs, &logs[i])
+	}
+	fake.receipts[hash] = receipt
+	fake.head = max(fake.head, blockNumber)
+}
+
+func TestMintWorker_CheckReceipt(t *testing.T) {
+	ctx := context.Background()
+
+	newWorker := func(t *testing.T, backend *fakeMintBackend, confirmations uint64) (*MintWorker, *[]string) {
+		ctrl := gomock.NewController(t)
+		transactionGateway := mock.NewMockTransactionGateway(ctrl)
+		var statuses []string
+		transactionGateway.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, transaction *domain.Transaction) error {
+			statuses = append(statuses, transaction.Status)
+			return nil
+		}).AnyTimes()
+		worker := NewMintWorker(transactionGateway, newTestIpfsGateway(t), backend, nil, nil, nil, NewMarketplaceContract(), MintWorkerConfig{Confirmations: confirmations}, &NullLogging{})
+		return worker, &statuses
+	}
+
+	contract := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	submitted := func(hash common.Hash) *domain.Transaction {
+		return &domain.Transaction{
+			ID:              uuid.NewString(),
+			ContractAddress: contract.Hex(),
+			TxHash:          sql.NullString{String: hash.Hex(), Valid: true},
+			Status:          domain.TransactionStatusSubmitted,
+		}
+	}
+	creator := common.HexToAddress("0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50")
+	created := func(tokenID int64) []types.Log {
+		return []types.Log{
+			marketLog(t, contract, "Transfer", common.Address{}, creator, big.NewInt(tokenID)),
+			marketLog(t, contract, "Transfer", creator, contract, big.NewInt(tokenID)),
+			marketLog(t, contract, "MarketItemCreated", big.NewInt(tokenID), creator, contract, big.NewInt(100), false, creator),
+		}
+	}
+
+	t.Run("正常系: 承認数に達するとconfirmedになる", func(t *testing.T) {
+		backend := newFakeMintBackend()
+		worker, statuses := newWorker(t, backend, 3)
+		hash := common.HexToHash("0x01")
+		transaction := submitted(hash)
+
+		done, err := worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.Equal(t, domain.TransactionStatusSubmitted, transaction.Status)
+
+		backend.mine(hash, 10, types.ReceiptStatusSuccessful, created(7)...)
+		done, err = worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.Equal(t, domain.TransactionStatusMined, transaction.Status)
+		assert.Equal(t, int64(10), transaction.BlockNumber.Int64)
+		assert.Equal(t, "7", transaction.TokenID.String)
+
+		backend.head = 12
+		done, err = worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, []string{domain.TransactionStatusMined, domain.TransactionStatusConfirmed}, *statuses)
+	})
+
+	t.Run("正常系: reorgでレシートが消えた場合はsubmittedに戻る", func(t *testing.T) {
+		backend := newFakeMintBackend()
+		worker, _ := newWorker(t, backend, 5)
+		hash := common.HexToHash("0x02")
+		transaction := submitted(hash)
+
+		backend.mine(hash, 20, types.ReceiptStatusSuccessful, created(8)...)
+		_, err := worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TransactionStatusMined, transaction.Status)
+		assert.True(t, transaction.TokenID.Valid)
+
+		delete(backend.receipts, hash)
+		done, err := worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.Equal(t, domain.TransactionStatusSubmitted, transaction.Status)
+		assert.False(t, transaction.BlockNumber.Valid)
+		assert.False(t, transaction.TokenID.Valid)
+	})
+
+	t.Run("正常系: MarketItemCreatedが無い場合は新規発行のTransferからトークンIDを取り出す", func(t *testing.T) {
+		backend := newFakeMintBackend()
+		worker, _ := newWorker(t, backend, 5)
+		hash := common.HexToHash("0x03")
+		transaction := submitted(hash)
+
+		other := common.HexToAddress("0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0")
+		backend.mine(hash, 20, types.ReceiptStatusSuccessful,
+			marketLog(t, other, "Transfer", common.Address{}, creator, big.NewInt(99)),
+			marketLog(t, contract, "Transfer", common.Address{}, creator, big.NewInt(4)),
+		)
+		_, err := worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		assert.Equal(t, "4", transaction.TokenID.String)
+	})
+
+	t.Run("正常系: トークンIDの無いミント済みのジョブをレシートから埋める", func(t *testing.T) {
+		backend := newFakeMintBackend()
+		worker, statuses := newWorker(t, backend, 1)
+		hash := common.HexToHash("0x04")
+		legacy := submitted(hash)
+		legacy.Status = domain.TransactionStatusConfirmed
+		filled := submitted(common.HexToHash("0x05"))
+		filled.Status = domain.TransactionStatusConfirmed
+		filled.TokenID = sql.NullString{String: "1", Valid: true}
+		backend.mine(hash, 10, types.ReceiptStatusSuccessful, created(2)...)
+
+		// ミントジョブを導入する前の created のミントはレシートを確認して mined にする
+		unconfirmed := submitted(common.HexToHash("0x06"))
+		unconfirmed.Status = domain.TransactionStatusCreated
+		unconfirmed.ContractAddress = ""
+		unconfirmed.To = sql.NullString{String: strings.ToLower(contract.Hex()), Valid: true}
+		backend.mine(common.HexToHash("0x06"), 12, types.ReceiptStatusSuccessful, created(3)...)
+
+		worker.TransactionGateway.(*mock.MockTransactionGateway).EXPECT().
+			ListByStatus(gomock.Any(), domain.TransactionStatusCreated, domain.TransactionStatusMined, domain.TransactionStatusConfirmed).
+			Return([]*domain.Transaction{legacy, filled, unconfirmed}, nil)
+		require.NoError(t, worker.BackfillTokenIDs(ctx))
+		assert.Equal(t, "2", legacy.TokenID.String)
+		assert.Equal(t, "3", unconfirmed.TokenID.String)
+		assert.Equal(t, int64(12), unconfirmed.BlockNumber.Int64)
+		assert.Equal(t, contract.Hex(), unconfirmed.ContractAddress)
+		assert.Equal(t, []string{domain.TransactionStatusConfirmed, domain.TransactionStatusMined}, *statuses)
+	})
+
+	t.Run("異常系: revertした場合は理由を記録してfailedになる", func(t *testing.T) {
+		backend := newFakeMintBackend()
+		backend.callErr = errors.New("execution reverted: Price must be equal to listing price")
+		worker, _ := newWorker(t, backend, 1)
+
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		to := common.HexToAddress("0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50")
+		tx, err := types.SignNewTx(privateKey, types.LatestSignerForChainID(big.NewInt(1337)), &types.LegacyTx{To: &to, Gas: 100000, GasPrice: big.NewInt(1)})
+		require.NoError(t, err)
+		backend.txs[tx.Hash()] = tx
+		backend.mine(tx.Hash(), 30, types.ReceiptStatusFailed)
+
+		transaction := submitted(tx.Hash())
+		done, err := worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, domain.TransactionStatusFailed, transaction.Status)
+		assert.Equal(t, "execution reverted: Price must be equal to listing price", transaction.RevertReason.String)
+	})
+}
+
+func TestNftInteractor_Mint(t *testing.T) {
+	wallet := "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"
+	user := &domain.User{ID: uuid.New(), Wallet: wallet}
+	ctx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: user.ID, Wallet: wallet, Role: domain.RoleCreator})
+	input := &ports.NftInput{Wallet: wallet, ChainID: 1337, GenreID: uuid.New(), Price: "0.5 gwei"}
+
+	newInteractor := func(t *testing.T, marketplace *MarketplaceContract) (*NftInteractor, *mock.MockUserGateway, *mock.MockTransactionGateway) {
+		ctrl := gomock.NewController(t)
+		userGateway := mock.NewMockUserGateway(ctrl)
+		transactionGateway := mock.NewMockTransactionGateway(ctrl)
+		worker := NewMintWorker(transactionGateway, newTestIpfsGateway(t), newFakeMintBackend(), nil, nil, nil, marketplace, MintWorkerConfig{}, &NullLogging{})
+		chainRegistry, _ := newTestChainRegistry(t)
+		return &NftInteractor{
+			UserGateway:        userGateway,
+			TransactionGateway: transactionGateway,
+			Marketplace:        marketplace,
+			MintWorker:         worker,
+			ChainRegistry:      chainRegistry,
+			Logging:            &NullLogging{},
+		}, userGateway, transactionGateway
+	}
+
+	address := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	bound := func(t *testing.T) *MarketplaceContract {
+		contract, err := contracts.NewContracts(address, &fakeMarketBackend{listingPrice: big.NewInt(500000000)})
+		require.NoError(t, err)
+		marketplace := NewMarketplaceContract()
+		marketplace.Set(address, contract)
+		return marketplace
+	}
+
+	t.Run("正常系: queuedのジョブを登録してキューに入れる", func(t *testing.T) {
+		interactor, userGateway, transactionGateway := newInteractor(t, bound(t))
+		userGateway.EXPECT().GetByWallet(gomock.Any(), gomock.Any()).Return(user, nil)
+		var created *domain.Transaction
+		transactionGateway.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, transaction *domain.Transaction) error {
+			created = transaction
+			return nil
+		})
+
+		output, err := interactor.Mint(ctx, input, "QmCid")
+		require.NoError(t, err)
+		assert.Equal(t, domain.TransactionStatusQueued, output.Status)
+		assert.Equal(t, address.Hex(), output.ContractAddress)
+		assert.Equal(t, "/ipfs/QmCid", created.TokenURL)
+		assert.Equal(t, "500000000", created.Price.String())
+		assert.Equal(t, output.ID, <-interactor.MintWorker.jobs)
+	})
+
+	t.Run("正常系: 価格を省略した場合はコントラクトのミント料でミントする", func(t *testing.T) {
+		interactor, userGateway, transactionGateway := newInteractor(t, bound(t))
+		userGateway.EXPECT().GetByWallet(gomock.Any(), gomock.Any()).Return(user, nil)
+		var created *domain.Transaction
+		transactionGateway.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, transaction *domain.Transaction) error {
+			created = transaction
+			return nil
+		})
+
+		_, err := interactor.Mint(ctx, &ports.NftInput{Wallet: wallet, ChainID: 1337, GenreID: uuid.New()}, "QmCid")
+		require.NoError(t, err)
+		assert.Equal(t, "500000000", created.Price.String())
+	})
+
+	t.Run("異常系: 価格がミント料と異なる場合は受け付けない", func(t *testing.T) {
+		interactor, userGateway, _ := newInteractor(t, bound(t))
+		userGateway.EXPECT().GetByWallet(gomock.Any(), gomock.Any()).Return(user, nil)
+
+		_, err := interactor.Mint(ctx, &ports.NftInput{Wallet: wallet, ChainID: 1337, GenreID: uuid.New(), Price: "1 gwei"}, "QmCid")
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+
+	t.Run("異常系: コントラクトに接続していない場合は受け付けない", func(t *testing.T) {
+		interactor, userGateway, _ := newInteractor(t, NewMarketplaceContract())
+		userGateway.EXPECT().GetByWallet(gomock.Any(), gomock.Any()).Return(user, nil)
+
+		_, err := interactor.Mint(ctx, input, "QmCid")
+		assert.ErrorContains(t, err, "ServiceUnavailable")
+	})
+}
+
+func TestMintWorker_Submit(t *testing.T) {
+	address := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	newWorker := func(t *testing.T) (*MintWorker, *fakeMarketBackend, *mock.MockTransactionGateway) {
+		backend := &fakeMarketBackend{
+			listingPrice: big.NewInt(2000),
+			txs:          map[common.Hash]*types.Transaction{},
+			receipts:     map[common.Hash]*types.Receipt{},
+		}
+		contract, err := contracts.NewContracts(address, backend)
+		require.NoError(t, err)
+		marketplace := NewMarketplaceContract()
+		marketplace.Set(address, contract)
+		transactionGateway := mock.NewMockTransactionGateway(gomock.NewController(t))
+		worker := NewMintWorker(transactionGateway, newTestIpfsGateway(t), backend, newTestSigner(t), NewNonceManager(backend, nil), nil, marketplace, MintWorkerConfig{}, &NullLogging{})
+		return worker, backend, transactionGateway
+	}
+
+	t.Run("正常系: ミント料を変更せず送信時のミント料を送金して記録する", func(t *testing.T) {
+		worker, backend, transactionGateway := newWorker(t)
+		// 登録した後に管理者がミント料を変更した
+		transaction := &domain.Transaction{ID: uuid.NewString(), TokenURL: "/ipfs/QmCid", Price: domain.NewAmount(big.NewInt(1000)), Status: domain.TransactionStatusQueued}
+		transactionGateway.EXPECT().Update(gomock.Any(), transaction).Return(nil)
+
+		deferred, err := worker.submit(context.Background(), transaction)
+		require.NoError(t, err)
+		assert.False(t, deferred)
+		require.Len(t, backend.txs, 1)
+		tx := backend.txs[common.HexToHash(transaction.TxHash.String)]
+		require.NotNil(t, tx)
+		assert.Equal(t, big.NewInt(2000), tx.Value())
+		assert.Equal(t, "2000", transaction.Price.String())
+	})
+
+	t.Run("正常系: 送信する前にノンスとハッシュを記録する", func(t *testing.T) {
+		worker, backend, transactionGateway := newWorker(t)
+		transaction := &domain.Transaction{ID: uuid.NewString(), TokenURL: "/ipfs/QmCid", Status: domain.TransactionStatusQueued}
+		var recorded string
+		transactionGateway.EXPECT().Update(gomock.Any(), transaction).DoAndReturn(func(ctx context.Context, transaction *domain.Transaction) error {
+			assert.Empty(t, backend.txs)
+			assert.Equal(t, domain.TransactionStatusQueued, transaction.Status)
+			recorded = transaction.TxHash.String
+			return nil
+		})
+
+		deferred, err := worker.submit(context.Background(), transaction)
+		require.NoError(t, err)
+		assert.False(t, deferred)
+		assert.Equal(t, recorded, transaction.TxHash.String)
+		assert.Contains(t, backend.txs, common.HexToHash(recorded))
+	})
+
+	t.Run("異常系: 記録できなかった場合は送信せず queued のまま残す", func(t *testing.T) {
+		worker, backend, transactionGateway := newWorker(t)
+		transaction := &domain.Transaction{ID: uuid.NewString(), TokenURL: "/ipfs/QmCid", Status: domain.TransactionStatusQueued}
+		transactionGateway.EXPECT().GetByTransactionid(gomock.Any(), transaction.ID).Return(transaction, nil)
+		transactionGateway.EXPECT().Update(gomock.Any(), transaction).Return(errors.New("database is down"))
+
+		worker.process(context.Background(), transaction.ID)
+		assert.Empty(t, backend.txs)
+		assert.Equal(t, domain.TransactionStatusQueued, transaction.Status)
+		assert.False(t, transaction.TxHash.Valid)
+
+		// 送信しなかったノンスは次のジョブで使う
+		nonce, err := worker.NonceManager.Reserve(context.Background(), worker.TxSigner.Address())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), nonce)
+	})
+
+	t.Run("異常系: ノードが受け付けなかった場合はハッシュを消して failed にする", func(t *testing.T) {
+		worker, backend, transactionGateway := newWorker(t)
+		backend.sendErr = errors.New("insufficient funds for gas * price + value")
+		transaction := &domain.Transaction{ID: uuid.NewString(), TokenURL: "/ipfs/QmCid", Status: domain.TransactionStatusQueued}
+		transactionGateway.EXPECT().GetByTransactionid(gomock.Any(), transaction.ID).Return(transaction, nil)
+		transactionGateway.EXPECT().Update(gomock.Any(), transaction).Return(nil).Times(2)
+
+		worker.process(context.Background(), transaction.ID)
+		assert.Equal(t, domain.TransactionStatusFailed, transaction.Status)
+		assert.False(t, transaction.TxHash.Valid)
+		assert.Contains(t, transaction.RevertReason.String, "insufficient funds")
+	})
+}
+
+func TestMintWorker_Resume(t *testing.T) {
+	t.Run("正常系: ハッシュを記録した queued のジョブは送り直さずに submitted として追跡する", func(t *testing.T) {
+		transactionGateway := mock.NewMockTransactionGateway(gomock.NewController(t))
+		worker := NewMintWorker(transactionGateway, newTestIpfsGateway(t), newFakeMintBackend(), nil, nil, nil, NewMarketplaceContract(), MintWorkerConfig{}, &NullLogging{})
+		recorded := &domain.Transaction{ID: uuid.NewString(), TxHash: sql.NullString{String: common.HexToHash("0x01").Hex(), Valid: true}, Status: domain.TransactionStatusQueued}
+		queued := &domain.Transaction{ID: uuid.NewString(), Status: domain.TransactionStatusQueued}
+		transactionGateway.EXPECT().ListByStatus(gomock.Any(), gomock.Any()).Return([]*domain.Transaction{recorded, queued}, nil)
+		transactionGateway.EXPECT().Update(gomock.Any(), recorded).Return(nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		worker.resume(ctx)
+		assert.Equal(t, domain.TransactionStatusSubmitted, recorded.Status)
+		require.Len(t, worker.jobs, 1)
+		assert.Equal(t, queued.ID, <-worker.jobs)
+		worker.mu.Lock()
+		assert.Contains(t, worker.tracking, recorded.ID)
+		worker.mu.Unlock()
+	})
+}
@@ -2,10 +2,14 @@
 package interactor
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
+	"slices"
 
 	"nft-music/adapters/presenters"
 	"nft-music/contracts"
@@ -15,31 +19,62 @@ import (
 	"nft-music/usecases/ports"
 	"nft-music/util"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 )
 
+// ミントの方式
+const (
+	MintModeCustodial = "custodial" // プラットフォームのウォレットで署名して送信する（MintWorker）
+	MintModeWallet    = "wallet"    // クリエイターのウォレットで署名する署名前のトランザクションを返す
+)
+
+// WalletMintBackend はクリエイターのウォレットで署名するミントの作成と送信に使うノードの機能（*ethclient.Client が実装している）
+type WalletMintBackend interface {
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
 type NftInteractor struct {
 	UserGateway        gateways.UserGateway
 	TransactionGateway gateways.TransactionGateway
+	VoucherGateway     gateways.VoucherGateway
 	IpfsGateway        gateways.IpfsGateway
-	EtherClient        *ethclient.Client
-	Auth               *bind.TransactOpts
-	Contracts          *contracts.Contracts
+	MediaGateway       gateways.MediaGateway
+	Client             WalletMintBackend
+	Marketplace        *MarketplaceContract
+	MintWorker         *MintWorker
+	ChainRegistry      *ChainRegistry
+	FeePolicy          *FeePolicy
+	MintMode           string // MintModeCustodial / MintModeWallet
 	Logging            logging.Logging
 	Error              *presenters.ErrorPresenter
 	Validator          *validator.Validate
 }
 
-func NewNftInteractor(userGateway gateways.UserGateway, transactionGateway gateways.TransactionGateway, ipfsGateway gateways.IpfsGateway, ethClient *ethclient.Client, auth *bind.TransactOpts, contracts *contracts.Contracts, logging logging.Logging, validate *validator.Validate) *NftInteractor {
+func NewNftInteractor(userGateway gateways.UserGateway, transactionGateway gateways.TransactionGateway, voucherGateway gateways.VoucherGateway, ipfsGateway gateways.IpfsGateway, mediaGateway gateways.MediaGateway, client WalletMintBackend, marketplace *MarketplaceContract, mintWorker *MintWorker, chainRegistry *ChainRegistry, feePolicy *FeePolicy, mintMode string, logging logging.Logging, validate *validator.Validate) *NftInteractor {
+	if mintMode != MintModeWallet {
+		mintMode = MintModeCustodial
+	}
 	return &NftInteractor{
 		UserGateway:        userGateway,
 		TransactionGateway: transactionGateway,
+		VoucherGateway:     voucherGateway,
 		IpfsGateway:        ipfsGateway,
-		EtherClient:        ethClient,
-		Auth:               auth,
-		Contracts:          contracts,
+		MediaGateway:       mediaGateway,
+		Client:             client,
+		Marketplace:        marketplace,
+		MintWorker:         mintWorker,
+		ChainRegistry:      chainRegistry,
+		FeePolicy:          feePolicy,
+		MintMode:           mintMode,
 		Logging:            logging,
 		Error:              presenters.NewErrorPresenter(logging),
 		Validator:          validate,
@@ -59,7 +94,7 @@ func (interactor *NftInteractor) List(ctx context.Context, limit int) ([]*ports.
 			return nil, err
 		}
 
-		transaction := outputPort(output, ipfsJSON)
+		transaction := interactor.outputPort(output, ipfsJSON)
 		transactions = append(transactions, transaction)
 	}
 	return transactions, nil
@@ -78,43 +113,88 @@ func (interactor *NftInteractor) ListByWallet(ctx context.Context, wallet string
 			return nil, err
 		}
 
-		transaction := outputPort(output, ipfsJSON)
+		transaction := interactor.outputPort(output, ipfsJSON)
 		transactions = append(transactions, transaction)
 	}
 	return transactions, nil
 }
 
-func (interactor *NftInteractor) Search(ctx context.Context, query string, genre string, minPrice int, maxPrice int, sort string) ([]*ports.TransactionOutput, error) {
-	outputs, err := interactor.TransactionGateway.Search(ctx, genre, minPrice, maxPrice, sort)
-	if err != nil {
-		return nil, err
+// Search はミント済みのNFTと出品中のバウチャーを検索する
+// listing が minted / lazy の場合はどちらかのみ、空の場合は両方を sort の順に並べて返す
+func (interactor *NftInteractor) Search(ctx context.Context, query string, genre string, minPrice domain.Amount, maxPrice domain.Amount, sort string, listing string) ([]*ports.TransactionOutput, error) {
+	if listing != "" && listing != domain.ListingStateMinted && listing != domain.ListingStateLazy {
+		return nil, fmt.Errorf("BadRequest: listing %s が正しくありません（minted / lazy）", listing)
 	}
 
 	var transactions []*ports.TransactionOutput
-	for _, output := range outputs {
-		ipfsJSON, err := interactor.IpfsGateway.Get(ctx, output.TokenURL)
+	if listing != domain.ListingStateLazy {
+		outputs, err := interactor.TransactionGateway.Search(ctx, genre, minPrice, maxPrice, sort)
 		if err != nil {
-			// 1つのNFTでエラーが発生しても処理を続行する
-			interactor.Logging.Warning(fmt.Sprintf("failed to get ipfs json for token %s: %v", output.TokenURL, err))
-			continue
+			return nil, err
 		}
-
-		// queryパラメータが存在する場合、IPFSメタJSONの内容でフィルタリングする
-		if query != "" {
-			searchQuery := util.NormalizeAndFold(query) // 検索クエリを正規化し、大文字小文字を区別しないようにする
-			// ipfsJSON.NameとipfsJSON.Descriptionのいずれかに検索クエリが含まれているかチェック
-			if !util.ContainsFold(util.NormalizeAndFold(ipfsJSON.Name), searchQuery) &&
-				!util.ContainsFold(util.NormalizeAndFold(ipfsJSON.Description), searchQuery) {
-				continue // 検索条件に合わない場合はスキップ
+		for _, output := range outputs {
+			ipfsJSON, ok := interactor.searchMeta(ctx, output.TokenURL, query)
+			if !ok {
+				continue
 			}
+			transactions = append(transactions, interactor.outputPort(output, ipfsJSON))
 		}
+	}
+	if listing == domain.ListingStateMinted {
+		return transactions, nil
+	}
 
-		transaction := outputPort(output, ipfsJSON)
-		transactions = append(transactions, transaction)
+	vouchers, err := interactor.VoucherGateway.Search(ctx, genre, minPrice, maxPrice, util.JapaneseNowTime().Unix())
+	if err != nil {
+		return nil, err
+	}
+	for _, voucher := range vouchers {
+		ipfsJSON, ok := interactor.searchMeta(ctx, voucher.TokenURL, query)
+		if !ok {
+			continue
+		}
+		transactions = append(transactions, interactor.voucherOutputPort(voucher, ipfsJSON))
+	}
+	if listing == "" {
+		sortListings(transactions, sort)
 	}
 	return transactions, nil
 }
 
+// searchMeta は検索結果のIPFSメタJSONを取得し、queryパラメータが存在する場合はその内容でフィルタリングする
+func (interactor *NftInteractor) searchMeta(ctx context.Context, tokenURL string, query string) (*domain.IpfsJSON, bool) {
+	ipfsJSON, err := interactor.IpfsGateway.Get(ctx, tokenURL)
+	if err != nil {
+		// 1つのNFTでエラーが発生しても処理を続行する
+		interactor.Logging.Warning(fmt.Sprintf("failed to get ipfs json for token %s: %v", tokenURL, err))
+		return nil, false
+	}
+
+	if query != "" {
+		searchQuery := util.NormalizeAndFold(query) // 検索クエリを正規化し、大文字小文字を区別しないようにする
+		// ipfsJSON.NameとipfsJSON.Descriptionのいずれかに検索クエリが含まれているかチェック
+		if !util.ContainsFold(util.NormalizeAndFold(ipfsJSON.Name), searchQuery) &&
+			!util.ContainsFold(util.NormalizeAndFold(ipfsJSON.Description), searchQuery) {
+			return nil, false // 検索条件に合わない場合はスキップ
+		}
+	}
+	return ipfsJSON, true
+}
+
+// sortListings はミント済みのNFTとバウチャーをまとめて TransactionGateway.Search と同じ順に並べる
+func sortListings(transactions []*ports.TransactionOutput, sort string) {
+	slices.SortStableFunc(transactions, func(a, b *ports.TransactionOutput) int {
+		switch sort {
+		case "price_asc":
+			return a.Price.Cmp(b.Price)
+		case "price_desc":
+			return b.Price.Cmp(a.Price)
+		default:
+			return b.CreatedAt.Compare(a.CreatedAt)
+		}
+	})
+}
+
 func (interactor *NftInteractor) GetByTransactionid(ctx context.Context, transactionID string) (*ports.TransactionOutput, error) {
 	output, err := interactor.TransactionGateway.GetByTransactionid(ctx, transactionID)
 	if err != nil {
@@ -126,12 +206,135 @@ func (interactor *NftInteractor) GetByTransactionid(ctx context.Context, transac
 		return nil, err
 	}
 
-	transaction := outputPort(output, ipfsJSON)
+	transaction := interactor.outputPort(output, ipfsJSON)
 
 	return transaction, nil
 }
 
-func (interactor *NftInteractor) Mint(ctx context.Context, input *ports.NftInput, cid string) (*ports.TransactionOutput, error) {
+// GetByToken はチェーン・コントラクト・トークンIDでミントしたNFTを取得する
+func (interactor *NftInteractor) GetByToken(ctx context.Context, chainID int, contractAddress string, tokenID string) (*ports.TransactionOutput, error) {
+	if !common.IsHexAddress(contractAddress) {
+		return nil, fmt.Errorf("BadRequest: コントラクトアドレス %s が正しくありません", contractAddress)
+	}
+	id, err := parseTokenID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := interactor.TransactionGateway.GetByToken(ctx, chainID, common.HexToAddress(contractAddress).Hex(), id.String())
+	if err != nil {
+		return nil, err
+	}
+
+	ipfsJSON, err := interactor.IpfsGateway.Get(ctx, output.TokenURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return interactor.outputPort(output, ipfsJSON), nil
+}
+
+// Master はミントしたNFTのフル音源を返す（クリエイターとトークンの所有者のみ）
+// 公開するメタデータにはプレビューだけを含めるため、フル音源はメタデータの audio_sha256 からアップロードの記録を検索する
+// 所有者はマーケットプレイスのコントラクトの ownerOf で確認する
+// S3・ローカルに保存したフル音源は公開URLから取得できないため、MasterContent で取得する
+func (interactor *NftInteractor) Master(ctx context.Context, transactionID string) (*ports.IpfsOutput, error) {
+	authUser, ok := ports.AuthUserFrom(ctx)
+	if !ok {
+		return nil, errors.New("Unauthorized: ログインが必要です")
+	}
+
+	transaction, err := interactor.TransactionGateway.GetByTransactionid(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	ipfsJSON, err := interactor.IpfsGateway.Get(ctx, transaction.TokenURL)
+	if err != nil {
+		return nil, err
+	}
+	if authUser.UserID != transaction.UserID {
+		if err := interactor.authorizeHolder(ctx, transaction, authUser.Wallet); err != nil {
+			return nil, err
+		}
+	}
+
+	// 以前に作成したメタデータはフル音源の audio_cid をそのまま返す
+	if ipfsJSON.AudioSha256 == "" {
+		if ipfsJSON.AudioCid == "" {
+			return nil, fmt.Errorf("record not found: NFT %s には音声ファイルがありません", transactionID)
+		}
+		return &ports.IpfsOutput{
+			UserID:   transaction.UserID,
+			Cid:      ipfsJSON.AudioCid,
+			Path:     ipfsPath(ipfsJSON.AudioCid),
+			FileType: domain.MediaKindAudio,
+			Audio:    ipfsJSON.Audio,
+		}, nil
+	}
+
+	media, err := interactor.MediaGateway.GetBySha256(ctx, ipfsJSON.AudioSha256)
+	if err != nil {
+		return nil, err
+	}
+	return &ports.IpfsOutput{
+		UserID:      media.UserID,
+		Cid:         media.Cid,
+		Path:        ipfsPath(media.Cid),
+		Sha256:      media.Sha256,
+		Size:        media.Size,
+		FileType:    media.Kind,
+		Audio:       media.Audio,
+		PreviewCid:  media.PreviewCid.String,
+		PreviewPath: ipfsPath(media.PreviewCid.String),
+	}, nil
+}
+
+// MasterContent はミントしたNFTのフル音源のコンテンツを返す（クリエイターとトークンの所有者のみ）
+// 保存先がIPFSのネットワークに公開しない場合も、バックエンドからフル音源を取得できる
+func (interactor *NftInteractor) MasterContent(ctx context.Context, transactionID string) (io.ReadCloser, error) {
+	master, err := interactor.Master(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	return interactor.IpfsGateway.Cat(ctx, master.Cid)
+}
+
+// authorizeHolder はログイン中のウォレットがトークンの所有者か確認する
+func (interactor *NftInteractor) authorizeHolder(ctx context.Context, transaction *domain.Transaction, wallet string) error {
+	if !transaction.TokenID.Valid {
+		return fmt.Errorf("Forbidden: NFT %s はまだミントされていません", transaction.ID)
+	}
+	tokenID, err := parseTokenID(transaction.TokenID.String)
+	if err != nil {
+		return err
+	}
+	contract, address, err := interactor.Marketplace.Get()
+	if err != nil {
+		return err
+	}
+	if !util.SameAddress(address.Hex(), transaction.ContractAddress) {
+		return fmt.Errorf("ServiceUnavailable: NFT %s のコントラクト %s には接続していません", transaction.ID, transaction.ContractAddress)
+	}
+	owner, err := contract.OwnerOf(&bind.CallOpts{Context: ctx}, tokenID)
+	if err != nil {
+		return err
+	}
+	if !util.SameAddress(owner.Hex(), wallet) {
+		return errors.New("Forbidden: フル音源はトークンの所有者のみ取得できます")
+	}
+	return nil
+}
+
+// Mint はミントジョブを登録する。送信とレシートの確認は MintWorker が非同期に行う
+// MintModeWallet の場合は送信せず、クリエイターのウォレットで署名する createToken のトランザクションを返す（送信後に SubmitMint で登録する）
+func (interactor *NftInteractor) Mint(ctx context.Context, input *ports.NftInput, cid string) (*ports.MintJobOutput, error) {
+	if err := authorize(ctx, ActionMintNft); err != nil {
+		return nil, err
+	}
+	if err := authorizeWallet(ctx, input.Wallet); err != nil {
+		return nil, err
+	}
+
 	wallet := &domain.User{
 		Wallet: input.Wallet,
 	}
@@ -140,131 +343,364 @@ func (interactor *NftInteractor) Mint(ctx context.Context, input *ports.NftInput
 		return nil, err
 	}
 
-	price := big.NewInt(int64(input.Price))
-	if input.ChainID == 1 || input.ChainID == 1337 || input.ChainID == 11155111 || input.ChainID == 5 || input.ChainID == 56 || input.ChainID == 97 || input.ChainID == 42161 || input.ChainID == 421613 || input.ChainID == 80001 {
-		price = big.NewInt(int64(input.Price * 1000000000))
+	// コントラクトに接続していない場合は受け付けない
+	contract, contractAddress, err := interactor.Marketplace.Get()
+	if err != nil {
+		return nil, err
 	}
 
-	if price.Cmp(big.NewInt(1)) < 0 {
-		return nil, fmt.Errorf("price must be greater than 1")
+	price, err := interactor.mintPrice(ctx, contract, input.ChainID, input.Price)
+	if err != nil {
+		return nil, err
+	}
+	status := domain.TransactionStatusQueued
+	var creatorAddress string
+	if interactor.MintMode == MintModeWallet {
+		// ウォレットは接続中のチェーンのコントラクトに送信する
+		if active := interactor.ChainRegistry.Active().ChainID; input.ChainID != active {
+			return nil, fmt.Errorf("BadRequest: チェーン %d には接続していません（接続中のチェーンは %d）", input.ChainID, active)
+		}
+		status = domain.TransactionStatusPrepared
+		creatorAddress = common.HexToAddress(input.Wallet).Hex()
 	}
 
-	header, err := interactor.EtherClient.HeaderByNumber(ctx, nil)
+	id, err := uuid.NewV7()
 	if err != nil {
 		return nil, err
 	}
 
-	// header.Number は Block Number
-	nonce, err := interactor.EtherClient.NonceAt(ctx, interactor.Auth.From, header.Number)
+	now := util.JapaneseNowTime()
+	transaction := domain.Transaction{
+		ID:              id.String(),
+		UserID:          user.ID,
+		ChainID:         input.ChainID,
+		ContractAddress: contractAddress.Hex(),
+		TokenURL:        fmt.Sprintf("/ipfs/%s", cid),
+		GenreID:         input.GenreID,
+		Price:           price,
+		Insentive:       input.Insentive,
+		Sale:            input.Sale,
+		Status:          status,
+		CreatorAddress:  creatorAddress,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	var unsignedTx *ports.UnsignedTxOutput
+	if status == domain.TransactionStatusPrepared {
+		// 見積もりに失敗するトランザクションはジョブにしない
+		if unsignedTx, err = interactor.unsignedMintTx(ctx, &transaction); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := interactor.TransactionGateway.Create(ctx, &transaction); err != nil {
+		interactor.Logging.Error(fmt.Sprintf("failed to insert transaction: %s", err.Error()))
+		return nil, err
+	}
+	if status == domain.TransactionStatusQueued {
+		interactor.MintWorker.Enqueue(transaction.ID)
+	}
+
+	output := mintJobOutput(&transaction)
+	output.Transaction = unsignedTx
+	return output, nil
+}
+
+// SubmitMint はクリエイターのウォレットで署名したミントのトランザクションを登録し、MintWorker で取り込まれるまで追跡する
+// 署名済みのトランザクション（raw_tx）の場合はノードに送信し、ハッシュ（tx_hash）の場合はウォレットが送信したものを確認する
+func (interactor *NftInteractor) SubmitMint(ctx context.Context, id string, input *ports.MintSubmitInput) (*ports.MintJobOutput, error) {
+	transaction, err := interactor.TransactionGateway.GetByTransactionid(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := authorizeUser(ctx, transaction.UserID); err != nil {
+		return nil, err
+	}
+	if transaction.Status != domain.TransactionStatusPrepared {
+		return nil, fmt.Errorf("BadRequest: ミントジョブ %s は既に %s です", transaction.ID, transaction.Status)
+	}
+	if (input.RawTx == "") == (input.TxHash == "") {
+		return nil, errors.New("BadRequest: raw_tx か tx_hash のどちらかを指定してください")
+	}
+
+	var tx *types.Transaction
+	if input.RawTx != "" {
+		raw, err := hexutil.Decode(input.RawTx)
+		if err != nil {
+			return nil, errors.New("BadRequest: raw_tx が正しくありません")
+		}
+		tx = new(types.Transaction)
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return nil, fmt.Errorf("BadRequest: raw_tx が正しくありません: %s", err.Error())
+		}
+	} else {
+		hashBytes, err := hexutil.Decode(input.TxHash)
+		if err != nil || len(hashBytes) != common.HashLength {
+			return nil, errors.New("BadRequest: tx_hash が正しくありません")
+		}
+		hash := common.BytesToHash(hashBytes)
+		tx, _, err = interactor.Client.TransactionByHash(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("BadRequest: トランザクション %s が見つかりません", hash.Hex())
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := verifyMintTx(transaction, tx, tokenURI(interactor.IpfsGateway, transaction)); err != nil {
+		return nil, err
+	}
+	if input.RawTx != "" {
+		if err := interactor.Client.SendTransaction(ctx, tx); err != nil {
+			return nil, fmt.Errorf("BadRequest: トランザクションを送信できません: %s", err.Error())
+		}
+	}
 
-	transactOpts := &bind.TransactOpts{
-		From:     interactor.Auth.From,
-		Nonce:    big.NewInt(int64(nonce)),
-		Signer:   interactor.Auth.Signer,
-		Context:  ctx,
-		GasPrice: interactor.Auth.GasPrice,
-		GasLimit: interactor.Auth.GasLimit,
-		// Valueは呼び出しごとに設定する
+	transaction.Status = domain.TransactionStatusSubmitted
+	transaction.TxHash = sql.NullString{String: tx.Hash().Hex(), Valid: true}
+	transaction.Nonce = int(tx.Nonce())
+	transaction.To = sql.NullString{String: tx.To().Hex(), Valid: true}
+	transaction.Cost = domain.NewAmount(tx.Cost())
+	transaction.UpdatedAt = util.JapaneseNowTime()
+	if err := interactor.TransactionGateway.Update(ctx, transaction); err != nil {
+		return nil, err
 	}
 
-	// UpdateListingPriceはpayableではないためValueを0に設定
-	transactOpts.Value = big.NewInt(0)
-	updateTx, err := interactor.Contracts.UpdateListingPrice(transactOpts, price)
+	// リクエストが終わっても追跡を続ける
+	interactor.MintWorker.Track(context.WithoutCancel(ctx), transaction)
+	return mintJobOutput(transaction), nil
+}
+
+// PreviewMint はミントを送信した場合のガス代とミント料を見積もる
+func (interactor *NftInteractor) PreviewMint(ctx context.Context, chainID int, inputPrice string) (*ports.MintPreviewOutput, error) {
+	if err := authorize(ctx, ActionMintNft); err != nil {
+		return nil, err
+	}
+
+	contract, contractAddress, err := interactor.Marketplace.Get()
+	if err != nil {
+		return nil, err
+	}
+	price, err := interactor.mintPrice(ctx, contract, chainID, inputPrice)
+	if err != nil {
+		return nil, err
+	}
+	createData, err := packMarketplace("createToken", interactor.IpfsGateway.URL("/ipfs/"))
 	if err != nil {
-		interactor.Logging.Error(fmt.Sprintf("failed to update listing price: %s", err.Error()))
 		return nil, err
 	}
-	// UpdateListingPrice トランザクションがブロックに書き込まれるのを待機します
-	_, err = bind.WaitMined(ctx, interactor.EtherClient, updateTx)
+
+	from := interactor.MintWorker.TxSigner.Address()
+	if interactor.MintMode == MintModeWallet {
+		// クリエイターのウォレットから送信する
+		authUser, _ := ports.AuthUserFrom(ctx)
+		from = common.HexToAddress(authUser.Wallet)
+	}
+	estimate, err := interactor.FeePolicy.Estimate(ctx,
+		ethereum.CallMsg{From: from, To: &contractAddress, Value: price.Wei(), Data: createData},
+	)
 	if err != nil {
-		interactor.Logging.Error(fmt.Sprintf("failed to mine UpdateListingPrice transaction: %s", err.Error()))
 		return nil, err
 	}
 
-	// これでコントラクトの `listingPrice` が `price` に更新されたことが保証されます。
-	// そのため、`GetListingPrice` を呼び出す必要はなく、`CreateToken` に支払うValueは `price` となります。
+	output := &ports.MintPreviewOutput{
+		ChainID:          chainID,
+		FeeType:          FeeModeLegacy,
+		GasLimit:         estimate.GasLimit,
+		Value:            price,
+		EstimatedGasCost: domain.NewAmount(estimate.Cost),
+		MaxGasCost:       domain.NewAmount(estimate.MaxCost),
+		EstimatedTotal:   price.Add(domain.NewAmount(estimate.Cost)),
+	}
+	if estimate.Fees.Dynamic {
+		output.FeeType = FeeModeDynamic
+		output.BaseFee = feeAmount(estimate.Fees.BaseFee)
+		output.MaxPriorityFeePerGas = feeAmount(estimate.Fees.GasTipCap)
+		output.MaxFeePerGas = feeAmount(estimate.Fees.GasFeeCap)
+	} else {
+		output.GasPrice = feeAmount(estimate.Fees.GasPrice)
+	}
+	return output, nil
+}
 
-	transactOpts.Nonce.Add(transactOpts.Nonce, big.NewInt(1))
-	transactOpts.Value = price // CreateTokenではミント料として更新後のlistingPriceを設定
-	trans, err := interactor.Contracts.CreateToken(transactOpts, fmt.Sprintf("https://ipfs.io/ipfs/%s", cid))
+// GetJob はミントジョブの状態を取得する
+func (interactor *NftInteractor) GetJob(ctx context.Context, id string) (*ports.MintJobOutput, error) {
+	transaction, err := interactor.TransactionGateway.GetByTransactionid(ctx, id)
 	if err != nil {
-		interactor.Logging.Error(fmt.Sprintf("failed to create token: %s", err.Error()))
 		return nil, err
 	}
+	return mintJobOutput(transaction), nil
+}
+
+// mintPrice はコントラクトの現在のミント料（wei）を返す
+// ミント料は管理者が変更するため、価格が入力された場合は登録簿のチェーンの単位も含めて読み取り、ミント料と一致するか確認する
+func (interactor *NftInteractor) mintPrice(ctx context.Context, contract *contracts.Contracts, chainID int, inputPrice string) (domain.Amount, error) {
+	chain, err := interactor.ChainRegistry.Get(chainID)
+	if err != nil {
+		return domain.Amount{}, err
+	}
 
-	floatPrice, _ := price.Float64()
-	now := util.JapaneseNowTime()
-	transactions := domain.Transaction{
-		ID:        trans.Hash().Hex(),
-		UserID:    user.ID,
-		ChainID:   input.ChainID,
-		Nonce:     int(trans.Nonce()),
-		TokenURL:  fmt.Sprintf("/ipfs/%s", cid),
-		GenreID:   input.GenreID,
-		To:        sql.NullString{String: trans.To().Hex(), Valid: true},
-		Price:     floatPrice,
-		Insentive: input.Insentive,
-		Cost:      int(trans.Cost().Int64()),
-		Sale:      input.Sale,
-		Status:    "created",
-		CreatedAt: now,
-		UpdatedAt: now,
-	}
-
-	if err := interactor.TransactionGateway.Create(ctx, &transactions); err != nil {
-		interactor.Logging.Error(fmt.Sprintf("failed to insert transaction: %s", err.Error()))
+	listingPrice, err := contract.GetListingPrice(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return domain.Amount{}, err
+	}
+	price := domain.NewAmount(listingPrice)
+	if inputPrice == "" {
+		return price, nil
+	}
+
+	input, err := chain.ParsePrice(inputPrice)
+	if err != nil {
+		return domain.Amount{}, err
+	}
+	if input.Cmp(price) != 0 {
+		return domain.Amount{}, fmt.Errorf("BadRequest: price %s does not match the listing price %s", chain.FormatAmount(input), chain.FormatAmount(price))
+	}
+	return price, nil
+}
+
+// unsignedMintTx はクリエイターのウォレットで署名する createToken のトランザクションを作成する
+// （ノンスはウォレットの送信待ちのトランザクションを含めた目安で、ガス代はウォレットで設定する）
+func (interactor *NftInteractor) unsignedMintTx(ctx context.Context, transaction *domain.Transaction) (*ports.UnsignedTxOutput, error) {
+	data, err := packMarketplace("createToken", tokenURI(interactor.IpfsGateway, transaction))
+	if err != nil {
+		return nil, err
+	}
+	from := common.HexToAddress(transaction.CreatorAddress)
+	to := common.HexToAddress(transaction.ContractAddress)
+	gas, err := interactor.Client.EstimateGas(ctx, ethereum.CallMsg{From: from, To: &to, Value: transaction.Price.Wei(), Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("BadRequest: トランザクションが失敗します: %w", err)
+	}
+	nonce, err := interactor.Client.PendingNonceAt(ctx, from)
+	if err != nil {
 		return nil, err
 	}
+	return &ports.UnsignedTxOutput{
+		ChainID: transaction.ChainID,
+		From:    from.Hex(),
+		To:      to.Hex(),
+		Data:    hexutil.Encode(data),
+		Value:   transaction.Price,
+		Gas:     gas,
+		Nonce:   nonce,
+	}, nil
+}
+
+// verifyMintTx は送信されたトランザクションがミントジョブの createToken（トークンURIは uri）と一致するか確認する
+func verifyMintTx(transaction *domain.Transaction, tx *types.Transaction, uri string) error {
+	mismatch := errors.New("BadRequest: ミントジョブの内容と異なるトランザクションです")
+
+	if tx.ChainId().Cmp(big.NewInt(int64(transaction.ChainID))) != 0 {
+		return mismatch
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil || !util.SameAddress(sender.Hex(), transaction.CreatorAddress) {
+		return mismatch
+	}
+	if tx.To() == nil || !util.SameAddress(tx.To().Hex(), transaction.ContractAddress) {
+		return mismatch
+	}
+	data, err := packMarketplace("createToken", uri)
+	if err != nil || !bytes.Equal(tx.Data(), data) {
+		return mismatch
+	}
+	if tx.Value().Cmp(transaction.Price.Wei()) != 0 {
+		return mismatch
+	}
+	return nil
+}
 
-	return &ports.TransactionOutput{ // APIで返す構造体
-		ID:          transactions.ID,
-		UserID:      transactions.UserID,
-		ChainID:     transactions.ChainID,
-		Nonce:       transactions.Nonce,
-		Name:        user.Name,
-		Description: input.Description,
-		ImageURL:    fmt.Sprintf("/ipfs/%s", cid),
-		TokenURL:    transactions.TokenURL,
-		GenreID:     transactions.GenreID,
-		To:          transactions.To.String,
-		Cost:        transactions.Cost,
-		Status:      transactions.Status,
-		Sale:        transactions.Sale,
-		Price:       transactions.Price,
-		Insentive:   transactions.Insentive,
-		CreatedAt:   transactions.CreatedAt,
-		UpdatedAt:   transactions.UpdatedAt,
-	}, nil // APIで返す構造体
+// feeAmount はノードが返したガス代を出力用の金額にする
+func feeAmount(fee *big.Int) *domain.Amount {
+	amount := domain.NewAmount(fee)
+	return &amount
 }
 
-func outputPort(output *domain.Transaction, ipfsJSON *domain.IpfsJSON) *ports.TransactionOutput {
-	price := output.Price
-	if output.ChainID == 1 || output.ChainID == 1337 {
-		price = output.Price / 1000000000
+func mintJobOutput(transaction *domain.Transaction) *ports.MintJobOutput {
+	return &ports.MintJobOutput{
+		ID:              transaction.ID,
+		Status:          transaction.Status,
+		ChainID:         transaction.ChainID,
+		ContractAddress: transaction.ContractAddress,
+		TxHash:          transaction.TxHash.String,
+		BlockNumber:     uint64(transaction.BlockNumber.Int64),
+		RevertReason:    transaction.RevertReason.String,
+		CreatedAt:       transaction.CreatedAt,
+		UpdatedAt:       transaction.UpdatedAt,
 	}
+}
+
+// ipfsPath はCIDを参照するパスを返す（CIDが空の場合は空）
+func ipfsPath(cid string) string {
+	if cid == "" {
+		return ""
+	}
+	return fmt.Sprintf("/ipfs/%s", cid)
+}
+
+// contentURL はCIDのコンテンツを公開するURLを返す（CIDが空の場合は空）
+// フル音源はメタデータに含めないため、audio_url は以前に作成したメタデータの場合のみ返す
+func (interactor *NftInteractor) contentURL(cid string) string {
+	return interactor.IpfsGateway.URL(cid)
+}
+
+func (interactor *NftInteractor) outputPort(output *domain.Transaction, ipfsJSON *domain.IpfsJSON) *ports.TransactionOutput {
+	return &ports.TransactionOutput{
+		ID:              output.ID,
+		UserID:          output.UserID,
+		ChainID:         output.ChainID,
+		ContractAddress: output.ContractAddress,
+		TokenID:         output.TokenID.String,
+		TxHash:          output.TxHash.String,
+		Nonce:           output.Nonce,
+		Name:            ipfsJSON.Name,
+		Description:     ipfsJSON.Description,
+		FileType:        ipfsJSON.FileType,
+		ImageURL:        interactor.contentURL(ipfsJSON.ImageCid),
+		AudioURL:        interactor.contentURL(ipfsJSON.AudioCid),
+		PreviewURL:      interactor.contentURL(ipfsJSON.PreviewCid),
+		VideoURL:        interactor.contentURL(ipfsJSON.VideoCid),
+		Audio:           ipfsJSON.Audio,
+		TokenURL:        output.TokenURL,
+		GenreID:         output.GenreID,
+		To:              output.To.String,
+		Price:           output.Price,
+		PriceFormatted:  interactor.ChainRegistry.format(output.ChainID, output.Price),
+		Insentive:       output.Insentive,
+		Cost:            output.Cost,
+		Sale:            output.Sale,
+		Status:          output.Status,
+		ListingState:    domain.ListingStateMinted,
+		CreatedAt:       output.CreatedAt,
+		UpdatedAt:       output.UpdatedAt,
+	}
+}
+
+// voucherOutputPort は出品中のバウチャーをミント済みのNFTと同じ形式で返す（トークンIDは引き換えるまで空）
+func (interactor *NftInteractor) voucherOutputPort(voucher *domain.MintVoucher, ipfsJSON *domain.IpfsJSON) *ports.TransactionOutput {
 	return &ports.TransactionOutput{
-		ID:          output.ID,
-		UserID:      output.UserID,
-		ChainID:     output.ChainID,
-		Nonce:       output.Nonce,
-		Name:        ipfsJSON.Name,
-		Description: ipfsJSON.Description,
-		FileType:    ipfsJSON.FileType,
-		ImageURL:    fmt.Sprintf("/ipfs/%s", ipfsJSON.ImageCid), // ipfsJSON.Cid,
-		AudioURL:    fmt.Sprintf("/ipfs/%s", ipfsJSON.AudioCid),
-		VideoURL:    fmt.Sprintf("/ipfs/%s", ipfsJSON.VideoCid),
-		TokenURL:    output.TokenURL,
-		GenreID:     output.GenreID,
-		To:          output.To.String,
-		Price:       price,
-		Insentive:   output.Insentive,
-		Cost:        output.Cost,
-		Sale:        output.Sale,
-		Status:      output.Status,
-		CreatedAt:   output.CreatedAt,
-		UpdatedAt:   output.UpdatedAt,
+		ID:              voucher.ID.String(),
+		UserID:          voucher.UserID,
+		ChainID:         voucher.ChainID,
+		ContractAddress: voucher.ContractAddress,
+		Name:            ipfsJSON.Name,
+		Description:     ipfsJSON.Description,
+		FileType:        ipfsJSON.FileType,
+		ImageURL:        interactor.contentURL(ipfsJSON.ImageCid),
+		AudioURL:        interactor.contentURL(ipfsJSON.AudioCid),
+		PreviewURL:      interactor.contentURL(ipfsJSON.PreviewCid),
+		VideoURL:        interactor.contentURL(ipfsJSON.VideoCid),
+		Audio:           ipfsJSON.Audio,
+		TokenURL:        voucher.TokenURL,
+		GenreID:         voucher.GenreID,
+		Price:           voucher.Price,
+		PriceFormatted:  interactor.ChainRegistry.format(voucher.ChainID, voucher.Price),
+		Sale:            true,
+		Status:          voucher.Status,
+		ListingState:    domain.ListingStateLazy,
+		CreatedAt:       voucher.CreatedAt,
+		UpdatedAt:       voucher.UpdatedAt,
 	}
 }
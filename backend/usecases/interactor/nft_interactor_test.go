@@ -3,14 +3,26 @@ package interactor
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"database/sql"
+	"io"
+	"math/big"
+	"strings"
 	"testing"
 
+	"nft-music/contracts"
 	"nft-music/domain"
 	"nft-music/usecases/gateways/mock"
 	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -30,13 +42,15 @@ func TestNftInteractor_List(t *testing.T) {
 
 	mockUserGateway := mock.NewMockUserGateway(ctrl)
 	mockTransactionGateway := mock.NewMockTransactionGateway(ctrl)
-	mockIpfsGateway := mock.NewMockIpfsGateway(ctrl)
+	mockIpfsGateway := expectPublicURL(mock.NewMockIpfsGateway(ctrl))
 	mockLogging := &NullLogging{}
+	chainRegistry, _ := newTestChainRegistry(t)
 
 	interactor := &NftInteractor{
 		UserGateway:        mockUserGateway,
 		TransactionGateway: mockTransactionGateway,
 		IpfsGateway:        mockIpfsGateway,
+		ChainRegistry:      chainRegistry,
 		Logging:            mockLogging,
 	}
 
@@ -63,3 +77,344 @@ func TestNftInteractor_List(t *testing.T) {
 		assert.Equal(t, "NFT Name", outputs[0].Name)
 	})
 }
+
+func TestNftInteractor_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionGateway := mock.NewMockTransactionGateway(ctrl)
+	mockVoucherGateway := mock.NewMockVoucherGateway(ctrl)
+	mockIpfsGateway := expectPublicURL(mock.NewMockIpfsGateway(ctrl))
+	chainRegistry, _ := newTestChainRegistry(t)
+
+	interactor := &NftInteractor{
+		TransactionGateway: mockTransactionGateway,
+		VoucherGateway:     mockVoucherGateway,
+		IpfsGateway:        mockIpfsGateway,
+		ChainRegistry:      chainRegistry,
+		Logging:            &NullLogging{},
+	}
+
+	minted := &domain.Transaction{ID: "0x123", ChainID: 1337, TokenURL: "/ipfs/QmMinted", Price: domain.NewAmount(big.NewInt(300))}
+	voucher := &domain.MintVoucher{ID: uuid.New(), ChainID: 1337, TokenURL: "/ipfs/QmLazy", Price: domain.NewAmount(big.NewInt(200)), Status: domain.VoucherStatusListed}
+	mockIpfsGateway.EXPECT().Get(gomock.Any(), "/ipfs/QmMinted").Return(&domain.IpfsJSON{Name: "Minted Song"}, nil).AnyTimes()
+	mockIpfsGateway.EXPECT().Get(gomock.Any(), "/ipfs/QmLazy").Return(&domain.IpfsJSON{Name: "Lazy Song"}, nil).AnyTimes()
+
+	t.Run("正常系: ミント済みのNFTと出品中のバウチャーを価格順に並べる", func(t *testing.T) {
+		mockTransactionGateway.EXPECT().Search(gomock.Any(), "", gomock.Any(), gomock.Any(), "price_asc").Return([]*domain.Transaction{minted}, nil)
+		mockVoucherGateway.EXPECT().Search(gomock.Any(), "", gomock.Any(), gomock.Any(), gomock.Any()).Return([]*domain.MintVoucher{voucher}, nil)
+
+		outputs, err := interactor.Search(context.Background(), "song", "", domain.Amount{}, domain.Amount{}, "price_asc", "")
+		require.NoError(t, err)
+		require.Len(t, outputs, 2)
+		assert.Equal(t, voucher.ID.String(), outputs[0].ID)
+		assert.Equal(t, domain.ListingStateLazy, outputs[0].ListingState)
+		assert.Empty(t, outputs[0].TokenID)
+		assert.Equal(t, "0x123", outputs[1].ID)
+		assert.Equal(t, domain.ListingStateMinted, outputs[1].ListingState)
+	})
+
+	t.Run("正常系: listing でどちらかに絞り込む", func(t *testing.T) {
+		mockVoucherGateway.EXPECT().Search(gomock.Any(), "", gomock.Any(), gomock.Any(), gomock.Any()).Return([]*domain.MintVoucher{voucher}, nil)
+
+		outputs, err := interactor.Search(context.Background(), "", "", domain.Amount{}, domain.Amount{}, "", domain.ListingStateLazy)
+		require.NoError(t, err)
+		require.Len(t, outputs, 1)
+		assert.Equal(t, "Lazy Song", outputs[0].Name)
+
+		mockTransactionGateway.EXPECT().Search(gomock.Any(), "", gomock.Any(), gomock.Any(), "").Return([]*domain.Transaction{minted}, nil)
+
+		outputs, err = interactor.Search(context.Background(), "", "", domain.Amount{}, domain.Amount{}, "", domain.ListingStateMinted)
+		require.NoError(t, err)
+		require.Len(t, outputs, 1)
+		assert.Equal(t, "Minted Song", outputs[0].Name)
+	})
+
+	t.Run("異常系: listing が正しくない", func(t *testing.T) {
+		_, err := interactor.Search(context.Background(), "", "", domain.Amount{}, domain.Amount{}, "", "burned")
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+}
+
+func TestNftInteractor_GetByToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionGateway := mock.NewMockTransactionGateway(ctrl)
+	mockIpfsGateway := expectPublicURL(mock.NewMockIpfsGateway(ctrl))
+	chainRegistry, _ := newTestChainRegistry(t)
+
+	interactor := &NftInteractor{
+		TransactionGateway: mockTransactionGateway,
+		IpfsGateway:        mockIpfsGateway,
+		ChainRegistry:      chainRegistry,
+		Logging:            &NullLogging{},
+	}
+
+	t.Run("正常系: チェックサムの無いアドレスでもトークンIDでNFTを取得できる", func(t *testing.T) {
+		contract := "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+		mockTransactionGateway.EXPECT().
+			GetByToken(gomock.Any(), 1337, contract, "7").
+			Return(&domain.Transaction{ID: "0x123", ChainID: 1337, ContractAddress: contract, TokenID: sql.NullString{String: "7", Valid: true}, TokenURL: "QmToken"}, nil)
+		mockIpfsGateway.EXPECT().
+			Get(gomock.Any(), "QmToken").
+			Return(&domain.IpfsJSON{Name: "NFT Name"}, nil)
+
+		output, err := interactor.GetByToken(context.Background(), 1337, strings.ToLower(contract), "07")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "7", output.TokenID)
+		assert.Equal(t, contract, output.ContractAddress)
+	})
+
+	t.Run("異常系: コントラクトアドレスが正しくない", func(t *testing.T) {
+		_, err := interactor.GetByToken(context.Background(), 1337, "0x123", "7")
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+
+	t.Run("異常系: トークンIDが正しくない", func(t *testing.T) {
+		_, err := interactor.GetByToken(context.Background(), 1337, "0x5FbDB2315678afecb367f032d93F642f64180aa3", "0")
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+}
+
+func TestNftInteractor_Master(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	creator := &ports.AuthUser{UserID: uuid.New(), Wallet: "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50", Role: domain.RoleCreator}
+	holder := &ports.AuthUser{UserID: uuid.New(), Wallet: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"}
+	other := &ports.AuthUser{UserID: uuid.New(), Wallet: "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"}
+	address := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+
+	backend := &fakeMarketBackend{items: []contracts.NFTMarketplaceMarketItem{
+		{TokenId: big.NewInt(1), Owner: common.HexToAddress(holder.Wallet), Price: big.NewInt(1000), Sold: true, Creator: common.HexToAddress(creator.Wallet)},
+	}}
+	marketplace := NewMarketplaceContract()
+	bound, err := contracts.NewContracts(address, backend)
+	require.NoError(t, err)
+	marketplace.Set(address, bound)
+
+	mockTransactionGateway := mock.NewMockTransactionGateway(ctrl)
+	mockIpfsGateway := expectPublicURL(mock.NewMockIpfsGateway(ctrl))
+	mockMediaGateway := mock.NewMockMediaGateway(ctrl)
+	chainRegistry, _ := newTestChainRegistry(t)
+	interactor := &NftInteractor{
+		TransactionGateway: mockTransactionGateway,
+		IpfsGateway:        mockIpfsGateway,
+		MediaGateway:       mockMediaGateway,
+		Marketplace:        marketplace,
+		ChainRegistry:      chainRegistry,
+		Logging:            &NullLogging{},
+	}
+
+	transaction := &domain.Transaction{ID: "0x123", UserID: creator.UserID, ContractAddress: address.Hex(), TokenID: sql.NullString{String: "1", Valid: true}, TokenURL: "QmToken"}
+	mockTransactionGateway.EXPECT().GetByTransactionid(gomock.Any(), "0x123").Return(transaction, nil).AnyTimes()
+	mockIpfsGateway.EXPECT().
+		Get(gomock.Any(), "QmToken").
+		Return(&domain.IpfsJSON{Name: "NFT Name", FileType: "audio", PreviewCid: "QmPreview", AudioSha256: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"}, nil).
+		AnyTimes()
+	mockMediaGateway.EXPECT().
+		GetBySha256(gomock.Any(), "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08").
+		Return(&domain.MediaFile{Cid: "QmMaster", UserID: creator.UserID, Kind: domain.MediaKindAudio, Sha256: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", PreviewCid: sql.NullString{String: "QmPreview", Valid: true}}, nil).
+		AnyTimes()
+
+	t.Run("正常系: クリエイターとトークンの所有者はフル音源を取得できる", func(t *testing.T) {
+		for _, authUser := range []*ports.AuthUser{creator, holder} {
+			output, err := interactor.Master(ports.WithAuthUser(context.Background(), authUser), "0x123")
+			require.NoError(t, err)
+			assert.Equal(t, "QmMaster", output.Cid)
+			assert.Equal(t, "/ipfs/QmMaster", output.Path)
+			assert.Equal(t, "/ipfs/QmPreview", output.PreviewPath)
+		}
+	})
+
+	t.Run("正常系: フル音源のコンテンツを取得できる", func(t *testing.T) {
+		mockIpfsGateway.EXPECT().Cat(gomock.Any(), "QmMaster").Return(io.NopCloser(strings.NewReader("master")), nil)
+
+		body, err := interactor.MasterContent(ports.WithAuthUser(context.Background(), holder), "0x123")
+		require.NoError(t, err)
+		defer body.Close()
+		content, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, "master", string(content))
+
+		_, err = interactor.MasterContent(ports.WithAuthUser(context.Background(), other), "0x123")
+		assert.ErrorContains(t, err, "Forbidden")
+	})
+
+	t.Run("異常系: 所有者ではない・ログインしていない", func(t *testing.T) {
+		_, err := interactor.Master(ports.WithAuthUser(context.Background(), other), "0x123")
+		assert.ErrorContains(t, err, "Forbidden")
+
+		_, err = interactor.Master(context.Background(), "0x123")
+		assert.ErrorContains(t, err, "Unauthorized")
+	})
+
+	t.Run("正常系: 公開するメタデータにはプレビューだけを返す", func(t *testing.T) {
+		output := interactor.outputPort(transaction, &domain.IpfsJSON{PreviewCid: "QmPreview", AudioSha256: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"})
+		assert.Empty(t, output.AudioURL)
+		assert.Equal(t, "https://ipfs.io/ipfs/QmPreview", output.PreviewURL)
+	})
+}
+
+func TestNftInteractor_WalletMint(t *testing.T) {
+	contract := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	creatorKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	creator := crypto.PubkeyToAddress(creatorKey.PublicKey)
+	userID := uuid.New()
+	authCtx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: userID, Wallet: creator.Hex(), Role: domain.RoleCreator})
+	input := &ports.NftInput{ChainID: 1337, Wallet: creator.Hex(), GenreID: uuid.New(), Insentive: 20}
+
+	newInteractor := func(t *testing.T) (*NftInteractor, *fakeMarketBackend, *mock.MockTransactionGateway) {
+		ctrl := gomock.NewController(t)
+		userGateway := mock.NewMockUserGateway(ctrl)
+		userGateway.EXPECT().GetByWallet(gomock.Any(), gomock.Any()).Return(&domain.User{ID: userID, Wallet: creator.Hex()}, nil).AnyTimes()
+		transactionGateway := mock.NewMockTransactionGateway(ctrl)
+
+		backend := &fakeMarketBackend{
+			listingPrice: big.NewInt(100),
+			txs:          map[common.Hash]*types.Transaction{},
+			receipts:     map[common.Hash]*types.Receipt{},
+		}
+		marketplace := NewMarketplaceContract()
+		bound, err := contracts.NewContracts(contract, backend)
+		require.NoError(t, err)
+		marketplace.Set(contract, bound)
+		chainRegistry, _ := newTestChainRegistry(t)
+
+		worker := NewMintWorker(transactionGateway, newTestIpfsGateway(t), newFakeMintBackend(), nil, nil, nil, marketplace, MintWorkerConfig{}, &NullLogging{})
+		return NewNftInteractor(userGateway, transactionGateway, nil, worker.IpfsGateway, nil, backend, marketplace, worker, chainRegistry, nil, MintModeWallet, &NullLogging{}, nil), backend, transactionGateway
+	}
+	prepared := func() *domain.Transaction {
+		return &domain.Transaction{
+			ID:              uuid.NewString(),
+			UserID:          userID,
+			ChainID:         1337,
+			ContractAddress: contract.Hex(),
+			TokenURL:        "/ipfs/QmToken",
+			Price:           domain.NewAmount(big.NewInt(100)),
+			Status:          domain.TransactionStatusPrepared,
+			CreatorAddress:  creator.Hex(),
+		}
+	}
+	sign := func(t *testing.T, key *ecdsa.PrivateKey, transaction *domain.Transaction, value int64) *types.Transaction {
+		data, err := packMarketplace("createToken", tokenURI(newTestIpfsGateway(t), transaction))
+		require.NoError(t, err)
+		tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(1337)), &types.DynamicFeeTx{
+			ChainID:   big.NewInt(1337),
+			Nonce:     3,
+			To:        &contract,
+			Value:     big.NewInt(value),
+			Gas:       120000,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(2),
+			Data:      data,
+		})
+		require.NoError(t, err)
+		return tx
+	}
+
+	t.Run("正常系: クリエイターのウォレットで署名する createToken を返す", func(t *testing.T) {
+		interactor, _, transactionGateway := newInteractor(t)
+		var created *domain.Transaction
+		transactionGateway.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, transaction *domain.Transaction) error {
+			created = transaction
+			return nil
+		})
+
+		output, err := interactor.Mint(authCtx, input, "QmToken")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.TransactionStatusPrepared, output.Status)
+		assert.Equal(t, creator.Hex(), created.CreatorAddress)
+		require.NotNil(t, output.Transaction)
+		assert.Equal(t, 1337, output.Transaction.ChainID)
+		assert.Equal(t, creator.Hex(), output.Transaction.From)
+		assert.Equal(t, contract.Hex(), output.Transaction.To)
+		assert.Equal(t, "100", output.Transaction.Value.String())
+		assert.Equal(t, uint64(3), output.Transaction.Nonce)
+		expected, err := packMarketplace("createToken", "https://ipfs.io/ipfs/QmToken")
+		require.NoError(t, err)
+		assert.Equal(t, expected, common.FromHex(output.Transaction.Data))
+		assert.Empty(t, interactor.MintWorker.jobs, "プラットフォームのウォレットでは送信しない")
+	})
+
+	t.Run("異常系: 接続していないチェーンではミントできない", func(t *testing.T) {
+		interactor, _, _ := newInteractor(t)
+		_, err := interactor.Mint(authCtx, &ports.NftInput{ChainID: 56, Wallet: creator.Hex()}, "QmToken")
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+
+	t.Run("正常系: 署名済みのトランザクションを送信して追跡する", func(t *testing.T) {
+		interactor, backend, transactionGateway := newInteractor(t)
+		transaction := prepared()
+		tx := sign(t, creatorKey, transaction, 100)
+		raw, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		transactionGateway.EXPECT().GetByTransactionid(gomock.Any(), transaction.ID).Return(transaction, nil)
+		transactionGateway.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		output, err := interactor.SubmitMint(authCtx, transaction.ID, &ports.MintSubmitInput{RawTx: hexutil.Encode(raw)})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.TransactionStatusSubmitted, output.Status)
+		assert.Equal(t, tx.Hash().Hex(), output.TxHash)
+		assert.Contains(t, backend.txs, tx.Hash())
+	})
+
+	t.Run("正常系: ウォレットが送信したトランザクションのハッシュを登録する", func(t *testing.T) {
+		interactor, backend, transactionGateway := newInteractor(t)
+		transaction := prepared()
+		tx := sign(t, creatorKey, transaction, 100)
+		backend.txs[tx.Hash()] = tx
+		transactionGateway.EXPECT().GetByTransactionid(gomock.Any(), transaction.ID).Return(transaction, nil)
+		transactionGateway.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		output, err := interactor.SubmitMint(authCtx, transaction.ID, &ports.MintSubmitInput{TxHash: tx.Hash().Hex()})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.TransactionStatusSubmitted, output.Status)
+		assert.Equal(t, 3, transaction.Nonce)
+	})
+
+	t.Run("異常系: ミントジョブと異なるトランザクションは登録できない", func(t *testing.T) {
+		interactor, backend, transactionGateway := newInteractor(t)
+		transaction := prepared()
+		transactionGateway.EXPECT().GetByTransactionid(gomock.Any(), transaction.ID).Return(transaction, nil).Times(2)
+
+		otherKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		for _, tx := range []*types.Transaction{sign(t, creatorKey, transaction, 1), sign(t, otherKey, transaction, 100)} {
+			raw, err := tx.MarshalBinary()
+			require.NoError(t, err)
+			_, err = interactor.SubmitMint(authCtx, transaction.ID, &ports.MintSubmitInput{RawTx: hexutil.Encode(raw)})
+			assert.ErrorContains(t, err, "BadRequest")
+		}
+		assert.Empty(t, backend.txs)
+	})
+
+	t.Run("異常系: 入力や状態が正しくない", func(t *testing.T) {
+		interactor, _, transactionGateway := newInteractor(t)
+		transaction := prepared()
+		transactionGateway.EXPECT().GetByTransactionid(gomock.Any(), transaction.ID).Return(transaction, nil).AnyTimes()
+
+		_, err := interactor.SubmitMint(authCtx, transaction.ID, &ports.MintSubmitInput{})
+		assert.ErrorContains(t, err, "BadRequest")
+
+		_, err = interactor.SubmitMint(authCtx, transaction.ID, &ports.MintSubmitInput{RawTx: "0x1234"})
+		assert.ErrorContains(t, err, "BadRequest")
+
+		_, err = interactor.SubmitMint(authCtx, transaction.ID, &ports.MintSubmitInput{TxHash: common.Hash{1}.Hex()})
+		assert.ErrorContains(t, err, "BadRequest")
+
+		otherCtx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: uuid.New(), Role: domain.RoleCreator})
+		_, err = interactor.SubmitMint(otherCtx, transaction.ID, &ports.MintSubmitInput{TxHash: common.Hash{1}.Hex()})
+		assert.ErrorContains(t, err, "Forbidden")
+
+		transaction.Status = domain.TransactionStatusSubmitted
+		_, err = interactor.SubmitMint(authCtx, transaction.ID, &ports.MintSubmitInput{TxHash: common.Hash{1}.Hex()})
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+}
@@ -0,0 +1,195 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+
+	"nft-music/usecases/gateways"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PendingNonceReader はpendingを含めたアドレスの次のノンスを取得する（*ethclient.Client が実装している）
+type PendingNonceReader interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// NonceManager はプラットフォームのウォレットから送信するトランザクションのノンスをアドレスごとに払い出す
+// 同時に送信しても同じノンスを使わないよう、予約・解放をロックの中で行う
+type NonceManager struct {
+	client    PendingNonceReader
+	feePolicy *FeePolicy // nil の場合はガス代もガスリミットも bind がノードから見積もる
+	mu        sync.Mutex
+	states    map[common.Address]*nonceState
+}
+
+type nonceState struct {
+	mu     sync.Mutex
+	synced bool
+	next   uint64   // 次に払い出すノンス
+	gaps   []uint64 // 送信に失敗して解放されたノンス（昇順）
+}
+
+func NewNonceManager(client PendingNonceReader, feePolicy *FeePolicy) *NonceManager {
+	return &NonceManager{
+		client:    client,
+		feePolicy: feePolicy,
+		states:    map[common.Address]*nonceState{},
+	}
+}
+
+func (manager *NonceManager) state(address common.Address) *nonceState {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	state, ok := manager.states[address]
+	if !ok {
+		state = &nonceState{}
+		manager.states[address] = state
+	}
+	return state
+}
+
+// Reserve はノンスを1つ予約する。解放されたノンスがあればそれを優先して使う
+func (manager *NonceManager) Reserve(ctx context.Context, address common.Address) (uint64, error) {
+	state := manager.state(address)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	// 他のプロセスやウォレットから送られたpendingのトランザクションを反映する
+	pending, err := manager.client.PendingNonceAt(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+	if !state.synced || pending > state.next {
+		state.next = pending
+		state.synced = true
+	}
+	state.gaps = slices.DeleteFunc(state.gaps, func(nonce uint64) bool { return nonce < pending })
+
+	if len(state.gaps) > 0 {
+		nonce := state.gaps[0]
+		state.gaps = state.gaps[1:]
+		return nonce, nil
+	}
+
+	nonce := state.next
+	state.next++
+	return nonce, nil
+}
+
+// Release は送信に失敗したノンスを解放し、次の予約で使えるようにする
+func (manager *NonceManager) Release(address common.Address, nonce uint64) {
+	state := manager.state(address)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if nonce+1 == state.next {
+		state.next--
+		// 末尾が解放済みのノンスで埋まる場合はまとめて戻す
+		for len(state.gaps) > 0 && state.gaps[len(state.gaps)-1]+1 == state.next {
+			state.next--
+			state.gaps = state.gaps[:len(state.gaps)-1]
+		}
+		return
+	}
+	if nonce < state.next && !slices.Contains(state.gaps, nonce) {
+		state.gaps = append(state.gaps, nonce)
+		slices.Sort(state.gaps)
+	}
+}
+
+// Resync はローカルの状態を破棄し、次の予約でノードのpendingノンスから数え直す
+func (manager *NonceManager) Resync(address common.Address) {
+	state := manager.state(address)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.synced = false
+	state.next = 0
+	state.gaps = nil
+}
+
+// Send はノンスを予約し、ガス代を設定してトランザクションを送信する
+// ノンスの競合で失敗した場合は数え直して1度だけ再送し、それ以外の失敗ではノンスを解放する
+func (manager *NonceManager) Send(ctx context.Context, signer gateways.Signer, chainID *big.Int, value *big.Int, send func(opts *bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	address := signer.Address()
+	for attempt := 0; ; attempt++ {
+		opts := newTransactOpts(ctx, signer, chainID)
+		opts.Value = value
+		if manager.feePolicy != nil {
+			if err := manager.feePolicy.Apply(ctx, opts); err != nil {
+				return nil, err
+			}
+		}
+
+		nonce, err := manager.Reserve(ctx, address)
+		if err != nil {
+			return nil, err
+		}
+		opts.Nonce = new(big.Int).SetUint64(nonce)
+
+		tx, err := send(opts)
+		if err == nil {
+			return tx, nil
+		}
+		if isNonceError(err) {
+			manager.Resync(address)
+			if attempt == 0 {
+				continue
+			}
+			return nil, err
+		}
+		manager.Release(address, nonce)
+		return nil, err
+	}
+}
+
+// Sign はノンスを予約し、ガス代を設定して署名したトランザクションを返す（送信はしない）
+// 送信する前にノンスとハッシュを記録するために使い、送信に失敗した場合は Abandon でノンスを戻す
+func (manager *NonceManager) Sign(ctx context.Context, signer gateways.Signer, chainID *big.Int, value *big.Int, sign func(opts *bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	address := signer.Address()
+	opts := newTransactOpts(ctx, signer, chainID)
+	opts.Value = value
+	opts.NoSend = true
+	if manager.feePolicy != nil {
+		if err := manager.feePolicy.Apply(ctx, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	nonce, err := manager.Reserve(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+
+	tx, err := sign(opts)
+	if err != nil {
+		manager.Release(address, nonce)
+		return nil, err
+	}
+	return tx, nil
+}
+
+// Abandon は送信できなかったトランザクションのノンスを戻す（ノンスの競合の場合は数え直す）
+func (manager *NonceManager) Abandon(address common.Address, nonce uint64, err error) {
+	if isNonceError(err) {
+		manager.Resync(address)
+		return
+	}
+	manager.Release(address, nonce)
+}
+
+// isNonceError はノードのpendingと手元のノンスがずれていることを示すエラーか判定する
+func isNonceError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "nonce too low") ||
+		strings.Contains(msg, "already known") ||
+		strings.Contains(msg, "known transaction") ||
+		strings.Contains(msg, "replacement transaction underpriced")
+}
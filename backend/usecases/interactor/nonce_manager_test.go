@@ -0,0 +1,143 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"nft-music/usecases/gateways/mock"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakePendingNonce はノードのpendingノンスを返すテスト用の実装
+type fakePendingNonce struct {
+	mu      sync.Mutex
+	pending uint64
+}
+
+func (fake *fakePendingNonce) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	return fake.pending, nil
+}
+
+func (fake *fakePendingNonce) set(pending uint64) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	fake.pending = pending
+}
+
+func TestNonceManager(t *testing.T) {
+	address := common.HexToAddress("0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50")
+	ctx := context.Background()
+
+	t.Run("正常系: 同時に予約しても同じノンスを払い出さない", func(t *testing.T) {
+		manager := NewNonceManager(&fakePendingNonce{pending: 5}, nil)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		nonces := map[uint64]bool{}
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				nonce, err := manager.Reserve(ctx, address)
+				assert.NoError(t, err)
+				mu.Lock()
+				nonces[nonce] = true
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, nonces, 20)
+		for nonce := uint64(5); nonce < 25; nonce++ {
+			assert.True(t, nonces[nonce])
+		}
+	})
+
+	t.Run("正常系: 解放したノンスを次の予約で使う", func(t *testing.T) {
+		manager := NewNonceManager(&fakePendingNonce{pending: 0}, nil)
+
+		first, _ := manager.Reserve(ctx, address)
+		second, _ := manager.Reserve(ctx, address)
+		third, _ := manager.Reserve(ctx, address)
+		assert.Equal(t, []uint64{0, 1, 2}, []uint64{first, second, third})
+
+		manager.Release(address, second)
+		next, _ := manager.Reserve(ctx, address)
+		assert.Equal(t, second, next)
+
+		// 末尾のノンスを解放した場合はそのまま数を戻す
+		manager.Release(address, third)
+		next, _ = manager.Reserve(ctx, address)
+		assert.Equal(t, third, next)
+	})
+
+	t.Run("正常系: ノードのpendingが進んでいる場合はそれに合わせる", func(t *testing.T) {
+		node := &fakePendingNonce{pending: 3}
+		manager := NewNonceManager(node, nil)
+
+		nonce, _ := manager.Reserve(ctx, address)
+		assert.Equal(t, uint64(3), nonce)
+
+		node.set(10) // 別のプロセスから送信された
+		nonce, _ = manager.Reserve(ctx, address)
+		assert.Equal(t, uint64(10), nonce)
+	})
+}
+
+func TestNonceManager_Send(t *testing.T) {
+	ctx := context.Background()
+	address := common.HexToAddress("0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50")
+	chainID := big.NewInt(1337)
+
+	newSigner := func(t *testing.T) *mock.MockSigner {
+		ctrl := gomock.NewController(t)
+		signer := mock.NewMockSigner(ctrl)
+		signer.EXPECT().Address().Return(address).AnyTimes()
+		return signer
+	}
+
+	t.Run("正常系: nonce too lowの場合は数え直して再送する", func(t *testing.T) {
+		node := &fakePendingNonce{pending: 0}
+		manager := NewNonceManager(node, nil)
+		_, _ = manager.Reserve(ctx, address) // 手元では0を使用済み
+
+		var used []uint64
+		tx, err := manager.Send(ctx, newSigner(t), chainID, big.NewInt(0), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			used = append(used, opts.Nonce.Uint64())
+			if len(used) == 1 {
+				node.set(4) // 他から送信されてノードが先に進んでいた
+				return nil, errors.New("nonce too low: next nonce 4, tx nonce 1")
+			}
+			return types.NewTx(&types.LegacyTx{Nonce: opts.Nonce.Uint64()}), nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{1, 4}, used)
+		assert.Equal(t, uint64(4), tx.Nonce())
+	})
+
+	t.Run("異常系: 送信に失敗したノンスは解放される", func(t *testing.T) {
+		manager := NewNonceManager(&fakePendingNonce{pending: 7}, nil)
+
+		_, err := manager.Send(ctx, newSigner(t), chainID, big.NewInt(0), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return nil, errors.New("execution reverted")
+		})
+		assert.Error(t, err)
+
+		nonce, err := manager.Reserve(ctx, address)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(7), nonce)
+	})
+}
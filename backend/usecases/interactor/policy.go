@@ -0,0 +1,53 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"nft-music/domain"
+	"nft-music/usecases/ports"
+)
+
+// Action は権限を確認する操作の種類
+type Action string
+
+// 権限を確認する操作
+const (
+	ActionMintNft          Action = "nft:mint"
+	ActionCreateCollection Action = "collection:create"
+	ActionWriteMaster      Action = "master:write"
+	ActionChangeRole       Action = "user:role"
+	ActionDeployContract   Action = "contract:deploy"
+	ActionReconcile        Action = "chain:reconcile"
+	ActionOperateContract  Action = "contract:operate"
+	ActionMaintenance      Action = "system:maintenance"
+	ActionReplaceTx        Action = "chain:replace"
+)
+
+// policies は操作ごとに許可するロール
+var policies = map[Action][]string{
+	ActionMintNft:          {domain.RoleCreator, domain.RoleAdmin},
+	ActionCreateCollection: {domain.RoleCreator, domain.RoleAdmin},
+	ActionWriteMaster:      {domain.RoleAdmin},
+	ActionChangeRole:       {domain.RoleAdmin},
+	ActionDeployContract:   {domain.RoleAdmin},
+	ActionReconcile:        {domain.RoleAdmin},
+	ActionOperateContract:  {domain.RoleAdmin},
+	ActionMaintenance:      {domain.RoleAdmin},
+	ActionReplaceTx:        {domain.RoleAdmin},
+}
+
+// authorize はリクエストしたユーザーのロールで操作が許可されているか確認する
+func authorize(ctx context.Context, action Action) error {
+	authUser, ok := ports.AuthUserFrom(ctx)
+	if !ok {
+		return errors.New("Unauthorized: ログインが必要です")
+	}
+	if !slices.Contains(policies[action], authUser.Role) {
+		return fmt.Errorf("Forbidden: %s の権限では %s は許可されていません", authUser.Role, action)
+	}
+	return nil
+}
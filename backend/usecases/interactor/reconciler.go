@@ -0,0 +1,307 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"nft-music/domain"
+	"nft-music/usecases/gateways"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+	"nft-music/util"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ReconcilerConfig は照合の設定
+type ReconcilerConfig struct {
+	Interval      time.Duration // 定期的に照合する間隔
+	Repair        bool          // 定期的な照合で安全に直せる食い違いを直す
+	StaleAfter    time.Duration // これより新しく更新された未完了のミントジョブは MintWorker が処理中として照合しない
+	Confirmations uint64        // confirmed とみなす承認数（MintWorker と同じ値）
+}
+
+// Reconciler はトランザクションの行とチェーンを照合して食い違いを報告する
+//
+//	FetchAllMarketItems → 行の無いトークン（missing_row）・チェーンに無いトークン（missing_token）
+//	ミントのレシート     → ステータス（stale_status）・トークンID（token_id）・価格（wrong_price）
+//	ownerOf            → 取り込んだTransferの所有者（wrong_owner）
+//
+// チェーンの値が正しいと言えるステータス・トークンID・価格だけを直す。
+// 行の無いトークンや所有者の食い違いは報告のみで、所有者は go run . backfill で取り込み直す
+type Reconciler struct {
+	TransactionGateway gateways.TransactionGateway
+	MarketEventGateway gateways.MarketEventGateway
+	Client             MintBackend
+	Marketplace        *MarketplaceContract
+	Config             ReconcilerConfig
+	Logging            logging.Logging
+
+	mu   sync.Mutex // 照合を同時に実行しない
+	last *domain.DriftReport
+}
+
+func NewReconciler(transactionGateway gateways.TransactionGateway, marketEventGateway gateways.MarketEventGateway, client MintBackend, marketplace *MarketplaceContract, config ReconcilerConfig, logging logging.Logging) *Reconciler {
+	if config.Interval <= 0 {
+		config.Interval = time.Hour
+	}
+	if config.StaleAfter <= 0 {
+		config.StaleAfter = 10 * time.Minute
+	}
+	if config.Confirmations == 0 {
+		config.Confirmations = 1
+	}
+	return &Reconciler{
+		TransactionGateway: transactionGateway,
+		MarketEventGateway: marketEventGateway,
+		Client:             client,
+		Marketplace:        marketplace,
+		Config:             config,
+		Logging:            logging,
+	}
+}
+
+// Run は Interval ごとに照合する（ctx が終了するまで戻らない）
+func (reconciler *Reconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(reconciler.Config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := reconciler.reconcile(ctx, reconciler.Config.Repair); err != nil {
+			reconciler.Logging.Warning(fmt.Sprintf("failed to reconcile transactions: %s", err.Error()))
+		}
+	}
+}
+
+// Reconcile はすぐに照合して結果を返す（管理者のみ）
+func (reconciler *Reconciler) Reconcile(ctx context.Context, input *ports.ReconcileInput) (*ports.DriftReportOutput, error) {
+	if err := authorize(ctx, ActionReconcile); err != nil {
+		return nil, err
+	}
+	report, err := reconciler.reconcile(ctx, input.Repair)
+	if err != nil {
+		return nil, err
+	}
+	return driftReportOutput(report), nil
+}
+
+// LastReport は最後に照合した結果を返す（管理者のみ）
+func (reconciler *Reconciler) LastReport(ctx context.Context) (*ports.DriftReportOutput, error) {
+	if err := authorize(ctx, ActionReconcile); err != nil {
+		return nil, err
+	}
+	reconciler.mu.Lock()
+	defer reconciler.mu.Unlock()
+	if reconciler.last == nil {
+		return nil, errors.New("record not found: まだ照合していません")
+	}
+	return driftReportOutput(reconciler.last), nil
+}
+
+func (reconciler *Reconciler) reconcile(ctx context.Context, repair bool) (*domain.DriftReport, error) {
+	reconciler.mu.Lock()
+	defer reconciler.mu.Unlock()
+
+	contract, address, err := reconciler.Marketplace.Get()
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := reconciler.Client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	items, err := contract.FetchAllMarketItems(opts)
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := reconciler.TransactionGateway.ListByContract(ctx, int(chainID.Int64()), address.Hex())
+	if err != nil {
+		return nil, err
+	}
+	owners, err := reconciler.MarketEventGateway.ListOwners(ctx, int(chainID.Int64()), address.Hex())
+	if err != nil {
+		return nil, err
+	}
+	head, err := reconciler.Client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.DriftReport{
+		ChainID:         int(chainID.Int64()),
+		ContractAddress: address.Hex(),
+		Tokens:          len(items),
+		Transactions:    len(transactions),
+		Repair:          repair,
+		StartedAt:       util.JapaneseNowTime(),
+	}
+
+	tokens := make(map[string]bool, len(items))
+	for _, item := range items {
+		tokens[item.TokenId.String()] = true
+	}
+	rows := make(map[string]bool, len(transactions))
+	for _, transaction := range transactions {
+		drifts, err := reconciler.reconcileRow(ctx, transaction, head, repair)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconcile transaction %s: %w", transaction.ID, err)
+		}
+		report.Drifts = append(report.Drifts, drifts...)
+
+		if !transaction.TokenID.Valid {
+			continue
+		}
+		rows[transaction.TokenID.String] = true
+		if !tokens[transaction.TokenID.String] {
+			report.Drifts = append(report.Drifts, domain.Drift{Kind: domain.DriftMissingToken, TransactionID: transaction.ID, TokenID: transaction.TokenID.String, Actual: transaction.TokenID.String})
+		}
+	}
+
+	for _, item := range items {
+		tokenID := item.TokenId.String()
+		if !rows[tokenID] {
+			report.Drifts = append(report.Drifts, domain.Drift{Kind: domain.DriftMissingRow, TokenID: tokenID, Expected: item.Creator.Hex()})
+		}
+
+		// まだ取り込んでいないトークンはインデクサーに任せる
+		indexed, ok := owners[tokenID]
+		if !ok {
+			continue
+		}
+		owner, err := contract.OwnerOf(opts, item.TokenId)
+		if err != nil {
+			return nil, err
+		}
+		if !util.SameAddress(owner.Hex(), indexed) {
+			report.Drifts = append(report.Drifts, domain.Drift{Kind: domain.DriftWrongOwner, TokenID: tokenID, Expected: owner.Hex(), Actual: indexed})
+		}
+	}
+
+	report.FinishedAt = util.JapaneseNowTime()
+	reconciler.last = report
+	if len(report.Drifts) > 0 {
+		reconciler.Logging.Warning(fmt.Sprintf("found %d drifts between transactions and %s on chain %d", len(report.Drifts), report.ContractAddress, report.ChainID))
+	}
+	return report, nil
+}
+
+// reconcileRow はミントのレシートと行を照合し、repair の場合はチェーンに合わせて行を直す
+func (reconciler *Reconciler) reconcileRow(ctx context.Context, transaction *domain.Transaction, head uint64, repair bool) ([]domain.Drift, error) {
+	// 未送信のジョブと、MintWorker が処理中のジョブは照合しない
+	if !transaction.TxHash.Valid {
+		return nil, nil
+	}
+	pending := transaction.Status != domain.TransactionStatusConfirmed && transaction.Status != domain.TransactionStatusFailed
+	if pending && util.JapaneseNowTime().Sub(transaction.UpdatedAt) < reconciler.Config.StaleAfter {
+		return nil, nil
+	}
+
+	hash := common.HexToHash(transaction.TxHash.String)
+	receipt, err := reconciler.Client.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		// 送信に失敗したジョブにはレシートが無い。取り込まれていないジョブはreorgか破棄の可能性があるため報告のみ
+		if transaction.Status == domain.TransactionStatusFailed {
+			return nil, nil
+		}
+		return []domain.Drift{{Kind: domain.DriftStaleStatus, TransactionID: transaction.ID, TokenID: transaction.TokenID.String, Expected: "not mined", Actual: transaction.Status}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []domain.Drift
+	status := domain.TransactionStatusFailed
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		status = domain.TransactionStatusMined
+		if head+1 >= receipt.BlockNumber.Uint64()+reconciler.Config.Confirmations {
+			status = domain.TransactionStatusConfirmed
+		}
+	}
+	if transaction.Status != status {
+		drifts = append(drifts, domain.Drift{Kind: domain.DriftStaleStatus, Expected: status, Actual: transaction.Status})
+		transaction.Status = status
+		transaction.BlockNumber = sql.NullInt64{Int64: receipt.BlockNumber.Int64(), Valid: true}
+		transaction.RevertReason = sql.NullString{}
+		if status == domain.TransactionStatusFailed {
+			transaction.RevertReason = sql.NullString{String: truncateRevertReason(revertReason(ctx, reconciler.Client, hash, receipt.BlockNumber)), Valid: true}
+		}
+	}
+
+	if status != domain.TransactionStatusFailed {
+		drifts = append(drifts, reconciler.reconcileItem(transaction, receipt)...)
+	}
+
+	for i := range drifts {
+		drifts[i].TransactionID = transaction.ID
+		drifts[i].TokenID = transaction.TokenID.String
+	}
+	if len(drifts) == 0 || !repair {
+		return drifts, nil
+	}
+
+	transaction.UpdatedAt = util.JapaneseNowTime()
+	if err := reconciler.TransactionGateway.Update(ctx, transaction); err != nil {
+		return nil, err
+	}
+	for i := range drifts {
+		drifts[i].Repaired = true
+	}
+	return drifts, nil
+}
+
+// reconcileItem はミントのレシートのログからトークンIDと価格を照合する
+func (reconciler *Reconciler) reconcileItem(transaction *domain.Transaction, receipt *types.Receipt) []domain.Drift {
+	item, err := mintedItem(transaction.ContractAddress, receipt)
+	if err != nil {
+		reconciler.Logging.Warning(fmt.Sprintf("failed to get token id of transaction %s: %s", transaction.ID, err.Error()))
+		return nil
+	}
+
+	var drifts []domain.Drift
+	if tokenID := item.TokenId.String(); transaction.TokenID.String != tokenID {
+		drifts = append(drifts, domain.Drift{Kind: domain.DriftTokenID, Expected: tokenID, Actual: transaction.TokenID.String})
+		transaction.TokenID = sql.NullString{String: tokenID, Valid: true}
+	}
+	if item.Price != nil && transaction.Price.Wei().Cmp(item.Price) != 0 {
+		drifts = append(drifts, domain.Drift{Kind: domain.DriftWrongPrice, Expected: item.Price.String(), Actual: transaction.Price.String()})
+		transaction.Price = domain.NewAmount(item.Price)
+	}
+	return drifts
+}
+
+func driftReportOutput(report *domain.DriftReport) *ports.DriftReportOutput {
+	drifts := make([]ports.DriftOutput, 0, len(report.Drifts))
+	for _, drift := range report.Drifts {
+		drifts = append(drifts, ports.DriftOutput{
+			Kind:          drift.Kind,
+			TransactionID: drift.TransactionID,
+			TokenID:       drift.TokenID,
+			Expected:      drift.Expected,
+			Actual:        drift.Actual,
+			Repaired:      drift.Repaired,
+		})
+	}
+	return &ports.DriftReportOutput{
+		ChainID:         report.ChainID,
+		ContractAddress: report.ContractAddress,
+		Tokens:          report.Tokens,
+		Transactions:    report.Transactions,
+		Repair:          report.Repair,
+		Drifts:          drifts,
+		StartedAt:       report.StartedAt,
+		FinishedAt:      report.FinishedAt,
+	}
+}
@@ -0,0 +1,163 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"testing"
+	"time"
+
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/usecases/gateways/mock"
+	"nft-music/usecases/ports"
+	"nft-music/util"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReconciler(t *testing.T) {
+	contract := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	creator := common.HexToAddress("0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50")
+	buyer := common.HexToAddress("0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0")
+	admin := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: uuid.New(), Role: domain.RoleAdmin})
+
+	// チェーンにはトークン1〜3があり、3はDBに行が無い
+	market := &fakeMarketBackend{items: []contracts.NFTMarketplaceMarketItem{
+		{TokenId: big.NewInt(1), Seller: creator, Owner: contract, Price: big.NewInt(100), Creator: creator},
+		{TokenId: big.NewInt(2), Seller: creator, Owner: contract, Price: big.NewInt(200), Creator: creator},
+		{TokenId: big.NewInt(3), Seller: creator, Owner: contract, Price: big.NewInt(300), Creator: creator},
+	}}
+	created := func(tokenID int64, price int64) []types.Log {
+		return []types.Log{
+			marketLog(t, contract, "Transfer", common.Address{}, creator, big.NewInt(tokenID)),
+			marketLog(t, contract, "MarketItemCreated", big.NewInt(tokenID), creator, contract, big.NewInt(price), false, creator),
+		}
+	}
+	backend := newFakeMintBackend()
+	backend.mine(common.HexToHash("0x01"), 10, types.ReceiptStatusSuccessful, created(1, 100)...)
+	backend.mine(common.HexToHash("0x02"), 11, types.ReceiptStatusSuccessful, created(2, 200)...)
+	backend.mine(common.HexToHash("0x03"), 12, types.ReceiptStatusFailed)
+	backend.head = 20
+
+	stale := util.JapaneseNowTime().Add(-time.Hour)
+	row := func(id string, hash string, status string, tokenID string, price int64, updatedAt time.Time) *domain.Transaction {
+		transaction := &domain.Transaction{
+			ID:              id,
+			ContractAddress: contract.Hex(),
+			Status:          status,
+			Price:           domain.NewAmount(big.NewInt(price)),
+			UpdatedAt:       updatedAt,
+		}
+		if hash != "" {
+			transaction.TxHash = sql.NullString{String: common.HexToHash(hash).Hex(), Valid: true}
+		}
+		if tokenID != "" {
+			transaction.TokenID = sql.NullString{String: tokenID, Valid: true}
+		}
+		return transaction
+	}
+	rows := func() []*domain.Transaction {
+		return []*domain.Transaction{
+			row("ok", "0x01", domain.TransactionStatusConfirmed, "1", 100, stale),
+			// レシートは成功しているが submitted のまま、トークンIDが無く価格も異なる
+			row("stuck", "0x02", domain.TransactionStatusSubmitted, "", 150, stale),
+			// revertしたが submitted のまま
+			row("reverted", "0x03", domain.TransactionStatusSubmitted, "", 100, stale),
+			// MintWorker が処理中
+			row("tracking", "0x04", domain.TransactionStatusSubmitted, "", 100, util.JapaneseNowTime()),
+			// チェーンに無いトークン
+			row("orphan", "", domain.TransactionStatusConfirmed, "9", 100, stale),
+		}
+	}
+
+	newReconciler := func(t *testing.T) (*Reconciler, *mock.MockTransactionGateway) {
+		bound, err := contracts.NewContracts(contract, market)
+		require.NoError(t, err)
+		marketplace := NewMarketplaceContract()
+		marketplace.Set(contract, bound)
+
+		transactionGateway := mock.NewMockTransactionGateway(gomock.NewController(t))
+		transactionGateway.EXPECT().ListByContract(gomock.Any(), 1337, contract.Hex()).Return(rows(), nil).AnyTimes()
+		// インデクサーはトークン1を購入者に移転したと記録している
+		marketEventGateway := &fakeMarketEventGateway{transfers: []domain.TokenTransfer{
+			{TokenID: "1", ToAddress: contract.Hex()},
+			{TokenID: "1", ToAddress: buyer.Hex()},
+		}}
+		reconciler := NewReconciler(transactionGateway, marketEventGateway, backend, marketplace, ReconcilerConfig{Confirmations: 3}, &NullLogging{})
+		return reconciler, transactionGateway
+	}
+
+	kinds := func(report *ports.DriftReportOutput) map[string][]string {
+		found := map[string][]string{}
+		for _, drift := range report.Drifts {
+			found[drift.Kind] = append(found[drift.Kind], drift.TransactionID+":"+drift.Expected+":"+drift.Actual)
+		}
+		return found
+	}
+
+	t.Run("正常系: 食い違いを報告し、安全なものだけチェーンに合わせて直す", func(t *testing.T) {
+		reconciler, transactionGateway := newReconciler(t)
+		updated := map[string]*domain.Transaction{}
+		transactionGateway.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, transaction *domain.Transaction) error {
+			updated[transaction.ID] = transaction
+			return nil
+		}).Times(2)
+
+		report, err := reconciler.Reconcile(admin, &ports.ReconcileInput{Repair: true})
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.Tokens)
+		assert.Equal(t, 5, report.Transactions)
+		assert.Equal(t, map[string][]string{
+			domain.DriftStaleStatus:  {"stuck:confirmed:submitted", "reverted:failed:submitted"},
+			domain.DriftTokenID:      {"stuck:2:"},
+			domain.DriftWrongPrice:   {"stuck:200:150"},
+			domain.DriftMissingToken: {"orphan::9"},
+			domain.DriftMissingRow:   {":" + creator.Hex() + ":"},
+			domain.DriftWrongOwner:   {":" + contract.Hex() + ":" + buyer.Hex()},
+		}, kinds(report))
+		for _, drift := range report.Drifts {
+			assert.Equal(t, drift.TransactionID == "stuck" || drift.TransactionID == "reverted", drift.Repaired, drift.Kind)
+		}
+
+		assert.Equal(t, domain.TransactionStatusConfirmed, updated["stuck"].Status)
+		assert.Equal(t, "2", updated["stuck"].TokenID.String)
+		assert.Equal(t, "200", updated["stuck"].Price.String())
+		assert.Equal(t, domain.TransactionStatusFailed, updated["reverted"].Status)
+		assert.Equal(t, "transaction reverted", updated["reverted"].RevertReason.String)
+
+		last, err := reconciler.LastReport(admin)
+		require.NoError(t, err)
+		assert.Equal(t, report, last)
+	})
+
+	t.Run("正常系: repairを指定しない場合は報告のみ", func(t *testing.T) {
+		reconciler, _ := newReconciler(t)
+
+		report, err := reconciler.Reconcile(admin, &ports.ReconcileInput{})
+		require.NoError(t, err)
+		assert.Len(t, report.Drifts, 7)
+		for _, drift := range report.Drifts {
+			assert.False(t, drift.Repaired)
+		}
+	})
+
+	t.Run("異常系: まだ照合していない", func(t *testing.T) {
+		reconciler, _ := newReconciler(t)
+		_, err := reconciler.LastReport(admin)
+		assert.ErrorContains(t, err, "record not found")
+	})
+
+	t.Run("異常系: 管理者以外は照合できない", func(t *testing.T) {
+		reconciler, _ := newReconciler(t)
+		creatorCtx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: uuid.New(), Role: domain.RoleCreator})
+		_, err := reconciler.Reconcile(creatorCtx, &ports.ReconcileInput{Repair: true})
+		assert.ErrorContains(t, err, "Forbidden")
+	})
+}
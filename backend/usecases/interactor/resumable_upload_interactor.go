@@ -0,0 +1,276 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"nft-music/domain"
+	"nft-music/usecases/gateways"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+	"nft-music/util"
+
+	"github.com/google/uuid"
+)
+
+// ResumableUploadConfig は tus の分割アップロードの設定
+type ResumableUploadConfig struct {
+	Expiry        time.Duration // 最後に受信してから削除するまでの時間（既定 24時間）
+	SweepInterval time.Duration // 期限切れのアップロードを削除する間隔（既定 1時間）
+}
+
+// ResumableUploadInteractor は tus プロトコルの分割アップロードのユースケース
+// 接続が切れても受信済みの位置から再開でき、最後まで受信したら IpfsInteractor.Upload で IPFS に追加する
+type ResumableUploadInteractor struct {
+	ResumableUploadGateway gateways.ResumableUploadGateway
+	IpfsInteractor         *IpfsInteractor
+	Config                 ResumableUploadConfig
+	Logging                logging.Logging
+
+	mu     sync.Mutex
+	locked map[uuid.UUID]bool // 書き込み中のアップロード（同じアップロードへの同時の PATCH は受け付けない）
+}
+
+func NewResumableUploadInteractor(resumableUploadGateway gateways.ResumableUploadGateway, ipfsInteractor *IpfsInteractor, config ResumableUploadConfig, logging logging.Logging) *ResumableUploadInteractor {
+	if config.Expiry <= 0 {
+		config.Expiry = 24 * time.Hour
+	}
+	if config.SweepInterval <= 0 {
+		config.SweepInterval = time.Hour
+	}
+	return &ResumableUploadInteractor{
+		ResumableUploadGateway: resumableUploadGateway,
+		IpfsInteractor:         ipfsInteractor,
+		Config:                 config,
+		Logging:                logging,
+		locked:                 map[uuid.UUID]bool{},
+	}
+}
+
+// MaxSize は受け付けるファイルの上限（Tus-Max-Size）
+func (interactor *ResumableUploadInteractor) MaxSize() int64 {
+	return interactor.IpfsInteractor.Config.maxSize()
+}
+
+// Create はアップロードを作成する（Upload-Defer-Length には対応しない）
+func (interactor *ResumableUploadInteractor) Create(ctx context.Context, input ports.ResumableUploadInput) (*ports.ResumableUploadOutput, error) {
+	authUser, ok := ports.AuthUserFrom(ctx)
+	if !ok {
+		return nil, errors.New("Unauthorized: ログインが必要です")
+	}
+	filename := input.Metadata["filename"]
+	if filename == "" {
+		return nil, errors.New("BadRequest: Upload-Metadata に filename がありません")
+	}
+	if input.Size <= 0 {
+		return nil, errors.New("BadRequest: Upload-Length が正しくありません")
+	}
+	if limit := interactor.IpfsInteractor.Config.limit(filename); input.Size > limit {
+		return nil, fmt.Errorf("PayloadTooLarge: ファイルサイズが上限（%d バイト）を超えています", limit)
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	now := util.JapaneseNowTime()
+	upload := &domain.ResumableUpload{
+		ID:        id,
+		UserID:    authUser.UserID,
+		Wallet:    authUser.Wallet,
+		Filename:  filename,
+		Size:      input.Size,
+		Metadata:  input.Metadata,
+		ExpiresAt: now.Add(interactor.Config.Expiry),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := interactor.ResumableUploadGateway.Create(ctx, upload); err != nil {
+		return nil, err
+	}
+	return resumableUploadOutput(upload), nil
+}
+
+// Get は受信済みのバイト数を返す（HEAD）。完了している場合は IPFS に追加した結果も返す
+func (interactor *ResumableUploadInteractor) Get(ctx context.Context, id uuid.UUID) (*ports.ResumableUploadOutput, error) {
+	upload, err := interactor.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return resumableUploadOutput(upload), nil
+}
+
+// Append は offset の位置から受信した内容を追記する（PATCH）
+// 接続が切れた場合も受信できた分は残し、クライアントは HEAD で受信済みの位置を確認して再開する
+// 最後まで受信したら IPFS に追加する。追加に失敗した場合は、同じ offset で空の PATCH を送ると追加し直す
+func (interactor *ResumableUploadInteractor) Append(ctx context.Context, id uuid.UUID, offset int64, content io.Reader) (*ports.ResumableUploadOutput, error) {
+	if !interactor.lock(id) {
+		return nil, fmt.Errorf("Conflict: アップロード %s は別のリクエストで書き込み中です", id)
+	}
+	defer interactor.unlock(id)
+
+	upload, err := interactor.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if offset != upload.Offset {
+		return nil, fmt.Errorf("Conflict: Upload-Offset %d が受信済みのバイト数 %d と一致しません", offset, upload.Offset)
+	}
+
+	if upload.Offset < upload.Size {
+		written, err := interactor.ResumableUploadGateway.Append(ctx, id, offset, io.LimitReader(content, upload.Size-upload.Offset))
+		upload.Offset += written
+		upload.UpdatedAt = util.JapaneseNowTime()
+		upload.ExpiresAt = upload.UpdatedAt.Add(interactor.Config.Expiry)
+		if updateErr := interactor.ResumableUploadGateway.Update(ctx, upload); updateErr != nil {
+			return nil, updateErr
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if n, _ := content.Read(make([]byte, 1)); n > 0 {
+		return nil, errors.New("BadRequest: Upload-Length を超えて送信されました")
+	}
+
+	if upload.Offset == upload.Size && !upload.Completed() {
+		if err := interactor.complete(ctx, upload); err != nil {
+			return nil, err
+		}
+	}
+	return resumableUploadOutput(upload), nil
+}
+
+// Terminate はアップロードを取り消して受信した内容を削除する（DELETE）
+func (interactor *ResumableUploadInteractor) Terminate(ctx context.Context, id uuid.UUID) error {
+	if !interactor.lock(id) {
+		return fmt.Errorf("Conflict: アップロード %s は別のリクエストで書き込み中です", id)
+	}
+	defer interactor.unlock(id)
+
+	if _, err := interactor.get(ctx, id); err != nil {
+		return err
+	}
+	return interactor.ResumableUploadGateway.Delete(ctx, id)
+}
+
+// Run は SweepInterval ごとに期限切れのアップロードを削除する（ctx が終了するまで戻らない）
+func (interactor *ResumableUploadInteractor) Run(ctx context.Context) {
+	ticker := time.NewTicker(interactor.Config.SweepInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := interactor.Sweep(ctx); err != nil {
+			interactor.Logging.Warning(fmt.Sprintf("failed to sweep expired uploads: %s", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep は期限切れのアップロードを削除し、削除した数を返す（書き込み中のものは次回に回す）
+func (interactor *ResumableUploadInteractor) Sweep(ctx context.Context) (int, error) {
+	expired, err := interactor.ResumableUploadGateway.ListExpired(ctx, util.JapaneseNowTime())
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, upload := range expired {
+		if !interactor.lock(upload.ID) {
+			continue
+		}
+		err := interactor.ResumableUploadGateway.Delete(ctx, upload.ID)
+		interactor.unlock(upload.ID)
+		if err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	if deleted > 0 {
+		interactor.Logging.Info(fmt.Sprintf("deleted %d expired uploads", deleted))
+	}
+	return deleted, nil
+}
+
+// complete は受信した内容を IPFS に追加して結果を記録し、受信した内容を削除する
+func (interactor *ResumableUploadInteractor) complete(ctx context.Context, upload *domain.ResumableUpload) (err error) {
+	data, err := interactor.ResumableUploadGateway.Open(ctx, upload.ID)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := data.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	ipfsOutput, err := interactor.IpfsInteractor.Upload(ctx, ports.IpfsUpload{Filename: upload.Filename, Size: upload.Size, Content: data}, ports.IpfsInput{Wallet: upload.Wallet})
+	if err != nil {
+		return err
+	}
+	upload.Cid = ipfsOutput.Cid
+	upload.Path = ipfsOutput.Path
+	upload.Sha256 = ipfsOutput.Sha256
+	upload.UpdatedAt = util.JapaneseNowTime()
+	if err := interactor.ResumableUploadGateway.Update(ctx, upload); err != nil {
+		return err
+	}
+	return interactor.ResumableUploadGateway.RemoveData(ctx, upload.ID)
+}
+
+// get はリクエストしたユーザーのアップロードを返す（期限切れのものは見つからない扱いにする）
+func (interactor *ResumableUploadInteractor) get(ctx context.Context, id uuid.UUID) (*domain.ResumableUpload, error) {
+	upload, err := interactor.ResumableUploadGateway.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, upload.UserID); err != nil {
+		return nil, err
+	}
+	if upload.ExpiresAt.Before(util.JapaneseNowTime()) {
+		return nil, fmt.Errorf("record not found: アップロード %s は期限切れです", id)
+	}
+	return upload, nil
+}
+
+func (interactor *ResumableUploadInteractor) lock(id uuid.UUID) bool {
+	interactor.mu.Lock()
+	defer interactor.mu.Unlock()
+	if interactor.locked[id] {
+		return false
+	}
+	interactor.locked[id] = true
+	return true
+}
+
+func (interactor *ResumableUploadInteractor) unlock(id uuid.UUID) {
+	interactor.mu.Lock()
+	defer interactor.mu.Unlock()
+	delete(interactor.locked, id)
+}
+
+func resumableUploadOutput(upload *domain.ResumableUpload) *ports.ResumableUploadOutput {
+	output := &ports.ResumableUploadOutput{
+		ID:        upload.ID,
+		Size:      upload.Size,
+		Offset:    upload.Offset,
+		ExpiresAt: upload.ExpiresAt,
+	}
+	if upload.Completed() {
+		output.Ipfs = &ports.IpfsOutput{
+			UserID: upload.UserID,
+			Cid:    upload.Cid,
+			Path:   upload.Path,
+			Sha256: upload.Sha256,
+			Size:   upload.Size,
+		}
+	}
+	return output
+}
@@ -0,0 +1,260 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"nft-music/domain"
+	"nft-music/usecases/gateways/mock"
+	"nft-music/usecases/ports"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeResumableUploadGateway は分割アップロードの記録と受信した内容をメモリに保存する
+type fakeResumableUploadGateway struct {
+	mu      sync.Mutex
+	uploads map[uuid.UUID]domain.ResumableUpload
+	data    map[uuid.UUID][]byte
+}
+
+func newFakeResumableUploadGateway() *fakeResumableUploadGateway {
+	return &fakeResumableUploadGateway{uploads: map[uuid.UUID]domain.ResumableUpload{}, data: map[uuid.UUID][]byte{}}
+}
+
+func (gateway *fakeResumableUploadGateway) Create(ctx context.Context, upload *domain.ResumableUpload) error {
+	gateway.mu.Lock()
+	defer gateway.mu.Unlock()
+	gateway.uploads[upload.ID] = *upload
+	gateway.data[upload.ID] = []byte{}
+	return nil
+}
+
+func (gateway *fakeResumableUploadGateway) Get(ctx context.Context, id uuid.UUID) (*domain.ResumableUpload, error) {
+	gateway.mu.Lock()
+	defer gateway.mu.Unlock()
+	upload, ok := gateway.uploads[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	return &upload, nil
+}
+
+func (gateway *fakeResumableUploadGateway) Update(ctx context.Context, upload *domain.ResumableUpload) error {
+	gateway.mu.Lock()
+	defer gateway.mu.Unlock()
+	gateway.uploads[upload.ID] = *upload
+	return nil
+}
+
+func (gateway *fakeResumableUploadGateway) Append(ctx context.Context, id uuid.UUID, offset int64, content io.Reader) (int64, error) {
+	received, err := io.ReadAll(content)
+	gateway.mu.Lock()
+	defer gateway.mu.Unlock()
+	gateway.data[id] = append(gateway.data[id][:offset], received...)
+	return int64(len(received)), err
+}
+
+func (gateway *fakeResumableUploadGateway) Open(ctx context.Context, id uuid.UUID) (io.ReadCloser, error) {
+	gateway.mu.Lock()
+	defer gateway.mu.Unlock()
+	return io.NopCloser(bytes.NewReader(gateway.data[id])), nil
+}
+
+func (gateway *fakeResumableUploadGateway) RemoveData(ctx context.Context, id uuid.UUID) error {
+	gateway.mu.Lock()
+	defer gateway.mu.Unlock()
+	delete(gateway.data, id)
+	return nil
+}
+
+func (gateway *fakeResumableUploadGateway) Delete(ctx context.Context, id uuid.UUID) error {
+	gateway.mu.Lock()
+	defer gateway.mu.Unlock()
+	delete(gateway.uploads, id)
+	delete(gateway.data, id)
+	return nil
+}
+
+func (gateway *fakeResumableUploadGateway) ListExpired(ctx context.Context, now time.Time) ([]*domain.ResumableUpload, error) {
+	gateway.mu.Lock()
+	defer gateway.mu.Unlock()
+	var expired []*domain.ResumableUpload
+	for _, upload := range gateway.uploads {
+		if upload.ExpiresAt.Before(now) {
+			expired = append(expired, &upload)
+		}
+	}
+	return expired, nil
+}
+
+// testMasterFLAC は 8kHz・16ビット・モノラルで16サンプルの無音の FLAC
+// STREAMINFO（MD5は未設定）と、CONSTANT のサブフレームだけのフレームが1つ（ヘッダーの CRC-8 とフレームの CRC-16 を含む）
+var testMasterFLAC = []byte{
+	'f', 'L', 'a', 'C',
+	// STREAMINFO（最後のメタデータブロック・34バイト）
+	0x80, 0x00, 0x00, 0x22,
+	0x00, 0x10, 0x00, 0x10, // ブロックサイズの最小・最大 16
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // フレームサイズの最小・最大（不明）
+	0x01, 0xf4, 0x00, 0xf0, 0x00, 0x00, 0x00, 0x10, // 8000Hz・1チャンネル・16ビット・総サンプル数 16
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // MD5
+	// フレーム: 同期コード・ブロックサイズ（8ビットで指定）・サンプルレート（STREAMINFO）・モノラル・16ビット・フレーム番号 0・ブロックサイズ-1・CRC-8
+	0xff, 0xf8, 0x60, 0x08, 0x00, 0x0f, 0x96,
+	// サブフレーム: CONSTANT の 0
+	0x00, 0x00, 0x00,
+	// CRC-16
+	0xae, 0x85,
+}
+
+func TestResumableUploadInteractor(t *testing.T) {
+	wallet := "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"
+	user := &domain.User{ID: uuid.New(), Wallet: wallet}
+	ctx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: user.ID, Wallet: wallet, Role: domain.RoleCreator})
+
+	newInteractor := func(t *testing.T) (*ResumableUploadInteractor, *mock.MockIpfsGateway, *fakeResumableUploadGateway) {
+		ctrl := gomock.NewController(t)
+		mockIpfsGateway := mock.NewMockIpfsGateway(ctrl)
+		mockUserGateway := mock.NewMockUserGateway(ctrl)
+		mockUserGateway.EXPECT().GetByWallet(gomock.Any(), gomock.Any()).Return(user, nil).AnyTimes()
+		mockMediaGateway := mock.NewMockMediaGateway(ctrl)
+		mockMediaGateway.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		ipfsInteractor := NewIpfsInteractor(mockIpfsGateway, mockUserGateway, mockMediaGateway, UploadConfig{MaxSize: 1024, TypeLimits: map[string]int64{"audio": 64}})
+		gateway := newFakeResumableUploadGateway()
+		return NewResumableUploadInteractor(gateway, ipfsInteractor, ResumableUploadConfig{Expiry: time.Hour}, &NullLogging{}), mockIpfsGateway, gateway
+	}
+	expectAdd := func(mockIpfsGateway *mock.MockIpfsGateway, want string) {
+		mockIpfsGateway.EXPECT().
+			Add(gomock.Any(), "master.flac", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, filename string, content io.Reader) (*domain.IpfsAdd, error) {
+				received, err := io.ReadAll(content)
+				assert.Equal(t, want, string(received))
+				return &domain.IpfsAdd{Hash: "QmMaster"}, err
+			})
+		mockIpfsGateway.EXPECT().
+			Pin(gomock.Any(), "QmMaster").
+			Return(&domain.IpfsResolve{Path: "/ipfs/QmMaster"}, nil)
+	}
+
+	t.Run("正常系: 接続が切れても受信済みの位置から再開し、最後まで受信したらIPFSに追加する", func(t *testing.T) {
+		interactor, mockIpfsGateway, _ := newInteractor(t)
+		created, err := interactor.Create(ctx, ports.ResumableUploadInput{Size: int64(len(testMasterFLAC)), Metadata: map[string]string{"filename": "master.flac"}})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), created.Offset)
+
+		// 途中で接続が切れた
+		output, err := interactor.Append(ctx, created.ID, 0, io.MultiReader(bytes.NewReader(testMasterFLAC[:3]), errorReader{}))
+		assert.Error(t, err)
+		assert.Nil(t, output)
+
+		head, err := interactor.Get(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), head.Offset)
+		assert.Nil(t, head.Ipfs)
+
+		_, err = interactor.Append(ctx, created.ID, 0, strings.NewReader("test"))
+		assert.ErrorContains(t, err, "Conflict")
+
+		expectAdd(mockIpfsGateway, string(testMasterFLAC))
+		output, err = interactor.Append(ctx, created.ID, 3, bytes.NewReader(testMasterFLAC[3:]))
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(testMasterFLAC)), output.Offset)
+		require.NotNil(t, output.Ipfs)
+		assert.Equal(t, "QmMaster", output.Ipfs.Cid)
+		assert.Equal(t, "/ipfs/QmMaster", output.Ipfs.Path)
+		assert.Equal(t, user.ID, output.Ipfs.UserID)
+		assert.Equal(t, "f54f425563a94e849089903e61797e1f7423ce8bbacf1efcfd7c8efe3c9d7a97", output.Ipfs.Sha256)
+
+		// 完了した後は追加し直さずに結果を返す
+		output, err = interactor.Append(ctx, created.ID, int64(len(testMasterFLAC)), strings.NewReader(""))
+		require.NoError(t, err)
+		assert.Equal(t, "QmMaster", output.Ipfs.Cid)
+	})
+
+	t.Run("正常系: IPFSへの追加に失敗した場合は空のPATCHで追加し直す", func(t *testing.T) {
+		interactor, mockIpfsGateway, _ := newInteractor(t)
+		created, err := interactor.Create(ctx, ports.ResumableUploadInput{Size: int64(len(testMasterFLAC)), Metadata: map[string]string{"filename": "master.flac"}})
+		require.NoError(t, err)
+
+		mockIpfsGateway.EXPECT().Add(gomock.Any(), "master.flac", gomock.Any()).Return(nil, errors.New("ipfs add returned 500"))
+		_, err = interactor.Append(ctx, created.ID, 0, bytes.NewReader(testMasterFLAC))
+		assert.ErrorContains(t, err, "500")
+
+		expectAdd(mockIpfsGateway, string(testMasterFLAC))
+		output, err := interactor.Append(ctx, created.ID, int64(len(testMasterFLAC)), strings.NewReader(""))
+		require.NoError(t, err)
+		assert.Equal(t, "QmMaster", output.Ipfs.Cid)
+	})
+
+	t.Run("異常系: 作成の入力が正しくない", func(t *testing.T) {
+		interactor, _, _ := newInteractor(t)
+
+		_, err := interactor.Create(ctx, ports.ResumableUploadInput{Size: 8, Metadata: map[string]string{}})
+		assert.ErrorContains(t, err, "BadRequest")
+
+		_, err = interactor.Create(ctx, ports.ResumableUploadInput{Size: 0, Metadata: map[string]string{"filename": "master.flac"}})
+		assert.ErrorContains(t, err, "BadRequest")
+
+		_, err = interactor.Create(ctx, ports.ResumableUploadInput{Size: 65, Metadata: map[string]string{"filename": "master.flac"}})
+		assert.ErrorContains(t, err, "PayloadTooLarge")
+
+		_, err = interactor.Create(context.Background(), ports.ResumableUploadInput{Size: 8, Metadata: map[string]string{"filename": "master.flac"}})
+		assert.ErrorContains(t, err, "Unauthorized")
+	})
+
+	t.Run("異常系: Upload-Length を超えて送信された・他のユーザーのアップロード", func(t *testing.T) {
+		interactor, _, _ := newInteractor(t)
+		created, err := interactor.Create(ctx, ports.ResumableUploadInput{Size: 4, Metadata: map[string]string{"filename": "master.flac"}})
+		require.NoError(t, err)
+
+		_, err = interactor.Append(ctx, created.ID, 0, strings.NewReader("testdata"))
+		assert.ErrorContains(t, err, "Upload-Length")
+
+		other := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: uuid.New(), Role: domain.RoleCreator})
+		_, err = interactor.Get(other, created.ID)
+		assert.ErrorContains(t, err, "Forbidden")
+		assert.ErrorContains(t, interactor.Terminate(other, created.ID), "Forbidden")
+	})
+
+	t.Run("正常系: 取り消し・期限切れのアップロードを削除する", func(t *testing.T) {
+		interactor, _, gateway := newInteractor(t)
+		terminated, err := interactor.Create(ctx, ports.ResumableUploadInput{Size: 4, Metadata: map[string]string{"filename": "master.flac"}})
+		require.NoError(t, err)
+		require.NoError(t, interactor.Terminate(ctx, terminated.ID))
+		_, err = interactor.Get(ctx, terminated.ID)
+		assert.ErrorContains(t, err, "record not found")
+
+		abandoned, err := interactor.Create(ctx, ports.ResumableUploadInput{Size: 4, Metadata: map[string]string{"filename": "master.flac"}})
+		require.NoError(t, err)
+		active, err := interactor.Create(ctx, ports.ResumableUploadInput{Size: 4, Metadata: map[string]string{"filename": "master.flac"}})
+		require.NoError(t, err)
+		upload := gateway.uploads[abandoned.ID]
+		upload.ExpiresAt = time.Now().Add(-time.Minute)
+		gateway.uploads[abandoned.ID] = upload
+
+		_, err = interactor.Get(ctx, abandoned.ID)
+		assert.ErrorContains(t, err, "期限切れ")
+
+		deleted, err := interactor.Sweep(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+		assert.NotContains(t, gateway.uploads, abandoned.ID)
+		assert.Contains(t, gateway.uploads, active.ID)
+	})
+}
+
+// errorReader は接続が切れたリクエストの本文
+type errorReader struct{}
+
+func (errorReader) Read(p []byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
@@ -0,0 +1,145 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/usecases/gateways"
+	"nft-music/usecases/ports"
+	"nft-music/util"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RoyaltyInteractor はトークンのロイヤリティを設定元の優先順に解決する
+// トークンごとの設定 → コレクションの既定値の順に探し、マーケットプレイスのトークンはコントラクトの royaltyFeeBps をクリエイターに支払う
+// コレクションの設定はプラットフォームでデプロイしたコントラクトの royaltyInfo（ERC-2981）にも設定したものだけで、販売時に支払われる値と一致する
+// プラットフォームでデプロイしていないコレクションはマーケットプレイスの扱いとし、マーケットプレイスのトークンでなければロイヤリティは支払われない
+type RoyaltyInteractor struct {
+	CollectionGateway   gateways.CollectionGateway
+	TokenRoyaltyGateway gateways.TokenRoyaltyGateway
+	ChainRegistry       *ChainRegistry
+	Marketplace         *MarketplaceContract
+}
+
+func NewRoyaltyInteractor(collectionGateway gateways.CollectionGateway, tokenRoyaltyGateway gateways.TokenRoyaltyGateway, chainRegistry *ChainRegistry, marketplace *MarketplaceContract) *RoyaltyInteractor {
+	return &RoyaltyInteractor{
+		CollectionGateway:   collectionGateway,
+		TokenRoyaltyGateway: tokenRoyaltyGateway,
+		ChainRegistry:       chainRegistry,
+		Marketplace:         marketplace,
+	}
+}
+
+// Info は ERC-2981 の royaltyInfo(tokenId, salePrice) と同じく、販売価格に対するロイヤリティの受取人と金額を計算する
+func (interactor *RoyaltyInteractor) Info(ctx context.Context, chainID int, contractAddress string, tokenID string, salePrice string) (*ports.RoyaltyInfoOutput, error) {
+	chain, err := interactor.ChainRegistry.Get(chainID)
+	if err != nil {
+		return nil, err
+	}
+	price, err := chain.ParsePrice(salePrice)
+	if err != nil {
+		return nil, err
+	}
+	if !common.IsHexAddress(contractAddress) {
+		return nil, fmt.Errorf("BadRequest: コントラクトアドレス %s が正しくありません", contractAddress)
+	}
+	address := common.HexToAddress(contractAddress)
+
+	royalty, token, err := interactor.resolve(ctx, chainID, address, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return royaltyInfoOutput(chainID, address, token, price, royalty), nil
+}
+
+// resolve はトークンに適用するロイヤリティを解決する
+func (interactor *RoyaltyInteractor) resolve(ctx context.Context, chainID int, address common.Address, tokenID string) (*domain.Royalty, *big.Int, error) {
+	if contract, marketplace, err := interactor.Marketplace.Get(); err == nil && marketplace == address && chainID == interactor.ChainRegistry.Active().ChainID {
+		token, err := parseTokenID(tokenID)
+		if err != nil {
+			return nil, nil, err
+		}
+		royalty, err := marketplaceTokenRoyalty(ctx, contract, token)
+		return royalty, token, err
+	}
+
+	token, err := parseCollectionTokenID(tokenID)
+	if err != nil {
+		return nil, nil, err
+	}
+	collection, err := interactor.CollectionGateway.GetByContract(ctx, chainID, address.Hex())
+	if err != nil {
+		return nil, nil, err
+	}
+	if !collection.DeployTx.Valid {
+		// 外部のコントラクトは royaltyInfo を設定できず、保存してある値も販売時に支払われない
+		return &domain.Royalty{Source: domain.RoyaltySourceMarketplace}, token, nil
+	}
+	override, err := interactor.TokenRoyaltyGateway.Get(ctx, chainID, address.Hex(), token.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	if override != nil {
+		return &domain.Royalty{Bps: override.RoyaltyBps, Receiver: override.Receiver, Source: domain.RoyaltySourceToken}, token, nil
+	}
+	return &domain.Royalty{Bps: collection.RoyaltyBps, Receiver: collection.RoyaltyReceiver, Source: domain.RoyaltySourceCollection}, token, nil
+}
+
+// marketplaceTokenRoyalty はマーケットプレイスのトークンのロイヤリティをコントラクトから読み取る
+func marketplaceTokenRoyalty(ctx context.Context, contract *contracts.Contracts, token *big.Int) (*domain.Royalty, error) {
+	market := &marketState{contract: contract}
+	item, err := market.item(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	royaltyBps, err := contract.RoyaltyFeeBps(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, err
+	}
+	return marketplaceRoyalty(royaltyBps, item), nil
+}
+
+// marketplaceRoyalty はマーケットプレイスのコントラクトが createMarketSale で支払うロイヤリティ
+// （コントラクトは royaltyFeeBps を一律でクリエイターに支払うため、トークンごとの設定は使わない）
+func marketplaceRoyalty(royaltyBps *big.Int, item *contracts.NFTMarketplaceMarketItem) *domain.Royalty {
+	return &domain.Royalty{Bps: int(royaltyBps.Int64()), Receiver: item.Creator.Hex(), Source: domain.RoyaltySourceMarketplace}
+}
+
+// validateRoyalty はロイヤリティ率が0〜10000 bpsの範囲で、受取人がチェックサム付きのアドレスか確認する
+// ロイヤリティ率が0の場合は受取人を省略できる
+func validateRoyalty(royaltyBps int, receiver string) error {
+	if royaltyBps < 0 || royaltyBps > domain.MaxRoyaltyBps {
+		return fmt.Errorf("BadRequest: ロイヤリティ率は0〜%d bpsで指定してください", domain.MaxRoyaltyBps)
+	}
+	if receiver == "" && royaltyBps == 0 {
+		return nil
+	}
+	if !util.IsChecksumAddress(receiver) {
+		return fmt.Errorf("BadRequest: ロイヤリティの受取人 %q はチェックサム付きのアドレスで指定してください", receiver)
+	}
+	if common.HexToAddress(receiver) == (common.Address{}) {
+		return fmt.Errorf("BadRequest: ロイヤリティの受取人にゼロアドレスは指定できません")
+	}
+	return nil
+}
+
+func royaltyInfoOutput(chainID int, address common.Address, token *big.Int, salePrice domain.Amount, royalty *domain.Royalty) *ports.RoyaltyInfoOutput {
+	amount := royalty.Amount(salePrice)
+	return &ports.RoyaltyInfoOutput{
+		ChainID:         chainID,
+		ContractAddress: address.Hex(),
+		TokenID:         token.String(),
+		SalePrice:       salePrice,
+		Receiver:        royalty.Receiver,
+		RoyaltyBps:      royalty.Bps,
+		RoyaltyAmount:   amount,
+		Remaining:       domain.NewAmount(new(big.Int).Sub(salePrice.Wei(), amount.Wei())),
+		Source:          royalty.Source,
+	}
+}
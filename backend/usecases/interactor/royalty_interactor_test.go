@@ -0,0 +1,139 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"testing"
+
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/usecases/gateways/mock"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRoyaltyInteractor(t *testing.T) {
+	ctx := context.Background()
+	marketplaceAddress := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	collectionAddress := common.HexToAddress("0x495f947276749ce646f68ac8c248420045075b34")
+	creator := common.HexToAddress("0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50")
+	receiver := common.HexToAddress("0xc5309Ef694C81C4a8e946F2810e09516436daeB5")
+
+	newInteractor := func(t *testing.T) (*RoyaltyInteractor, *mock.MockCollectionGateway, *mock.MockTokenRoyaltyGateway) {
+		backend := &fakeMarketBackend{
+			items: []contracts.NFTMarketplaceMarketItem{
+				{TokenId: big.NewInt(1), Owner: marketplaceAddress, Price: big.NewInt(1000), Creator: creator},
+			},
+			royaltyBps: big.NewInt(1000),
+		}
+		marketplace := NewMarketplaceContract()
+		bound, err := contracts.NewContracts(marketplaceAddress, backend)
+		require.NoError(t, err)
+		marketplace.Set(marketplaceAddress, bound)
+
+		ctrl := gomock.NewController(t)
+		collectionGateway := mock.NewMockCollectionGateway(ctrl)
+		tokenRoyaltyGateway := mock.NewMockTokenRoyaltyGateway(ctrl)
+		registry, _ := newTestChainRegistry(t)
+		return NewRoyaltyInteractor(collectionGateway, tokenRoyaltyGateway, registry, marketplace), collectionGateway, tokenRoyaltyGateway
+	}
+	collection := &domain.Collection{ID: uuid.New(), ChainID: 56, ContractAddress: collectionAddress.Hex(), DeployTx: sql.NullString{String: "0x01", Valid: true}, RoyaltyBps: 500, RoyaltyReceiver: creator.Hex()}
+
+	t.Run("正常系: トークンごとの設定を優先する", func(t *testing.T) {
+		interactor, collectionGateway, tokenRoyaltyGateway := newInteractor(t)
+		collectionGateway.EXPECT().GetByContract(gomock.Any(), 56, collectionAddress.Hex()).Return(collection, nil)
+		tokenRoyaltyGateway.EXPECT().Get(gomock.Any(), 56, collectionAddress.Hex(), "0").
+			Return(&domain.TokenRoyalty{RoyaltyBps: 1250, Receiver: receiver.Hex()}, nil)
+
+		output, err := interactor.Info(ctx, 56, collectionAddress.Hex(), "0", "2 BNB")
+
+		require.NoError(t, err)
+		assert.Equal(t, receiver.Hex(), output.Receiver)
+		assert.Equal(t, 1250, output.RoyaltyBps)
+		assert.Equal(t, "250000000000000000", output.RoyaltyAmount.String())
+		assert.Equal(t, "1750000000000000000", output.Remaining.String())
+		assert.Equal(t, domain.RoyaltySourceToken, output.Source)
+	})
+
+	t.Run("正常系: トークンごとの設定が無い場合はコレクションの既定値を使う", func(t *testing.T) {
+		interactor, collectionGateway, tokenRoyaltyGateway := newInteractor(t)
+		collectionGateway.EXPECT().GetByContract(gomock.Any(), 56, collectionAddress.Hex()).Return(collection, nil)
+		tokenRoyaltyGateway.EXPECT().Get(gomock.Any(), 56, collectionAddress.Hex(), "3").Return(nil, nil)
+
+		// アドレスは小文字でも受け付ける
+		output, err := interactor.Info(ctx, 56, "0x495f947276749ce646f68ac8c248420045075b34", "3", "1 BNB")
+
+		require.NoError(t, err)
+		assert.Equal(t, creator.Hex(), output.Receiver)
+		assert.Equal(t, "50000000000000000", output.RoyaltyAmount.String())
+		assert.Equal(t, domain.RoyaltySourceCollection, output.Source)
+	})
+
+	t.Run("正常系: マーケットプレイスのトークンは royaltyFeeBps をクリエイターに支払う", func(t *testing.T) {
+		interactor, _, _ := newInteractor(t)
+
+		output, err := interactor.Info(ctx, 1337, marketplaceAddress.Hex(), "1", "1000 wei")
+
+		require.NoError(t, err)
+		assert.Equal(t, creator.Hex(), output.Receiver)
+		assert.Equal(t, 1000, output.RoyaltyBps)
+		assert.Equal(t, "100", output.RoyaltyAmount.String())
+		assert.Equal(t, domain.RoyaltySourceMarketplace, output.Source)
+	})
+
+	t.Run("正常系: プラットフォームでデプロイしていないコレクションは保存してある値を使わない", func(t *testing.T) {
+		interactor, collectionGateway, _ := newInteractor(t)
+		external := *collection
+		external.DeployTx = sql.NullString{}
+		collectionGateway.EXPECT().GetByContract(gomock.Any(), 56, collectionAddress.Hex()).Return(&external, nil)
+
+		output, err := interactor.Info(ctx, 56, collectionAddress.Hex(), "3", "1 BNB")
+
+		require.NoError(t, err)
+		assert.Empty(t, output.Receiver)
+		assert.Zero(t, output.RoyaltyBps)
+		assert.Equal(t, "1000000000000000000", output.Remaining.String())
+		assert.Equal(t, domain.RoyaltySourceMarketplace, output.Source)
+	})
+
+	t.Run("異常系: 登録されていないコントラクト", func(t *testing.T) {
+		interactor, collectionGateway, _ := newInteractor(t)
+		collectionGateway.EXPECT().GetByContract(gomock.Any(), 56, collectionAddress.Hex()).Return(nil, errors.New("record not found"))
+
+		_, err := interactor.Info(ctx, 56, collectionAddress.Hex(), "0", "1 BNB")
+
+		assert.ErrorContains(t, err, "record not found")
+	})
+
+	t.Run("異常系: 入力が正しくない", func(t *testing.T) {
+		interactor, _, _ := newInteractor(t)
+
+		_, err := interactor.Info(ctx, 56, "0x1234", "0", "1 BNB")
+		assert.ErrorContains(t, err, "BadRequest")
+
+		_, err = interactor.Info(ctx, 56, collectionAddress.Hex(), "-1", "1 BNB")
+		assert.ErrorContains(t, err, "BadRequest")
+
+		_, err = interactor.Info(ctx, 56, collectionAddress.Hex(), "0", "1 DOGE")
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+}
+
+func TestValidateRoyalty(t *testing.T) {
+	receiver := "0xc5309Ef694C81C4a8e946F2810e09516436daeB5"
+
+	assert.NoError(t, validateRoyalty(0, ""))
+	assert.NoError(t, validateRoyalty(domain.MaxRoyaltyBps, receiver))
+	assert.ErrorContains(t, validateRoyalty(domain.MaxRoyaltyBps+1, receiver), "BadRequest")
+	assert.ErrorContains(t, validateRoyalty(-1, receiver), "BadRequest")
+	assert.ErrorContains(t, validateRoyalty(500, ""), "BadRequest")
+	assert.ErrorContains(t, validateRoyalty(500, "0xc5309ef694c81c4a8e946f2810e09516436daeb5"), "チェックサム")
+	assert.ErrorContains(t, validateRoyalty(500, common.Address{}.Hex()), "ゼロアドレス")
+}
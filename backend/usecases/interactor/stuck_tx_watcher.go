@@ -0,0 +1,385 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"nft-music/domain"
+	"nft-music/usecases/gateways"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+	"nft-music/util"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+)
+
+// StuckActionNone は詰まったトランザクションを自動で置き換えない（管理者の置き換えだけを受け付ける）
+const StuckActionNone = "none"
+
+// cancelGasLimit は自分宛てに0を送金するトランザクションのガスリミット
+const cancelGasLimit = 21000
+
+// StuckTxConfig は詰まったトランザクションの監視の設定
+type StuckTxConfig struct {
+	StuckAfter      time.Duration // 送信（置き換え）してからこの時間を過ぎても取り込まれないトランザクションを詰まったとみなす
+	Action          string        // 詰まった場合に自動で行う置き換え（speed_up / cancel / none）
+	FeeBumpPercent  int           // 置き換えで元のガス代に上乗せする割合（%）。ノードは10%以上でないと受け付けない
+	MaxReplacements int           // 自動で置き換える回数の上限（管理者の置き換えは制限しない）
+}
+
+// ReplacementBackend は置き換えの送信と確認に使うノードの機能（*ethclient.Client が実装している）
+type ReplacementBackend interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// StuckTxWatcher はプラットフォームのウォレットから送信したミントのトランザクションが詰まっていないか監視し、
+// 同じノンスでガス代を上げて送り直す（speed_up）か、自分宛てに0を送金して取り消す（cancel）
+//
+// 監視するのは MintWorker が追跡するミントジョブ（transactions の行）だけで、
+// コントラクトの管理操作・バウチャーの setMinter・コレクションのデプロイなど、リクエストの中で取り込みを待つ送信は対象外
+// （これらは取り込まれるまでリクエストが返らず、詰まった場合は同じノンスの後続の送信も止まる）
+//
+// transactions.tx_hash は取り込まれる見込みのミントのトランザクション（最後に送り直したもの）を指し、
+// 取り消しのトランザクションは置き換えの記録にだけ残す。どれが取り込まれたかは Receipt で同じノンスの全てを確認する
+// 行の更新は追跡中のジョブをロックしている MintWorker が行う
+type StuckTxWatcher struct {
+	ReplacementGateway gateways.ReplacementGateway
+	Client             ReplacementBackend
+	TxSigner           gateways.Signer
+	FeePolicy          *FeePolicy
+	Config             StuckTxConfig
+	Logging            logging.Logging
+
+	mu      sync.Mutex
+	watched map[string]*watchedTx
+}
+
+// watchedTx は置き換えの記録のキャッシュ（取り込まれるまでレシートを確認するたびにDBを読まない）
+type watchedTx struct {
+	replacements []*domain.TransactionReplacement
+	attemptedAt  time.Time // 最後に自動で置き換えようとした日時（失敗した場合も StuckAfter の間は再試行しない）
+}
+
+func NewStuckTxWatcher(replacementGateway gateways.ReplacementGateway, client ReplacementBackend, signer gateways.Signer, feePolicy *FeePolicy, config StuckTxConfig, logging logging.Logging) *StuckTxWatcher {
+	if config.StuckAfter <= 0 {
+		config.StuckAfter = 5 * time.Minute
+	}
+	if config.Action == "" {
+		config.Action = domain.ReplacementKindSpeedUp
+	}
+	if config.FeeBumpPercent < 10 {
+		config.FeeBumpPercent = 15
+	}
+	if config.MaxReplacements <= 0 {
+		config.MaxReplacements = 3
+	}
+	return &StuckTxWatcher{
+		ReplacementGateway: replacementGateway,
+		Client:             client,
+		TxSigner:           signer,
+		FeePolicy:          feePolicy,
+		Config:             config,
+		Logging:            logging,
+		watched:            map[string]*watchedTx{},
+	}
+}
+
+// load はミントジョブの置き換えの記録を読み込む（読み込み済みの場合はキャッシュを返す）
+func (watcher *StuckTxWatcher) load(ctx context.Context, transactionID string) (*watchedTx, error) {
+	watcher.mu.Lock()
+	defer watcher.mu.Unlock()
+	if watched, ok := watcher.watched[transactionID]; ok {
+		return watched, nil
+	}
+	replacements, err := watcher.ReplacementGateway.ListByTransaction(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	// ノードが受け付けなかった置き換えは送信されていないため、レシートの確認と次の置き換えには使わない
+	replacements = slices.DeleteFunc(replacements, func(replacement *domain.TransactionReplacement) bool {
+		return replacement.Status == domain.ReplacementStatusFailed
+	})
+	watched := &watchedTx{replacements: replacements}
+	watcher.watched[transactionID] = watched
+	return watched, nil
+}
+
+// Receipt はミントのトランザクションのレシートを取得する
+// 置き換えたことがある場合は、同じノンスで送信したトランザクションのうち取り込まれたもののレシートと置き換えの記録を返す
+// （置き換える前のトランザクションが取り込まれた場合、置き換えの記録は nil）
+func (watcher *StuckTxWatcher) Receipt(ctx context.Context, transaction *domain.Transaction) (*types.Receipt, *domain.TransactionReplacement, error) {
+	receipt, err := watcher.Client.TransactionReceipt(ctx, common.HexToHash(transaction.TxHash.String))
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return nil, nil, err
+	}
+
+	watched, loadErr := watcher.load(ctx, transaction.ID)
+	if loadErr != nil {
+		return nil, nil, loadErr
+	}
+	if err == nil {
+		return receipt, findReplacement(watched.replacements, receipt.TxHash.Hex()), nil
+	}
+
+	// 新しい置き換えから順に確認する
+	checked := map[string]bool{transaction.TxHash.String: true}
+	for _, replacement := range slices.Backward(watched.replacements) {
+		for _, hash := range []string{replacement.TxHash, replacement.ReplacedTxHash} {
+			if checked[hash] {
+				continue
+			}
+			checked[hash] = true
+			receipt, err := watcher.Client.TransactionReceipt(ctx, common.HexToHash(hash))
+			if errors.Is(err, ethereum.NotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			return receipt, findReplacement(watched.replacements, hash), nil
+		}
+	}
+	return nil, nil, ethereum.NotFound
+}
+
+// Settle は同じノンスのうち minedHash が取り込まれたことを置き換えの記録に反映する
+func (watcher *StuckTxWatcher) Settle(ctx context.Context, transaction *domain.Transaction, minedHash string) error {
+	watched, err := watcher.load(ctx, transaction.ID)
+	if err != nil {
+		return err
+	}
+	for _, replacement := range watched.replacements {
+		status := domain.ReplacementStatusReplaced
+		if replacement.TxHash == minedHash {
+			status = domain.ReplacementStatusMined
+		}
+		if replacement.Status == status {
+			continue
+		}
+		replacement.Status = status
+		replacement.UpdatedAt = util.JapaneseNowTime()
+		if err := watcher.ReplacementGateway.Update(ctx, replacement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Forget は取り込まれたミントジョブの置き換えの記録のキャッシュを捨てる
+func (watcher *StuckTxWatcher) Forget(transactionID string) {
+	watcher.mu.Lock()
+	defer watcher.mu.Unlock()
+	delete(watcher.watched, transactionID)
+}
+
+// List はミントジョブの置き換えの記録を古い順に返す
+func (watcher *StuckTxWatcher) List(ctx context.Context, transactionID string) ([]*domain.TransactionReplacement, error) {
+	return watcher.ReplacementGateway.ListByTransaction(ctx, transactionID)
+}
+
+// ReplaceIfStuck はプラットフォームのウォレットから送信したミントのトランザクションが StuckAfter を過ぎても取り込まれていなければ、
+// 設定に従って自動で置き換える。置き換えなかった場合は nil を返す
+// 取り消しを送った後は、取り消しが詰まった場合もガス代を上げた取り消しで置き換える
+func (watcher *StuckTxWatcher) ReplaceIfStuck(ctx context.Context, transaction *domain.Transaction) (*domain.TransactionReplacement, error) {
+	if watcher.Config.Action == StuckActionNone || transaction.Status != domain.TransactionStatusSubmitted || transaction.CreatorAddress != "" || !transaction.TxHash.Valid {
+		return nil, nil
+	}
+	now := util.JapaneseNowTime()
+	if now.Sub(transaction.UpdatedAt) < watcher.Config.StuckAfter {
+		return nil, nil
+	}
+
+	watched, err := watcher.load(ctx, transaction.ID)
+	if err != nil {
+		return nil, err
+	}
+	watcher.mu.Lock()
+	if now.Sub(watched.attemptedAt) < watcher.Config.StuckAfter || len(watched.replacements) >= watcher.Config.MaxReplacements {
+		watcher.mu.Unlock()
+		return nil, nil
+	}
+	watched.attemptedAt = now
+	watcher.mu.Unlock()
+
+	kind := watcher.Config.Action
+	if len(watched.replacements) > 0 && watched.replacements[len(watched.replacements)-1].Kind == domain.ReplacementKindCancel {
+		kind = domain.ReplacementKindCancel
+	}
+	watcher.Logging.Warning(fmt.Sprintf("mint job %s (nonce %d) is not mined for %s, sending %s", transaction.ID, transaction.Nonce, now.Sub(transaction.UpdatedAt).Round(time.Second), kind))
+
+	replacement, err := watcher.Replace(ctx, transaction, kind, uuid.NullUUID{})
+	if err != nil {
+		return nil, err
+	}
+	if len(watched.replacements) >= watcher.Config.MaxReplacements {
+		watcher.Logging.Warning(fmt.Sprintf("mint job %s reached %d replacements, further replacements must be made by an admin", transaction.ID, watcher.Config.MaxReplacements))
+	}
+	return replacement, nil
+}
+
+// Replace はミントのトランザクションを同じノンスで置き換えて記録する
+// speed_up の場合は transaction の tx_hash と送金額 + ガス代の上限を置き換えたトランザクションに更新する（行の保存は呼び出し側で行う）
+func (watcher *StuckTxWatcher) Replace(ctx context.Context, transaction *domain.Transaction, kind string, userID uuid.NullUUID) (*domain.TransactionReplacement, error) {
+	if transaction.CreatorAddress != "" {
+		return nil, fmt.Errorf("BadRequest: ミントジョブ %s はクリエイターのウォレットから送信されたため置き換えられません", transaction.ID)
+	}
+	if !transaction.TxHash.Valid {
+		return nil, fmt.Errorf("BadRequest: ミントジョブ %s はまだ送信されていません", transaction.ID)
+	}
+	watched, err := watcher.load(ctx, transaction.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 送り直す内容は最後に送ったミントのトランザクション、上乗せするガス代は最後に送ったトランザクション（取り消しを含む）から決める
+	mint, err := watcher.transactionByHash(ctx, transaction.TxHash.String)
+	if err != nil {
+		return nil, err
+	}
+	latestHash := transaction.TxHash.String
+	if len(watched.replacements) > 0 {
+		latestHash = watched.replacements[len(watched.replacements)-1].TxHash
+	}
+	latest, err := watcher.transactionByHash(ctx, latestHash)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		latest = mint
+	}
+
+	from := watcher.TxSigner.Address()
+	var to common.Address
+	var value *big.Int
+	var data []byte
+	var gas uint64
+	switch kind {
+	case domain.ReplacementKindSpeedUp:
+		if mint == nil {
+			return nil, fmt.Errorf("record not found: ノードにミントのトランザクション %s がありません", transaction.TxHash.String)
+		}
+		to, value, data, gas = *mint.To(), mint.Value(), mint.Data(), mint.Gas()
+	case domain.ReplacementKindCancel:
+		to, value, gas = from, new(big.Int), cancelGasLimit
+	default:
+		return nil, fmt.Errorf("BadRequest: 置き換え方 %s には対応していません", kind)
+	}
+	if mint != nil {
+		sender, err := types.Sender(types.LatestSignerForChainID(mint.ChainId()), mint)
+		if err != nil {
+			return nil, err
+		}
+		if sender != from {
+			return nil, fmt.Errorf("BadRequest: ミントのトランザクションの送信元 %s はプラットフォームのウォレットではありません", sender.Hex())
+		}
+	}
+
+	fees, err := watcher.FeePolicy.Bump(ctx, latest, watcher.Config.FeeBumpPercent)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := watcher.Client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nonce := uint64(transaction.Nonce)
+	var unsigned *types.Transaction
+	if fees.Dynamic {
+		unsigned = types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: nonce, GasTipCap: fees.GasTipCap, GasFeeCap: fees.GasFeeCap, Gas: gas, To: &to, Value: value, Data: data})
+	} else {
+		unsigned = types.NewTx(&types.LegacyTx{Nonce: nonce, GasPrice: fees.GasPrice, Gas: gas, To: &to, Value: value, Data: data})
+	}
+	signed, err := watcher.TxSigner.SignTx(ctx, unsigned, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := util.JapaneseNowTime()
+	replacement := &domain.TransactionReplacement{
+		ID:             uuid.Must(uuid.NewV7()),
+		TransactionID:  transaction.ID,
+		Kind:           kind,
+		Nonce:          transaction.Nonce,
+		ReplacedTxHash: latestHash,
+		TxHash:         signed.Hash().Hex(),
+		Status:         domain.ReplacementStatusPending,
+		UserID:         userID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if fees.Dynamic {
+		tip, feeCap := domain.NewAmount(fees.GasTipCap), domain.NewAmount(fees.GasFeeCap)
+		replacement.GasTipCap, replacement.GasFeeCap = &tip, &feeCap
+	} else {
+		gasPrice := domain.NewAmount(fees.GasPrice)
+		replacement.GasPrice = &gasPrice
+	}
+	// 送信した置き換えが記録に残らないことが無いよう、送信する前に pending で記録する
+	if err := watcher.ReplacementGateway.Create(ctx, replacement); err != nil {
+		return nil, err
+	}
+	if err := watcher.Client.SendTransaction(ctx, signed); err != nil {
+		replacement.Status = domain.ReplacementStatusFailed
+		replacement.UpdatedAt = util.JapaneseNowTime()
+		if updateErr := watcher.ReplacementGateway.Update(context.WithoutCancel(ctx), replacement); updateErr != nil {
+			watcher.Logging.Error(fmt.Sprintf("failed to record failure of %s %s of mint job %s: %s", kind, replacement.TxHash, transaction.ID, updateErr.Error()))
+		}
+		return nil, fmt.Errorf("failed to send %s of mint job %s: %w", kind, transaction.ID, err)
+	}
+
+	watcher.mu.Lock()
+	watched.replacements = append(watched.replacements, replacement)
+	watcher.mu.Unlock()
+
+	if kind == domain.ReplacementKindSpeedUp {
+		transaction.TxHash.String = replacement.TxHash
+		transaction.Cost = domain.NewAmount(signed.Cost())
+	}
+	return replacement, nil
+}
+
+// transactionByHash はノードからトランザクションを取得する（ノードに無い場合は nil）
+func (watcher *StuckTxWatcher) transactionByHash(ctx context.Context, hash string) (*types.Transaction, error) {
+	tx, _, err := watcher.Client.TransactionByHash(ctx, common.HexToHash(hash))
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	return tx, err
+}
+
+func findReplacement(replacements []*domain.TransactionReplacement, hash string) *domain.TransactionReplacement {
+	for _, replacement := range replacements {
+		if replacement.TxHash == hash {
+			return replacement
+		}
+	}
+	return nil
+}
+
+func replacementOutput(replacement *domain.TransactionReplacement) ports.ReplacementOutput {
+	return ports.ReplacementOutput{
+		ID:             replacement.ID,
+		TransactionID:  replacement.TransactionID,
+		Kind:           replacement.Kind,
+		Nonce:          replacement.Nonce,
+		ReplacedTxHash: replacement.ReplacedTxHash,
+		TxHash:         replacement.TxHash,
+		GasPrice:       replacement.GasPrice,
+		GasTipCap:      replacement.GasTipCap,
+		GasFeeCap:      replacement.GasFeeCap,
+		Status:         replacement.Status,
+		CreatedAt:      replacement.CreatedAt,
+	}
+}
@@ -0,0 +1,203 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"nft-music/domain"
+	"nft-music/usecases/gateways/mock"
+	"nft-music/usecases/ports"
+	"nft-music/util"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStuckTxWatcher(t *testing.T) {
+	ctx := context.Background()
+	contract := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	signer := newTestSigner(t)
+
+	// newWorker は詰まったミントのトランザクション（gasPrice 100 wei、ノンス3）を送信済みのワーカーを作成する
+	newWorker := func(t *testing.T, config StuckTxConfig) (*MintWorker, *fakeMarketBackend, *domain.Transaction, *[]*domain.TransactionReplacement) {
+		ctrl := gomock.NewController(t)
+		backend := &fakeMarketBackend{txs: map[common.Hash]*types.Transaction{}, receipts: map[common.Hash]*types.Receipt{}}
+
+		mint, err := signer.SignTx(ctx, types.NewTx(&types.LegacyTx{Nonce: 3, GasPrice: big.NewInt(100), Gas: 200000, To: &contract, Value: big.NewInt(1000), Data: []byte{0x72, 0xb3, 0xb6, 0x20}}), big.NewInt(1337))
+		require.NoError(t, err)
+		backend.txs[mint.Hash()] = mint
+
+		var replacements []*domain.TransactionReplacement
+		replacementGateway := mock.NewMockReplacementGateway(ctrl)
+		replacementGateway.EXPECT().ListByTransaction(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+		replacementGateway.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, replacement *domain.TransactionReplacement) error {
+			// 送信する前に pending で記録する
+			assert.Equal(t, domain.ReplacementStatusPending, replacement.Status)
+			assert.NotContains(t, backend.txs, common.HexToHash(replacement.TxHash))
+			replacements = append(replacements, replacement)
+			return nil
+		}).AnyTimes()
+		replacementGateway.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		transactionGateway := mock.NewMockTransactionGateway(ctrl)
+		transactionGateway.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		watcher := NewStuckTxWatcher(replacementGateway, backend, signer, NewFeePolicy(backend, FeeConfig{Mode: FeeModeLegacy}), config, &NullLogging{})
+		worker := NewMintWorker(transactionGateway, newTestIpfsGateway(t), backend, signer, nil, watcher, NewMarketplaceContract(), MintWorkerConfig{}, &NullLogging{})
+		transaction := &domain.Transaction{
+			ID:              uuid.NewString(),
+			ContractAddress: contract.Hex(),
+			TxHash:          sql.NullString{String: mint.Hash().Hex(), Valid: true},
+			Nonce:           3,
+			Status:          domain.TransactionStatusSubmitted,
+			UpdatedAt:       util.JapaneseNowTime().Add(-10 * time.Minute),
+		}
+		transactionGateway.EXPECT().GetByTransactionid(gomock.Any(), transaction.ID).Return(transaction, nil).AnyTimes()
+		return worker, backend, transaction, &replacements
+	}
+
+	t.Run("正常系: StuckAfter を過ぎたミントを同じノンスでガス代を上げて送り直す", func(t *testing.T) {
+		worker, backend, transaction, replacements := newWorker(t, StuckTxConfig{StuckAfter: time.Minute})
+		original := transaction.TxHash.String
+
+		done, err := worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		assert.False(t, done)
+		require.Len(t, *replacements, 1)
+		replacement := (*replacements)[0]
+		assert.Equal(t, domain.ReplacementKindSpeedUp, replacement.Kind)
+		assert.Equal(t, original, replacement.ReplacedTxHash)
+		assert.Equal(t, "115", replacement.GasPrice.String())
+		assert.False(t, replacement.UserID.Valid)
+		assert.Equal(t, replacement.TxHash, transaction.TxHash.String)
+
+		sent := backend.txs[common.HexToHash(replacement.TxHash)]
+		assert.Equal(t, uint64(3), sent.Nonce())
+		assert.Equal(t, contract, *sent.To())
+		assert.Equal(t, big.NewInt(1000), sent.Value())
+		assert.Equal(t, []byte{0x72, 0xb3, 0xb6, 0x20}, sent.Data())
+
+		// 送り直したトランザクションが取り込まれる
+		done, err = worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, domain.TransactionStatusConfirmed, transaction.Status)
+		assert.Equal(t, domain.ReplacementStatusMined, replacement.Status)
+	})
+
+	t.Run("正常系: 置き換える前のトランザクションが取り込まれた場合はそちらを記録する", func(t *testing.T) {
+		worker, backend, transaction, replacements := newWorker(t, StuckTxConfig{StuckAfter: time.Minute})
+		original := common.HexToHash(transaction.TxHash.String)
+
+		_, err := worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		require.Len(t, *replacements, 1)
+		replacement := (*replacements)[0]
+		delete(backend.receipts, common.HexToHash(replacement.TxHash))
+		backend.receipts[original] = &types.Receipt{TxHash: original, Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1)}
+
+		done, err := worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, original.Hex(), transaction.TxHash.String)
+		assert.Equal(t, domain.TransactionStatusConfirmed, transaction.Status)
+		assert.Equal(t, domain.ReplacementStatusReplaced, replacement.Status)
+	})
+
+	t.Run("正常系: 取り消しが取り込まれるとミントジョブはfailedになる", func(t *testing.T) {
+		worker, backend, transaction, replacements := newWorker(t, StuckTxConfig{StuckAfter: time.Minute, Action: domain.ReplacementKindCancel})
+		original := transaction.TxHash.String
+
+		_, err := worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		require.Len(t, *replacements, 1)
+		replacement := (*replacements)[0]
+		assert.Equal(t, domain.ReplacementKindCancel, replacement.Kind)
+		assert.Equal(t, original, transaction.TxHash.String)
+		sent := backend.txs[common.HexToHash(replacement.TxHash)]
+		assert.Equal(t, signer.Address(), *sent.To())
+		assert.Zero(t, sent.Value().Sign())
+		assert.Equal(t, uint64(cancelGasLimit), sent.Gas())
+
+		done, err := worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, domain.TransactionStatusFailed, transaction.Status)
+		assert.Contains(t, transaction.RevertReason.String, replacement.TxHash)
+		assert.Equal(t, original, transaction.TxHash.String)
+		assert.Equal(t, domain.ReplacementStatusMined, replacement.Status)
+	})
+
+	t.Run("異常系: ノードが受け付けなかった置き換えは failed で記録してエラーを返す", func(t *testing.T) {
+		worker, backend, transaction, replacements := newWorker(t, StuckTxConfig{StuckAfter: time.Minute})
+		original := transaction.TxHash.String
+		backend.sendErr = errors.New("replacement transaction underpriced")
+
+		_, err := worker.checkReceipt(ctx, transaction)
+		assert.ErrorContains(t, err, "underpriced")
+		require.Len(t, *replacements, 1)
+		assert.Equal(t, domain.ReplacementStatusFailed, (*replacements)[0].Status)
+		assert.Equal(t, original, transaction.TxHash.String)
+
+		// 送信されていない置き換えはレシートの確認に使わない
+		watched, err := worker.Watcher.load(ctx, transaction.ID)
+		require.NoError(t, err)
+		assert.Empty(t, watched.replacements)
+	})
+
+	t.Run("正常系: StuckAfter を過ぎていない・クリエイターが送信したミントは置き換えない", func(t *testing.T) {
+		worker, _, transaction, replacements := newWorker(t, StuckTxConfig{StuckAfter: time.Hour})
+
+		_, err := worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		assert.Empty(t, *replacements)
+
+		worker, _, transaction, replacements = newWorker(t, StuckTxConfig{StuckAfter: time.Minute})
+		transaction.CreatorAddress = "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"
+		_, err = worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		assert.Empty(t, *replacements)
+	})
+
+	t.Run("正常系: 管理者が追跡中のミントを置き換える", func(t *testing.T) {
+		worker, _, transaction, replacements := newWorker(t, StuckTxConfig{Action: StuckActionNone})
+		worker.tracking[transaction.ID] = &trackedJob{transaction: transaction}
+		adminID := uuid.New()
+		admin := ports.WithAuthUser(ctx, &ports.AuthUser{UserID: adminID, Wallet: "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50", Role: domain.RoleAdmin})
+
+		_, err := worker.checkReceipt(ctx, transaction)
+		require.NoError(t, err)
+		assert.Empty(t, *replacements)
+
+		output, err := worker.Replace(admin, transaction.ID, domain.ReplacementKindSpeedUp)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReplacementKindSpeedUp, output.Kind)
+		assert.Equal(t, 3, output.Nonce)
+		require.Len(t, *replacements, 1)
+		assert.Equal(t, uuid.NullUUID{UUID: adminID, Valid: true}, (*replacements)[0].UserID)
+	})
+
+	t.Run("異常系: 管理者以外は置き換えられない", func(t *testing.T) {
+		worker, _, transaction, _ := newWorker(t, StuckTxConfig{})
+		creator := ports.WithAuthUser(ctx, &ports.AuthUser{UserID: uuid.New(), Wallet: "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50", Role: domain.RoleCreator})
+
+		_, err := worker.Replace(creator, transaction.ID, domain.ReplacementKindCancel)
+		assert.ErrorContains(t, err, "Forbidden")
+	})
+
+	t.Run("異常系: 追跡していないミントは置き換えられない", func(t *testing.T) {
+		worker, _, transaction, _ := newWorker(t, StuckTxConfig{})
+		admin := ports.WithAuthUser(ctx, &ports.AuthUser{UserID: uuid.New(), Wallet: "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50", Role: domain.RoleAdmin})
+
+		_, err := worker.Replace(admin, transaction.ID, domain.ReplacementKindCancel)
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+}
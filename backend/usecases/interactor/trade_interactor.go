@@ -0,0 +1,423 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/usecases/gateways"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+	"nft-music/util"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+)
+
+// TradeBackend は二次流通のトランザクションの作成と確認に使うノードの機能（*ethclient.Client が実装している）
+type TradeBackend interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// TradeInteractor はユーザーのウォレットで署名する購入・再出品を扱う
+// 署名前のトランザクションを返し、送信されたトランザクションが取り込まれるまで追跡する
+type TradeInteractor struct {
+	TradeGateway gateways.TradeGateway
+	Client       TradeBackend
+	Marketplace  *MarketplaceContract
+	PollInterval time.Duration
+	Logging      logging.Logging
+}
+
+func NewTradeInteractor(tradeGateway gateways.TradeGateway, client TradeBackend, marketplace *MarketplaceContract, logging logging.Logging) *TradeInteractor {
+	return &TradeInteractor{
+		TradeGateway: tradeGateway,
+		Client:       client,
+		Marketplace:  marketplace,
+		PollInterval: 2 * time.Second,
+		Logging:      logging,
+	}
+}
+
+// marketState は取引の確認に使うコントラクトの現在の状態
+type marketState struct {
+	contract     *contracts.Contracts
+	address      common.Address
+	chainID      *big.Int
+	listingPrice *big.Int
+	royaltyBps   *big.Int
+}
+
+// Purchase は販売中のNFTを購入する署名前のトランザクションを作成する
+func (interactor *TradeInteractor) Purchase(ctx context.Context, tokenID string, input *ports.PurchaseInput) (*ports.TradeOutput, error) {
+	authUser, err := tradeUser(ctx, input.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseTokenID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	market, err := interactor.market(ctx)
+	if err != nil {
+		return nil, err
+	}
+	item, err := market.item(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Owner != market.address {
+		return nil, fmt.Errorf("BadRequest: トークン %s は販売中ではありません", id)
+	}
+	if util.SameAddress(item.Seller.Hex(), input.Wallet) {
+		return nil, errors.New("BadRequest: 自分が出品したNFTは購入できません")
+	}
+	// 販売価格から出品手数料とロイヤリティを差し引けない場合、createMarketSale はrevertする
+	if minimum := market.minimumPrice(); item.Price.Cmp(minimum) < 0 {
+		return nil, fmt.Errorf("BadRequest: 販売価格 %s wei が出品手数料とロイヤリティの合計 %s wei を下回っているため購入できません", item.Price, minimum)
+	}
+
+	data, err := packMarketplace("createMarketSale", id)
+	if err != nil {
+		return nil, err
+	}
+	return interactor.prepare(ctx, domain.TradeKindPurchase, authUser, market, id, item.Price, item.Price, data, marketplaceRoyalty(market.royaltyBps, item))
+}
+
+// Resell は所有しているNFTを再出品する署名前のトランザクションを作成する（出品手数料を送金する）
+func (interactor *TradeInteractor) Resell(ctx context.Context, tokenID string, input *ports.ResellInput) (*ports.TradeOutput, error) {
+	authUser, err := tradeUser(ctx, input.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseTokenID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := domain.ParsePrice(input.Price)
+	if err != nil {
+		return nil, err
+	}
+	if amount.Sign() <= 0 {
+		return nil, errors.New("BadRequest: price は0より大きい金額で指定してください")
+	}
+	price := amount.Wei()
+	market, err := interactor.market(ctx)
+	if err != nil {
+		return nil, err
+	}
+	item, err := market.item(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !util.SameAddress(item.Owner.Hex(), input.Wallet) {
+		return nil, fmt.Errorf("Forbidden: トークン %s の所有者のみ再出品できます", id)
+	}
+	if minimum := market.minimumPrice(); price.Cmp(minimum) < 0 {
+		return nil, fmt.Errorf("BadRequest: 価格は出品手数料とロイヤリティの合計 %s wei 以上にしてください", minimum)
+	}
+
+	data, err := packMarketplace("resellToken", id, price)
+	if err != nil {
+		return nil, err
+	}
+	return interactor.prepare(ctx, domain.TradeKindResell, authUser, market, id, price, market.listingPrice, data, marketplaceRoyalty(market.royaltyBps, item))
+}
+
+// Submit はユーザーが署名して送信したトランザクションを確認し、取り込まれるまで追跡する
+func (interactor *TradeInteractor) Submit(ctx context.Context, id uuid.UUID, input *ports.TradeSubmitInput) (*ports.TradeOutput, error) {
+	trade, err := interactor.TradeGateway.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, trade.UserID); err != nil {
+		return nil, err
+	}
+	if trade.Status != domain.TradeStatusPrepared {
+		return nil, fmt.Errorf("BadRequest: 取引 %s は既に %s です", trade.ID, trade.Status)
+	}
+
+	hashBytes, err := hexutil.Decode(input.TxHash)
+	if err != nil || len(hashBytes) != common.HashLength {
+		return nil, errors.New("BadRequest: tx_hash が正しくありません")
+	}
+	hash := common.BytesToHash(hashBytes)
+	tx, _, err := interactor.Client.TransactionByHash(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, fmt.Errorf("BadRequest: トランザクション %s が見つかりません", hash.Hex())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyTradeTx(trade, tx); err != nil {
+		return nil, err
+	}
+
+	trade.Status = domain.TradeStatusSubmitted
+	trade.TxHash = sql.NullString{String: hash.Hex(), Valid: true}
+	trade.UpdatedAt = util.JapaneseNowTime()
+	if err := interactor.TradeGateway.Update(ctx, trade); err != nil {
+		return nil, err
+	}
+
+	// リクエストが終わっても追跡を続ける
+	go interactor.track(context.WithoutCancel(ctx), trade)
+	return tradeOutput(trade), nil
+}
+
+// Get は取引の状態を取得する
+func (interactor *TradeInteractor) Get(ctx context.Context, id uuid.UUID) (*ports.TradeOutput, error) {
+	trade, err := interactor.TradeGateway.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return tradeOutput(trade), nil
+}
+
+// Resume は前回の起動時に取り込まれるのを待っていた取引の追跡を再開する
+func (interactor *TradeInteractor) Resume(ctx context.Context) {
+	trades, err := interactor.TradeGateway.ListByStatus(ctx, domain.TradeStatusSubmitted)
+	if err != nil {
+		interactor.Logging.Error(fmt.Sprintf("failed to resume trades: %s", err.Error()))
+		return
+	}
+	for _, trade := range trades {
+		go interactor.track(ctx, trade)
+	}
+}
+
+func (interactor *TradeInteractor) market(ctx context.Context) (*marketState, error) {
+	contract, address, err := interactor.Marketplace.Get()
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	paused, err := contract.Paused(opts)
+	if err != nil {
+		return nil, err
+	}
+	if paused {
+		return nil, errors.New("ServiceUnavailable: マーケットプレイスは一時停止中です")
+	}
+
+	chainID, err := interactor.Client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	listingPrice, err := contract.GetListingPrice(opts)
+	if err != nil {
+		return nil, err
+	}
+	royaltyBps, err := contract.RoyaltyFeeBps(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &marketState{
+		contract:     contract,
+		address:      address,
+		chainID:      chainID,
+		listingPrice: listingPrice,
+		royaltyBps:   royaltyBps,
+	}, nil
+}
+
+// item はトークンのマーケットアイテムを取得する
+func (market *marketState) item(ctx context.Context, tokenID *big.Int) (*contracts.NFTMarketplaceMarketItem, error) {
+	items, err := market.contract.FetchAllMarketItems(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, err
+	}
+	// トークンIDは1からの連番
+	if tokenID.Cmp(big.NewInt(int64(len(items)))) > 0 {
+		return nil, fmt.Errorf("record not found: トークン %s は存在しません", tokenID)
+	}
+	return &items[tokenID.Int64()-1], nil
+}
+
+// minimumPrice は販売時に出品手数料とロイヤリティを差し引ける最低価格
+// （price - price*royaltyBps/10000 >= listingPrice）
+func (market *marketState) minimumPrice() *big.Int {
+	remaining := new(big.Int).Sub(big.NewInt(10000), market.royaltyBps)
+	if remaining.Sign() <= 0 {
+		return new(big.Int).Set(market.listingPrice)
+	}
+	minimum := new(big.Int).Mul(market.listingPrice, big.NewInt(10000))
+	minimum.Add(minimum, new(big.Int).Sub(remaining, big.NewInt(1)))
+	return minimum.Div(minimum, remaining)
+}
+
+// prepare はガスとノンスを見積もり、署名前のトランザクションとして取引を登録する
+// royalty は販売時にコントラクトが支払うロイヤリティで、内訳として返す
+func (interactor *TradeInteractor) prepare(ctx context.Context, kind string, authUser *ports.AuthUser, market *marketState, tokenID *big.Int, price *big.Int, value *big.Int, data []byte, royalty *domain.Royalty) (*ports.TradeOutput, error) {
+	from := common.HexToAddress(authUser.Wallet)
+	gas, err := interactor.Client.EstimateGas(ctx, ethereum.CallMsg{From: from, To: &market.address, Value: value, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("BadRequest: トランザクションが失敗します: %w", err)
+	}
+	nonce, err := interactor.Client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	now := util.JapaneseNowTime()
+	trade := &domain.Trade{
+		ID:              id,
+		Kind:            kind,
+		ChainID:         int(market.chainID.Int64()),
+		ContractAddress: market.address.Hex(),
+		TokenID:         tokenID.String(),
+		UserID:          authUser.UserID,
+		Wallet:          from.Hex(),
+		Price:           domain.NewAmount(price),
+		Value:           domain.NewAmount(value),
+		Calldata:        hexutil.Encode(data),
+		Status:          domain.TradeStatusPrepared,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := interactor.TradeGateway.Create(ctx, trade); err != nil {
+		return nil, err
+	}
+
+	output := tradeOutput(trade)
+	output.Transaction = &ports.UnsignedTxOutput{
+		ChainID: trade.ChainID,
+		From:    trade.Wallet,
+		To:      trade.ContractAddress,
+		Data:    trade.Calldata,
+		Value:   trade.Value,
+		Gas:     gas,
+		Nonce:   nonce,
+	}
+	output.Royalty = royaltyInfoOutput(trade.ChainID, market.address, tokenID, trade.Price, royalty)
+	return output, nil
+}
+
+// track は取引のトランザクションが取り込まれるまでレシートを確認する
+func (interactor *TradeInteractor) track(ctx context.Context, trade *domain.Trade) {
+	ticker := time.NewTicker(interactor.PollInterval)
+	defer ticker.Stop()
+	for {
+		done, err := interactor.checkReceipt(ctx, trade)
+		if err != nil {
+			interactor.Logging.Warning(fmt.Sprintf("failed to check receipt of trade %s: %s", trade.ID, err.Error()))
+		}
+		if done {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkReceipt はレシートがあれば取引を mined か failed にして true を返す
+func (interactor *TradeInteractor) checkReceipt(ctx context.Context, trade *domain.Trade) (bool, error) {
+	hash := common.HexToHash(trade.TxHash.String)
+	receipt, err := interactor.Client.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	trade.BlockNumber = sql.NullInt64{Int64: receipt.BlockNumber.Int64(), Valid: true}
+	trade.Status = domain.TradeStatusMined
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		trade.Status = domain.TradeStatusFailed
+		trade.RevertReason = sql.NullString{String: truncateRevertReason(revertReason(ctx, interactor.Client, hash, receipt.BlockNumber)), Valid: true}
+	}
+	trade.UpdatedAt = util.JapaneseNowTime()
+	if err := interactor.TradeGateway.Update(ctx, trade); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// tradeUser はログイン中のユーザーが指定したウォレットで取引できるか確認する
+func tradeUser(ctx context.Context, wallet string) (*ports.AuthUser, error) {
+	if err := authorizeWallet(ctx, wallet); err != nil {
+		return nil, err
+	}
+	authUser, _ := ports.AuthUserFrom(ctx)
+	return authUser, nil
+}
+
+func parseTokenID(tokenID string) (*big.Int, error) {
+	id, ok := new(big.Int).SetString(tokenID, 10)
+	if !ok || id.Sign() <= 0 {
+		return nil, fmt.Errorf("BadRequest: トークンID %s が正しくありません", tokenID)
+	}
+	return id, nil
+}
+
+func packMarketplace(method string, args ...any) ([]byte, error) {
+	parsed, err := contracts.ContractsMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Pack(method, args...)
+}
+
+// verifyTradeTx は送信されたトランザクションが取引で返した内容と一致するか確認する
+func verifyTradeTx(trade *domain.Trade, tx *types.Transaction) error {
+	mismatch := errors.New("BadRequest: 取引の内容と異なるトランザクションです")
+
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil || !util.SameAddress(sender.Hex(), trade.Wallet) {
+		return mismatch
+	}
+	if tx.To() == nil || !util.SameAddress(tx.To().Hex(), trade.ContractAddress) {
+		return mismatch
+	}
+	data, err := hexutil.Decode(trade.Calldata)
+	if err != nil || !bytes.Equal(tx.Data(), data) {
+		return mismatch
+	}
+	if tx.Value().Cmp(trade.Value.Wei()) != 0 {
+		return mismatch
+	}
+	return nil
+}
+
+func tradeOutput(trade *domain.Trade) *ports.TradeOutput {
+	return &ports.TradeOutput{
+		ID:              trade.ID,
+		Kind:            trade.Kind,
+		TokenID:         trade.TokenID,
+		ContractAddress: trade.ContractAddress,
+		Wallet:          trade.Wallet,
+		Price:           trade.Price,
+		Status:          trade.Status,
+		TxHash:          trade.TxHash.String,
+		BlockNumber:     uint64(trade.BlockNumber.Int64),
+		RevertReason:    trade.RevertReason.String,
+		CreatedAt:       trade.CreatedAt,
+		UpdatedAt:       trade.UpdatedAt,
+	}
+}
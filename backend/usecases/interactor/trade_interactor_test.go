@@ -0,0 +1,313 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"testing"
+
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/usecases/gateways/mock"
+	"nft-music/usecases/ports"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeMarketBackend はマーケットプレイスの状態を返すテスト用のノード
+type fakeMarketBackend struct {
+	bind.ContractBackend // テストで使わないメソッド
+
+	items        []contracts.NFTMarketplaceMarketItem
+	owner        common.Address
+	listingPrice *big.Int
+	royaltyBps   *big.Int
+	paused       bool
+	estimateErr  error
+	revertSent   bool  // 送信したトランザクションをrevertさせる
+	sendErr      error // ノードが送信を受け付けない
+	txs          map[common.Hash]*types.Transaction
+	receipts     map[common.Hash]*types.Receipt
+}
+
+func (fake *fakeMarketBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	parsed, err := contracts.ContractsMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	method, err := parsed.MethodById(msg.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "fetchAllMarketItems":
+		return method.Outputs.Pack(fake.items)
+	case "getListingPrice":
+		return method.Outputs.Pack(fake.listingPrice)
+	case "royaltyFeeBps":
+		return method.Outputs.Pack(fake.royaltyBps)
+	case "paused":
+		return method.Outputs.Pack(fake.paused)
+	case "owner":
+		return method.Outputs.Pack(fake.owner)
+	case "ownerOf":
+		args, err := method.Inputs.Unpack(msg.Data[4:])
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(fake.items[args[0].(*big.Int).Int64()-1].Owner)
+	}
+	return nil, errors.New("execution reverted")
+}
+
+func (fake *fakeMarketBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1337), nil
+}
+
+func (fake *fakeMarketBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return 120000, fake.estimateErr
+}
+
+func (fake *fakeMarketBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return 3, nil
+}
+
+func (fake *fakeMarketBackend) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	tx, ok := fake.txs[hash]
+	if !ok {
+		return nil, false, ethereum.NotFound
+	}
+	return tx, false, nil
+}
+
+func (fake *fakeMarketBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	return 1, nil
+}
+
+func (fake *fakeMarketBackend) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return []byte{0x60}, nil
+}
+
+func (fake *fakeMarketBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(1)}, nil
+}
+
+func (fake *fakeMarketBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+// SendTransaction は送信したトランザクションをすぐにブロック1に取り込む
+func (fake *fakeMarketBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if fake.sendErr != nil {
+		return fake.sendErr
+	}
+	status := types.ReceiptStatusSuccessful
+	if fake.revertSent {
+		status = types.ReceiptStatusFailed
+	}
+	fake.txs[tx.Hash()] = tx
+	fake.receipts[tx.Hash()] = &types.Receipt{TxHash: tx.Hash(), Status: status, BlockNumber: big.NewInt(1)}
+	return nil
+}
+
+func (fake *fakeMarketBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	receipt, ok := fake.receipts[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func TestTradeInteractor(t *testing.T) {
+	contract := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	seller := common.HexToAddress("0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50")
+	buyerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	buyer := crypto.PubkeyToAddress(buyerKey.PublicKey)
+	buyerID := uuid.New()
+	buyerCtx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: buyerID, Wallet: buyer.Hex(), Role: domain.RoleMember})
+
+	newInteractor := func(t *testing.T) (*TradeInteractor, *fakeMarketBackend, *mock.MockTradeGateway) {
+		backend := &fakeMarketBackend{
+			items: []contracts.NFTMarketplaceMarketItem{
+				// 1: 販売中 2: buyerが所有
+				{TokenId: big.NewInt(1), Seller: seller, Owner: contract, Price: big.NewInt(1000), Creator: seller},
+				{TokenId: big.NewInt(2), Owner: buyer, Price: big.NewInt(1000), Sold: true, Creator: seller},
+			},
+			listingPrice: big.NewInt(100),
+			royaltyBps:   big.NewInt(1000),
+			txs:          map[common.Hash]*types.Transaction{},
+			receipts:     map[common.Hash]*types.Receipt{},
+		}
+		marketplace := NewMarketplaceContract()
+		bound, err := contracts.NewContracts(contract, backend)
+		require.NoError(t, err)
+		marketplace.Set(contract, bound)
+
+		tradeGateway := mock.NewMockTradeGateway(gomock.NewController(t))
+		return NewTradeInteractor(tradeGateway, backend, marketplace, &NullLogging{}), backend, tradeGateway
+	}
+
+	t.Run("正常系: 販売中のNFTを購入するトランザクションを返す", func(t *testing.T) {
+		interactor, _, tradeGateway := newInteractor(t)
+		var created *domain.Trade
+		tradeGateway.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, trade *domain.Trade) error {
+			created = trade
+			return nil
+		})
+
+		output, err := interactor.Purchase(buyerCtx, "1", &ports.PurchaseInput{Wallet: buyer.Hex()})
+		require.NoError(t, err)
+		assert.Equal(t, domain.TradeStatusPrepared, output.Status)
+		assert.Equal(t, domain.TradeKindPurchase, created.Kind)
+		assert.Equal(t, contract.Hex(), output.Transaction.To)
+		assert.Equal(t, "1000", output.Transaction.Value.String())
+		assert.Equal(t, uint64(120000), output.Transaction.Gas)
+		assert.Equal(t, uint64(3), output.Transaction.Nonce)
+
+		expected, err := packMarketplace("createMarketSale", big.NewInt(1))
+		require.NoError(t, err)
+		assert.Equal(t, expected, common.FromHex(output.Transaction.Data))
+
+		// royaltyFeeBps の10%をクリエイターに支払う
+		require.NotNil(t, output.Royalty)
+		assert.Equal(t, seller.Hex(), output.Royalty.Receiver)
+		assert.Equal(t, "100", output.Royalty.RoyaltyAmount.String())
+		assert.Equal(t, "900", output.Royalty.Remaining.String())
+		assert.Equal(t, domain.RoyaltySourceMarketplace, output.Royalty.Source)
+	})
+
+	t.Run("異常系: 販売中ではないNFTは購入できない", func(t *testing.T) {
+		interactor, _, _ := newInteractor(t)
+		_, err := interactor.Purchase(buyerCtx, "2", &ports.PurchaseInput{Wallet: buyer.Hex()})
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+
+	t.Run("異常系: 存在しないトークン", func(t *testing.T) {
+		interactor, _, _ := newInteractor(t)
+		_, err := interactor.Purchase(buyerCtx, "9", &ports.PurchaseInput{Wallet: buyer.Hex()})
+		assert.ErrorContains(t, err, "record not found")
+	})
+
+	t.Run("異常系: 一時停止中は取引できない", func(t *testing.T) {
+		interactor, backend, _ := newInteractor(t)
+		backend.paused = true
+		_, err := interactor.Purchase(buyerCtx, "1", &ports.PurchaseInput{Wallet: buyer.Hex()})
+		assert.ErrorContains(t, err, "ServiceUnavailable")
+	})
+
+	t.Run("正常系: 所有しているNFTを出品手数料を付けて再出品する", func(t *testing.T) {
+		interactor, _, tradeGateway := newInteractor(t)
+		tradeGateway.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		output, err := interactor.Resell(buyerCtx, "2", &ports.ResellInput{Wallet: buyer.Hex(), Price: "5000 wei"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.TradeKindResell, output.Kind)
+		assert.Equal(t, "5000", output.Price.String())
+		assert.Equal(t, "100", output.Transaction.Value.String())
+		assert.Equal(t, "500", output.Royalty.RoyaltyAmount.String())
+	})
+
+	t.Run("異常系: 所有者以外は再出品できない", func(t *testing.T) {
+		interactor, _, _ := newInteractor(t)
+		_, err := interactor.Resell(buyerCtx, "1", &ports.ResellInput{Wallet: buyer.Hex(), Price: "5000 wei"})
+		assert.ErrorContains(t, err, "Forbidden")
+	})
+
+	t.Run("異常系: 手数料とロイヤリティを下回る価格では再出品できない", func(t *testing.T) {
+		interactor, _, _ := newInteractor(t)
+		// 100 / (1 - 0.1) = 111.1... wei 未満
+		_, err := interactor.Resell(buyerCtx, "2", &ports.ResellInput{Wallet: buyer.Hex(), Price: "111 wei"})
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+
+	t.Run("正常系: 取引と一致するトランザクションを登録する", func(t *testing.T) {
+		interactor, backend, tradeGateway := newInteractor(t)
+		data, err := packMarketplace("createMarketSale", big.NewInt(1))
+		require.NoError(t, err)
+		trade := &domain.Trade{
+			ID:              uuid.New(),
+			Kind:            domain.TradeKindPurchase,
+			ContractAddress: contract.Hex(),
+			UserID:          buyerID,
+			Wallet:          buyer.Hex(),
+			Value:           domain.NewAmount(big.NewInt(1000)),
+			Calldata:        hexutil.Encode(data),
+			Status:          domain.TradeStatusPrepared,
+		}
+		tx, err := types.SignNewTx(buyerKey, types.LatestSignerForChainID(big.NewInt(1337)), &types.DynamicFeeTx{
+			ChainID: big.NewInt(1337), To: &contract, Value: big.NewInt(1000), Data: data, Gas: 120000,
+		})
+		require.NoError(t, err)
+		backend.txs[tx.Hash()] = tx
+
+		tradeGateway.EXPECT().Get(gomock.Any(), trade.ID).Return(trade, nil)
+		tradeGateway.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		output, err := interactor.Submit(buyerCtx, trade.ID, &ports.TradeSubmitInput{TxHash: tx.Hash().Hex()})
+		require.NoError(t, err)
+		assert.Equal(t, domain.TradeStatusSubmitted, output.Status)
+		assert.Equal(t, tx.Hash().Hex(), output.TxHash)
+	})
+
+	t.Run("正常系: レシートのステータスで mined か failed にする", func(t *testing.T) {
+		interactor, backend, tradeGateway := newInteractor(t)
+		tradeGateway.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mined := &domain.Trade{TxHash: sql.NullString{String: common.HexToHash("0x01").Hex(), Valid: true}, Status: domain.TradeStatusSubmitted}
+		reverted := &domain.Trade{TxHash: sql.NullString{String: common.HexToHash("0x02").Hex(), Valid: true}, Status: domain.TradeStatusSubmitted}
+		pending := &domain.Trade{TxHash: sql.NullString{String: common.HexToHash("0x03").Hex(), Valid: true}, Status: domain.TradeStatusSubmitted}
+		backend.receipts[common.HexToHash("0x01")] = &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(42)}
+		backend.receipts[common.HexToHash("0x02")] = &types.Receipt{Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(43)}
+
+		done, err := interactor.checkReceipt(context.Background(), mined)
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, domain.TradeStatusMined, mined.Status)
+		assert.Equal(t, int64(42), mined.BlockNumber.Int64)
+
+		done, err = interactor.checkReceipt(context.Background(), reverted)
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, domain.TradeStatusFailed, reverted.Status)
+		assert.True(t, reverted.RevertReason.Valid)
+
+		done, err = interactor.checkReceipt(context.Background(), pending)
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.Equal(t, domain.TradeStatusSubmitted, pending.Status)
+	})
+
+	t.Run("異常系: 取引と異なるトランザクションは登録できない", func(t *testing.T) {
+		interactor, backend, tradeGateway := newInteractor(t)
+		trade := &domain.Trade{
+			ID:              uuid.New(),
+			ContractAddress: contract.Hex(),
+			UserID:          buyerID,
+			Wallet:          buyer.Hex(),
+			Value:           domain.NewAmount(big.NewInt(1000)),
+			Calldata:        "0xbe9af536",
+			Status:          domain.TradeStatusPrepared,
+		}
+		// 送金額が少ない
+		tx, err := types.SignNewTx(buyerKey, types.LatestSignerForChainID(big.NewInt(1337)), &types.DynamicFeeTx{
+			ChainID: big.NewInt(1337), To: &contract, Value: big.NewInt(1), Data: common.FromHex(trade.Calldata), Gas: 120000,
+		})
+		require.NoError(t, err)
+		backend.txs[tx.Hash()] = tx
+		tradeGateway.EXPECT().Get(gomock.Any(), trade.ID).Return(trade, nil)
+
+		_, err = interactor.Submit(buyerCtx, trade.ID, &ports.TradeSubmitInput{TxHash: tx.Hash().Hex()})
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+}
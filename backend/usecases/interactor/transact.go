@@ -0,0 +1,73 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"math/big"
+
+	"nft-music/usecases/gateways"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// newTransactOpts は署名者でトランザクションに署名する bind.TransactOpts を作成する
+// （ガス代とガスリミットは FeePolicy で設定するか、未設定の場合は送信時にノードから見積もられる）
+func newTransactOpts(ctx context.Context, signer gateways.Signer, chainID *big.Int) *bind.TransactOpts {
+	from := signer.Address()
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			return signer.SignTx(ctx, tx, chainID)
+		},
+		Context: ctx,
+	}
+}
+
+// revertReasonMaxLength は revert_reason カラムの長さ
+const revertReasonMaxLength = 1024
+
+// revertReasonBackend は失敗したトランザクションの再実行に使うノードの機能
+type revertReasonBackend interface {
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// revertReason は失敗したトランザクションを直前のブロックで再実行してrevertの理由を取得する
+func revertReason(ctx context.Context, client revertReasonBackend, hash common.Hash, blockNumber *big.Int) string {
+	const unknown = "transaction reverted"
+
+	tx, _, err := client.TransactionByHash(ctx, hash)
+	if err != nil {
+		return unknown
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return unknown
+	}
+
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	if _, err := client.CallContract(ctx, msg, new(big.Int).Sub(blockNumber, big.NewInt(1))); err != nil {
+		return err.Error()
+	}
+	return unknown
+}
+
+// truncateRevertReason はrevertの理由をカラムの長さに切り詰める
+func truncateRevertReason(reason string) string {
+	if len(reason) > revertReasonMaxLength {
+		return reason[:revertReasonMaxLength]
+	}
+	return reason
+}
@@ -0,0 +1,192 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"nft-music/domain"
+	"nft-music/util/audio"
+)
+
+// defaultUploadMaxSize はファイルの種類を問わない上限の既定値（2GiB）
+const defaultUploadMaxSize = 2 << 30
+
+// UploadConfig はストリーミングでアップロードするファイルの上限
+type UploadConfig struct {
+	MaxSize    int64             // ファイルの種類を問わない上限（既定 2GiB）
+	TypeLimits map[string]int64  // ファイルの種類（audio / image / video）ごとの上限。MaxSize を超える値は MaxSize になる
+	Preview    audio.ClipOptions // 音声ファイルから切り出すプレビューの開始位置・長さ・フェード
+}
+
+// uploadKinds は mime に登録されていないことがある音声・動画の拡張子の種類
+var uploadKinds = map[string]string{
+	".mp3":  "audio",
+	".wav":  "audio",
+	".flac": "audio",
+	".aac":  "audio",
+	".m4a":  "audio",
+	".ogg":  "audio",
+	".mp4":  "video",
+	".mov":  "video",
+	".webm": "video",
+}
+
+// uploadKind はファイル名の拡張子からファイルの種類（MIMEタイプの主タイプ）を判定する
+func uploadKind(filename string) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if kind, ok := uploadKinds[ext]; ok {
+		return kind
+	}
+	kind, _, _ := strings.Cut(mime.TypeByExtension(ext), "/")
+	return kind
+}
+
+// maxSize はファイルの種類を問わない上限を返す
+func (config UploadConfig) maxSize() int64 {
+	if config.MaxSize <= 0 {
+		return defaultUploadMaxSize
+	}
+	return config.MaxSize
+}
+
+// limit はファイルの種類ごとの上限を返す
+func (config UploadConfig) limit(filename string) int64 {
+	maxSize := config.maxSize()
+	if limit, ok := config.TypeLimits[uploadKind(filename)]; ok && limit > 0 && limit < maxSize {
+		return limit
+	}
+	return maxSize
+}
+
+// uploadReader はアップロード中のファイルを読み取りながら SHA-256 の計算・上限の確認・進捗の記録を行う
+// コンテキストが終わった（クライアントの切断・取り消し）場合は、保存先への送信を止めるため読み取りをエラーにする
+type uploadReader struct {
+	ctx      context.Context
+	reader   io.Reader
+	hash     hash.Hash
+	limit    int64
+	received atomic.Int64
+}
+
+func newUploadReader(ctx context.Context, reader io.Reader, limit int64) *uploadReader {
+	return &uploadReader{ctx: ctx, reader: reader, hash: sha256.New(), limit: limit}
+}
+
+func (upload *uploadReader) Read(p []byte) (int, error) {
+	if err := context.Cause(upload.ctx); err != nil {
+		return 0, err
+	}
+	n, err := upload.reader.Read(p)
+	upload.hash.Write(p[:n])
+	if upload.received.Add(int64(n)) > upload.limit {
+		return n, fmt.Errorf("PayloadTooLarge: ファイルサイズが上限（%d バイト）を超えています", upload.limit)
+	}
+	return n, err
+}
+
+// sha256 は読み取った内容の SHA-256（保存先への送信が終わってから呼び出す）
+func (upload *uploadReader) sha256() string {
+	return hex.EncodeToString(upload.hash.Sum(nil))
+}
+
+// spooledAudio は内容を確認するために一時ファイルに書き出した音声ファイル
+// 保存先への送信とプレビューの切り出しは、この一時ファイルから読み取る
+type spooledAudio struct {
+	file     *os.File
+	size     int64
+	metadata *domain.AudioMetadata
+}
+
+// Close は一時ファイルを削除する（音声ではないファイルの nil でも呼び出せる）
+func (spooled *spooledAudio) Close() {
+	if spooled == nil {
+		return
+	}
+	spooled.file.Close()
+	os.Remove(spooled.file.Name())
+}
+
+// inspectAudio は音声ファイルの内容を確認して技術的なメタデータを読み取る
+// 拡張子が画像・動画のファイルは確認しない。拡張子が音声のファイルと、内容が音声のファイルは、対応している形式で壊れていないものだけを受け付ける
+// 読み取りにはファイル全体が必要なため音声は一時ファイルに書き出し、保存先にはその一時ファイルの内容を送る
+// 音声ではないファイルは spooled が nil になる
+func inspectAudio(filename string, reader io.Reader) (content io.Reader, spooled *spooledAudio, err error) {
+	kind := uploadKind(filename)
+	if kind == domain.MediaKindImage || kind == domain.MediaKindVideo {
+		return reader, nil, nil
+	}
+
+	head := bufio.NewReaderSize(reader, audio.SniffSize)
+	peek, err := head.Peek(audio.SniffSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, err
+	}
+	if audio.Sniff(peek) == "" {
+		if kind == domain.MediaKindAudio {
+			return nil, nil, fmt.Errorf("BadRequest: %s は対応している音声ファイル（MP3 / FLAC / WAV / AAC / M4A / OGG）ではありません", filename)
+		}
+		return head, nil, nil
+	}
+
+	file, err := os.CreateTemp("", "upload-audio-*")
+	if err != nil {
+		return nil, nil, err
+	}
+	spooled = &spooledAudio{file: file}
+	if spooled.size, err = io.Copy(file, head); err != nil {
+		spooled.Close()
+		return nil, nil, err
+	}
+	info, err := audio.Probe(file, spooled.size)
+	if err != nil {
+		spooled.Close()
+		return nil, nil, fmt.Errorf("BadRequest: %s を音声ファイルとして読み取れません: %w", filename, err)
+	}
+	spooled.metadata = audioMetadata(info)
+	return io.NewSectionReader(file, 0, spooled.size), spooled, nil
+}
+
+// audioMetadata は読み取った音声ファイルの情報を保存する形にする
+func audioMetadata(info *audio.Info) *domain.AudioMetadata {
+	return &domain.AudioMetadata{
+		Format:     info.Format,
+		Codec:      info.Codec,
+		Duration:   math.Round(info.Duration.Seconds()*1000) / 1000,
+		SampleRate: info.SampleRate,
+		BitDepth:   info.BitDepth,
+		Channels:   info.Channels,
+		Bitrate:    info.Bitrate,
+		Tags:       info.Tags,
+	}
+}
+
+// previewFilename はプレビューのファイル名（track.mp3 → track.preview.mp3）
+func previewFilename(filename string) string {
+	ext := filepath.Ext(filename)
+	return strings.TrimSuffix(filename, ext) + ".preview" + ext
+}
+
+// mediaKind は保存するファイルの種類（audio / image / video 以外は空）
+func mediaKind(filename string, metadata *domain.AudioMetadata) string {
+	if metadata != nil {
+		return domain.MediaKindAudio
+	}
+	switch kind := uploadKind(filename); kind {
+	case domain.MediaKindAudio, domain.MediaKindImage, domain.MediaKindVideo:
+		return kind
+	}
+	return ""
+}
@@ -4,6 +4,7 @@ package interactor
 import (
 	"context"
 	"database/sql"
+	"errors"
 
 	"nft-music/adapters/presenters"
 	"nft-music/domain"
@@ -79,7 +80,7 @@ func (interactor *UserInteractor) Create(ctx context.Context, input ports.UserIn
 			String: input.Profile,
 			Valid:  true,
 		},
-		Role:      "member",
+		Role:      domain.RoleMember, // 権限の変更は管理者のみが行う
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -88,6 +89,14 @@ func (interactor *UserInteractor) Create(ctx context.Context, input ports.UserIn
 }
 
 func (interactor *UserInteractor) Update(ctx context.Context, id uuid.UUID, input ports.UserInput) error {
+	if err := interactor.authorizeSelf(ctx, id); err != nil {
+		return err
+	}
+	// ウォレットアドレスを他人のものに付け替えることはできない
+	if err := authorizeWallet(ctx, input.Wallet); err != nil {
+		return err
+	}
+
 	user := &domain.User{
 		ID:     id,
 		Name:   input.Name,
@@ -114,7 +123,6 @@ func (interactor *UserInteractor) Update(ctx context.Context, id uuid.UUID, inpu
 			String: input.Profile,
 			Valid:  true,
 		},
-		Role:      "member",
 		UpdatedAt: util.JapaneseNowTime(),
 	}
 
@@ -122,8 +130,47 @@ func (interactor *UserInteractor) Update(ctx context.Context, id uuid.UUID, inpu
 }
 
 func (interactor *UserInteractor) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := interactor.authorizeSelf(ctx, id); err != nil {
+		return err
+	}
+
 	user := &domain.User{
 		ID: id,
 	}
 	return interactor.UserGateway.Delete(ctx, user)
 }
+
+// UpdateRole はユーザーの権限を変更する（管理者のみ）
+func (interactor *UserInteractor) UpdateRole(ctx context.Context, id uuid.UUID, input ports.UserRoleInput) error {
+	if err := authorize(ctx, ActionChangeRole); err != nil {
+		return err
+	}
+	if !domain.ValidRole(input.Role) {
+		return errors.New("BadRequest: 権限が不正です")
+	}
+
+	// 管理者が自分の権限を外して管理者がいなくなることを防ぐ
+	if authUser, _ := ports.AuthUserFrom(ctx); authUser.UserID == id {
+		return errors.New("Forbidden: 自分自身の権限は変更できません")
+	}
+
+	if _, err := interactor.UserGateway.Get(ctx, &domain.User{ID: id}); err != nil {
+		return err
+	}
+
+	user := &domain.User{
+		ID:        id,
+		Role:      input.Role,
+		UpdatedAt: util.JapaneseNowTime(),
+	}
+	return interactor.UserGateway.Update(ctx, user)
+}
+
+// authorizeSelf はリクエストしたユーザー本人のアカウントであるか確認する
+func (interactor *UserInteractor) authorizeSelf(ctx context.Context, id uuid.UUID) error {
+	user, err := interactor.UserGateway.Get(ctx, &domain.User{ID: id})
+	if err != nil {
+		return err
+	}
+	return authorizeWallet(ctx, user.Wallet)
+}
@@ -0,0 +1,75 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	"nft-music/domain"
+	"nft-music/usecases/gateways/mock"
+	"nft-music/usecases/ports"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserInteractor_UpdateRole(t *testing.T) {
+	adminID := uuid.New()
+	adminCtx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: adminID, Role: domain.RoleAdmin})
+
+	t.Run("正常系: 管理者はユーザーをクリエイターに昇格できる", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockUserGateway := mock.NewMockUserGateway(ctrl)
+		interactor := NewUserInteractor(mockUserGateway, &NullLogging{})
+
+		id := uuid.New()
+		mockUserGateway.EXPECT().
+			Get(gomock.Any(), gomock.Any()).
+			Return(&domain.User{ID: id, Role: domain.RoleMember}, nil)
+		mockUserGateway.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, user *domain.User) error {
+				assert.Equal(t, id, user.ID)
+				assert.Equal(t, domain.RoleCreator, user.Role)
+				return nil
+			})
+
+		err := interactor.UpdateRole(adminCtx, id, ports.UserRoleInput{Role: domain.RoleCreator})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系: 管理者以外は権限を変更できない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		interactor := NewUserInteractor(mock.NewMockUserGateway(ctrl), &NullLogging{})
+
+		creatorID := uuid.New()
+		creatorCtx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: creatorID, Role: domain.RoleCreator})
+		err := interactor.UpdateRole(creatorCtx, creatorID, ports.UserRoleInput{Role: domain.RoleAdmin})
+
+		assert.ErrorContains(t, err, "Forbidden")
+	})
+
+	t.Run("異常系: 存在しない権限", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		interactor := NewUserInteractor(mock.NewMockUserGateway(ctrl), &NullLogging{})
+
+		err := interactor.UpdateRole(adminCtx, uuid.New(), ports.UserRoleInput{Role: "owner"})
+
+		assert.ErrorContains(t, err, "BadRequest")
+	})
+
+	t.Run("異常系: 自分自身の権限は変更できない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		interactor := NewUserInteractor(mock.NewMockUserGateway(ctrl), &NullLogging{})
+
+		err := interactor.UpdateRole(adminCtx, adminID, ports.UserRoleInput{Role: domain.RoleMember})
+
+		assert.ErrorContains(t, err, "Forbidden")
+	})
+}
@@ -0,0 +1,673 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/usecases/gateways"
+	"nft-music/usecases/logging"
+	"nft-music/usecases/ports"
+	"nft-music/util"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/google/uuid"
+)
+
+// Collection.sol の EIP-712 のドメイン
+const (
+	voucherDomainName    = "NFT Music Collection"
+	voucherDomainVersion = "1"
+)
+
+// voucherStatusExpired は出品中のまま有効期限が過ぎたバウチャーの出力用のステータス
+const voucherStatusExpired = "expired"
+
+// VoucherInteractor は遅延ミントのバウチャーを扱う
+// クリエイターが EIP-712 で署名したバウチャーを出品し、最初の購入者が署名する redeem のトランザクションでコレクションのコントラクトにミントする
+type VoucherInteractor struct {
+	VoucherGateway gateways.VoucherGateway
+	IpfsGateway    gateways.IpfsGateway
+	Collections    *CollectionInteractor
+	ChainRegistry  *ChainRegistry
+	PollInterval   time.Duration
+	Logging        logging.Logging
+}
+
+func NewVoucherInteractor(voucherGateway gateways.VoucherGateway, ipfsGateway gateways.IpfsGateway, collections *CollectionInteractor, chainRegistry *ChainRegistry, logging logging.Logging) *VoucherInteractor {
+	return &VoucherInteractor{
+		VoucherGateway: voucherGateway,
+		IpfsGateway:    ipfsGateway,
+		Collections:    collections,
+		ChainRegistry:  chainRegistry,
+		PollInterval:   2 * time.Second,
+		Logging:        logging,
+	}
+}
+
+// Create はコレクションのバウチャーを作成し、クリエイターが署名する EIP-712 のデータを返す（コレクションの作成者のみ）
+// cid は IpfsInteractor.MetaJSON で登録したメタJSONのCID
+func (interactor *VoucherInteractor) Create(ctx context.Context, collectionID uuid.UUID, input *ports.VoucherInput, cid string) (*ports.VoucherOutput, error) {
+	if err := authorize(ctx, ActionMintNft); err != nil {
+		return nil, err
+	}
+	if err := authorizeWallet(ctx, input.Wallet); err != nil {
+		return nil, err
+	}
+	collection, err := interactor.Collections.authorizeOwner(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	// redeem は Collection.sol にしか無いため、外部でデプロイしたコントラクトでは引き換えられない
+	if !collection.DeployTx.Valid {
+		return nil, fmt.Errorf("BadRequest: コレクション %s はプラットフォームでデプロイしていないためバウチャーを作成できません", collection.ID)
+	}
+	if _, err := interactor.Collections.chainID(ctx, collection.ChainID); err != nil {
+		return nil, err
+	}
+
+	chain, err := interactor.ChainRegistry.Get(collection.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	price, err := chain.ParsePrice(input.Price)
+	if err != nil {
+		return nil, err
+	}
+	if price.Sign() <= 0 {
+		return nil, errors.New("BadRequest: price は0より大きい金額で指定してください")
+	}
+	if err := validateRoyalty(input.RoyaltyBps, input.RoyaltyReceiver); err != nil {
+		return nil, err
+	}
+	if input.RoyaltyBps > 0 && input.RoyaltyReceiver == "" {
+		return nil, errors.New("BadRequest: ロイヤリティの受取人を指定してください")
+	}
+	now := util.JapaneseNowTime()
+	if !input.ExpiresAt.After(now) {
+		return nil, errors.New("BadRequest: expires_at には現在より後の日時を指定してください")
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	authUser, _ := ports.AuthUserFrom(ctx)
+	voucher := &domain.MintVoucher{
+		ID:              id,
+		CollectionID:    collection.ID,
+		ChainID:         collection.ChainID,
+		ContractAddress: collection.ContractAddress,
+		UserID:          authUser.UserID,
+		Signer:          common.HexToAddress(input.Wallet).Hex(),
+		TokenURL:        fmt.Sprintf("/ipfs/%s", cid),
+		GenreID:         input.GenreID,
+		Price:           price,
+		RoyaltyBps:      input.RoyaltyBps,
+		RoyaltyReceiver: input.RoyaltyReceiver,
+		Expiry:          input.ExpiresAt.Unix(),
+		Status:          domain.VoucherStatusPrepared,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := interactor.VoucherGateway.Create(ctx, voucher); err != nil {
+		return nil, err
+	}
+	return interactor.output(voucher)
+}
+
+// Sign はクリエイターの署名を検証してバウチャーを出品する
+// 署名者がコレクションのコントラクトで minter でない場合は、プラットフォームのウォレットから setMinter を送信する
+func (interactor *VoucherInteractor) Sign(ctx context.Context, id uuid.UUID, input *ports.VoucherSignInput) (*ports.VoucherOutput, error) {
+	voucher, err := interactor.VoucherGateway.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, voucher.UserID); err != nil {
+		return nil, err
+	}
+	if voucher.Status != domain.VoucherStatusPrepared {
+		return nil, fmt.Errorf("BadRequest: バウチャー %s は既に %s です", voucher.ID, voucher.Status)
+	}
+	if voucher.Expired(util.JapaneseNowTime()) {
+		return nil, fmt.Errorf("BadRequest: バウチャー %s は有効期限が切れています", voucher.ID)
+	}
+
+	signer, err := util.RecoverTypedData(interactor.voucherTypedData(voucher), input.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if !util.SameAddress(signer.Hex(), voucher.Signer) {
+		return nil, fmt.Errorf("BadRequest: 署名者 %s がバウチャーのウォレット %s と一致しません", signer.Hex(), voucher.Signer)
+	}
+
+	collection, err := interactor.Collections.Gateway.Get(ctx, voucher.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	contract, _, err := interactor.Collections.bind(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	minter, err := contract.Minters(&bind.CallOpts{Context: ctx}, signer)
+	if err != nil {
+		return nil, err
+	}
+	if !minter {
+		tx, err := interactor.Collections.sendAsOwner(ctx, collection, func(contract *contracts.Collection, opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contract.SetMinter(opts, signer, true)
+		})
+		if err != nil {
+			return nil, err
+		}
+		interactor.Logging.Info(fmt.Sprintf("allowed %s to sign vouchers of collection %s (tx %s)", signer.Hex(), collection.ContractAddress, tx.Hash().Hex()))
+	}
+
+	voucher.Signature = sql.NullString{String: input.Signature, Valid: true}
+	voucher.Status = domain.VoucherStatusListed
+	voucher.UpdatedAt = util.JapaneseNowTime()
+	if err := interactor.VoucherGateway.Update(ctx, voucher); err != nil {
+		return nil, err
+	}
+	return interactor.output(voucher)
+}
+
+// Get はバウチャーを取得する（署名前の場合は署名するデータも返す）
+func (interactor *VoucherInteractor) Get(ctx context.Context, id uuid.UUID) (*ports.VoucherOutput, error) {
+	voucher, err := interactor.VoucherGateway.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return interactor.output(voucher)
+}
+
+// List は出品中で有効期限内のバウチャーを新しい順に取得する
+func (interactor *VoucherInteractor) List(ctx context.Context, genre string, minPrice domain.Amount, maxPrice domain.Amount) ([]*ports.VoucherOutput, error) {
+	vouchers, err := interactor.VoucherGateway.Search(ctx, genre, minPrice, maxPrice, util.JapaneseNowTime().Unix())
+	if err != nil {
+		return nil, err
+	}
+	return interactor.outputs(vouchers)
+}
+
+// ListByCollection はコレクションのバウチャーをすべてのステータスで取得する
+func (interactor *VoucherInteractor) ListByCollection(ctx context.Context, collectionID uuid.UUID) ([]*ports.VoucherOutput, error) {
+	vouchers, err := interactor.VoucherGateway.ListByCollection(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	return interactor.outputs(vouchers)
+}
+
+// Purchase は出品中のバウチャーを引き換える redeem の署名前のトランザクションを作成する（送金額は販売価格）
+// 先に取り込まれたトランザクションだけがミントでき、他の購入者のトランザクションはrevertする
+func (interactor *VoucherInteractor) Purchase(ctx context.Context, id uuid.UUID, input *ports.VoucherPurchaseInput) (*ports.VoucherOutput, error) {
+	if _, err := tradeUser(ctx, input.Wallet); err != nil {
+		return nil, err
+	}
+	voucher, err := interactor.VoucherGateway.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if voucher.Status != domain.VoucherStatusListed {
+		return nil, fmt.Errorf("BadRequest: バウチャー %s は販売中ではありません", voucher.ID)
+	}
+	if voucher.Expired(util.JapaneseNowTime()) {
+		return nil, fmt.Errorf("BadRequest: バウチャー %s は有効期限が切れています", voucher.ID)
+	}
+	if util.SameAddress(voucher.Signer, input.Wallet) {
+		return nil, errors.New("BadRequest: 自分が出品したバウチャーは購入できません")
+	}
+	if _, err := interactor.Collections.chainID(ctx, voucher.ChainID); err != nil {
+		return nil, err
+	}
+
+	from := common.HexToAddress(input.Wallet)
+	data, err := interactor.redeemCalldata(voucher, from)
+	if err != nil {
+		return nil, err
+	}
+	to := common.HexToAddress(voucher.ContractAddress)
+	client := interactor.Collections.Client
+	gas, err := client.EstimateGas(ctx, ethereum.CallMsg{From: from, To: &to, Value: voucher.Price.Wei(), Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("BadRequest: トランザクションが失敗します: %w", err)
+	}
+	nonce, err := client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	// 署名が購入者に渡るため、以降の取り消しはコントラクトにも記録する
+	if !voucher.Issued {
+		voucher.Issued = true
+		voucher.UpdatedAt = util.JapaneseNowTime()
+		if err := interactor.VoucherGateway.Update(ctx, voucher); err != nil {
+			return nil, err
+		}
+	}
+
+	output, err := interactor.output(voucher)
+	if err != nil {
+		return nil, err
+	}
+	output.Transaction = &ports.UnsignedTxOutput{
+		ChainID: voucher.ChainID,
+		From:    from.Hex(),
+		To:      to.Hex(),
+		Data:    hexutil.Encode(data),
+		Value:   voucher.Price,
+		Gas:     gas,
+		Nonce:   nonce,
+	}
+	return output, nil
+}
+
+// Submit は購入者が署名して送信した redeem のトランザクションを確認し、取り込まれるまで追跡する
+func (interactor *VoucherInteractor) Submit(ctx context.Context, id uuid.UUID, input *ports.TradeSubmitInput) (*ports.VoucherOutput, error) {
+	voucher, err := interactor.VoucherGateway.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if voucher.Status != domain.VoucherStatusListed {
+		return nil, fmt.Errorf("BadRequest: バウチャー %s は既に %s です", voucher.ID, voucher.Status)
+	}
+
+	hashBytes, err := hexutil.Decode(input.TxHash)
+	if err != nil || len(hashBytes) != common.HashLength {
+		return nil, errors.New("BadRequest: tx_hash が正しくありません")
+	}
+	hash := common.BytesToHash(hashBytes)
+	tx, _, err := interactor.Collections.Client.TransactionByHash(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, fmt.Errorf("BadRequest: トランザクション %s が見つかりません", hash.Hex())
+	}
+	if err != nil {
+		return nil, err
+	}
+	buyer, err := interactor.verifyRedeemTx(voucher, tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeWallet(ctx, buyer.Hex()); err != nil {
+		return nil, err
+	}
+
+	voucher.Status = domain.VoucherStatusRedeeming
+	voucher.Buyer = sql.NullString{String: buyer.Hex(), Valid: true}
+	voucher.TxHash = sql.NullString{String: hash.Hex(), Valid: true}
+	voucher.UpdatedAt = util.JapaneseNowTime()
+	if err := interactor.VoucherGateway.Update(ctx, voucher); err != nil {
+		return nil, err
+	}
+
+	// リクエストが終わっても追跡を続ける
+	go interactor.track(context.WithoutCancel(ctx), voucher)
+	return interactor.output(voucher)
+}
+
+// Cancel はバウチャーを取り消す（作成したクリエイターのみ）
+// 購入者に署名を渡している場合は、プラットフォームのウォレットから cancelVoucher を送信して引き換えられないようにする
+func (interactor *VoucherInteractor) Cancel(ctx context.Context, id uuid.UUID) (*ports.VoucherOutput, error) {
+	voucher, err := interactor.VoucherGateway.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, voucher.UserID); err != nil {
+		return nil, err
+	}
+	if voucher.Status != domain.VoucherStatusPrepared && voucher.Status != domain.VoucherStatusListed {
+		return nil, fmt.Errorf("BadRequest: バウチャー %s は既に %s です", voucher.ID, voucher.Status)
+	}
+
+	if voucher.Issued && !voucher.Expired(util.JapaneseNowTime()) {
+		collection, err := interactor.Collections.Gateway.Get(ctx, voucher.CollectionID)
+		if err != nil {
+			return nil, err
+		}
+		tx, err := interactor.Collections.sendAsOwner(ctx, collection, func(contract *contracts.Collection, opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contract.CancelVoucher(opts, voucherID(voucher.ID))
+		})
+		if err != nil {
+			return nil, err
+		}
+		interactor.Logging.Info(fmt.Sprintf("cancelled voucher %s of collection %s (tx %s)", voucher.ID, collection.ContractAddress, tx.Hash().Hex()))
+	}
+
+	voucher.Status = domain.VoucherStatusCancelled
+	voucher.UpdatedAt = util.JapaneseNowTime()
+	if err := interactor.VoucherGateway.Update(ctx, voucher); err != nil {
+		return nil, err
+	}
+	return interactor.output(voucher)
+}
+
+// Resume は前回の起動時に取り込まれるのを待っていたバウチャーの追跡を再開する
+func (interactor *VoucherInteractor) Resume(ctx context.Context) {
+	vouchers, err := interactor.VoucherGateway.ListByStatus(ctx, domain.VoucherStatusRedeeming)
+	if err != nil {
+		interactor.Logging.Error(fmt.Sprintf("failed to resume vouchers: %s", err.Error()))
+		return
+	}
+	for _, voucher := range vouchers {
+		go interactor.track(ctx, voucher)
+	}
+}
+
+// track は redeem のトランザクションが取り込まれるまでレシートを確認する
+func (interactor *VoucherInteractor) track(ctx context.Context, voucher *domain.MintVoucher) {
+	ticker := time.NewTicker(interactor.PollInterval)
+	defer ticker.Stop()
+	for {
+		done, err := interactor.checkReceipt(ctx, voucher)
+		if err != nil {
+			interactor.Logging.Warning(fmt.Sprintf("failed to check receipt of voucher %s: %s", voucher.ID, err.Error()))
+		}
+		if done {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkReceipt はレシートがあればバウチャーを redeemed にして true を返す
+// revertした場合は出品中に戻すが、コントラクトで使用済みの場合は先に引き換えた購入者を記録し、引き換えられていなければ取り消し扱いにする
+func (interactor *VoucherInteractor) checkReceipt(ctx context.Context, voucher *domain.MintVoucher) (bool, error) {
+	client := interactor.Collections.Client
+	hash := common.HexToHash(voucher.TxHash.String)
+	receipt, err := client.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	contract, err := contracts.NewCollection(common.HexToAddress(voucher.ContractAddress), client)
+	if err != nil {
+		return false, err
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		reason := revertReason(ctx, client, hash, receipt.BlockNumber)
+		interactor.Logging.Warning(fmt.Sprintf("redeem of voucher %s reverted (tx %s): %s", voucher.ID, hash.Hex(), reason))
+		used, err := contract.VoucherUsed(&bind.CallOpts{Context: ctx}, voucherID(voucher.ID))
+		if err != nil {
+			return false, err
+		}
+		var event *contracts.CollectionVoucherRedeemed
+		if used {
+			event, err = interactor.findRedeemed(ctx, contract, voucher)
+			if err != nil {
+				return false, err
+			}
+		}
+		if event == nil {
+			voucher.Status = domain.VoucherStatusListed
+			if used {
+				voucher.Status = domain.VoucherStatusCancelled
+			}
+			voucher.Buyer = sql.NullString{}
+			voucher.TxHash = sql.NullString{}
+			voucher.UpdatedAt = util.JapaneseNowTime()
+			return true, interactor.VoucherGateway.Update(ctx, voucher)
+		}
+		// 他の購入者の redeem が先に取り込まれた
+		voucher.Buyer = sql.NullString{String: event.To.Hex(), Valid: true}
+		voucher.TxHash = sql.NullString{String: event.Raw.TxHash.Hex(), Valid: true}
+		if err := interactor.redeemed(ctx, voucher, event.TokenId, event.Raw.BlockNumber); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	tokenID, err := redeemedTokenID(contract, voucher, receipt)
+	if err != nil {
+		return false, err
+	}
+	if err := interactor.redeemed(ctx, voucher, tokenID, receipt.BlockNumber.Uint64()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// redeemed はバウチャーを引き換えたトークンを記録する
+func (interactor *VoucherInteractor) redeemed(ctx context.Context, voucher *domain.MintVoucher, tokenID *big.Int, blockNumber uint64) error {
+	voucher.Status = domain.VoucherStatusRedeemed
+	voucher.TokenID = sql.NullString{String: tokenID.String(), Valid: true}
+	voucher.BlockNumber = sql.NullInt64{Int64: int64(blockNumber), Valid: true}
+	voucher.UpdatedAt = util.JapaneseNowTime()
+	if err := interactor.VoucherGateway.Update(ctx, voucher); err != nil {
+		return err
+	}
+	interactor.Logging.Info(fmt.Sprintf("redeemed voucher %s as token %s of collection %s to %s (tx %s)", voucher.ID, tokenID, voucher.ContractAddress, voucher.Buyer.String, voucher.TxHash.String))
+
+	// コントラクトはバウチャーのロイヤリティをトークンに設定するため、DBにも記録する
+	if voucher.RoyaltyBps > 0 {
+		if err := interactor.saveTokenRoyalty(ctx, voucher); err != nil {
+			interactor.Logging.Warning(fmt.Sprintf("failed to save the royalty of redeemed token %s of collection %s: %s", tokenID, voucher.ContractAddress, err.Error()))
+		}
+	}
+	return nil
+}
+
+// findRedeemed はコレクションをデプロイしたブロック以降からバウチャーの VoucherRedeemed を探す（取り消された場合は nil）
+func (interactor *VoucherInteractor) findRedeemed(ctx context.Context, contract *contracts.Collection, voucher *domain.MintVoucher) (*contracts.CollectionVoucherRedeemed, error) {
+	collection, err := interactor.Collections.Gateway.Get(ctx, voucher.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	var start uint64
+	if collection.DeployTx.Valid {
+		receipt, err := interactor.Collections.Client.TransactionReceipt(ctx, common.HexToHash(collection.DeployTx.String))
+		if err != nil {
+			return nil, err
+		}
+		start = receipt.BlockNumber.Uint64()
+	}
+	iterator, err := contract.FilterVoucherRedeemed(&bind.FilterOpts{Start: start, Context: ctx}, [][32]byte{voucherID(voucher.ID)}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+	if iterator.Next() {
+		return iterator.Event, nil
+	}
+	return nil, iterator.Error()
+}
+
+func (interactor *VoucherInteractor) saveTokenRoyalty(ctx context.Context, voucher *domain.MintVoucher) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+	now := util.JapaneseNowTime()
+	return interactor.Collections.TokenRoyaltyGateway.Save(ctx, &domain.TokenRoyalty{
+		ID:              id,
+		ChainID:         voucher.ChainID,
+		ContractAddress: voucher.ContractAddress,
+		TokenID:         voucher.TokenID.String,
+		RoyaltyBps:      voucher.RoyaltyBps,
+		Receiver:        voucher.RoyaltyReceiver,
+		UserID:          voucher.UserID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	})
+}
+
+func (interactor *VoucherInteractor) outputs(vouchers []*domain.MintVoucher) ([]*ports.VoucherOutput, error) {
+	outputs := make([]*ports.VoucherOutput, 0, len(vouchers))
+	for _, voucher := range vouchers {
+		output, err := interactor.output(voucher)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// output はバウチャーを出力用にする（署名は含めない）
+func (interactor *VoucherInteractor) output(voucher *domain.MintVoucher) (*ports.VoucherOutput, error) {
+	status := voucher.Status
+	if status == domain.VoucherStatusListed && voucher.Expired(util.JapaneseNowTime()) {
+		status = voucherStatusExpired
+	}
+	output := &ports.VoucherOutput{
+		ID:              voucher.ID,
+		CollectionID:    voucher.CollectionID,
+		ChainID:         voucher.ChainID,
+		ContractAddress: voucher.ContractAddress,
+		Signer:          voucher.Signer,
+		TokenURL:        voucher.TokenURL,
+		GenreID:         voucher.GenreID,
+		Price:           voucher.Price,
+		PriceFormatted:  interactor.ChainRegistry.format(voucher.ChainID, voucher.Price),
+		RoyaltyBps:      voucher.RoyaltyBps,
+		RoyaltyReceiver: voucher.RoyaltyReceiver,
+		ExpiresAt:       time.Unix(voucher.Expiry, 0).In(util.JapaneseNowTime().Location()),
+		Status:          status,
+		Buyer:           voucher.Buyer.String,
+		TxHash:          voucher.TxHash.String,
+		TokenID:         voucher.TokenID.String,
+		CreatedAt:       voucher.CreatedAt,
+		UpdatedAt:       voucher.UpdatedAt,
+	}
+	if voucher.Status == domain.VoucherStatusPrepared {
+		typedData, err := json.Marshal(interactor.voucherTypedData(voucher))
+		if err != nil {
+			return nil, err
+		}
+		output.TypedData = typedData
+	}
+	return output, nil
+}
+
+// voucherID はバウチャーのUUIDを署名する bytes32 にする（下位16バイトに入れる）
+func voucherID(id uuid.UUID) [32]byte {
+	var bytes32 [32]byte
+	copy(bytes32[16:], id[:])
+	return bytes32
+}
+
+// voucherURI はバウチャーで署名するトークンURI（マーケットプレイスのミントと同じ形式）
+func (interactor *VoucherInteractor) voucherURI(voucher *domain.MintVoucher) string {
+	return interactor.IpfsGateway.URL(voucher.TokenURL)
+}
+
+// voucherTypedData は Collection.sol の redeem が検証する EIP-712 のデータを作成する
+func (interactor *VoucherInteractor) voucherTypedData(voucher *domain.MintVoucher) apitypes.TypedData {
+	id := voucherID(voucher.ID)
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"MintVoucher": {
+				{Name: "id", Type: "bytes32"},
+				{Name: "uri", Type: "string"},
+				{Name: "price", Type: "uint256"},
+				{Name: "royaltyReceiver", Type: "address"},
+				{Name: "royaltyBps", Type: "uint96"},
+				{Name: "expiry", Type: "uint256"},
+			},
+		},
+		PrimaryType: "MintVoucher",
+		Domain: apitypes.TypedDataDomain{
+			Name:              voucherDomainName,
+			Version:           voucherDomainVersion,
+			ChainId:           math.NewHexOrDecimal256(int64(voucher.ChainID)),
+			VerifyingContract: voucher.ContractAddress,
+		},
+		Message: apitypes.TypedDataMessage{
+			"id":              hexutil.Encode(id[:]),
+			"uri":             interactor.voucherURI(voucher),
+			"price":           voucher.Price.Wei().String(),
+			"royaltyReceiver": common.HexToAddress(voucher.RoyaltyReceiver).Hex(),
+			"royaltyBps":      strconv.Itoa(voucher.RoyaltyBps),
+			"expiry":          strconv.FormatInt(voucher.Expiry, 10),
+		},
+	}
+}
+
+// redeemCalldata は購入者にミントする redeem の呼び出しデータを作成する
+func (interactor *VoucherInteractor) redeemCalldata(voucher *domain.MintVoucher, to common.Address) ([]byte, error) {
+	signature, err := hexutil.Decode(voucher.Signature.String)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := contracts.CollectionMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Pack("redeem", to, contracts.CollectionMintVoucher{
+		Id:              voucherID(voucher.ID),
+		Uri:             interactor.voucherURI(voucher),
+		Price:           voucher.Price.Wei(),
+		RoyaltyReceiver: common.HexToAddress(voucher.RoyaltyReceiver),
+		RoyaltyBps:      big.NewInt(int64(voucher.RoyaltyBps)),
+		Expiry:          big.NewInt(voucher.Expiry),
+	}, signature)
+}
+
+// verifyRedeemTx は送信されたトランザクションがバウチャーの redeem と一致するか確認し、購入者（送信者）を返す
+func (interactor *VoucherInteractor) verifyRedeemTx(voucher *domain.MintVoucher, tx *types.Transaction) (common.Address, error) {
+	mismatch := errors.New("BadRequest: バウチャーの内容と異なるトランザクションです")
+
+	if tx.ChainId().Cmp(big.NewInt(int64(voucher.ChainID))) != 0 {
+		return common.Address{}, mismatch
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return common.Address{}, mismatch
+	}
+	if tx.To() == nil || !util.SameAddress(tx.To().Hex(), voucher.ContractAddress) {
+		return common.Address{}, mismatch
+	}
+	data, err := interactor.redeemCalldata(voucher, sender)
+	if err != nil || !bytes.Equal(tx.Data(), data) {
+		return common.Address{}, mismatch
+	}
+	if tx.Value().Cmp(voucher.Price.Wei()) != 0 {
+		return common.Address{}, mismatch
+	}
+	return sender, nil
+}
+
+// redeemedTokenID はレシートの VoucherRedeemed イベントからミントしたトークンIDを取得する
+func redeemedTokenID(contract *contracts.Collection, voucher *domain.MintVoucher, receipt *types.Receipt) (*big.Int, error) {
+	address := common.HexToAddress(voucher.ContractAddress)
+	id := voucherID(voucher.ID)
+	for _, log := range receipt.Logs {
+		if log.Address != address {
+			continue
+		}
+		redeemed, err := contract.ParseVoucherRedeemed(*log)
+		if err != nil || redeemed.Id != id {
+			continue
+		}
+		return redeemed.TokenId, nil
+	}
+	return nil, fmt.Errorf("no VoucherRedeemed event in the receipt of %s", receipt.TxHash.Hex())
+}
@@ -0,0 +1,582 @@
+// Package interactor は、ビジネスロジックを実装します。
+package interactor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"database/sql"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"nft-music/contracts"
+	"nft-music/domain"
+	"nft-music/usecases/gateways/mock"
+	"nft-music/usecases/ports"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// signTypedData はクリエイターのウォレットと同じく eth_signTypedData_v4 で署名する
+func signTypedData(t *testing.T, typedData []byte, key *ecdsa.PrivateKey) string {
+	var data apitypes.TypedData
+	require.NoError(t, json.Unmarshal(typedData, &data))
+	hash, _, err := apitypes.TypedDataAndHash(data)
+	require.NoError(t, err)
+	sig, err := crypto.Sign(hash, key)
+	require.NoError(t, err)
+	sig[crypto.RecoveryIDOffset] += 27
+	return hexutil.Encode(sig)
+}
+
+func TestVoucherInteractor(t *testing.T) {
+	signer := newTestSigner(t)
+	contract := common.HexToAddress("0x495F947276749Ce646f68AC8c248420045075B34")
+	creatorKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	creator := crypto.PubkeyToAddress(creatorKey.PublicKey)
+	creatorID := uuid.New()
+	creatorCtx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: creatorID, Wallet: creator.Hex(), Role: domain.RoleCreator})
+	buyerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	buyer := crypto.PubkeyToAddress(buyerKey.PublicKey)
+	buyerCtx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: uuid.New(), Wallet: buyer.Hex(), Role: domain.RoleMember})
+
+	collection := &domain.Collection{
+		ID:              uuid.New(),
+		UserID:          creatorID,
+		ChainID:         1337,
+		ContractAddress: contract.Hex(),
+		DeployTx:        sql.NullString{String: common.HexToHash("0x01").Hex(), Valid: true},
+	}
+
+	type fixture struct {
+		interactor     *VoucherInteractor
+		backend        *fakeCollectionBackend
+		voucherGateway *mock.MockVoucherGateway
+		royaltyGateway *mock.MockTokenRoyaltyGateway
+	}
+	newInteractor := func(t *testing.T) *fixture {
+		ctrl := gomock.NewController(t)
+		collectionGateway := mock.NewMockCollectionGateway(ctrl)
+		collectionGateway.EXPECT().Get(gomock.Any(), collection.ID).Return(collection, nil).AnyTimes()
+		royaltyGateway := mock.NewMockTokenRoyaltyGateway(ctrl)
+		backend := newFakeCollectionBackend(signer.Address())
+		backend.contracts[contract] = true
+		backend.receipts[common.HexToHash(collection.DeployTx.String)] = &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1)}
+		collections := NewCollectionInteractor(collectionGateway, royaltyGateway, backend, signer, NewNonceManager(backend, nil), &NullLogging{})
+		chainRegistry, _ := newTestChainRegistry(t)
+		voucherGateway := mock.NewMockVoucherGateway(ctrl)
+		return &fixture{
+			interactor:     NewVoucherInteractor(voucherGateway, newTestIpfsGateway(t), collections, chainRegistry, &NullLogging{}),
+			backend:        backend,
+			voucherGateway: voucherGateway,
+			royaltyGateway: royaltyGateway,
+		}
+	}
+
+	newVoucher := func(status string) *domain.MintVoucher {
+		return &domain.MintVoucher{
+			ID:              uuid.New(),
+			CollectionID:    collection.ID,
+			ChainID:         1337,
+			ContractAddress: contract.Hex(),
+			UserID:          creatorID,
+			Signer:          creator.Hex(),
+			TokenURL:        "/ipfs/QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS",
+			Price:           domain.NewAmount(big.NewInt(15000)),
+			RoyaltyBps:      750,
+			RoyaltyReceiver: creator.Hex(),
+			Expiry:          time.Now().Add(time.Hour).Unix(),
+			Status:          status,
+		}
+	}
+	// vouchers は署名するデータと呼び出しデータを作成する（トークンURIは https://ipfs.io から公開する）
+	vouchers := &VoucherInteractor{IpfsGateway: newTestIpfsGateway(t)}
+	signedVoucher := func(t *testing.T, status string) *domain.MintVoucher {
+		voucher := newVoucher(domain.VoucherStatusPrepared)
+		typedData, err := json.Marshal(vouchers.voucherTypedData(voucher))
+		require.NoError(t, err)
+		voucher.Signature = sql.NullString{String: signTypedData(t, typedData, creatorKey), Valid: true}
+		voucher.Status = status
+		return voucher
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		input := &ports.VoucherInput{
+			Wallet:          creator.Hex(),
+			GenreID:         uuid.New(),
+			Price:           "0.015 ETH",
+			RoyaltyBps:      750,
+			RoyaltyReceiver: creator.Hex(),
+			ExpiresAt:       time.Now().Add(24 * time.Hour),
+		}
+
+		t.Run("正常系: バウチャーを作成して署名するデータを返す", func(t *testing.T) {
+			f := newInteractor(t)
+			var created *domain.MintVoucher
+			f.voucherGateway.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, voucher *domain.MintVoucher) error {
+				created = voucher
+				return nil
+			})
+
+			output, err := f.interactor.Create(creatorCtx, collection.ID, input, "QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS")
+			require.NoError(t, err)
+			assert.Equal(t, domain.VoucherStatusPrepared, output.Status)
+			assert.Equal(t, "15000000000000000", output.Price.String())
+			assert.Equal(t, "0.015 ETH", output.PriceFormatted)
+			assert.Equal(t, input.ExpiresAt.Unix(), created.Expiry)
+			assert.Equal(t, "/ipfs/QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS", created.TokenURL)
+
+			var typedData apitypes.TypedData
+			require.NoError(t, json.Unmarshal(output.TypedData, &typedData))
+			assert.Equal(t, "MintVoucher", typedData.PrimaryType)
+			assert.Equal(t, voucherDomainName, typedData.Domain.Name)
+			assert.Equal(t, contract.Hex(), typedData.Domain.VerifyingContract)
+			assert.Equal(t, "https://ipfs.io/ipfs/QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS", typedData.Message["uri"])
+		})
+
+		t.Run("異常系: 外部でデプロイしたコレクションや過去の有効期限では作成できない", func(t *testing.T) {
+			f := newInteractor(t)
+			external := *collection
+			external.ID = uuid.New()
+			external.DeployTx = sql.NullString{}
+			f.interactor.Collections.Gateway.(*mock.MockCollectionGateway).EXPECT().Get(gomock.Any(), external.ID).Return(&external, nil)
+
+			_, err := f.interactor.Create(creatorCtx, external.ID, input, "Qm")
+			assert.ErrorContains(t, err, "BadRequest")
+
+			expired := *input
+			expired.ExpiresAt = time.Now().Add(-time.Minute)
+			_, err = f.interactor.Create(creatorCtx, collection.ID, &expired, "Qm")
+			assert.ErrorContains(t, err, "BadRequest")
+		})
+
+		t.Run("異常系: 他のユーザーのウォレットでは作成できない", func(t *testing.T) {
+			f := newInteractor(t)
+			other := *input
+			other.Wallet = buyer.Hex()
+			_, err := f.interactor.Create(creatorCtx, collection.ID, &other, "Qm")
+			assert.ErrorContains(t, err, "Forbidden")
+		})
+	})
+
+	t.Run("Sign", func(t *testing.T) {
+		t.Run("正常系: 署名を検証して出品し、クリエイターを minter にする", func(t *testing.T) {
+			f := newInteractor(t)
+			voucher := newVoucher(domain.VoucherStatusPrepared)
+			typedData, err := json.Marshal(vouchers.voucherTypedData(voucher))
+			require.NoError(t, err)
+			f.voucherGateway.EXPECT().Get(gomock.Any(), voucher.ID).Return(voucher, nil)
+			f.voucherGateway.EXPECT().Update(gomock.Any(), voucher).Return(nil)
+
+			output, err := f.interactor.Sign(creatorCtx, voucher.ID, &ports.VoucherSignInput{Signature: signTypedData(t, typedData, creatorKey)})
+			require.NoError(t, err)
+			assert.Equal(t, domain.VoucherStatusListed, output.Status)
+			assert.Nil(t, output.TypedData)
+			assert.True(t, voucher.Signature.Valid)
+			assert.True(t, f.backend.minters[creator])
+			assert.Len(t, f.backend.txs, 1)
+		})
+
+		t.Run("正常系: 既に minter の場合は送信しない", func(t *testing.T) {
+			f := newInteractor(t)
+			f.backend.minters[creator] = true
+			voucher := newVoucher(domain.VoucherStatusPrepared)
+			typedData, err := json.Marshal(vouchers.voucherTypedData(voucher))
+			require.NoError(t, err)
+			f.voucherGateway.EXPECT().Get(gomock.Any(), voucher.ID).Return(voucher, nil)
+			f.voucherGateway.EXPECT().Update(gomock.Any(), voucher).Return(nil)
+
+			_, err = f.interactor.Sign(creatorCtx, voucher.ID, &ports.VoucherSignInput{Signature: signTypedData(t, typedData, creatorKey)})
+			require.NoError(t, err)
+			assert.Empty(t, f.backend.txs)
+		})
+
+		t.Run("異常系: 別のウォレットの署名は登録できない", func(t *testing.T) {
+			f := newInteractor(t)
+			voucher := newVoucher(domain.VoucherStatusPrepared)
+			typedData, err := json.Marshal(vouchers.voucherTypedData(voucher))
+			require.NoError(t, err)
+			otherKey, err := crypto.GenerateKey()
+			require.NoError(t, err)
+			f.voucherGateway.EXPECT().Get(gomock.Any(), voucher.ID).Return(voucher, nil)
+
+			_, err = f.interactor.Sign(creatorCtx, voucher.ID, &ports.VoucherSignInput{Signature: signTypedData(t, typedData, otherKey)})
+			assert.ErrorContains(t, err, "BadRequest")
+			assert.Equal(t, domain.VoucherStatusPrepared, voucher.Status)
+		})
+
+		t.Run("異常系: 署名したデータと内容が異なる場合", func(t *testing.T) {
+			f := newInteractor(t)
+			voucher := newVoucher(domain.VoucherStatusPrepared)
+			typedData, err := json.Marshal(vouchers.voucherTypedData(voucher))
+			require.NoError(t, err)
+			voucher.Price = domain.NewAmount(big.NewInt(1))
+			f.voucherGateway.EXPECT().Get(gomock.Any(), voucher.ID).Return(voucher, nil)
+
+			_, err = f.interactor.Sign(creatorCtx, voucher.ID, &ports.VoucherSignInput{Signature: signTypedData(t, typedData, creatorKey)})
+			assert.ErrorContains(t, err, "BadRequest")
+		})
+	})
+
+	t.Run("Purchase", func(t *testing.T) {
+		t.Run("正常系: redeem の署名前のトランザクションを返す", func(t *testing.T) {
+			f := newInteractor(t)
+			voucher := signedVoucher(t, domain.VoucherStatusListed)
+			f.voucherGateway.EXPECT().Get(gomock.Any(), voucher.ID).Return(voucher, nil)
+			f.voucherGateway.EXPECT().Update(gomock.Any(), voucher).Return(nil)
+
+			output, err := f.interactor.Purchase(buyerCtx, voucher.ID, &ports.VoucherPurchaseInput{Wallet: buyer.Hex()})
+			require.NoError(t, err)
+			require.NotNil(t, output.Transaction)
+			assert.Equal(t, contract.Hex(), output.Transaction.To)
+			assert.Equal(t, buyer.Hex(), output.Transaction.From)
+			assert.Equal(t, "15000", output.Transaction.Value.String())
+			assert.True(t, voucher.Issued)
+
+			expected, err := vouchers.redeemCalldata(voucher, buyer)
+			require.NoError(t, err)
+			assert.Equal(t, expected, common.FromHex(output.Transaction.Data))
+		})
+
+		t.Run("異常系: 有効期限切れ・出品前・自分のバウチャーは購入できない", func(t *testing.T) {
+			f := newInteractor(t)
+			expired := signedVoucher(t, domain.VoucherStatusListed)
+			expired.Expiry = time.Now().Add(-time.Minute).Unix()
+			prepared := newVoucher(domain.VoucherStatusPrepared)
+			own := signedVoucher(t, domain.VoucherStatusListed)
+			for _, voucher := range []*domain.MintVoucher{expired, prepared, own} {
+				f.voucherGateway.EXPECT().Get(gomock.Any(), voucher.ID).Return(voucher, nil)
+			}
+
+			_, err := f.interactor.Purchase(buyerCtx, expired.ID, &ports.VoucherPurchaseInput{Wallet: buyer.Hex()})
+			assert.ErrorContains(t, err, "有効期限")
+			_, err = f.interactor.Purchase(buyerCtx, prepared.ID, &ports.VoucherPurchaseInput{Wallet: buyer.Hex()})
+			assert.ErrorContains(t, err, "BadRequest")
+			_, err = f.interactor.Purchase(creatorCtx, own.ID, &ports.VoucherPurchaseInput{Wallet: creator.Hex()})
+			assert.ErrorContains(t, err, "BadRequest")
+		})
+	})
+
+	t.Run("Submit", func(t *testing.T) {
+		redeemTx := func(t *testing.T, voucher *domain.MintVoucher, value *big.Int) *types.Transaction {
+			data, err := vouchers.redeemCalldata(voucher, buyer)
+			require.NoError(t, err)
+			tx, err := types.SignNewTx(buyerKey, types.LatestSignerForChainID(big.NewInt(1337)), &types.DynamicFeeTx{
+				ChainID: big.NewInt(1337), To: &contract, Value: value, Data: data, Gas: 120000,
+			})
+			require.NoError(t, err)
+			return tx
+		}
+
+		t.Run("正常系: 購入者が送信した redeem を登録する", func(t *testing.T) {
+			f := newInteractor(t)
+			voucher := signedVoucher(t, domain.VoucherStatusListed)
+			tx := redeemTx(t, voucher, big.NewInt(15000))
+			f.backend.txs[tx.Hash()] = tx
+			f.voucherGateway.EXPECT().Get(gomock.Any(), voucher.ID).Return(voucher, nil)
+			f.voucherGateway.EXPECT().Update(gomock.Any(), voucher).Return(nil)
+
+			output, err := f.interactor.Submit(buyerCtx, voucher.ID, &ports.TradeSubmitInput{TxHash: tx.Hash().Hex()})
+			require.NoError(t, err)
+			assert.Equal(t, domain.VoucherStatusRedeeming, output.Status)
+			assert.Equal(t, buyer.Hex(), output.Buyer)
+			assert.Equal(t, tx.Hash().Hex(), output.TxHash)
+		})
+
+		t.Run("異常系: 送金額が販売価格と異なるトランザクションは登録できない", func(t *testing.T) {
+			f := newInteractor(t)
+			voucher := signedVoucher(t, domain.VoucherStatusListed)
+			tx := redeemTx(t, voucher, big.NewInt(1))
+			f.backend.txs[tx.Hash()] = tx
+			f.voucherGateway.EXPECT().Get(gomock.Any(), voucher.ID).Return(voucher, nil)
+
+			_, err := f.interactor.Submit(buyerCtx, voucher.ID, &ports.TradeSubmitInput{TxHash: tx.Hash().Hex()})
+			assert.ErrorContains(t, err, "BadRequest")
+		})
+
+		t.Run("正常系: 取り込まれたらトークンIDを記録してロイヤリティを保存する", func(t *testing.T) {
+			f := newInteractor(t)
+			f.backend.nextTokenID = 5
+			voucher := signedVoucher(t, domain.VoucherStatusListed)
+			tx := redeemTx(t, voucher, big.NewInt(15000))
+			require.NoError(t, f.backend.SendTransaction(context.Background(), tx))
+			voucher.Status = domain.VoucherStatusRedeeming
+			voucher.Buyer = sql.NullString{String: buyer.Hex(), Valid: true}
+			voucher.TxHash = sql.NullString{String: tx.Hash().Hex(), Valid: true}
+
+			f.voucherGateway.EXPECT().Update(gomock.Any(), voucher).Return(nil)
+			var saved *domain.TokenRoyalty
+			f.royaltyGateway.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, royalty *domain.TokenRoyalty) error {
+				saved = royalty
+				return nil
+			})
+
+			done, err := f.interactor.checkReceipt(context.Background(), voucher)
+			require.NoError(t, err)
+			assert.True(t, done)
+			assert.Equal(t, domain.VoucherStatusRedeemed, voucher.Status)
+			assert.Equal(t, "5", voucher.TokenID.String)
+			assert.Equal(t, buyer, f.backend.owners[5])
+			require.NotNil(t, saved)
+			assert.Equal(t, "5", saved.TokenID)
+			assert.Equal(t, 750, saved.RoyaltyBps)
+		})
+
+		t.Run("正常系: revertした場合は出品中に戻し、取り消されていた場合は取り消し扱いにする", func(t *testing.T) {
+			f := newInteractor(t)
+			f.voucherGateway.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			reverted := newVoucher(domain.VoucherStatusRedeeming)
+			reverted.TxHash = sql.NullString{String: common.HexToHash("0x02").Hex(), Valid: true}
+			cancelled := newVoucher(domain.VoucherStatusRedeeming)
+			cancelled.TxHash = sql.NullString{String: common.HexToHash("0x03").Hex(), Valid: true}
+			f.backend.vouchersUsed[voucherID(cancelled.ID)] = true
+			f.backend.receipts[common.HexToHash("0x02")] = &types.Receipt{Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(2)}
+			f.backend.receipts[common.HexToHash("0x03")] = &types.Receipt{Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(3)}
+
+			done, err := f.interactor.checkReceipt(context.Background(), reverted)
+			require.NoError(t, err)
+			assert.True(t, done)
+			assert.Equal(t, domain.VoucherStatusListed, reverted.Status)
+			assert.False(t, reverted.TxHash.Valid)
+
+			done, err = f.interactor.checkReceipt(context.Background(), cancelled)
+			require.NoError(t, err)
+			assert.True(t, done)
+			assert.Equal(t, domain.VoucherStatusCancelled, cancelled.Status)
+			assert.False(t, cancelled.Buyer.Valid)
+		})
+
+		t.Run("正常系: 他の購入者が先に引き換えていた場合はその購入者とトークンを記録する", func(t *testing.T) {
+			f := newInteractor(t)
+			f.backend.nextTokenID = 7
+			voucher := signedVoucher(t, domain.VoucherStatusListed)
+			otherKey, err := crypto.GenerateKey()
+			require.NoError(t, err)
+			other := crypto.PubkeyToAddress(otherKey.PublicKey)
+			data, err := vouchers.redeemCalldata(voucher, other)
+			require.NoError(t, err)
+			first, err := types.SignNewTx(otherKey, types.LatestSignerForChainID(big.NewInt(1337)), &types.DynamicFeeTx{
+				ChainID: big.NewInt(1337), To: &contract, Value: big.NewInt(15000), Data: data, Gas: 120000,
+			})
+			require.NoError(t, err)
+			require.NoError(t, f.backend.SendTransaction(context.Background(), first))
+			late := redeemTx(t, voucher, big.NewInt(15000))
+			require.NoError(t, f.backend.SendTransaction(context.Background(), late))
+			voucher.Status = domain.VoucherStatusRedeeming
+			voucher.Buyer = sql.NullString{String: buyer.Hex(), Valid: true}
+			voucher.TxHash = sql.NullString{String: late.Hash().Hex(), Valid: true}
+
+			f.voucherGateway.EXPECT().Update(gomock.Any(), voucher).Return(nil)
+			f.royaltyGateway.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+			done, err := f.interactor.checkReceipt(context.Background(), voucher)
+			require.NoError(t, err)
+			assert.True(t, done)
+			assert.Equal(t, domain.VoucherStatusRedeemed, voucher.Status)
+			assert.Equal(t, other.Hex(), voucher.Buyer.String)
+			assert.Equal(t, first.Hash().Hex(), voucher.TxHash.String)
+			assert.Equal(t, "7", voucher.TokenID.String)
+		})
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		t.Run("正常系: 購入者に渡した署名はコントラクトでも取り消す", func(t *testing.T) {
+			f := newInteractor(t)
+			voucher := signedVoucher(t, domain.VoucherStatusListed)
+			voucher.Issued = true
+			f.voucherGateway.EXPECT().Get(gomock.Any(), voucher.ID).Return(voucher, nil)
+			f.voucherGateway.EXPECT().Update(gomock.Any(), voucher).Return(nil)
+
+			output, err := f.interactor.Cancel(creatorCtx, voucher.ID)
+			require.NoError(t, err)
+			assert.Equal(t, domain.VoucherStatusCancelled, output.Status)
+			assert.True(t, f.backend.vouchersUsed[voucherID(voucher.ID)])
+		})
+
+		t.Run("正常系: 署名を渡していない場合は送信しない", func(t *testing.T) {
+			f := newInteractor(t)
+			voucher := signedVoucher(t, domain.VoucherStatusListed)
+			f.voucherGateway.EXPECT().Get(gomock.Any(), voucher.ID).Return(voucher, nil)
+			f.voucherGateway.EXPECT().Update(gomock.Any(), voucher).Return(nil)
+
+			_, err := f.interactor.Cancel(creatorCtx, voucher.ID)
+			require.NoError(t, err)
+			assert.Empty(t, f.backend.txs)
+		})
+
+		t.Run("異常系: 他のユーザーや引き換え中のバウチャーは取り消せない", func(t *testing.T) {
+			f := newInteractor(t)
+			listed := signedVoucher(t, domain.VoucherStatusListed)
+			redeeming := signedVoucher(t, domain.VoucherStatusRedeeming)
+			f.voucherGateway.EXPECT().Get(gomock.Any(), listed.ID).Return(listed, nil)
+			f.voucherGateway.EXPECT().Get(gomock.Any(), redeeming.ID).Return(redeeming, nil)
+
+			_, err := f.interactor.Cancel(buyerCtx, listed.ID)
+			assert.ErrorContains(t, err, "Forbidden")
+			_, err = f.interactor.Cancel(creatorCtx, redeeming.ID)
+			assert.ErrorContains(t, err, "BadRequest")
+		})
+	})
+
+	t.Run("正常系: 出品中のまま有効期限が過ぎたバウチャーは expired として返す", func(t *testing.T) {
+		f := newInteractor(t)
+		voucher := signedVoucher(t, domain.VoucherStatusListed)
+		voucher.Expiry = time.Now().Add(-time.Minute).Unix()
+		f.voucherGateway.EXPECT().Get(gomock.Any(), voucher.ID).Return(voucher, nil)
+
+		output, err := f.interactor.Get(context.Background(), voucher.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "expired", output.Status)
+	})
+}
+
+// TestVoucherInteractor_Redeem はシミュレートしたチェーンで デプロイ → 署名 → 引き換え を通して確認する
+func TestVoucherInteractor_Redeem(t *testing.T) {
+	requireCollectionBytecode(t)
+	signer := newTestSigner(t)
+	creatorKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	creator := crypto.PubkeyToAddress(creatorKey.PublicKey)
+	creatorID := uuid.New()
+	creatorCtx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: creatorID, Wallet: creator.Hex(), Role: domain.RoleCreator})
+	buyerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	buyer := crypto.PubkeyToAddress(buyerKey.PublicKey)
+	buyerCtx := ports.WithAuthUser(context.Background(), &ports.AuthUser{UserID: uuid.New(), Wallet: buyer.Hex(), Role: domain.RoleMember})
+
+	funds := new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))
+	sim := simulated.NewBackend(types.GenesisAlloc{
+		signer.Address(): {Balance: funds},
+		buyer:            {Balance: funds},
+	})
+	client := sim.Client()
+	// ジェネシスブロックはマージ前として扱われ PUSH0 などを実行できないため、先に1ブロック進める
+	sim.Commit()
+	// bind.WaitMined と追跡が取り込みを待てるようにブロックを作り続ける
+	ctx, cancel := context.WithCancel(context.Background())
+	mining := make(chan struct{})
+	go func() {
+		defer close(mining)
+		ticker := time.NewTicker(20 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sim.Commit()
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-mining
+		sim.Close()
+	})
+
+	ctrl := gomock.NewController(t)
+	var collection *domain.Collection
+	collectionGateway := mock.NewMockCollectionGateway(ctrl)
+	collectionGateway.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, created *domain.Collection) error {
+		collection = created
+		return nil
+	})
+	collectionGateway.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id uuid.UUID) (*domain.Collection, error) {
+		return collection, nil
+	}).AnyTimes()
+	royaltyGateway := mock.NewMockTokenRoyaltyGateway(ctrl)
+	royaltyGateway.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	var voucher *domain.MintVoucher
+	redeemed := make(chan struct{})
+	voucherGateway := mock.NewMockVoucherGateway(ctrl)
+	voucherGateway.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, created *domain.MintVoucher) error {
+		voucher = created
+		return nil
+	})
+	voucherGateway.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id uuid.UUID) (*domain.MintVoucher, error) {
+		return voucher, nil
+	}).AnyTimes()
+	voucherGateway.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, updated *domain.MintVoucher) error {
+		if updated.Status == domain.VoucherStatusRedeemed {
+			close(redeemed)
+		}
+		return nil
+	}).AnyTimes()
+
+	collections := NewCollectionInteractor(collectionGateway, royaltyGateway, client, signer, NewNonceManager(client, nil), &NullLogging{})
+	chainRegistry, _ := newTestChainRegistry(t)
+	interactor := NewVoucherInteractor(voucherGateway, newTestIpfsGateway(t), collections, chainRegistry, &NullLogging{})
+	interactor.PollInterval = 20 * time.Millisecond
+
+	// プラットフォームのウォレットでコレクションをデプロイする
+	created, err := collections.Create(creatorCtx, &ports.CollectionInput{UserID: creatorID, Name: "Simulated", Symbol: "SIM"})
+	require.NoError(t, err)
+
+	// クリエイターがバウチャーに署名して出品する（署名者はプラットフォームのウォレットから minter にする）
+	output, err := interactor.Create(creatorCtx, created.ID, &ports.VoucherInput{
+		Wallet:          creator.Hex(),
+		GenreID:         uuid.New(),
+		Price:           "0.015 ETH",
+		RoyaltyBps:      750,
+		RoyaltyReceiver: creator.Hex(),
+		ExpiresAt:       time.Now().Add(time.Hour),
+	}, "QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS")
+	require.NoError(t, err)
+	_, err = interactor.Sign(creatorCtx, output.ID, &ports.VoucherSignInput{Signature: signTypedData(t, output.TypedData, creatorKey)})
+	require.NoError(t, err)
+
+	// 購入者が redeem のトランザクションに署名して送信する
+	output, err = interactor.Purchase(buyerCtx, output.ID, &ports.VoucherPurchaseInput{Wallet: buyer.Hex()})
+	require.NoError(t, err)
+	unsigned := output.Transaction
+	gasPrice, err := client.SuggestGasPrice(context.Background())
+	require.NoError(t, err)
+	to := common.HexToAddress(unsigned.To)
+	tx, err := types.SignNewTx(buyerKey, types.LatestSignerForChainID(big.NewInt(int64(unsigned.ChainID))), &types.LegacyTx{
+		Nonce: unsigned.Nonce, To: &to, Value: unsigned.Value.Wei(), Gas: unsigned.Gas, GasPrice: gasPrice, Data: common.FromHex(unsigned.Data),
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.SendTransaction(context.Background(), tx))
+
+	output, err = interactor.Submit(buyerCtx, output.ID, &ports.TradeSubmitInput{TxHash: tx.Hash().Hex()})
+	require.NoError(t, err)
+	assert.Equal(t, domain.VoucherStatusRedeeming, output.Status)
+
+	select {
+	case <-redeemed:
+	case <-time.After(10 * time.Second):
+		t.Fatal("redeem のトランザクションが取り込まれませんでした")
+	}
+	assert.Equal(t, "0", voucher.TokenID.String)
+
+	contract, err := contracts.NewCollection(common.HexToAddress(created.ContractAddress), client)
+	require.NoError(t, err)
+	opts := &bind.CallOpts{Context: context.Background()}
+	owner, err := contract.OwnerOf(opts, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, buyer, owner)
+	uri, err := contract.TokenURI(opts, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, "https://ipfs.io/ipfs/QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS", uri)
+	used, err := contract.VoucherUsed(opts, voucherID(voucher.ID))
+	require.NoError(t, err)
+	assert.True(t, used)
+	// 販売価格は署名したクリエイターに支払われる
+	balance, err := client.BalanceAt(context.Background(), creator, nil)
+	require.NoError(t, err)
+	assert.Equal(t, voucher.Price.Wei(), balance)
+}
@@ -70,6 +70,10 @@ func (interactor *WalletInteractor) List(ctx context.Context) ([]ports.WalletOut
 
 // Create はWalletに登録する操作
 func (interactor *WalletInteractor) Create(ctx context.Context, input *ports.WalletInput) (*ports.WalletOutput, error) {
+	if err := authorizeWallet(ctx, input.Address); err != nil {
+		return nil, err
+	}
+
 	uuidV7, err := uuid.NewV7()
 	if err != nil {
 		return nil, err
@@ -79,7 +79,7 @@ func TestWalletInteractor_Create(t *testing.T) {
 			Create(gomock.Any(), gomock.Any()).
 			Return(nil)
 
-		output, err := interactor.Create(context.Background(), &ports.WalletInput{Address: address})
+		output, err := interactor.Create(ports.WithAuthUser(context.Background(), &ports.AuthUser{Wallet: address}), &ports.WalletInput{Address: address})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, output)
@@ -96,7 +96,7 @@ func TestWalletInteractor_Create(t *testing.T) {
 			Create(gomock.Any(), gomock.Any()).
 			Return(errors.New("create error"))
 
-		output, err := interactor.Create(context.Background(), &ports.WalletInput{Address: address})
+		output, err := interactor.Create(ports.WithAuthUser(context.Background(), &ports.AuthUser{Wallet: address}), &ports.WalletInput{Address: address})
 
 		assert.Error(t, err)
 		assert.Nil(t, output)
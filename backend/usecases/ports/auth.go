@@ -0,0 +1,50 @@
+// Package ports は、ユースケースで使用される入力・出力モデルを定義します。
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuthNonceOutput はSIWEの署名対象メッセージを返す構造体
+type AuthNonceOutput struct {
+	Nonce     string    `json:"nonce" example:"4f2a9c1d8e7b6a5f"`
+	Message   string    `json:"message" example:"music.threenext.com wants you to sign in with your Ethereum account:..."`
+	ExpiresAt time.Time `json:"expires_at" example:"2024-11-04T20:51:26Z"`
+}
+
+// AuthVerifyInput は署名済みのSIWEメッセージを受け取る構造体
+type AuthVerifyInput struct {
+	Message   string `json:"message" validate:"required" example:"music.threenext.com wants you to sign in with your Ethereum account:..."`
+	Signature string `json:"signature" validate:"required" example:"0x5d1c...1b"`
+}
+
+// AuthSessionOutput は発行したセッショントークンを返す構造体
+type AuthSessionOutput struct {
+	Token     string    `json:"token" example:"3b5d5c3712955042212316173ccf37be800..."`
+	UserID    uuid.UUID `json:"user_id" example:"01932563-f671-71ff-9a0d-c452de9d06aa"`
+	Wallet    string    `json:"wallet" example:"0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"`
+	ExpiresAt time.Time `json:"expires_at" example:"2024-11-04T20:51:26Z"`
+}
+
+// AuthUser は認証済みのリクエストを行ったユーザーを表す
+type AuthUser struct {
+	UserID uuid.UUID `json:"user_id" example:"01932563-f671-71ff-9a0d-c452de9d06aa"`
+	Wallet string    `json:"wallet" example:"0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"`
+	Role   string    `json:"role" example:"creator"`
+}
+
+type authUserKey struct{}
+
+// WithAuthUser は認証済みユーザーをコンテキストに格納する
+func WithAuthUser(ctx context.Context, user *AuthUser) context.Context {
+	return context.WithValue(ctx, authUserKey{}, user)
+}
+
+// AuthUserFrom はコンテキストから認証済みユーザーを取り出す
+func AuthUserFrom(ctx context.Context) (*AuthUser, bool) {
+	user, ok := ctx.Value(authUserKey{}).(*AuthUser)
+	return user, ok && user != nil
+}
@@ -0,0 +1,15 @@
+// Package ports は、ユースケースで使用される入力・出力モデルを定義します。
+package ports
+
+// ChainOutput は対応しているチェーン
+type ChainOutput struct {
+	ChainID            int    `json:"chain_id" example:"11155111"`
+	Name               string `json:"name" example:"Sepolia"`
+	Symbol             string `json:"symbol" example:"ETH"`
+	Decimals           int    `json:"decimals" example:"18"`
+	RPCURL             string `json:"rpc_url" example:"https://rpc.sepolia.org"`
+	ExplorerURL        string `json:"explorer_url" example:"https://sepolia.etherscan.io"`
+	MarketplaceAddress string `json:"marketplace_address" example:"0x5FbDB2315678afecb367f032d93F642f64180aa3"`
+	Confirmations      uint64 `json:"confirmations" example:"3"`
+	Active             bool   `json:"active"` // バックエンドが接続中のチェーン
+}
@@ -9,15 +9,17 @@ import (
 
 type CollectionInput struct {
 	UserID          uuid.UUID `json:"user_id" example:"0193254c-a151-7c4c-b06a-259da7258a27"`
-	ChainID         int       `json:"chain_id" example:"1"`
+	ChainID         int       `json:"chain_id" example:"1337"` // 0 の場合は接続中のチェーン
 	Name            string    `json:"name" example:"建築"`
-	ContractAddress string    `json:"contract_address" example:"0x495f947276749ce646f68ac8c248420045075b34"`
+	Symbol          string    `json:"symbol" example:"ARCH"`                                                 // デプロイする場合のシンボル
+	ContractAddress string    `json:"contract_address" example:"0x495f947276749ce646f68ac8c248420045075b34"` // 空の場合はコレクションのコントラクトをデプロイする
 	Description     string    `json:"description" example:"私はいつでも明るいです"`
 	ImageURL        string    `json:"image_url" example:"https://www.yahoo.com/img/test.jpg"`
 	BannerImageURL  string    `json:"banner_image_url" example:"https://www.yahoo.com/img/test.jpg"`
 	ExternalURL     string    `json:"external_url" example:"https://www.yahoo.com"`
-	Royalty         int       `json:"royalty" example:"10"`
-	RoyaltyReceiver string    `json:"royalty_receiver" example:"0x495f947276749ce646f68ac8c248420045075b34"`
+	RoyaltyBps      int       `json:"royalty_bps" validate:"min=0,max=10000" example:"750"`                  // 既定のロイヤリティ率（bps）
+	Royalty         int       `json:"royalty" validate:"min=0,max=100" example:"0"`                          // 非推奨: ロイヤリティ率（%）。royalty_bps が0の場合のみ royalty*100 bps として使う
+	RoyaltyReceiver string    `json:"royalty_receiver" example:"0x495F947276749Ce646f68AC8c248420045075B34"` // チェックサム付きのアドレス（royalty_bps が0より大きい場合は必須）
 }
 
 type CollectionOutput struct {
@@ -26,12 +28,30 @@ type CollectionOutput struct {
 	ChainID         int       `json:"chain_id" example:"1"`
 	Name            string    `json:"name" example:"建築"`
 	ContractAddress string    `json:"contract_address" example:"0x495f947276749ce646f68ac8c248420045075b34"`
+	DeployTx        string    `json:"deploy_tx,omitempty" example:"0x3f1c0e2b7a9d4c5e6f8a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6"` // 外部でデプロイした場合は空
 	Description     string    `json:"description" example:"私はいつでも明るいです"`
 	ImageURL        string    `json:"image_url" example:"https://www.yahoo.com/img/test.jpg"`
 	BannerImageURL  string    `json:"banner_image_url" example:"https://www.yahoo.com/img/test.jpg"`
 	ExternalURL     string    `json:"external_url" example:"https://www.yahoo.com"`
-	Royalty         int       `json:"royalty" example:"10"`
-	RoyaltyReceiver string    `json:"royalty_receiver" example:"0x495f947276749ce646f68ac8c248420045075b34"`
+	RoyaltyBps      int       `json:"royalty_bps" example:"750"`
+	Royalty         int       `json:"royalty" example:"7"` // 非推奨: royalty_bps を%に切り捨てた値
+	RoyaltyReceiver string    `json:"royalty_receiver" example:"0x495F947276749Ce646f68AC8c248420045075B34"`
 	CreatedAt       time.Time `json:"created_at" example:"2024-11-04T20:51:26Z"`
 	UpdatedAt       time.Time `json:"updated_at" example:"2024-11-04T20:51:26Z"`
 }
+
+// CollectionMintInput はコレクションのコントラクトでミントする入力
+type CollectionMintInput struct {
+	To string `json:"to" example:"0xc5309Ef694C81C4a8e946F2810e09516436daeB5"` // 空の場合はリクエストしたユーザーのウォレット
+}
+
+// CollectionTokenOutput はコレクションのコントラクトでミント・バーンしたトークン
+type CollectionTokenOutput struct {
+	CollectionID    uuid.UUID `json:"collection_id" example:"0193254c-a151-7c4c-b06a-259da7258a27"`
+	ChainID         int       `json:"chain_id" example:"1337"`
+	ContractAddress string    `json:"contract_address" example:"0x495f947276749ce646f68ac8c248420045075b34"`
+	TokenID         string    `json:"token_id" example:"0"`
+	Owner           string    `json:"owner,omitempty" example:"0xc5309Ef694C81C4a8e946F2810e09516436daeB5"` // バーンした場合は空
+	TxHash          string    `json:"tx_hash" example:"0x3f1c0e2b7a9d4c5e6f8a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6"`
+	BlockNumber     uint64    `json:"block_number" example:"120"`
+}
@@ -0,0 +1,54 @@
+// Package ports は、ユースケースで使用される入力・出力モデルを定義します。
+package ports
+
+import (
+	"time"
+
+	"nft-music/domain"
+
+	"github.com/google/uuid"
+)
+
+// ContractStateOutput は接続中のマーケットプレイスのコントラクトの設定を返す構造体
+type ContractStateOutput struct {
+	ChainID               int           `json:"chain_id" example:"1337"`
+	Address               string        `json:"address" example:"0x5FbDB2315678afecb367f032d93F642f64180aa3"`
+	Owner                 string        `json:"owner" example:"0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"` // 放棄した場合はゼロアドレス
+	Paused                bool          `json:"paused" example:"false"`
+	RoyaltyFeeBps         int64         `json:"royalty_fee_bps" example:"1000"`
+	ListingPrice          domain.Amount `json:"listing_price" swaggertype:"string" example:"1000000000000000"` // ミント料（wei）
+	ListingPriceFormatted string        `json:"listing_price_formatted" example:"0.001 ETH"`
+}
+
+// RoyaltyFeeInput はロイヤリティを変更する構造体
+type RoyaltyFeeInput struct {
+	RoyaltyFeeBps int64 `json:"royalty_fee_bps" validate:"min=0,max=10000" example:"1000"`
+}
+
+// ListingPriceInput はミント料を変更する構造体
+type ListingPriceInput struct {
+	ListingPrice string `json:"listing_price" validate:"required" example:"0.001 ETH"` // 単位が無い場合はネイティブ通貨
+}
+
+// RenounceOwnershipInput はコントラクトの所有権を放棄する構造体
+// 放棄すると以降のオーナー操作ができなくなるため、確認として接続中のコントラクトアドレスを指定する
+type RenounceOwnershipInput struct {
+	Confirm string `json:"confirm" validate:"required" example:"0x5FbDB2315678afecb367f032d93F642f64180aa3"`
+}
+
+// ContractAuditOutput はコントラクトのオーナー操作の監査ログを返す構造体
+type ContractAuditOutput struct {
+	ID              uuid.UUID `json:"id" example:"0193254c-a151-7c4c-b06a-259da7258a27"`
+	ChainID         int       `json:"chain_id" example:"1337"`
+	ContractAddress string    `json:"contract_address" example:"0x5FbDB2315678afecb367f032d93F642f64180aa3"`
+	Operation       string    `json:"operation" example:"update_listing_price"` // pause / unpause / update_royalty_fee / update_listing_price / renounce_ownership
+	OldValue        string    `json:"old_value" example:"1000000000000000"`
+	NewValue        string    `json:"new_value" example:"2000000000000000"`
+	TxHash          string    `json:"tx_hash,omitempty" example:"0x9d605694113bde48dd07bf4d6f408906d3d5a9ee6656df600209ad6a38b2669e"`
+	BlockNumber     uint64    `json:"block_number,omitempty" example:"12"`
+	Status          string    `json:"status" example:"mined"` // pending / mined / failed（pending は取り込みを待っている操作）
+	Error           string    `json:"error,omitempty"`
+	UserID          uuid.UUID `json:"user_id" example:"0193254c-a151-7c4c-b06a-259da7258a27"`
+	Wallet          string    `json:"wallet" example:"0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"`
+	CreatedAt       time.Time `json:"created_at" example:"2024-11-04T20:51:26Z"`
+}
@@ -1,9 +1,29 @@
 // Package ports は、ユースケースで使用される入力・出力モデルを定義します。
 package ports
 
-type EVMInput struct{}
+import (
+	"time"
 
-type SignerOutput struct {
-	AddressHex      string `json:"address_hex" example:"0x47CD2D0873833Ba015e0C31AB94D30313eF07942"`
-	TransactionHash string `json:"transaction_hash" example:"0x9d605694113bde48dd07bf4d6f408906d3d5a9ee6656df600209ad6a38b2669e"`
+	"github.com/google/uuid"
+)
+
+// DeploymentInput はマーケットプレイスのコントラクトをデプロイする構造体
+type DeploymentInput struct {
+	Name          string `json:"name" validate:"required" example:"NFT Music"`
+	Symbol        string `json:"symbol" validate:"required" example:"NFTM"`
+	ListingPrice  string `json:"listing_price" validate:"required" example:"0.001 ETH"` // 単位が無い場合はネイティブ通貨
+	RoyaltyFeeBps int64  `json:"royalty_fee_bps" validate:"min=0,max=10000" example:"1000"`
+}
+
+// DeploymentOutput はデプロイ済みコントラクトを返す構造体
+type DeploymentOutput struct {
+	ID           uuid.UUID `json:"id" example:"0193254c-a151-7c4c-b06a-259da7258a27"`
+	ChainID      int       `json:"chain_id" example:"1337"`
+	ContractName string    `json:"contract_name" example:"NFTMarketplace"`
+	Address      string    `json:"address" example:"0x47CD2D0873833Ba015e0C31AB94D30313eF07942"`
+	DeployTx     string    `json:"deploy_tx" example:"0x9d605694113bde48dd07bf4d6f408906d3d5a9ee6656df600209ad6a38b2669e"`
+	BlockNumber  uint64    `json:"block_number" example:"12"`
+	AbiVersion   string    `json:"abi_version" example:"0x5e0c31ab94d30313ef07942d605694113bde48dd07bf4d6f408906d3d5a9ee66"`
+	Active       bool      `json:"active" example:"true"`
+	CreatedAt    time.Time `json:"created_at" example:"2024-11-04T20:51:26Z"`
 }
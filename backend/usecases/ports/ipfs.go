@@ -1,7 +1,13 @@
 // Package ports は、ユースケースで使用される入力・出力モデルを定義します。
 package ports
 
-import "github.com/google/uuid"
+import (
+	"io"
+
+	"nft-music/domain"
+
+	"github.com/google/uuid"
+)
 
 // IpfsInput はコントローラーから取得する構造体
 type IpfsInput struct {
@@ -9,10 +15,27 @@ type IpfsInput struct {
 	File   string `form:"file"`
 }
 
+// IpfsUpload はストリーミングでアップロードするファイル（リクエストから読み取りながら保存先に送る）
+type IpfsUpload struct {
+	UploadID string    // 進捗の確認と取り消しに使うID（クライアントが X-Upload-ID で指定する。省略可）
+	Filename string    // 拡張子からファイルの種類を判定する
+	Size     int64     // リクエストの Content-Length（分からない場合は -1）
+	Content  io.Reader // ファイルの内容
+}
+
+// IpfsUploadProgress はアップロード中のファイルの進捗
+type IpfsUploadProgress struct {
+	UploadID string `json:"upload_id" example:"0b7c6f4e-track-01"`
+	Filename string `json:"filename" example:"track.wav"`
+	Received int64  `json:"received" example:"10485760"` // 受信したバイト数
+	Size     int64  `json:"size" example:"209715200"`    // リクエストの Content-Length（分からない場合は -1）
+	Limit    int64  `json:"limit" example:"314572800"`   // ファイルの種類ごとの上限
+}
+
 type IpfsMetaInput struct {
 	Name        string `json:"name" validate:"required" example:"GoodNFT"`
 	Description string `json:"description" validate:"required" example:"良いNFTです"`
-	FileType    string `json:"file_type" validate:"required" example:"audio"`
+	FileType    string `json:"file_type" validate:"omitempty" example:"audio"` // audio_cid・video_cid のアップロードしたファイルから決める（どちらも無い場合のみ指定した値を使う）
 	ImageCid    string `json:"image_cid" validate:"required" example:"QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"`
 	AudioCid    string `json:"audio_cid" validate:"required" example:"QmPYVinZ3fmWSetwc9FWMF9b93hfKjWiHtygXfVY8exwEM"`
 	VideoCid    string `json:"video_cid" validate:"required" example:"QmQfESxcop5u9zjvK5hJyX8oKJJZCsEYVh4kTU64Wzmxqz"`
@@ -24,4 +47,12 @@ type IpfsOutput struct {
 	UserID uuid.UUID `json:"user_id"`
 	Cid    string    `json:"cid"`
 	Path   string    `json:"path"`
+	Sha256 string    `json:"sha256,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"` // アップロードしたファイルのSHA-256（16進）
+	Size   int64     `json:"size,omitempty" example:"209715200"`
+
+	FileType string                `json:"file_type,omitempty" example:"audio"` // アップロードしたファイルの種類（audio / image / video）
+	Audio    *domain.AudioMetadata `json:"audio,omitempty"`                     // 音声ファイルの技術的なメタデータ
+
+	PreviewCid  string `json:"preview_cid,omitempty"`  // 音声ファイルから切り出したプレビューのCID（切り出せない形式は省略）
+	PreviewPath string `json:"preview_path,omitempty"` // プレビューを参照するパス
 }
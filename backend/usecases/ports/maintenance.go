@@ -0,0 +1,31 @@
+// Package ports は、ユースケースで使用される入力・出力モデルを定義します。
+package ports
+
+import "time"
+
+// MaintenanceInput は運用者がメンテナンスモードを切り替える構造体
+type MaintenanceInput struct {
+	Enabled  bool   `json:"enabled" example:"true"`
+	Reason   string `json:"reason" validate:"max=255" example:"データベースのメンテナンス中です"`
+	Duration string `json:"duration" example:"30m"` // この時間が過ぎるとメンテナンスモードを終える（省略した場合は解除するまで続け、再試行の目安に既定値を使う）
+}
+
+// MaintenanceOutput はメンテナンスモードの状態を返す構造体
+type MaintenanceOutput struct {
+	Active         bool       `json:"active" example:"true"`          // 書き込みのAPIを止めている
+	ContractPaused bool       `json:"contract_paused" example:"true"` // マーケットプレイスのコントラクトが一時停止中
+	Operator       bool       `json:"operator" example:"false"`       // 運用者がメンテナンスモードにしている
+	Reason         string     `json:"reason,omitempty" example:"マーケットプレイスのコントラクトが一時停止されています"`
+	Until          *time.Time `json:"until,omitempty"`          // 運用者が設定した終了時刻
+	RetryAfter     int        `json:"retry_after" example:"60"` // 再試行までの秒数（停止していない場合は0）
+	CheckedAt      time.Time  `json:"checked_at"`               // 最後にコントラクトの状態を確認した日時
+}
+
+// MaintenanceResponseObject はメンテナンス中に書き込みのAPIが返すオブジェクト
+type MaintenanceResponseObject struct {
+	StatusCode  int               `json:"status_code" example:"503"`
+	ErrorType   string            `json:"error_type" example:"メンテナンス中のため書き込みを受け付けていません"`
+	Message     string            `json:"message" example:"マーケットプレイスのコントラクトが一時停止されています"`
+	RetryAfter  int               `json:"retry_after" example:"60"` // 再試行までの秒数（Retry-After ヘッダーと同じ値）
+	Maintenance MaintenanceOutput `json:"maintenance"`
+}
@@ -4,12 +4,14 @@ package ports
 import (
 	"time"
 
+	"nft-music/domain"
+
 	"github.com/google/uuid"
 )
 
 // NftInput はコントローラから取得する構造体を表します。
 type NftInput struct {
-	ChainID     int       `json:"chain_id" validate:"required" example:"222"`
+	ChainID     int       `json:"chain_id" validate:"required" example:"1337"` // chains テーブルのチェーンID
 	Wallet      string    `json:"wallet" validate:"required" example:"0xc5309Ef694C81C4a8e946F2810e09516436daeB5"`
 	Name        string    `json:"name" validate:"required" example:"GoodNFT"`
 	Description string    `json:"description" validate:"required" example:"良いNFTです"`
@@ -19,24 +21,24 @@ type NftInput struct {
 	VideoCid    string    `json:"video_cid" validate:"omitempty" example:"QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"`
 	GenreID     uuid.UUID `json:"genre_id" validate:"required" example:"019504e3-d996-7979-8043-ef03fa7a6d89"`
 	Status      string    `json:"status" validate:"required" example:"mint"`
-	Price       float64   `json:"price,string" validate:"required" example:"1000.11"`
+	Price       string    `json:"price" validate:"omitempty" example:"0.015 ETH"` // 省略した場合はコントラクトのミント料。指定する場合はミント料と一致する必要がある（単位が無い場合はネイティブ通貨）
 	Insentive   int       `json:"insentive,string" validate:"required" example:"20"`
 	Sale        bool      `json:"sale" example:"0"`
 }
 
 // NftOutput はAPIで返す構造体
 type NftOutput struct {
-	ID            uuid.UUID `json:"id"`
-	UserID        uuid.UUID `json:"user_id"`
-	ChainID       int       `json:"chain_id"`
-	FileType      string    `json:"file_type"`
-	TransactionID string    `json:"transaction_id"`
-	TokenURL      string    `json:"token_url"`
-	GenreID       uuid.UUID `json:"genre_id"`
-	Status        string    `json:"status"`
-	Price         float64   `json:"price"`
-	Insentive     int       `json:"insentive"`
-	Sale          bool      `json:"sale"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            uuid.UUID     `json:"id"`
+	UserID        uuid.UUID     `json:"user_id"`
+	ChainID       int           `json:"chain_id"`
+	FileType      string        `json:"file_type"`
+	TransactionID string        `json:"transaction_id"`
+	TokenURL      string        `json:"token_url"`
+	GenreID       uuid.UUID     `json:"genre_id"`
+	Status        string        `json:"status"`
+	Price         domain.Amount `json:"price" swaggertype:"string" example:"15000000000000000"`
+	Insentive     int           `json:"insentive"`
+	Sale          bool          `json:"sale"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
 }
@@ -0,0 +1,31 @@
+// Package ports は、ユースケースで使用される入力・出力モデルを定義します。
+package ports
+
+import "time"
+
+// ReconcileInput はDBとチェーンの照合の指定
+type ReconcileInput struct {
+	Repair bool `json:"repair" example:"true"` // 安全に直せる食い違い（ステータス・トークンID・価格）をチェーンに合わせて直す
+}
+
+// DriftOutput はDBとチェーンの食い違い
+type DriftOutput struct {
+	Kind          string `json:"kind" example:"stale_status"` // missing_row / missing_token / stale_status / token_id / wrong_owner / wrong_price
+	TransactionID string `json:"transaction_id,omitempty"`
+	TokenID       string `json:"token_id,omitempty" example:"1"`
+	Expected      string `json:"expected" example:"failed"`  // チェーンの値
+	Actual        string `json:"actual" example:"submitted"` // DBの値
+	Repaired      bool   `json:"repaired"`
+}
+
+// DriftReportOutput はDBとチェーンを照合した結果
+type DriftReportOutput struct {
+	ChainID         int           `json:"chain_id" example:"1337"`
+	ContractAddress string        `json:"contract_address" example:"0x5FbDB2315678afecb367f032d93F642f64180aa3"`
+	Tokens          int           `json:"tokens" example:"12"`       // チェーンのトークン数
+	Transactions    int           `json:"transactions" example:"12"` // 照合したトランザクションの行数
+	Repair          bool          `json:"repair"`
+	Drifts          []DriftOutput `json:"drifts"`
+	StartedAt       time.Time     `json:"started_at"`
+	FinishedAt      time.Time     `json:"finished_at"`
+}
@@ -0,0 +1,25 @@
+// Package ports は、ユースケースで使用される入力・出力モデルを定義します。
+package ports
+
+import (
+	"time"
+
+	"nft-music/domain"
+
+	"github.com/google/uuid"
+)
+
+// ReplacementOutput は詰まったミントのトランザクションを同じノンスで置き換えた記録
+type ReplacementOutput struct {
+	ID             uuid.UUID      `json:"id" example:"0193254c-a151-7c4c-b06a-259da7258a27"`
+	TransactionID  string         `json:"transaction_id"`
+	Kind           string         `json:"kind" example:"speed_up"` // speed_up / cancel
+	Nonce          int            `json:"nonce" example:"3"`
+	ReplacedTxHash string         `json:"replaced_tx_hash" example:"0x9d605694113bde48dd07bf4d6f408906d3d5a9ee6656df600209ad6a38b2669e"`
+	TxHash         string         `json:"tx_hash" example:"0x2f1c3a5e6b7d8f90a1b2c3d4e5f60718293a4b5c6d7e8f9001a2b3c4d5e6f708"`
+	GasPrice       *domain.Amount `json:"gas_price,omitempty" swaggertype:"string" example:"2200000000"`   // legacy のみ
+	GasTipCap      *domain.Amount `json:"gas_tip_cap,omitempty" swaggertype:"string" example:"1700000000"` // dynamic のみ
+	GasFeeCap      *domain.Amount `json:"gas_fee_cap,omitempty" swaggertype:"string" example:"4600000000"` // dynamic のみ
+	Status         string         `json:"status" example:"pending"`                                        // pending / mined / replaced / failed
+	CreatedAt      time.Time      `json:"created_at"`
+}
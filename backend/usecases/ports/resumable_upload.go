@@ -0,0 +1,23 @@
+// Package ports は、ユースケースで使用される入力・出力モデルを定義します。
+package ports
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResumableUploadInput は tus の作成リクエスト（POST）から取得する構造体
+type ResumableUploadInput struct {
+	Size     int64             // Upload-Length
+	Metadata map[string]string // Upload-Metadata（filename は必須）
+}
+
+// ResumableUploadOutput は tus のレスポンスヘッダーに使う構造体
+type ResumableUploadOutput struct {
+	ID        uuid.UUID
+	Size      int64
+	Offset    int64
+	ExpiresAt time.Time
+	Ipfs      *IpfsOutput // 完了して IPFS に追加した場合のみ
+}
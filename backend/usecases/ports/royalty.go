@@ -0,0 +1,41 @@
+// Package ports は、ユースケースで使用される入力・出力モデルを定義します。
+package ports
+
+import (
+	"time"
+
+	"nft-music/domain"
+
+	"github.com/google/uuid"
+)
+
+// TokenRoyaltyInput はトークンごとのロイヤリティを設定する構造体
+type TokenRoyaltyInput struct {
+	RoyaltyBps int    `json:"royalty_bps" validate:"min=0,max=10000" example:"750"`
+	Receiver   string `json:"receiver" validate:"required" example:"0xc5309Ef694C81C4a8e946F2810e09516436daeB5"` // チェックサム付きのアドレス
+}
+
+// TokenRoyaltyOutput はトークンごとのロイヤリティを返す構造体
+type TokenRoyaltyOutput struct {
+	CollectionID    uuid.UUID `json:"collection_id" example:"0193254c-a151-7c4c-b06a-259da7258a27"`
+	ChainID         int       `json:"chain_id" example:"1337"`
+	ContractAddress string    `json:"contract_address" example:"0x495F947276749Ce646f68AC8c248420045075B34"`
+	TokenID         string    `json:"token_id" example:"0"`
+	RoyaltyBps      int       `json:"royalty_bps" example:"750"`
+	Receiver        string    `json:"receiver" example:"0xc5309Ef694C81C4a8e946F2810e09516436daeB5"`
+	TxHash          string    `json:"tx_hash,omitempty" example:"0x3f1c0e2b7a9d4c5e6f8a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6"` // プラットフォームでデプロイしたコレクションの場合はチェーンにも設定する
+	UpdatedAt       time.Time `json:"updated_at" example:"2024-11-04T20:51:26Z"`
+}
+
+// RoyaltyInfoOutput は ERC-2981 の royaltyInfo と同じく、販売価格に対するロイヤリティの受取人と金額を返す構造体
+type RoyaltyInfoOutput struct {
+	ChainID         int           `json:"chain_id" example:"1337"`
+	ContractAddress string        `json:"contract_address" example:"0x495F947276749Ce646f68AC8c248420045075B34"`
+	TokenID         string        `json:"token_id" example:"0"`
+	SalePrice       domain.Amount `json:"sale_price" swaggertype:"string" example:"2000000000000000"` // wei
+	Receiver        string        `json:"receiver" example:"0xc5309Ef694C81C4a8e946F2810e09516436daeB5"`
+	RoyaltyBps      int           `json:"royalty_bps" example:"750"`
+	RoyaltyAmount   domain.Amount `json:"royalty_amount" swaggertype:"string" example:"150000000000000"` // wei
+	Remaining       domain.Amount `json:"remaining" swaggertype:"string" example:"1850000000000000"`     // 販売価格からロイヤリティを差し引いた金額（wei）
+	Source          string        `json:"source" example:"collection"`                                   // token / collection / marketplace
+}
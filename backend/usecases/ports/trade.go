@@ -0,0 +1,56 @@
+// Package ports は、ユースケースで使用される入力・出力モデルを定義します。
+package ports
+
+import (
+	"time"
+
+	"nft-music/domain"
+
+	"github.com/google/uuid"
+)
+
+// PurchaseInput は販売中のNFTを購入する構造体
+type PurchaseInput struct {
+	Wallet string `json:"wallet" validate:"required" example:"0xc5309Ef694C81C4a8e946F2810e09516436daeB5"`
+}
+
+// ResellInput は所有しているNFTを再出品する構造体
+type ResellInput struct {
+	Wallet string `json:"wallet" validate:"required" example:"0xc5309Ef694C81C4a8e946F2810e09516436daeB5"`
+	Price  string `json:"price" validate:"required" example:"0.002 ETH"` // 単位が無い場合はETH
+}
+
+// TradeSubmitInput はユーザーが署名して送信したトランザクションを登録する構造体
+type TradeSubmitInput struct {
+	TxHash string `json:"tx_hash" validate:"required" example:"0x9d605694113bde48dd07bf4d6f408906d3d5a9ee6656df600209ad6a38b2669e"`
+}
+
+// UnsignedTxOutput はユーザーのウォレットで署名するトランザクション
+// （ガス代はウォレットで設定する）
+type UnsignedTxOutput struct {
+	ChainID int           `json:"chain_id" example:"1337"`
+	From    string        `json:"from" example:"0xc5309Ef694C81C4a8e946F2810e09516436daeB5"`
+	To      string        `json:"to" example:"0x47CD2D0873833Ba015e0C31AB94D30313eF07942"`
+	Data    string        `json:"data" example:"0xbe9af5360000000000000000000000000000000000000000000000000000000000000001"`
+	Value   domain.Amount `json:"value" swaggertype:"string" example:"2000000000000000"` // wei
+	Gas     uint64        `json:"gas" example:"120000"`
+	Nonce   uint64        `json:"nonce" example:"3"`
+}
+
+// TradeOutput は二次流通の取引を返す構造体
+type TradeOutput struct {
+	ID              uuid.UUID          `json:"id" example:"0193254c-a151-7c4c-b06a-259da7258a27"`
+	Kind            string             `json:"kind" example:"purchase"`
+	TokenID         string             `json:"token_id" example:"1"`
+	ContractAddress string             `json:"contract_address" example:"0x47CD2D0873833Ba015e0C31AB94D30313eF07942"`
+	Wallet          string             `json:"wallet" example:"0xc5309Ef694C81C4a8e946F2810e09516436daeB5"`
+	Price           domain.Amount      `json:"price" swaggertype:"string" example:"2000000000000000"` // wei
+	Status          string             `json:"status" example:"prepared"`                             // prepared / submitted / mined / failed
+	TxHash          string             `json:"tx_hash"`
+	BlockNumber     uint64             `json:"block_number"`
+	RevertReason    string             `json:"revert_reason"`
+	Transaction     *UnsignedTxOutput  `json:"transaction,omitempty"` // prepared の場合のみ
+	Royalty         *RoyaltyInfoOutput `json:"royalty,omitempty"`     // 販売価格に対するロイヤリティの内訳（prepared の場合のみ）
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
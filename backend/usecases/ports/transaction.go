@@ -4,30 +4,74 @@ package ports
 import (
 	"time"
 
+	"nft-music/domain"
+
 	"github.com/google/uuid"
 )
 
 type TransactionOutput struct {
-	ID          string    `json:"id"`
-	UserID      uuid.UUID `json:"user_id"`
-	ChainID     int       `json:"chain_id"`
-	TokenID     int       `json:"token_id"`
-	Nonce       int       `json:"nonce"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	FileType    string    `json:"file_type"`
-	ImageURL    string    `json:"image_url"`
-	AudioURL    string    `json:"audio_url"`
-	VideoURL    string    `json:"video_url"`
-	TokenURL    string    `json:"token_url"`
-	GenreID     uuid.UUID `json:"genre_id"`
-	GenreName   string    `json:"genre_name"`
-	To          string    `json:"to"`
-	Price       float64   `json:"price"`
-	Insentive   int       `json:"insentive"`
-	Cost        int       `json:"cost"`
-	Sale        bool      `json:"sale"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID              string                `json:"id"`
+	UserID          uuid.UUID             `json:"user_id"`
+	ChainID         int                   `json:"chain_id"`
+	ContractAddress string                `json:"contract_address" example:"0x5FbDB2315678afecb367f032d93F642f64180aa3"`
+	TokenID         string                `json:"token_id" example:"1"` // ミントが取り込まれるまでは空
+	TxHash          string                `json:"tx_hash"`
+	Nonce           int                   `json:"nonce"`
+	Name            string                `json:"name"`
+	Description     string                `json:"description"`
+	FileType        string                `json:"file_type"`
+	ImageURL        string                `json:"image_url"`
+	AudioURL        string                `json:"audio_url,omitempty"`   // フル音源（以前に作成したメタデータのみ。所有者は GET /nfts/detail/{transaction_id}/master で取得する）
+	PreviewURL      string                `json:"preview_url,omitempty"` // 購入前に試聴するプレビュー
+	VideoURL        string                `json:"video_url"`
+	Audio           *domain.AudioMetadata `json:"audio,omitempty"` // メタデータに含まれる音声ファイルの技術的なメタデータ
+	TokenURL        string                `json:"token_url"`
+	GenreID         uuid.UUID             `json:"genre_id"`
+	GenreName       string                `json:"genre_name"`
+	To              string                `json:"to"`
+	Price           domain.Amount         `json:"price" swaggertype:"string" example:"15000000000000000"` // wei
+	PriceFormatted  string                `json:"price_formatted" example:"0.015 ETH"`
+	Insentive       int                   `json:"insentive"`
+	Cost            domain.Amount         `json:"cost" swaggertype:"string" example:"15210000000000000"` // wei
+	Sale            bool                  `json:"sale"`
+	Status          string                `json:"status"`
+	ListingState    string                `json:"listing_state" example:"minted"` // minted / lazy（lazy の場合 id はバウチャーID）
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+}
+
+// MintJobOutput はミントジョブの状態
+type MintJobOutput struct {
+	ID              string            `json:"id"`
+	Status          string            `json:"status" example:"queued"` // queued / prepared / submitted / mined / confirmed / failed
+	ChainID         int               `json:"chain_id"`
+	ContractAddress string            `json:"contract_address"`
+	TxHash          string            `json:"tx_hash"`
+	BlockNumber     uint64            `json:"block_number"`
+	RevertReason    string            `json:"revert_reason"`
+	Transaction     *UnsignedTxOutput `json:"transaction,omitempty"` // prepared の場合のみ。クリエイターのウォレットで署名する
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// MintSubmitInput はクリエイターのウォレットで署名したミントのトランザクションを登録する構造体
+// 署名済みのトランザクション（raw_tx）か、ウォレットが送信したトランザクションのハッシュ（tx_hash）のどちらかを指定する
+type MintSubmitInput struct {
+	RawTx  string `json:"raw_tx" example:"0x02f8b4820539038459682f00845d21dba0830249f0945fbdb2315678afecb367f032d93f642f64180aa3..."`
+	TxHash string `json:"tx_hash" example:"0x9d605694113bde48dd07bf4d6f408906d3d5a9ee6656df600209ad6a38b2669e"`
+}
+
+// MintPreviewOutput はミントを送信する前のガス代の見積もり（金額はwei）
+type MintPreviewOutput struct {
+	ChainID              int            `json:"chain_id"`
+	FeeType              string         `json:"fee_type" example:"dynamic"` // legacy / dynamic
+	BaseFee              *domain.Amount `json:"base_fee,omitempty" swaggertype:"string" example:"1000000000"`
+	GasPrice             *domain.Amount `json:"gas_price,omitempty" swaggertype:"string" example:"2000000000"`
+	MaxPriorityFeePerGas *domain.Amount `json:"max_priority_fee_per_gas,omitempty" swaggertype:"string" example:"1500000000"`
+	MaxFeePerGas         *domain.Amount `json:"max_fee_per_gas,omitempty" swaggertype:"string" example:"3500000000"`
+	GasLimit             uint64         `json:"gas_limit" example:"120000"`
+	Value                domain.Amount  `json:"value" swaggertype:"string" example:"1000110000000"` // ミント料
+	EstimatedGasCost     domain.Amount  `json:"estimated_gas_cost" swaggertype:"string" example:"600000000000000"`
+	MaxGasCost           domain.Amount  `json:"max_gas_cost" swaggertype:"string" example:"840000000000000"`
+	EstimatedTotal       domain.Amount  `json:"estimated_total" swaggertype:"string" example:"601000110000000"` // ミント料 + 見込みのガス代
 }
@@ -18,7 +18,11 @@ type UserInput struct {
 	FaceImage  string    `json:"face_image" example:"https://www.yahoo.com/img/test.jpg"`
 	Eyecatch   string    `json:"eyecatch" example:"https://www.yahoo.com/img/test.jpg"`
 	Profile    string    `json:"profile" validate:"min=10,max=4000" example:"私はいつでも明るいです"`
-	Role       string    `json:"role" example:"member"`
+}
+
+// UserRoleInput はユーザーの権限を変更する構造体
+type UserRoleInput struct {
+	Role string `json:"role" validate:"required,oneof=member creator admin" example:"creator"`
 }
 
 // UserOutput はAPIで返す構造体
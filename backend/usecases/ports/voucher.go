@@ -0,0 +1,61 @@
+// Package ports は、ユースケースで使用される入力・出力モデルを定義します。
+package ports
+
+import (
+	"encoding/json"
+	"time"
+
+	"nft-music/domain"
+
+	"github.com/google/uuid"
+)
+
+// VoucherInput は遅延ミントのバウチャーを作成する構造体（メタJSONはNFTのミントと同じ内容でIPFSに登録する）
+type VoucherInput struct {
+	Wallet          string    `json:"wallet" validate:"required" example:"0xc5309Ef694C81C4a8e946F2810e09516436daeB5"` // 署名するクリエイターのウォレット（代金の受取人）
+	Name            string    `json:"name" validate:"required" example:"GoodNFT"`
+	Description     string    `json:"description" validate:"required" example:"良いNFTです"`
+	FileType        string    `json:"file_type" example:"audio"`
+	ImageCid        string    `json:"image_cid" validate:"omitempty" example:"QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"`
+	AudioCid        string    `json:"audio_cid" validate:"omitempty" example:"QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"`
+	VideoCid        string    `json:"video_cid" validate:"omitempty" example:"QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"`
+	GenreID         uuid.UUID `json:"genre_id" validate:"required" example:"019504e3-d996-7979-8043-ef03fa7a6d89"`
+	Price           string    `json:"price" validate:"required" example:"0.015 ETH"`                         // 単位が無い場合はネイティブ通貨
+	RoyaltyBps      int       `json:"royalty_bps" validate:"min=0,max=10000" example:"750"`                  // 0 の場合はコレクションの既定値
+	RoyaltyReceiver string    `json:"royalty_receiver" example:"0xc5309Ef694C81C4a8e946F2810e09516436daeB5"` // チェックサム付きのアドレス（royalty_bps が0より大きい場合は必須）
+	ExpiresAt       time.Time `json:"expires_at" validate:"required" example:"2026-12-31T23:59:59+09:00"`    // 有効期限（秒未満は切り捨て）
+}
+
+// VoucherSignInput はクリエイターが eth_signTypedData_v4 で署名した結果を登録する構造体
+type VoucherSignInput struct {
+	Signature string `json:"signature" validate:"required" example:"0x5c2a3f...1b"`
+}
+
+// VoucherPurchaseInput はバウチャーを購入する（redeem する）構造体
+type VoucherPurchaseInput struct {
+	Wallet string `json:"wallet" validate:"required" example:"0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"`
+}
+
+// VoucherOutput は遅延ミントのバウチャーを返す構造体（署名は購入者に返す redeem のトランザクションにのみ含める）
+type VoucherOutput struct {
+	ID              uuid.UUID         `json:"id" example:"0193254c-a151-7c4c-b06a-259da7258a27"`
+	CollectionID    uuid.UUID         `json:"collection_id" example:"0193254c-a151-7c4c-b06a-259da7258a27"`
+	ChainID         int               `json:"chain_id" example:"1337"`
+	ContractAddress string            `json:"contract_address" example:"0x495F947276749Ce646f68AC8c248420045075B34"`
+	Signer          string            `json:"signer" example:"0xc5309Ef694C81C4a8e946F2810e09516436daeB5"`
+	TokenURL        string            `json:"token_url" example:"/ipfs/QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS"`
+	GenreID         uuid.UUID         `json:"genre_id" example:"019504e3-d996-7979-8043-ef03fa7a6d89"`
+	Price           domain.Amount     `json:"price" swaggertype:"string" example:"15000000000000000"` // wei
+	PriceFormatted  string            `json:"price_formatted" example:"0.015 ETH"`
+	RoyaltyBps      int               `json:"royalty_bps" example:"750"`
+	RoyaltyReceiver string            `json:"royalty_receiver" example:"0xc5309Ef694C81C4a8e946F2810e09516436daeB5"`
+	ExpiresAt       time.Time         `json:"expires_at" example:"2026-12-31T23:59:59+09:00"`
+	Status          string            `json:"status" example:"listed"` // prepared / listed / redeeming / redeemed / cancelled / expired
+	Buyer           string            `json:"buyer,omitempty" example:"0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"`
+	TxHash          string            `json:"tx_hash,omitempty"`
+	TokenID         string            `json:"token_id,omitempty" example:"0"`            // redeemed の場合のみ
+	TypedData       json.RawMessage   `json:"typed_data,omitempty" swaggertype:"object"` // prepared の場合のみ。eth_signTypedData_v4 でクリエイターのウォレットで署名する
+	Transaction     *UnsignedTxOutput `json:"transaction,omitempty"`                     // 購入した場合のみ。購入者のウォレットで署名する
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
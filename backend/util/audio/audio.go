@@ -0,0 +1,165 @@
+// Package audio は、音声ファイルの形式の判定と技術的なメタデータの読み取りを実装します。
+// デコーダーは使わず、コンテナとフレームのヘッダーだけを読みます。
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// 対応する音声ファイルの形式
+const (
+	FormatMP3  = "mp3"
+	FormatFLAC = "flac"
+	FormatWAV  = "wav"
+	FormatAAC  = "aac" // ADTS
+	FormatM4A  = "m4a" // MP4 コンテナ（AAC / ALAC）
+	FormatOgg  = "ogg" // Vorbis / Opus
+)
+
+// SniffSize は Sniff で判定に使う先頭のバイト数
+const SniffSize = 512
+
+// ErrUnsupported は対応していない形式のファイル
+var ErrUnsupported = errors.New("unsupported audio format")
+
+// CorruptError はヘッダーが壊れている・途中で切れているファイル
+type CorruptError struct {
+	Format string
+	Reason string
+}
+
+func (err *CorruptError) Error() string {
+	return fmt.Sprintf("corrupt %s file: %s", err.Format, err.Reason)
+}
+
+func corrupt(format string, reason string, args ...any) error {
+	return &CorruptError{Format: format, Reason: fmt.Sprintf(reason, args...)}
+}
+
+// Info は音声ファイルの技術的なメタデータ
+type Info struct {
+	Format     string            // mp3 / flac / wav / aac / m4a / ogg
+	Codec      string            // mp3 / flac / pcm / aac / alac / vorbis / opus
+	Duration   time.Duration     // 再生時間
+	SampleRate int               // Hz
+	BitDepth   int               // 非可逆圧縮の場合は 0
+	Channels   int               // チャンネル数
+	Bitrate    int               // 平均のビットレート（bps）
+	Tags       map[string]string // 埋め込みのタグ（title / artist / album など。ID3v2 と Vorbis コメントを同じ名前にする）
+}
+
+// Sniff はファイルの先頭から音声ファイルの形式を判定する（音声ではない場合は空）
+// ID3v2 タグで始まるファイルは、タグの後ろを読むまで分からないため mp3 とする
+func Sniff(head []byte) string {
+	switch {
+	case len(head) >= 12 && string(head[:4]) == "RIFF" && string(head[8:12]) == "WAVE":
+		return FormatWAV
+	case bytes.HasPrefix(head, []byte("fLaC")):
+		return FormatFLAC
+	case bytes.HasPrefix(head, []byte("OggS")):
+		return FormatOgg
+	case len(head) >= 8 && string(head[4:8]) == "ftyp":
+		return FormatM4A
+	case bytes.HasPrefix(head, []byte("ID3")):
+		return FormatMP3
+	}
+	if _, ok := parseADTSHeader(head); ok {
+		return FormatAAC
+	}
+	if _, ok := ParseMPEGHeader(head); ok {
+		return FormatMP3
+	}
+	return ""
+}
+
+// Probe はファイル全体からメタデータを読み取る
+// 対応していない形式は ErrUnsupported、壊れている場合は *CorruptError を返す
+func Probe(r io.ReaderAt, size int64) (*Info, error) {
+	head, err := readAt(r, size, 0, min(size, 12))
+	if err != nil {
+		return nil, err
+	}
+
+	// ID3v2 タグは MP3 以外（ADTS・FLAC）の先頭にも付くことがある
+	var offset int64
+	var id3Tags map[string]string
+	if bytes.HasPrefix(head, []byte("ID3")) {
+		offset, id3Tags, err = readID3(r, size, 0)
+		if err != nil {
+			return nil, err
+		}
+		if head, err = readAt(r, size, offset, min(size-offset, 12)); err != nil {
+			return nil, corrupt(FormatMP3, "ID3 タグの後ろに音声がありません")
+		}
+	}
+
+	var info *Info
+	switch format := Sniff(head); format {
+	case FormatWAV:
+		info, err = probeWAV(r, size)
+	case FormatFLAC:
+		info, err = probeFLAC(r, size, offset)
+	case FormatOgg:
+		info, err = probeOgg(r, size)
+	case FormatM4A:
+		info, err = probeM4A(r, size)
+	case FormatAAC:
+		info, err = probeADTS(r, size, offset)
+	case FormatMP3:
+		info, err = probeMP3(r, size, offset)
+	default:
+		if id3Tags != nil {
+			// タグの後ろのフレームが見つからない
+			return nil, corrupt(FormatMP3, "ID3 タグの後ろにフレームがありません")
+		}
+		return nil, ErrUnsupported
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(info.Tags) == 0 {
+		info.Tags = id3Tags
+	}
+	if info.Duration <= 0 {
+		return nil, corrupt(info.Format, "再生時間がありません")
+	}
+	return info, nil
+}
+
+// bitrate は音声のバイト数と再生時間から平均のビットレートを求める
+func bitrate(bytes int64, duration time.Duration) int {
+	if duration <= 0 {
+		return 0
+	}
+	return int(float64(bytes) * 8 / duration.Seconds())
+}
+
+// samplesDuration はサンプル数を再生時間にする
+func samplesDuration(samples int64, sampleRate int) time.Duration {
+	if sampleRate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(samples) / float64(sampleRate) * float64(time.Second))
+}
+
+// readAt は off から n バイトを読み取る（ファイルの終わりを超える場合は io.ErrUnexpectedEOF）
+func readAt(r io.ReaderAt, size int64, off int64, n int64) ([]byte, error) {
+	if off < 0 || n < 0 || off+n > size {
+		return nil, io.ErrUnexpectedEOF
+	}
+	buf := make([]byte, n)
+	if _, err := r.ReadAt(buf, off); err != nil && !(errors.Is(err, io.EOF) && off+n == size) {
+		return nil, err
+	}
+	return buf, nil
+}
+
+var (
+	le = binary.LittleEndian
+	be = binary.BigEndian
+)
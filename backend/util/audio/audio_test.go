@@ -0,0 +1,301 @@
+// Package audio は、音声ファイルの形式の判定と技術的なメタデータの読み取りを実装します。
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func u16le(v int) []byte { return binary.LittleEndian.AppendUint16(nil, uint16(v)) }
+func u32le(v int) []byte { return binary.LittleEndian.AppendUint32(nil, uint32(v)) }
+func u32be(v int) []byte { return binary.BigEndian.AppendUint32(nil, uint32(v)) }
+
+func concat(parts ...[]byte) []byte { return bytes.Join(parts, nil) }
+
+// testWAV は無音の PCM の WAV を作る
+func testWAV(channels int, rate int, bits int, samples int, extra ...[]byte) []byte {
+	blockAlign := channels * bits / 8
+	fmtChunk := concat([]byte("fmt "), u32le(16), u16le(wavFormatPCM), u16le(channels), u32le(rate),
+		u32le(rate*blockAlign), u16le(blockAlign), u16le(bits))
+	data := concat([]byte("data"), u32le(samples*blockAlign), make([]byte, samples*blockAlign))
+	body := concat(append([][]byte{[]byte("WAVE"), fmtChunk}, append(extra, data)...)...)
+	return concat([]byte("RIFF"), u32le(len(body)), body)
+}
+
+// testID3 は ID3v2.3 のテキストフレームのタグを作る
+func testID3(frames map[string]string) []byte {
+	var body []byte
+	for id, value := range frames {
+		text := append([]byte{3}, value...)
+		body = concat(body, []byte(id), u32be(len(text)), []byte{0, 0}, text)
+	}
+	size := len(body)
+	return concat([]byte("ID3"), []byte{3, 0, 0}, []byte{byte(size >> 21 & 0x7F), byte(size >> 14 & 0x7F), byte(size >> 7 & 0x7F), byte(size & 0x7F)}, body)
+}
+
+// testMP3 は MPEG-1 Layer III・128kbps・44.1kHz・ステレオのフレームを作る（1フレーム417バイト）
+func testMP3(frames int) []byte {
+	frame := make([]byte, 417)
+	copy(frame, []byte{0xFF, 0xFB, 0x90, 0x00})
+	return bytes.Repeat(frame, frames)
+}
+
+// testADTS は AAC LC・44.1kHz・ステレオの ADTS フレームを作る
+func testADTS(frames int) []byte {
+	const size = 200
+	frame := make([]byte, size)
+	copy(frame, []byte{0xFF, 0xF1, 0x50, 0x80 | size>>11, size >> 3 & 0xFF, size&0x07<<5 | 0x1F, 0xFC})
+	return bytes.Repeat(frame, frames)
+}
+
+func testVorbisComment(comments ...string) []byte {
+	body := concat(u32le(len("test")), []byte("test"), u32le(len(comments)))
+	for _, comment := range comments {
+		body = concat(body, u32le(len(comment)), []byte(comment))
+	}
+	return body
+}
+
+// testFLAC は STREAMINFO・VORBIS_COMMENT と最初のフレームの同期コードだけの FLAC を作る
+func testFLAC(rate int, channels int, bits int, samples int64, comments ...string) []byte {
+	info := make([]byte, 34)
+	info[10] = byte(rate >> 12)
+	info[11] = byte(rate >> 4)
+	info[12] = byte(rate&0x0F)<<4 | byte(channels-1)<<1 | byte(bits-1)>>4
+	info[13] = byte(bits-1)<<4 | byte(samples>>32&0x0F)
+	binary.BigEndian.PutUint32(info[14:], uint32(samples))
+	comment := testVorbisComment(comments...)
+	return concat([]byte("fLaC"),
+		[]byte{flacBlockStreamInfo, 0, 0, 34}, info,
+		[]byte{0x80 | flacBlockVorbisComment, 0, byte(len(comment) >> 8), byte(len(comment))}, comment,
+		[]byte{0xFF, 0xF8}, make([]byte, 1000))
+}
+
+// testOggPage は1つのパケットだけの Ogg のページを作る（CRC は確認しないため 0）
+func testOggPage(headerType byte, granule int64, packet []byte) []byte {
+	var segments []byte
+	for rest := len(packet); ; rest -= 255 {
+		if rest < 255 {
+			segments = append(segments, byte(rest))
+			break
+		}
+		segments = append(segments, 255)
+	}
+	header := concat([]byte("OggS"), []byte{0, headerType}, binary.LittleEndian.AppendUint64(nil, uint64(granule)),
+		u32le(1), u32le(0), u32le(0), []byte{byte(len(segments))}, segments)
+	return concat(header, packet)
+}
+
+func testOggVorbis(rate int, channels int, samples int64, comments ...string) []byte {
+	ident := concat([]byte("\x01vorbis"), u32le(0), []byte{byte(channels)}, u32le(rate), u32le(0), u32le(128000), u32le(0), []byte{0xB8, 0x01})
+	comment := concat([]byte("\x03vorbis"), testVorbisComment(comments...), []byte{1})
+	return concat(
+		testOggPage(0x02, 0, ident),
+		testOggPage(0, 0, comment),
+		testOggPage(0, samples/2, make([]byte, 300)),
+		testOggPage(oggHeaderEOS, samples, make([]byte, 300)),
+	)
+}
+
+func testBox(kind string, children ...[]byte) []byte {
+	body := concat(children...)
+	return concat(u32be(8+len(body)), []byte(kind), body)
+}
+
+// testM4A は1つの音声トラックの MP4 を作る
+func testM4A(codec string, handler string, rate int, duration int, tags ...[]byte) []byte {
+	mdhd := testBox("mdhd", make([]byte, 12), u32be(rate), u32be(duration), make([]byte, 4))
+	hdlr := testBox("hdlr", make([]byte, 8), []byte(handler), make([]byte, 12))
+	entry := testBox(codec, make([]byte, 6), []byte{0, 1}, make([]byte, 8), []byte{0, 2, 0, 16}, make([]byte, 4), u32be(rate<<16))
+	stsd := testBox("stsd", make([]byte, 4), u32be(1), entry)
+	trak := testBox("trak", testBox("mdia", mdhd, hdlr, testBox("minf", testBox("stbl", stsd))))
+	moov := testBox("moov", testBox("mvhd", make([]byte, 12), u32be(1000), u32be(duration*1000/rate), make([]byte, 80)), trak,
+		testBox("udta", testBox("meta", make([]byte, 4), testBox("ilst", tags...))))
+	return concat(testBox("ftyp", []byte("M4A "), u32be(0)), moov, testBox("mdat", make([]byte, 16000)))
+}
+
+func testM4ATag(kind string, value string) []byte {
+	return testBox(kind, testBox("data", u32be(1), u32be(0), []byte(value)))
+}
+
+func TestSniff(t *testing.T) {
+	tests := []struct {
+		name   string
+		head   []byte
+		format string
+	}{
+		{"WAV", testWAV(1, 8000, 16, 10), FormatWAV},
+		{"FLAC", testFLAC(44100, 2, 16, 100), FormatFLAC},
+		{"Ogg", testOggVorbis(44100, 2, 100), FormatOgg},
+		{"M4A", testM4A("mp4a", "soun", 44100, 100), FormatM4A},
+		{"ID3 で始まる MP3", testID3(map[string]string{"TIT2": "t"}), FormatMP3},
+		{"MP3 のフレーム", testMP3(1), FormatMP3},
+		{"ADTS のフレーム", testADTS(1), FormatAAC},
+		{"PNG は音声ではない", []byte("\x89PNG\r\n\x1a\n0000"), ""},
+		{"テキストは音声ではない", []byte("hello world"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.format, Sniff(tt.head[:min(len(tt.head), SniffSize)]))
+		})
+	}
+}
+
+func TestProbe(t *testing.T) {
+	probe := func(t *testing.T, data []byte) *Info {
+		t.Helper()
+		info, err := Probe(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		return info
+	}
+
+	t.Run("正常系: WAV の PCM と ID3 チャンクのタグ", func(t *testing.T) {
+		id3 := testID3(map[string]string{"TIT2": "Song", "TPE1": "Artist"})
+		info := probe(t, testWAV(2, 48000, 24, 96000, concat([]byte("id3 "), u32le(len(id3)), id3)))
+		assert.Equal(t, FormatWAV, info.Format)
+		assert.Equal(t, "pcm", info.Codec)
+		assert.Equal(t, 2*time.Second, info.Duration)
+		assert.Equal(t, 48000, info.SampleRate)
+		assert.Equal(t, 24, info.BitDepth)
+		assert.Equal(t, 2, info.Channels)
+		assert.Equal(t, 48000*6*8, info.Bitrate)
+		assert.Equal(t, map[string]string{"title": "Song", "artist": "Artist"}, info.Tags)
+	})
+
+	t.Run("正常系: FLAC の STREAMINFO と Vorbis コメント", func(t *testing.T) {
+		info := probe(t, testFLAC(96000, 2, 24, 96000*3, "TITLE=Song", "artist=Artist", "ALBUM=", "DESCRIPTION=x"))
+		assert.Equal(t, FormatFLAC, info.Format)
+		assert.Equal(t, 3*time.Second, info.Duration)
+		assert.Equal(t, 96000, info.SampleRate)
+		assert.Equal(t, 24, info.BitDepth)
+		assert.Equal(t, 2, info.Channels)
+		assert.Equal(t, map[string]string{"title": "Song", "artist": "Artist"}, info.Tags)
+	})
+
+	t.Run("正常系: ID3v2 タグ付きの固定ビットレートの MP3", func(t *testing.T) {
+		info := probe(t, concat(testID3(map[string]string{"TIT2": "Song", "TCON": "Jazz"}), testMP3(100)))
+		assert.Equal(t, FormatMP3, info.Format)
+		assert.Equal(t, 44100, info.SampleRate)
+		assert.Equal(t, 0, info.BitDepth)
+		assert.Equal(t, 2, info.Channels)
+		assert.Equal(t, 128000, info.Bitrate)
+		assert.InDelta(t, 2.606, info.Duration.Seconds(), 0.001)
+		assert.Equal(t, map[string]string{"title": "Song", "genre": "Jazz"}, info.Tags)
+	})
+
+	t.Run("正常系: Xing ヘッダーの総フレーム数から再生時間を求める", func(t *testing.T) {
+		data := testMP3(10)
+		copy(data[4+32:], concat([]byte("Xing"), u32be(1), u32be(1000)))
+		info := probe(t, data)
+		assert.InDelta(t, 1000*1152/44100.0, info.Duration.Seconds(), 0.001)
+	})
+
+	t.Run("正常系: 末尾の ID3v1 タグを音声に含めない", func(t *testing.T) {
+		tag := make([]byte, 128)
+		copy(tag, "TAG")
+		info := probe(t, concat(testMP3(100), tag))
+		assert.InDelta(t, 2.606, info.Duration.Seconds(), 0.001)
+	})
+
+	t.Run("正常系: ADTS のすべてのフレームを数える", func(t *testing.T) {
+		info := probe(t, testADTS(431))
+		assert.Equal(t, FormatAAC, info.Format)
+		assert.Equal(t, "aac", info.Codec)
+		assert.Equal(t, 44100, info.SampleRate)
+		assert.Equal(t, 2, info.Channels)
+		assert.InDelta(t, 431*1024/44100.0, info.Duration.Seconds(), 0.001)
+	})
+
+	t.Run("正常系: Ogg Vorbis の最後のページのグラニュール位置", func(t *testing.T) {
+		info := probe(t, testOggVorbis(44100, 2, 44100*5, "TITLE=Song"))
+		assert.Equal(t, FormatOgg, info.Format)
+		assert.Equal(t, "vorbis", info.Codec)
+		assert.Equal(t, 5*time.Second, info.Duration)
+		assert.Equal(t, map[string]string{"title": "Song"}, info.Tags)
+	})
+
+	t.Run("正常系: Opus はプリスキップを除いた48kHz のグラニュール位置", func(t *testing.T) {
+		ident := concat([]byte("OpusHead"), []byte{1, 2}, u16le(312), u32le(44100), u16le(0), []byte{0})
+		data := concat(
+			testOggPage(0x02, 0, ident),
+			testOggPage(0, 0, concat([]byte("OpusTags"), testVorbisComment("ARTIST=Artist"))),
+			testOggPage(oggHeaderEOS, 48000*2+312, make([]byte, 100)),
+		)
+		info := probe(t, data)
+		assert.Equal(t, "opus", info.Codec)
+		assert.Equal(t, 44100, info.SampleRate)
+		assert.Equal(t, 2*time.Second, info.Duration)
+		assert.Equal(t, map[string]string{"artist": "Artist"}, info.Tags)
+	})
+
+	t.Run("正常系: M4A の音声トラックと iTunes のメタデータ", func(t *testing.T) {
+		info := probe(t, testM4A("mp4a", "soun", 44100, 44100*4, testM4ATag("\xa9nam", "Song"), testM4ATag("\xa9ART", "Artist")))
+		assert.Equal(t, FormatM4A, info.Format)
+		assert.Equal(t, "aac", info.Codec)
+		assert.Equal(t, 4*time.Second, info.Duration)
+		assert.Equal(t, 44100, info.SampleRate)
+		assert.Equal(t, 0, info.BitDepth)
+		assert.Equal(t, 2, info.Channels)
+		assert.Equal(t, 16000*8/4, info.Bitrate)
+		assert.Equal(t, map[string]string{"title": "Song", "artist": "Artist"}, info.Tags)
+	})
+
+	t.Run("正常系: M4A の ALAC はビット深度がある", func(t *testing.T) {
+		info := probe(t, testM4A("alac", "soun", 48000, 48000))
+		assert.Equal(t, "alac", info.Codec)
+		assert.Equal(t, 16, info.BitDepth)
+	})
+
+	t.Run("正常系: 長すぎるタグの値は切り詰める", func(t *testing.T) {
+		info := probe(t, testFLAC(44100, 2, 16, 44100, "TITLE="+string(bytes.Repeat([]byte("あ"), 500))))
+		assert.LessOrEqual(t, len(info.Tags["title"]), maxTagValue)
+		assert.Equal(t, 341, len([]rune(info.Tags["title"])))
+	})
+}
+
+func TestProbe_Rejects(t *testing.T) {
+	truncated := func(data []byte, n int) []byte { return data[:len(data)-n] }
+	wav := testWAV(2, 44100, 16, 1000)
+	adpcmWAV := bytes.Replace(testWAV(1, 8000, 16, 10), concat(u32le(16), u16le(wavFormatPCM)), concat(u32le(16), u16le(2)), 1)
+	oggNoEOS := testOggVorbis(44100, 2, 1000)
+	oggNoEOS[len(oggNoEOS)-300-29+5] = 0
+
+	tests := []struct {
+		name    string
+		data    []byte
+		corrupt bool
+	}{
+		{"テキストファイル", []byte("this is not audio at all"), false},
+		{"空のファイル", []byte{}, false},
+		{"WAV の ADPCM は対応しない", adpcmWAV, false},
+		{"data チャンクが途中で切れた WAV", truncated(wav, 100), true},
+		{"フレームの無い FLAC", testFLAC(44100, 2, 16, 100)[:4+4+34+4+len(testVorbisComment())], true},
+		{"総サンプル数が 0 の FLAC", testFLAC(44100, 2, 16, 0), true},
+		{"ID3 タグの後ろがゴミ", concat(testID3(map[string]string{"TIT2": "t"}), []byte("garbage garbage garbage")), true},
+		{"途中で切れた ID3 タグ", truncated(testID3(map[string]string{"TIT2": "title"}), 3), true},
+		{"同期コードが続かない MP3", concat(testMP3(1)[:4], make([]byte, 1000)), true},
+		{"途中で切れた ADTS", truncated(testADTS(10), 50), true},
+		{"最後のページが切れた Ogg", truncated(testOggVorbis(44100, 2, 1000), 10), true},
+		{"EOS の無い Ogg", oggNoEOS, true},
+		{"mdat が途中で切れた M4A", truncated(testM4A("mp4a", "soun", 44100, 44100), 100), true},
+		{"映像トラックの MP4", testM4A("avc1", "vide", 90000, 90000), false},
+		{"対応していないコーデックの M4A", testM4A("ac-3", "soun", 48000, 48000), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Probe(bytes.NewReader(tt.data), int64(len(tt.data)))
+			require.Error(t, err)
+			var corruptErr *CorruptError
+			assert.Equal(t, tt.corrupt, errors.As(err, &corruptErr), err.Error())
+			if !tt.corrupt {
+				assert.ErrorIs(t, err, ErrUnsupported)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package audio
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"time"
+)
+
+const (
+	// DefaultClipLength は長さを指定しない場合の切り出す長さ
+	DefaultClipLength = 30 * time.Second
+	// clipChunkFrames は WAV を一度に読み書きするサンプル数
+	clipChunkFrames = 4096
+)
+
+// ClipOptions は音声ファイルの一部を切り出す位置と長さ
+// 開始位置からの長さが再生時間を超える場合は、長さを保つように開始位置を前にずらす
+type ClipOptions struct {
+	Start  time.Duration // 切り出す開始位置
+	Length time.Duration // 切り出す長さ（0 の場合は DefaultClipLength）
+	Fade   time.Duration // 最初と最後のフェードイン・フェードアウトの長さ（WAV のみ）
+}
+
+// CanClip は Clip で切り出せる形式か
+func CanClip(format string) bool {
+	return format == FormatWAV || format == FormatMP3 || format == FormatAAC
+}
+
+// Clip は音声ファイルの一部を元と同じ形式で切り出して w に書き込む（デコード・再エンコードはしない）
+// WAV（PCM）はサンプル単位で切り出してフェードをかける。MP3・ADTS はフレーム単位で切り出し、タグは含めない
+// MP3 はビットリザーバーで前のフレームを参照するため、最初の数フレームが正しく再生されないことがある
+func Clip(r io.ReaderAt, size int64, w io.Writer, options ClipOptions) error {
+	head, err := readAt(r, size, 0, min(size, 12))
+	if err != nil {
+		return err
+	}
+	var offset int64
+	if bytes.HasPrefix(head, []byte("ID3")) {
+		if offset, _, err = readID3(r, size, 0); err != nil {
+			return err
+		}
+		if head, err = readAt(r, size, offset, min(size-offset, 12)); err != nil {
+			return corrupt(FormatMP3, "ID3 タグの後ろに音声がありません")
+		}
+	}
+
+	switch Sniff(head) {
+	case FormatWAV:
+		return clipWAV(r, size, w, options)
+	case FormatMP3:
+		return clipMP3(r, size, offset, w, options)
+	case FormatAAC:
+		return clipADTS(r, size, offset, w, options)
+	}
+	return ErrUnsupported
+}
+
+// clipRange は units 個（1つあたり unitSamples サンプル）のうち切り出す範囲を求める
+func clipRange(units int64, unitSamples int, sampleRate int, options ClipOptions) (start int64, count int64) {
+	length := options.Length
+	if length <= 0 {
+		length = DefaultClipLength
+	}
+	perSecond := float64(sampleRate) / float64(unitSamples)
+	count = min(int64(math.Ceil(length.Seconds()*perSecond)), units)
+	start = int64(max(options.Start.Seconds(), 0) * perSecond)
+	if start+count > units {
+		start = units - count
+	}
+	return start, count
+}
+
+// clipWAV は data チャンクのサンプルを切り出し、fmt チャンクと data チャンクだけの WAV を書き込む
+func clipWAV(r io.ReaderAt, size int64, w io.Writer, options ClipOptions) error {
+	format, _, err := ReadWAVFormat(r, size)
+	if err != nil {
+		return err
+	}
+	blockAlign := int64(format.BlockAlign)
+	start, count := clipRange(format.DataSize/blockAlign, 1, format.SampleRate, options)
+	dataSize := count * blockAlign
+
+	header := make([]byte, 44)
+	copy(header[0:], "RIFF")
+	le.PutUint32(header[4:], uint32(36+dataSize))
+	copy(header[8:], "WAVEfmt ")
+	le.PutUint32(header[16:], 16)
+	le.PutUint16(header[20:], uint16(format.AudioFormat))
+	le.PutUint16(header[22:], uint16(format.Channels))
+	le.PutUint32(header[24:], uint32(format.SampleRate))
+	le.PutUint32(header[28:], uint32(format.SampleRate*format.BlockAlign))
+	le.PutUint16(header[32:], uint16(format.BlockAlign))
+	le.PutUint16(header[34:], uint16(format.BitsPerSample))
+	copy(header[36:], "data")
+	le.PutUint32(header[40:], uint32(dataSize))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	fade := min(int64(options.Fade.Seconds()*float64(format.SampleRate)), count/2)
+	buf := make([]byte, clipChunkFrames*blockAlign)
+	for done := int64(0); done < count; {
+		frames := min(count-done, clipChunkFrames)
+		chunk := buf[:frames*blockAlign]
+		if n, err := r.ReadAt(chunk, format.DataOffset+(start+done)*blockAlign); n < len(chunk) {
+			return err
+		}
+		if fade > 0 {
+			for i := int64(0); i < frames; i++ {
+				position := done + i
+				gain := 1.0
+				if position < fade {
+					gain = float64(position) / float64(fade)
+				} else if remaining := count - 1 - position; remaining < fade {
+					gain = float64(remaining) / float64(fade)
+				}
+				if gain < 1 {
+					applyGain(chunk[i*blockAlign:(i+1)*blockAlign], format, gain)
+				}
+			}
+		}
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+		done += frames
+	}
+	return nil
+}
+
+// applyGain は1サンプル（全チャンネル）の音量を gain 倍にする
+func applyGain(frame []byte, format *WAVFormat, gain float64) {
+	width := format.BlockAlign / format.Channels
+	for pos := 0; pos+width <= len(frame); pos += width {
+		sample := frame[pos : pos+width]
+		if format.AudioFormat == wavFormatFloat {
+			switch width {
+			case 4:
+				le.PutUint32(sample, math.Float32bits(math.Float32frombits(le.Uint32(sample))*float32(gain)))
+			case 8:
+				le.PutUint64(sample, math.Float64bits(math.Float64frombits(le.Uint64(sample))*gain))
+			}
+			continue
+		}
+		switch width {
+		case 1: // 8ビットは符号なし
+			sample[0] = byte(math.Round(float64(int(sample[0])-128)*gain) + 128)
+		case 2:
+			le.PutUint16(sample, uint16(int16(math.Round(float64(int16(le.Uint16(sample)))*gain))))
+		case 3:
+			value := int32(uint32(sample[0])|uint32(sample[1])<<8|uint32(sample[2])<<16) << 8 >> 8
+			value = int32(math.Round(float64(value) * gain))
+			sample[0], sample[1], sample[2] = byte(value), byte(value>>8), byte(value>>16)
+		case 4:
+			le.PutUint32(sample, uint32(int32(math.Round(float64(int32(le.Uint32(sample)))*gain))))
+		}
+	}
+}
+
+// clipMP3 はフレームの範囲をそのまま書き込む（Xing / VBRI ヘッダーのフレームは総フレーム数が合わなくなるため含めない）
+func clipMP3(r io.ReaderAt, size int64, offset int64, w io.Writer, options ClipOptions) error {
+	pos, first, err := FirstMPEGFrame(r, size, offset)
+	if err != nil {
+		return err
+	}
+	end := audioEnd(r, size)
+	var frames []int64
+	for pos+4 <= end {
+		header, err := readAt(r, size, pos, 4)
+		if err != nil {
+			break
+		}
+		frame, ok := ParseMPEGHeader(header)
+		if !ok || pos+int64(frame.Size) > end {
+			break
+		}
+		if len(frames) > 0 || !vbrHeader(r, size, pos, frame) {
+			frames = append(frames, pos)
+		}
+		pos += int64(frame.Size)
+	}
+	if len(frames) == 0 {
+		return corrupt(FormatMP3, "フレームがありません")
+	}
+	frames = append(frames, pos)
+	return copyFrames(r, w, frames, first.Samples, first.SampleRate, options)
+}
+
+// vbrHeader は最初のフレームが音声ではなく Xing / Info / VBRI ヘッダーか
+func vbrHeader(r io.ReaderAt, size int64, pos int64, frame MPEGFrame) bool {
+	body, err := readAt(r, size, pos+4, min(int64(frame.Size)-4, 40))
+	if err != nil {
+		return false
+	}
+	return bytes.Contains(body, []byte("Xing")) || bytes.Contains(body, []byte("Info")) || bytes.Contains(body, []byte("VBRI"))
+}
+
+// clipADTS は ADTS のフレームの範囲をそのまま書き込む
+func clipADTS(r io.ReaderAt, size int64, offset int64, w io.Writer, options ClipOptions) error {
+	end := audioEnd(r, size)
+	var first ADTSFrame
+	var frames []int64
+	pos := offset
+	for pos+7 <= end {
+		header, err := readAt(r, size, pos, 7)
+		if err != nil {
+			break
+		}
+		frame, ok := parseADTSHeader(header)
+		if !ok || pos+int64(frame.Size) > end {
+			break
+		}
+		if len(frames) == 0 {
+			first = frame
+		}
+		frames = append(frames, pos)
+		pos += int64(frame.Size)
+	}
+	if len(frames) == 0 {
+		return corrupt(FormatAAC, "フレームがありません")
+	}
+	frames = append(frames, pos)
+	return copyFrames(r, w, frames, first.Samples, first.SampleRate, options)
+}
+
+// copyFrames は frames（各フレームの位置と最後のフレームの終わり）のうち切り出す範囲を書き込む
+func copyFrames(r io.ReaderAt, w io.Writer, frames []int64, samples int, sampleRate int, options ClipOptions) error {
+	start, count := clipRange(int64(len(frames)-1), samples, sampleRate, options)
+	from, to := frames[start], frames[start+count]
+	_, err := io.Copy(w, io.NewSectionReader(r, from, to-from))
+	return err
+}
@@ -0,0 +1,99 @@
+// Package audio は、音声ファイルの形式の判定と技術的なメタデータの読み取りを実装します。
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClip(t *testing.T) {
+	clip := func(t *testing.T, data []byte, options ClipOptions) []byte {
+		t.Helper()
+		var out bytes.Buffer
+		require.NoError(t, Clip(bytes.NewReader(data), int64(len(data)), &out, options))
+		return out.Bytes()
+	}
+
+	// 8kHz・16ビット・モノラルで、すべてのサンプルが 1000 の1秒の WAV
+	wav := testWAV(1, 8000, 16, 8000)
+	for i := 44; i < len(wav); i += 2 {
+		binary.LittleEndian.PutUint16(wav[i:], 1000)
+	}
+	sample := func(data []byte, i int) int16 {
+		return int16(binary.LittleEndian.Uint16(data[44+i*2:]))
+	}
+
+	t.Run("正常系: WAV をサンプル単位で切り出してフェードをかける", func(t *testing.T) {
+		out := clip(t, wav, ClipOptions{Start: 250 * time.Millisecond, Length: 500 * time.Millisecond, Fade: 100 * time.Millisecond})
+		assert.Len(t, out, 44+4000*2)
+
+		info, err := Probe(bytes.NewReader(out), int64(len(out)))
+		require.NoError(t, err)
+		assert.Equal(t, 500*time.Millisecond, info.Duration)
+		assert.Equal(t, 8000, info.SampleRate)
+
+		assert.Equal(t, int16(0), sample(out, 0))
+		assert.Equal(t, int16(500), sample(out, 400))
+		assert.Equal(t, int16(1000), sample(out, 2000))
+		assert.Equal(t, int16(0), sample(out, 3999))
+	})
+
+	t.Run("正常系: 再生時間を超える場合は開始位置を前にずらす", func(t *testing.T) {
+		wav := testWAV(1, 8000, 16, 8000)
+		for i := 0; i < 8000; i++ {
+			binary.LittleEndian.PutUint16(wav[44+i*2:], uint16(i))
+		}
+		out := clip(t, wav, ClipOptions{Start: 10 * time.Second, Length: 500 * time.Millisecond})
+		assert.Len(t, out, 44+4000*2)
+		assert.Equal(t, int16(4000), sample(out, 0))
+		assert.Equal(t, int16(7999), sample(out, 3999))
+
+		// 長さが再生時間を超える場合は全体
+		out = clip(t, wav, ClipOptions{})
+		assert.Len(t, out, len(wav))
+	})
+
+	t.Run("正常系: 24ビットの負のサンプルにフェードをかける", func(t *testing.T) {
+		format := &WAVFormat{AudioFormat: wavFormatPCM, Channels: 2, BitsPerSample: 24, BlockAlign: 6}
+		frame := []byte{0x00, 0x00, 0x80, 0xFF, 0xFF, 0x7F} // -8388608, 8388607
+		applyGain(frame, format, 0.5)
+		assert.Equal(t, []byte{0x00, 0x00, 0xC0, 0x00, 0x00, 0x40}, frame)
+	})
+
+	t.Run("正常系: MP3 をフレーム単位で切り出し、Xing ヘッダーのフレームは含めない", func(t *testing.T) {
+		mp3 := testMP3(101)
+		copy(mp3[4+32:], concat([]byte("Xing"), u32be(1), u32be(100)))
+		tagged := concat(testID3(map[string]string{"TIT2": "Song"}), mp3)
+
+		// 1秒は 44100/1152 = 38.3 フレーム
+		out := clip(t, tagged, ClipOptions{Length: time.Second})
+		assert.Equal(t, mp3[417:417+39*417], out)
+
+		out = clip(t, tagged, ClipOptions{Start: time.Minute, Length: time.Second})
+		assert.Equal(t, mp3[len(mp3)-39*417:], out)
+
+		info, err := Probe(bytes.NewReader(out), int64(len(out)))
+		require.NoError(t, err)
+		assert.InDelta(t, 39*1152/44100.0, info.Duration.Seconds(), 0.01)
+	})
+
+	t.Run("正常系: ADTS をフレーム単位で切り出す", func(t *testing.T) {
+		adts := testADTS(100)
+		out := clip(t, adts, ClipOptions{Start: time.Second, Length: time.Second})
+		// 1秒は 44100/1024 = 43.07 フレーム
+		assert.Equal(t, adts[43*200:(43+44)*200], out)
+	})
+
+	t.Run("異常系: 切り出せない形式", func(t *testing.T) {
+		flac := testFLAC(44100, 2, 16, 44100)
+		err := Clip(bytes.NewReader(flac), int64(len(flac)), &bytes.Buffer{}, ClipOptions{})
+		assert.ErrorIs(t, err, ErrUnsupported)
+		assert.False(t, CanClip(FormatFLAC))
+		assert.True(t, CanClip(FormatMP3))
+	})
+}
@@ -0,0 +1,69 @@
+package audio
+
+import "io"
+
+// FLAC のメタデータブロックの種類
+const (
+	flacBlockStreamInfo    = 0
+	flacBlockVorbisComment = 4
+	flacBlockInvalid       = 127
+)
+
+// probeFLAC は STREAMINFO と VORBIS_COMMENT を読み取り、最初のフレームの同期コードを確認する
+func probeFLAC(r io.ReaderAt, size int64, offset int64) (*Info, error) {
+	info := &Info{Format: FormatFLAC, Codec: "flac"}
+	var totalSamples int64
+	pos := offset + 4 // "fLaC"
+	for first := true; ; first = false {
+		header, err := readAt(r, size, pos, 4)
+		if err != nil {
+			return nil, corrupt(FormatFLAC, "メタデータブロックが途中で切れています")
+		}
+		last, blockType := header[0]&0x80 != 0, header[0]&0x7F
+		length := int64(header[1])<<16 | int64(header[2])<<8 | int64(header[3])
+		if blockType == flacBlockInvalid || first != (blockType == flacBlockStreamInfo) {
+			return nil, corrupt(FormatFLAC, "最初のメタデータブロックが STREAMINFO ではありません")
+		}
+
+		switch blockType {
+		case flacBlockStreamInfo:
+			if length != 34 {
+				return nil, corrupt(FormatFLAC, "STREAMINFO の長さが正しくありません")
+			}
+			b, err := readAt(r, size, pos+4, 34)
+			if err != nil {
+				return nil, corrupt(FormatFLAC, "STREAMINFO が途中で切れています")
+			}
+			// サンプルレート20ビット・チャンネル数-1 3ビット・ビット深度-1 5ビット・総サンプル数36ビット
+			info.SampleRate = int(b[10])<<12 | int(b[11])<<4 | int(b[12])>>4
+			info.Channels = int(b[12]>>1&0x07) + 1
+			info.BitDepth = (int(b[12]&0x01)<<4 | int(b[13])>>4) + 1
+			totalSamples = int64(b[13]&0x0F)<<32 | int64(be.Uint32(b[14:]))
+		case flacBlockVorbisComment:
+			if length <= maxTagBytes {
+				comment, err := readAt(r, size, pos+4, length)
+				if err != nil {
+					return nil, corrupt(FormatFLAC, "VORBIS_COMMENT が途中で切れています")
+				}
+				info.Tags = parseVorbisComment(comment)
+			}
+		}
+
+		pos += 4 + length
+		if last {
+			break
+		}
+	}
+
+	// 最初のフレームは同期コード 0b11111111111110 で始まる
+	sync, err := readAt(r, size, pos, 2)
+	if err != nil || sync[0] != 0xFF || sync[1]&0xFE != 0xF8 {
+		return nil, corrupt(FormatFLAC, "メタデータの後ろにフレームがありません")
+	}
+	if info.SampleRate == 0 || totalSamples == 0 {
+		return nil, corrupt(FormatFLAC, "STREAMINFO にサンプルレートまたは総サンプル数がありません")
+	}
+	info.Duration = samplesDuration(totalSamples, info.SampleRate)
+	info.Bitrate = bitrate(size-pos, info.Duration)
+	return info, nil
+}
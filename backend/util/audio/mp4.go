@@ -0,0 +1,233 @@
+package audio
+
+import (
+	"io"
+	"strings"
+)
+
+// mp4Codecs はサンプルエントリーの種類とコーデック
+var mp4Codecs = map[string]string{
+	"mp4a": "aac",
+	"alac": "alac",
+	"fLaC": "flac",
+}
+
+// mp4Tags は iTunes 形式のメタデータ（moov/udta/meta/ilst）のキーとタグの名前
+var mp4Tags = map[string]string{
+	"\xa9nam": "title",
+	"\xa9ART": "artist",
+	"\xa9alb": "album",
+	"aART":    "album_artist",
+	"\xa9gen": "genre",
+	"\xa9day": "date",
+	"\xa9wrt": "composer",
+}
+
+// mp4Box は MP4 のボックスの位置
+type mp4Box struct {
+	kind string
+	body int64 // 本文の位置
+	end  int64 // 次のボックスの位置
+}
+
+// readBoxes は start から end までのボックスを読み取る
+func readBoxes(r io.ReaderAt, size int64, start int64, end int64) ([]mp4Box, error) {
+	var boxes []mp4Box
+	for pos := start; pos+8 <= end; {
+		header, err := readAt(r, size, pos, 8)
+		if err != nil {
+			return nil, err
+		}
+		box := mp4Box{kind: string(header[4:8]), body: pos + 8}
+		switch length := int64(be.Uint32(header)); length {
+		case 0: // ファイルの終わりまで
+			box.end = end
+		case 1: // 64ビットのサイズ
+			large, err := readAt(r, size, pos+8, 8)
+			if err != nil {
+				return nil, corrupt(FormatM4A, "%s ボックスが途中で切れています", box.kind)
+			}
+			box.body, box.end = pos+16, pos+int64(be.Uint64(large))
+		default:
+			box.end = pos + length
+		}
+		if box.end < box.body || box.end > end {
+			return nil, corrupt(FormatM4A, "%s ボックスが途中で切れています", box.kind)
+		}
+		boxes = append(boxes, box)
+		pos = box.end
+	}
+	return boxes, nil
+}
+
+// findBox は path の順にボックスをたどる
+func findBox(r io.ReaderAt, size int64, parent mp4Box, path ...string) (mp4Box, bool) {
+	box := parent
+	for _, kind := range path {
+		children, err := readBoxes(r, size, box.body, box.end)
+		if err != nil {
+			return mp4Box{}, false
+		}
+		found := false
+		for _, child := range children {
+			if child.kind == kind {
+				box, found = child, true
+				break
+			}
+		}
+		if !found {
+			return mp4Box{}, false
+		}
+	}
+	return box, true
+}
+
+// readTimes は mvhd / mdhd の timescale と duration を読み取る
+func readTimes(r io.ReaderAt, size int64, box mp4Box) (timescale int64, duration int64) {
+	version, err := readAt(r, size, box.body, 1)
+	if err != nil {
+		return 0, 0
+	}
+	if version[0] == 1 {
+		if b, err := readAt(r, size, box.body+20, 12); err == nil {
+			return int64(be.Uint32(b)), int64(be.Uint64(b[4:]))
+		}
+		return 0, 0
+	}
+	if b, err := readAt(r, size, box.body+12, 8); err == nil {
+		return int64(be.Uint32(b)), int64(be.Uint32(b[4:]))
+	}
+	return 0, 0
+}
+
+// probeM4A は音声トラックの mdhd と stsd を読み取る。映像トラックを含むファイルは対応しない
+func probeM4A(r io.ReaderAt, size int64) (*Info, error) {
+	boxes, err := readBoxes(r, size, 0, size)
+	if err != nil {
+		return nil, err
+	}
+	file := mp4Box{body: 0, end: size}
+	moov, ok := findBox(r, size, file, "moov")
+	if !ok {
+		return nil, corrupt(FormatM4A, "moov ボックスがありません")
+	}
+	var mediaSize int64
+	for _, box := range boxes {
+		if box.kind == "mdat" {
+			mediaSize += box.end - box.body
+		}
+	}
+	if mediaSize == 0 {
+		return nil, corrupt(FormatM4A, "mdat ボックスがありません")
+	}
+
+	tracks, err := readBoxes(r, size, moov.body, moov.end)
+	if err != nil {
+		return nil, err
+	}
+	info := &Info{Format: FormatM4A}
+	var timescale, duration int64
+	for _, trak := range tracks {
+		if trak.kind != "trak" {
+			continue
+		}
+		hdlr, ok := findBox(r, size, trak, "mdia", "hdlr")
+		if !ok {
+			continue
+		}
+		handler, err := readAt(r, size, hdlr.body+8, 4)
+		if err != nil {
+			continue
+		}
+		switch string(handler) {
+		case "vide":
+			return nil, ErrUnsupported
+		case "soun":
+		default:
+			continue
+		}
+		if info.Codec != "" {
+			continue
+		}
+
+		if mdhd, ok := findBox(r, size, trak, "mdia", "mdhd"); ok {
+			timescale, duration = readTimes(r, size, mdhd)
+		}
+		stsd, ok := findBox(r, size, trak, "mdia", "minf", "stbl", "stsd")
+		if !ok {
+			return nil, corrupt(FormatM4A, "stsd ボックスがありません")
+		}
+		// 最初のサンプルエントリー（AudioSampleEntry）
+		entry, err := readAt(r, size, stsd.body+8, 36)
+		if err != nil {
+			return nil, corrupt(FormatM4A, "stsd ボックスが途中で切れています")
+		}
+		codec, ok := mp4Codecs[string(entry[4:8])]
+		if !ok {
+			return nil, ErrUnsupported
+		}
+		info.Codec = codec
+		info.Channels = int(be.Uint16(entry[24:]))
+		if codec != "aac" {
+			info.BitDepth = int(be.Uint16(entry[26:]))
+		}
+		// 16.16 の固定小数点（65535Hz を超える場合は 0 になるため mdhd の timescale を使う）
+		info.SampleRate = int(be.Uint32(entry[32:]) >> 16)
+	}
+	if info.Codec == "" {
+		return nil, corrupt(FormatM4A, "音声トラックがありません")
+	}
+	if info.SampleRate == 0 {
+		info.SampleRate = int(timescale)
+	}
+	if duration == 0 || timescale == 0 {
+		if mvhd, ok := findBox(r, size, moov, "mvhd"); ok {
+			timescale, duration = readTimes(r, size, mvhd)
+		}
+	}
+	if timescale == 0 {
+		return nil, corrupt(FormatM4A, "timescale がありません")
+	}
+	info.Duration = samplesDuration(duration, int(timescale))
+	info.Bitrate = bitrate(mediaSize, info.Duration)
+	info.Tags = readMP4Tags(r, size, moov)
+	return info, nil
+}
+
+// readMP4Tags は moov/udta/meta/ilst のテキストのメタデータを読み取る
+func readMP4Tags(r io.ReaderAt, size int64, moov mp4Box) map[string]string {
+	meta, ok := findBox(r, size, moov, "udta", "meta")
+	if !ok {
+		return nil
+	}
+	// meta はフルボックス（バージョンとフラグの4バイト）
+	meta.body += 4
+	ilst, ok := findBox(r, size, meta, "ilst")
+	if !ok || ilst.end-ilst.body > maxTagBytes {
+		return nil
+	}
+	items, err := readBoxes(r, size, ilst.body, ilst.end)
+	if err != nil {
+		return nil
+	}
+	tags := map[string]string{}
+	for _, item := range items {
+		name, ok := mp4Tags[item.kind]
+		if !ok {
+			continue
+		}
+		data, ok := findBox(r, size, item, "data")
+		if !ok || data.end-data.body < 8 {
+			continue
+		}
+		// 型（1 は UTF-8）とロケールの8バイトの後ろが値
+		value, err := readAt(r, size, data.body, data.end-data.body)
+		if err != nil || be.Uint32(value)&0xFFFFFF != 1 {
+			continue
+		}
+		if text := tagValue(strings.TrimRight(string(value[8:]), "\x00")); text != "" {
+			tags[name] = text
+		}
+	}
+	return tags
+}
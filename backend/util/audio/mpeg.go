@@ -0,0 +1,213 @@
+package audio
+
+import (
+	"bytes"
+	"io"
+)
+
+// maxFrameSearch は最初のフレームを探す範囲（先頭のゴミや埋め込みのデータを飛ばす）
+const maxFrameSearch = 64 << 10
+
+// Layer III のビットレート（kbps）。MPEG-1 と MPEG-2/2.5
+var (
+	mpeg1Bitrates = [16]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0}
+	mpeg2Bitrates = [16]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0}
+	mpegRates     = map[int][3]int{1: {44100, 48000, 32000}, 2: {22050, 24000, 16000}, 25: {11025, 12000, 8000}}
+	adtsRates     = [13]int{96000, 88200, 64000, 48000, 44100, 32000, 24000, 22050, 16000, 12000, 11025, 8000, 7350}
+)
+
+// MPEGFrame は MPEG Audio Layer III（MP3）のフレームヘッダー
+type MPEGFrame struct {
+	Version    int // 1 / 2 / 25（MPEG 2.5）
+	Bitrate    int // bps
+	SampleRate int
+	Channels   int
+	Size       int // ヘッダーを含むフレームのバイト数
+	Samples    int // フレームあたりのサンプル数
+}
+
+// ParseMPEGHeader はフレームヘッダー（4バイト）を読み取る（Layer III 以外とフリーフォーマットは対応しない）
+func ParseMPEGHeader(b []byte) (MPEGFrame, bool) {
+	if len(b) < 4 || b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		return MPEGFrame{}, false
+	}
+	var frame MPEGFrame
+	switch b[1] >> 3 & 0x03 {
+	case 0:
+		frame.Version = 25
+	case 2:
+		frame.Version = 2
+	case 3:
+		frame.Version = 1
+	default:
+		return MPEGFrame{}, false
+	}
+	if b[1]>>1&0x03 != 1 { // Layer III
+		return MPEGFrame{}, false
+	}
+	bitrateIndex, rateIndex, padding := b[2]>>4, b[2]>>2&0x03, int(b[2]>>1&0x01)
+	if rateIndex == 3 {
+		return MPEGFrame{}, false
+	}
+	frame.SampleRate = mpegRates[frame.Version][rateIndex]
+	frame.Samples = 576
+	if frame.Version == 1 {
+		frame.Bitrate = mpeg1Bitrates[bitrateIndex] * 1000
+		frame.Samples = 1152
+	} else {
+		frame.Bitrate = mpeg2Bitrates[bitrateIndex] * 1000
+	}
+	if frame.Bitrate == 0 {
+		return MPEGFrame{}, false
+	}
+	frame.Size = frame.Samples/8*frame.Bitrate/frame.SampleRate + padding
+	frame.Channels = 2
+	if b[3]>>6 == 3 {
+		frame.Channels = 1
+	}
+	return frame, true
+}
+
+// FirstMPEGFrame は offset から最初のフレームを探す
+// 偶然の同期コードと区別するため、次のフレームのヘッダーも正しいもの（またはファイルの終わり）だけを選ぶ
+func FirstMPEGFrame(r io.ReaderAt, size int64, offset int64) (int64, MPEGFrame, error) {
+	window, err := readAt(r, size, offset, min(size-offset, maxFrameSearch))
+	if err != nil {
+		return 0, MPEGFrame{}, err
+	}
+	for i := 0; i+4 <= len(window); i++ {
+		frame, ok := ParseMPEGHeader(window[i:])
+		if !ok {
+			continue
+		}
+		pos := offset + int64(i)
+		next := pos + int64(frame.Size)
+		if next == size {
+			return pos, frame, nil
+		}
+		if header, err := readAt(r, size, next, 4); err == nil {
+			if nextFrame, ok := ParseMPEGHeader(header); ok && nextFrame.Version == frame.Version && nextFrame.SampleRate == frame.SampleRate {
+				return pos, frame, nil
+			}
+		}
+	}
+	return 0, MPEGFrame{}, corrupt(FormatMP3, "フレームが見つかりません")
+}
+
+// probeMP3 は最初のフレームの Xing / VBRI ヘッダーから可変ビットレートの再生時間を求める
+// どちらも無い場合は固定ビットレートとして音声のバイト数から求める
+func probeMP3(r io.ReaderAt, size int64, offset int64) (*Info, error) {
+	pos, frame, err := FirstMPEGFrame(r, size, offset)
+	if err != nil {
+		return nil, err
+	}
+	end := audioEnd(r, size)
+
+	var frames int64
+	// サイド情報の後ろに Xing（Info）ヘッダー、ヘッダーから32バイト後ろに VBRI ヘッダーがある
+	sideInfo := 32
+	if frame.Version == 1 && frame.Channels == 1 || frame.Version != 1 && frame.Channels == 2 {
+		sideInfo = 17
+	} else if frame.Version != 1 {
+		sideInfo = 9
+	}
+	if xing, err := readAt(r, size, pos+4+int64(sideInfo), 12); err == nil && (bytes.HasPrefix(xing, []byte("Xing")) || bytes.HasPrefix(xing, []byte("Info"))) {
+		if be.Uint32(xing[4:])&0x01 != 0 {
+			frames = int64(be.Uint32(xing[8:]))
+		}
+	} else if vbri, err := readAt(r, size, pos+4+32, 18); err == nil && bytes.HasPrefix(vbri, []byte("VBRI")) {
+		frames = int64(be.Uint32(vbri[14:]))
+	}
+
+	info := &Info{
+		Format:     FormatMP3,
+		Codec:      "mp3",
+		SampleRate: frame.SampleRate,
+		Channels:   frame.Channels,
+	}
+	if frames > 0 {
+		info.Duration = samplesDuration(frames*int64(frame.Samples), frame.SampleRate)
+		info.Bitrate = bitrate(end-pos, info.Duration)
+	} else {
+		info.Bitrate = frame.Bitrate
+		info.Duration = samplesDuration((end-pos)*8, frame.Bitrate)
+	}
+	return info, nil
+}
+
+// ADTSFrame は AAC の ADTS フレームヘッダー
+type ADTSFrame struct {
+	SampleRate int
+	Channels   int
+	Size       int // ヘッダーを含むフレームのバイト数
+	Samples    int // フレームあたりのサンプル数
+}
+
+func parseADTSHeader(b []byte) (ADTSFrame, bool) {
+	// 同期コード12ビット・layer は 00
+	if len(b) < 7 || b[0] != 0xFF || b[1]&0xF6 != 0xF0 {
+		return ADTSFrame{}, false
+	}
+	rateIndex := int(b[2] >> 2 & 0x0F)
+	if rateIndex >= len(adtsRates) {
+		return ADTSFrame{}, false
+	}
+	frame := ADTSFrame{
+		SampleRate: adtsRates[rateIndex],
+		Channels:   int(b[2]&0x01)<<2 | int(b[3]>>6),
+		Size:       int(b[3]&0x03)<<11 | int(b[4])<<3 | int(b[5]>>5),
+		Samples:    1024 * (int(b[6]&0x03) + 1),
+	}
+	headerSize := 9
+	if b[1]&0x01 != 0 { // CRC なし
+		headerSize = 7
+	}
+	if frame.Size < headerSize {
+		return ADTSFrame{}, false
+	}
+	return frame, true
+}
+
+// probeADTS はすべてのフレームのヘッダーをたどってサンプル数を数える
+func probeADTS(r io.ReaderAt, size int64, offset int64) (*Info, error) {
+	end := audioEnd(r, size)
+	var first ADTSFrame
+	var samples int64
+	pos := offset
+	for pos < end {
+		header, err := readAt(r, size, pos, 7)
+		if err != nil {
+			return nil, corrupt(FormatAAC, "フレームが途中で切れています")
+		}
+		frame, ok := parseADTSHeader(header)
+		if !ok || pos+int64(frame.Size) > end {
+			return nil, corrupt(FormatAAC, "%d バイト目のフレームが正しくありません", pos)
+		}
+		if samples == 0 {
+			first = frame
+		}
+		samples += int64(frame.Samples)
+		pos += int64(frame.Size)
+	}
+	if samples == 0 {
+		return nil, corrupt(FormatAAC, "フレームがありません")
+	}
+
+	info := &Info{
+		Format:     FormatAAC,
+		Codec:      "aac",
+		SampleRate: first.SampleRate,
+		Channels:   first.Channels,
+		Duration:   samplesDuration(samples, first.SampleRate),
+	}
+	info.Bitrate = bitrate(end-offset, info.Duration)
+	return info, nil
+}
+
+// audioEnd は末尾の ID3v1 タグ（128バイト）を除いた音声の終わり
+func audioEnd(r io.ReaderAt, size int64) int64 {
+	if tag, err := readAt(r, size, size-128, 3); err == nil && string(tag) == "TAG" {
+		return size - 128
+	}
+	return size
+}
@@ -0,0 +1,163 @@
+package audio
+
+import (
+	"bytes"
+	"io"
+)
+
+const (
+	// oggTailSearch は最後のページを探す範囲（ページは最大で約64KiB）
+	oggTailSearch = 65307 + 1024
+	// oggHeaderEOS は論理ストリームの最後のページ
+	oggHeaderEOS = 0x04
+	// opusGranuleRate は Opus のグラニュール位置のサンプルレート（元のサンプルレートに関わらず48kHz）
+	opusGranuleRate = 48000
+)
+
+// oggPage は Ogg のページヘッダー
+type oggPage struct {
+	headerType byte
+	granule    int64
+	serial     uint32
+	segments   []byte // 各セグメントの長さ
+	body       int64  // 本文の位置
+	end        int64  // 次のページの位置
+}
+
+func readOggPage(r io.ReaderAt, size int64, pos int64) (*oggPage, error) {
+	header, err := readAt(r, size, pos, 27)
+	if err != nil || string(header[:4]) != "OggS" || header[4] != 0 {
+		return nil, corrupt(FormatOgg, "%d バイト目のページが正しくありません", pos)
+	}
+	segments, err := readAt(r, size, pos+27, int64(header[26]))
+	if err != nil {
+		return nil, corrupt(FormatOgg, "ページが途中で切れています")
+	}
+	page := &oggPage{
+		headerType: header[5],
+		granule:    int64(le.Uint64(header[6:])),
+		serial:     le.Uint32(header[14:]),
+		segments:   segments,
+		body:       pos + 27 + int64(len(segments)),
+	}
+	page.end = page.body
+	for _, length := range segments {
+		page.end += int64(length)
+	}
+	if page.end > size {
+		return nil, corrupt(FormatOgg, "ページが途中で切れています")
+	}
+	return page, nil
+}
+
+// probeOgg は最初の論理ストリームの識別ヘッダーとコメントヘッダーを読み取り、
+// 最後のページのグラニュール位置から再生時間を求める
+func probeOgg(r io.ReaderAt, size int64) (*Info, error) {
+	first, err := readOggPage(r, size, 0)
+	if err != nil {
+		return nil, err
+	}
+	packets, err := oggHeaderPackets(r, size, first.serial)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Info{Format: FormatOgg}
+	var rate int
+	var preSkip int64
+	ident := packets[0]
+	switch {
+	case len(ident) >= 30 && bytes.HasPrefix(ident, []byte("\x01vorbis")):
+		info.Codec = "vorbis"
+		info.Channels = int(ident[11])
+		info.SampleRate = int(le.Uint32(ident[12:]))
+		rate = info.SampleRate
+		if len(packets) > 1 && bytes.HasPrefix(packets[1], []byte("\x03vorbis")) {
+			info.Tags = parseVorbisComment(packets[1][7:])
+		}
+	case len(ident) >= 19 && bytes.HasPrefix(ident, []byte("OpusHead")):
+		info.Codec = "opus"
+		info.Channels = int(ident[9])
+		info.SampleRate = int(le.Uint32(ident[12:]))
+		rate = opusGranuleRate
+		preSkip = int64(le.Uint16(ident[10:]))
+		if len(packets) > 1 && bytes.HasPrefix(packets[1], []byte("OpusTags")) {
+			info.Tags = parseVorbisComment(packets[1][8:])
+		}
+	default:
+		// FLAC in Ogg・Speex などは対応しない
+		return nil, ErrUnsupported
+	}
+	if info.Channels == 0 || rate == 0 {
+		return nil, corrupt(FormatOgg, "識別ヘッダーの値が正しくありません")
+	}
+
+	granule, err := oggLastGranule(r, size, first.serial)
+	if err != nil {
+		return nil, err
+	}
+	info.Duration = samplesDuration(granule-preSkip, rate)
+	info.Bitrate = bitrate(size, info.Duration)
+	return info, nil
+}
+
+// oggHeaderPackets は識別ヘッダーとコメントヘッダーのパケットを返す
+// コメントヘッダーが大きすぎる場合は識別ヘッダーだけを返す
+func oggHeaderPackets(r io.ReaderAt, size int64, serial uint32) ([][]byte, error) {
+	var packets [][]byte
+	var packet []byte
+	for pos := int64(0); pos < size && len(packets) < 2; {
+		page, err := readOggPage(r, size, pos)
+		if err != nil {
+			return nil, err
+		}
+		if page.serial == serial {
+			body, err := readAt(r, size, page.body, page.end-page.body)
+			if err != nil {
+				return nil, err
+			}
+			for _, length := range page.segments {
+				packet = append(packet, body[:length]...)
+				body = body[length:]
+				// 255 未満のセグメントでパケットが終わる
+				if length < 255 {
+					packets = append(packets, packet)
+					packet = nil
+				}
+			}
+			if len(packet) > maxTagBytes {
+				break
+			}
+		}
+		pos = page.end
+	}
+	if len(packets) == 0 {
+		return nil, corrupt(FormatOgg, "識別ヘッダーがありません")
+	}
+	return packets, nil
+}
+
+// oggLastGranule はファイルの末尾にある最後のページのグラニュール位置を返す
+func oggLastGranule(r io.ReaderAt, size int64, serial uint32) (int64, error) {
+	start := max(size-oggTailSearch, 0)
+	tail, err := readAt(r, size, start, size-start)
+	if err != nil {
+		return 0, err
+	}
+	last := true
+	for i := bytes.LastIndex(tail, []byte("OggS")); i >= 0; i = bytes.LastIndex(tail[:i], []byte("OggS")) {
+		page, err := readOggPage(r, size, start+int64(i))
+		if err != nil {
+			continue
+		}
+		// ファイルの最後のページが途中で切れていないことを確認する
+		if last && (page.end != size || page.headerType&oggHeaderEOS == 0) {
+			return 0, corrupt(FormatOgg, "最後のページがありません（ファイルが途中で切れています）")
+		}
+		last = false
+		if page.serial == serial && page.granule > 0 {
+			return page.granule, nil
+		}
+	}
+	return 0, corrupt(FormatOgg, "最後のページがありません（ファイルが途中で切れています）")
+}
@@ -0,0 +1,227 @@
+package audio
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const (
+	// maxTagBytes はタグを読み取る上限（大きなカバー画像を含むタグは読まずに飛ばす）
+	maxTagBytes = 16 << 20
+	// maxTagValue はタグの値の上限（バイト）
+	maxTagValue = 1024
+)
+
+// id3Frames は ID3v2 のテキストフレームとタグの名前（v2.2 の3文字のIDを含む）
+var id3Frames = map[string]string{
+	"TIT2": "title", "TT2": "title",
+	"TPE1": "artist", "TP1": "artist",
+	"TALB": "album", "TAL": "album",
+	"TPE2": "album_artist", "TP2": "album_artist",
+	"TCON": "genre", "TCO": "genre",
+	"TRCK": "track", "TRK": "track",
+	"TDRC": "date", "TYER": "date", "TYE": "date",
+	"TCOM": "composer", "TCM": "composer",
+	"TSRC": "isrc", "TRC": "isrc",
+}
+
+// vorbisKeys は Vorbis コメントのフィールド名とタグの名前
+var vorbisKeys = map[string]string{
+	"TITLE":       "title",
+	"ARTIST":      "artist",
+	"ALBUM":       "album",
+	"ALBUMARTIST": "album_artist",
+	"GENRE":       "genre",
+	"TRACKNUMBER": "track",
+	"DATE":        "date",
+	"COMPOSER":    "composer",
+	"ISRC":        "isrc",
+}
+
+// readID3 は off にある ID3v2 タグを読み取り、タグ全体の長さとタグを返す
+func readID3(r io.ReaderAt, size int64, off int64) (int64, map[string]string, error) {
+	header, err := readAt(r, size, off, 10)
+	if err != nil {
+		return 0, nil, corrupt(FormatMP3, "ID3 タグのヘッダーが途中で切れています")
+	}
+	major, flags := header[3], header[5]
+	if major < 2 || major > 4 {
+		return 0, nil, corrupt(FormatMP3, "ID3v2.%d には対応していません", major)
+	}
+	tagSize, ok := syncsafe(header[6:10])
+	if !ok {
+		return 0, nil, corrupt(FormatMP3, "ID3 タグのサイズが正しくありません")
+	}
+	length := 10 + tagSize
+	if flags&0x10 != 0 {
+		length += 10 // フッター
+	}
+	if off+length > size {
+		return 0, nil, corrupt(FormatMP3, "ID3 タグが途中で切れています")
+	}
+	if tagSize > maxTagBytes {
+		return length, nil, nil
+	}
+
+	body, err := readAt(r, size, off+10, tagSize)
+	if err != nil {
+		return 0, nil, err
+	}
+	if flags&0x80 != 0 {
+		// 非同期化（0xFF 0x00）を戻す
+		body = bytes.ReplaceAll(body, []byte{0xFF, 0x00}, []byte{0xFF})
+	}
+	return length, parseID3Frames(body, major, flags), nil
+}
+
+func parseID3Frames(body []byte, major byte, flags byte) map[string]string {
+	pos := 0
+	if flags&0x40 != 0 && major >= 3 && len(body) >= 4 {
+		// 拡張ヘッダー（v2.3 は長さを含まない、v2.4 は含む）
+		if major == 3 {
+			pos = 4 + int(be.Uint32(body))
+		} else if extSize, ok := syncsafe(body[:4]); ok {
+			pos = int(extSize)
+		}
+	}
+
+	idLen, headerLen := 4, 10
+	if major == 2 {
+		idLen, headerLen = 3, 6
+	}
+	tags := map[string]string{}
+	for pos+headerLen <= len(body) && body[pos] != 0 {
+		id := string(body[pos : pos+idLen])
+		var frameSize int
+		switch major {
+		case 2:
+			frameSize = int(body[pos+3])<<16 | int(body[pos+4])<<8 | int(body[pos+5])
+		case 3:
+			frameSize = int(be.Uint32(body[pos+4:]))
+		default:
+			size, ok := syncsafe(body[pos+4 : pos+8])
+			if !ok {
+				return tags
+			}
+			frameSize = int(size)
+		}
+		data := pos + headerLen
+		if frameSize < 0 || data+frameSize > len(body) {
+			break
+		}
+		if name, ok := id3Frames[id]; ok {
+			if text := decodeID3Text(body[data : data+frameSize]); text != "" {
+				tags[name] = text
+			}
+		}
+		pos = data + frameSize
+	}
+	return tags
+}
+
+// decodeID3Text はテキストフレームの値を読み取る（複数の値は最初の値だけ）
+func decodeID3Text(data []byte) string {
+	if len(data) < 2 {
+		return ""
+	}
+	encoding, text := data[0], data[1:]
+	var value string
+	switch encoding {
+	case 0: // ISO-8859-1
+		runes := make([]rune, 0, len(text))
+		for _, b := range text {
+			if b == 0 {
+				break
+			}
+			runes = append(runes, rune(b))
+		}
+		value = string(runes)
+	case 1, 2: // UTF-16（BOM あり）/ UTF-16BE
+		value = decodeUTF16(text, encoding == 2)
+	case 3: // UTF-8
+		value, _, _ = strings.Cut(string(text), "\x00")
+	}
+	return tagValue(value)
+}
+
+func decodeUTF16(text []byte, bigEndian bool) string {
+	if len(text) >= 2 && text[0] == 0xFF && text[1] == 0xFE {
+		text, bigEndian = text[2:], false
+	} else if len(text) >= 2 && text[0] == 0xFE && text[1] == 0xFF {
+		text, bigEndian = text[2:], true
+	}
+	units := make([]uint16, 0, len(text)/2)
+	for i := 0; i+1 < len(text); i += 2 {
+		unit := le.Uint16(text[i:])
+		if bigEndian {
+			unit = be.Uint16(text[i:])
+		}
+		if unit == 0 {
+			break
+		}
+		units = append(units, unit)
+	}
+	return string(utf16.Decode(units))
+}
+
+// parseVorbisComment は Vorbis コメント（FLAC・Ogg Vorbis・Opus のタグ）を読み取る
+func parseVorbisComment(data []byte) map[string]string {
+	tags := map[string]string{}
+	if len(data) < 8 {
+		return tags
+	}
+	pos := 4 + int(le.Uint32(data))
+	if pos < 4 || pos+4 > len(data) {
+		return tags
+	}
+	count := int(le.Uint32(data[pos:]))
+	pos += 4
+	for i := 0; i < count && pos+4 <= len(data); i++ {
+		length := int(le.Uint32(data[pos:]))
+		pos += 4
+		if length < 0 || pos+length > len(data) {
+			break
+		}
+		key, value, ok := strings.Cut(string(data[pos:pos+length]), "=")
+		pos += length
+		if !ok {
+			continue
+		}
+		if name, ok := vorbisKeys[strings.ToUpper(key)]; ok {
+			if _, exists := tags[name]; !exists {
+				if value = tagValue(value); value != "" {
+					tags[name] = value
+				}
+			}
+		}
+	}
+	return tags
+}
+
+// tagValue は値の前後の空白を除き、上限を超える値を文字の境目で切り詰める
+func tagValue(value string) string {
+	value = strings.TrimSpace(strings.ToValidUTF8(value, ""))
+	if len(value) <= maxTagValue {
+		return value
+	}
+	cut := maxTagValue
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return value[:cut]
+}
+
+// syncsafe は7ビットずつの整数（ID3v2 のサイズ）を読み取る
+func syncsafe(b []byte) (int64, bool) {
+	var size int64
+	for _, v := range b {
+		if v&0x80 != 0 {
+			return 0, false
+		}
+		size = size<<7 | int64(v)
+	}
+	return size, true
+}
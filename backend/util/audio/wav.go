@@ -0,0 +1,110 @@
+package audio
+
+import "io"
+
+// WAV の fmt チャンクのフォーマット
+const (
+	wavFormatPCM        = 1
+	wavFormatFloat      = 3
+	wavFormatExtensible = 0xFFFE
+)
+
+// WAVFormat は WAV の fmt チャンクと data チャンクの位置
+type WAVFormat struct {
+	AudioFormat   int
+	Channels      int
+	SampleRate    int
+	BitsPerSample int
+	BlockAlign    int   // 1サンプル（全チャンネル）のバイト数
+	DataOffset    int64 // data チャンクの本文の位置
+	DataSize      int64 // data チャンクの本文のバイト数
+}
+
+// ReadWAVFormat は RIFF のチャンクをたどって fmt チャンクと data チャンクを読み取る
+func ReadWAVFormat(r io.ReaderAt, size int64) (*WAVFormat, map[string]string, error) {
+	header, err := readAt(r, size, 0, 12)
+	if err != nil || string(header[:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return nil, nil, ErrUnsupported
+	}
+
+	var format *WAVFormat
+	var tags map[string]string
+	dataFound := false
+	for pos := int64(12); pos+8 <= size; {
+		chunk, err := readAt(r, size, pos, 8)
+		if err != nil {
+			return nil, nil, err
+		}
+		id, length := string(chunk[:4]), int64(le.Uint32(chunk[4:]))
+		body := pos + 8
+		switch id {
+		case "fmt ":
+			if length < 16 {
+				return nil, nil, corrupt(FormatWAV, "fmt チャンクが短すぎます")
+			}
+			fmtChunk, err := readAt(r, size, body, min(length, 40))
+			if err != nil {
+				return nil, nil, corrupt(FormatWAV, "fmt チャンクが途中で切れています")
+			}
+			format = &WAVFormat{
+				AudioFormat:   int(le.Uint16(fmtChunk[0:])),
+				Channels:      int(le.Uint16(fmtChunk[2:])),
+				SampleRate:    int(le.Uint32(fmtChunk[4:])),
+				BlockAlign:    int(le.Uint16(fmtChunk[12:])),
+				BitsPerSample: int(le.Uint16(fmtChunk[14:])),
+			}
+			if format.AudioFormat == wavFormatExtensible && len(fmtChunk) >= 26 {
+				// WAVE_FORMAT_EXTENSIBLE はサブフォーマットの GUID の先頭2バイトが実際のフォーマット
+				format.AudioFormat = int(le.Uint16(fmtChunk[24:]))
+			}
+		case "data":
+			if format == nil {
+				return nil, nil, corrupt(FormatWAV, "fmt チャンクより前に data チャンクがあります")
+			}
+			if body+length > size {
+				return nil, nil, corrupt(FormatWAV, "data チャンクが途中で切れています")
+			}
+			format.DataOffset, format.DataSize = body, length
+			dataFound = true
+		case "id3 ", "ID3 ":
+			if _, id3Tags, err := readID3(io.NewSectionReader(r, body, length), length, 0); err == nil {
+				tags = id3Tags
+			}
+		}
+		// チャンクは2バイト境界に揃える
+		pos = body + length + length&1
+	}
+
+	if format == nil || !dataFound {
+		return nil, nil, corrupt(FormatWAV, "fmt チャンクまたは data チャンクがありません")
+	}
+	if format.AudioFormat != wavFormatPCM && format.AudioFormat != wavFormatFloat {
+		return nil, nil, ErrUnsupported
+	}
+	if format.Channels <= 0 || format.SampleRate <= 0 || format.BitsPerSample <= 0 ||
+		format.BlockAlign != format.Channels*((format.BitsPerSample+7)/8) {
+		return nil, nil, corrupt(FormatWAV, "fmt チャンクの値が正しくありません")
+	}
+	return format, tags, nil
+}
+
+func probeWAV(r io.ReaderAt, size int64) (*Info, error) {
+	format, tags, err := ReadWAVFormat(r, size)
+	if err != nil {
+		return nil, err
+	}
+	codec := "pcm"
+	if format.AudioFormat == wavFormatFloat {
+		codec = "float"
+	}
+	return &Info{
+		Format:     FormatWAV,
+		Codec:      codec,
+		Duration:   samplesDuration(format.DataSize/int64(format.BlockAlign), format.SampleRate),
+		SampleRate: format.SampleRate,
+		BitDepth:   format.BitsPerSample,
+		Channels:   format.Channels,
+		Bitrate:    format.SampleRate * format.BlockAlign * 8,
+		Tags:       tags,
+	}, nil
+}
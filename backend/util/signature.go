@@ -0,0 +1,69 @@
+// Package util は、共通のユーティリティ関数を提供します。
+package util
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// RecoverPersonalSign は personal_sign (EIP-191) の署名から署名者のアドレスを復元します。
+func RecoverPersonalSign(message string, signature string) (common.Address, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return common.Address{}, errors.New("BadRequest: 署名の形式が不正です")
+	}
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, errors.New("BadRequest: 署名の長さが不正です")
+	}
+
+	// ウォレットは v を 27/28 で返すため 0/1 に戻す
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(accounts.TextHash([]byte(message)), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// RecoverTypedData は eth_signTypedData_v4 (EIP-712) の署名から署名者のアドレスを復元します。
+func RecoverTypedData(typedData apitypes.TypedData, signature string) (common.Address, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return common.Address{}, errors.New("BadRequest: 署名の形式が不正です")
+	}
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, errors.New("BadRequest: 署名の長さが不正です")
+	}
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return common.Address{}, err
+	}
+	publicKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// SameAddress はウォレットアドレスを大文字小文字を区別せずに比較します。
+func SameAddress(a string, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+// IsChecksumAddress はアドレスが EIP-55 のチェックサム付きで書かれているか確認します。
+func IsChecksumAddress(address string) bool {
+	return common.IsHexAddress(address) && common.HexToAddress(address).Hex() == address
+}
@@ -0,0 +1,110 @@
+// Package util は、共通のユーティリティ関数を提供します。
+package util
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverPersonalSign(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	message := "music.threenext.com wants you to sign in with your Ethereum account"
+
+	sig, err := crypto.Sign(accounts.TextHash([]byte(message)), privateKey)
+	require.NoError(t, err)
+	sig[crypto.RecoveryIDOffset] += 27 // ウォレットと同じ形式にする
+
+	t.Run("正常系: 署名者のアドレスを復元できる", func(t *testing.T) {
+		recovered, err := RecoverPersonalSign(message, hexutil.Encode(sig))
+		assert.NoError(t, err)
+		assert.Equal(t, address, recovered)
+	})
+
+	t.Run("異常系: メッセージが改ざんされている場合は別のアドレスになる", func(t *testing.T) {
+		recovered, err := RecoverPersonalSign(message+"!", hexutil.Encode(sig))
+		assert.NoError(t, err)
+		assert.NotEqual(t, address, recovered)
+	})
+
+	t.Run("異常系: 署名の形式が不正", func(t *testing.T) {
+		_, err := RecoverPersonalSign(message, "0x1234")
+		assert.Error(t, err)
+	})
+}
+
+func TestRecoverTypedData(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Listing": {
+				{Name: "uri", Type: "string"},
+				{Name: "price", Type: "uint256"},
+			},
+		},
+		PrimaryType: "Listing",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "NFT Music Collection",
+			Version:           "1",
+			ChainId:           math.NewHexOrDecimal256(1337),
+			VerifyingContract: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+		},
+		Message: apitypes.TypedDataMessage{
+			"uri":   "https://ipfs.io/ipfs/QmW9qWKbZneDijFPcHdbn41fQiHRwownM4U6avLsCHfMJS",
+			"price": "15000000000000000",
+		},
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	require.NoError(t, err)
+	sig, err := crypto.Sign(hash, privateKey)
+	require.NoError(t, err)
+	sig[crypto.RecoveryIDOffset] += 27
+
+	t.Run("正常系: 署名者のアドレスを復元できる", func(t *testing.T) {
+		recovered, err := RecoverTypedData(typedData, hexutil.Encode(sig))
+		assert.NoError(t, err)
+		assert.Equal(t, address, recovered)
+	})
+
+	t.Run("異常系: 別のチェーンのデータとして検証すると別のアドレスになる", func(t *testing.T) {
+		other := typedData
+		other.Domain.ChainId = math.NewHexOrDecimal256(56)
+		recovered, err := RecoverTypedData(other, hexutil.Encode(sig))
+		assert.NoError(t, err)
+		assert.NotEqual(t, address, recovered)
+	})
+
+	t.Run("異常系: 署名の長さが不正", func(t *testing.T) {
+		_, err := RecoverTypedData(typedData, hexutil.Encode(sig[:64]))
+		assert.Error(t, err)
+	})
+}
+
+func TestSameAddress(t *testing.T) {
+	assert.True(t, SameAddress("0xc5309Ef694C81C4a8e946F2810e09516436daeB5", "0xc5309ef694c81c4a8e946f2810e09516436daeb5"))
+	assert.False(t, SameAddress("0xc5309Ef694C81C4a8e946F2810e09516436daeB5", "0x411F8Cd035DB4874a8F1247Cb49908c794A0AB50"))
+}
+
+func TestIsChecksumAddress(t *testing.T) {
+	assert.True(t, IsChecksumAddress("0xc5309Ef694C81C4a8e946F2810e09516436daeB5"))
+	assert.False(t, IsChecksumAddress("0xc5309ef694c81c4a8e946f2810e09516436daeb5"))
+	assert.False(t, IsChecksumAddress("0xc5309Ef694C81C4a8e946F2810e09516436daeb5"))
+	assert.False(t, IsChecksumAddress("0x1234"))
+}
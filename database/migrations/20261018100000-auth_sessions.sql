@@ -0,0 +1,27 @@
+
+-- +migrate Up
+CREATE TABLE `auth_nonces`
+(
+  nonce       varchar(64) not null primary key comment 'ノンス',
+  wallet      char(42) not null comment 'ウォレットアドレス',
+  chain_id    int not null comment 'チェーンID',
+  expires_at  datetime not null comment '有効期限',
+  used_at     datetime comment '使用日時',
+  created_at  datetime not null comment '作成日時'
+) comment 'SIWEログイン用ノンス';
+
+CREATE TABLE `sessions`
+(
+  id          char(36) not null primary key comment 'ID',
+  token_hash  char(64) not null comment 'セッショントークンのSHA-256',
+  user_id     char(36) not null comment 'ユーザーID',
+  wallet      char(42) not null comment 'ウォレットアドレス',
+  expires_at  datetime not null comment '有効期限',
+  created_at  datetime not null comment '作成日時',
+  unique key unique_token_hash (token_hash),
+  foreign key session_user_foreign_key (user_id) references users (id) on delete cascade
+) comment 'ログインセッション';
+
+-- +migrate Down
+DROP TABLE `sessions`;
+DROP TABLE `auth_nonces`;
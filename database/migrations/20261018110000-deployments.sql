@@ -0,0 +1,19 @@
+
+-- +migrate Up
+CREATE TABLE `deployments`
+(
+  id             char(36) not null primary key comment 'ID',
+  chain_id       int not null comment 'チェーンID',
+  contract_name  varchar(64) not null comment 'コントラクト名',
+  address        char(42) not null comment 'コントラクトアドレス',
+  deploy_tx      char(66) not null comment 'デプロイしたトランザクションハッシュ',
+  block_number   bigint unsigned not null comment 'デプロイされたブロック番号',
+  abi_version    char(66) not null comment 'ABIのkeccak256ハッシュ',
+  deployed_by    char(36) comment 'デプロイしたユーザーID',
+  created_at     datetime not null comment '作成日時',
+  unique key unique_chain_address (chain_id, address),
+  index index_chain_contract (chain_id, contract_name, created_at)
+) comment 'デプロイ済みコントラクト';
+
+-- +migrate Down
+DROP TABLE `deployments`;
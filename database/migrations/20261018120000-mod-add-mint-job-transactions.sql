@@ -0,0 +1,17 @@
+
+-- +migrate Up
+ALTER TABLE `transactions`
+  ADD COLUMN `tx_hash` char(66) COMMENT 'CreateTokenのトランザクションハッシュ' AFTER `contract_address`,
+  ADD COLUMN `block_number` bigint unsigned COMMENT '取り込まれたブロック番号' AFTER `tx_hash`,
+  ADD COLUMN `revert_reason` varchar(1024) COMMENT '失敗した理由' AFTER `status`,
+  ADD INDEX `index_status` (`status`);
+
+-- これまではトランザクションハッシュをIDにしていた
+UPDATE `transactions` SET `tx_hash` = `id` WHERE `id` LIKE '0x%';
+
+-- +migrate Down
+ALTER TABLE `transactions`
+  DROP INDEX `index_status`,
+  DROP COLUMN `tx_hash`,
+  DROP COLUMN `block_number`,
+  DROP COLUMN `revert_reason`;
@@ -0,0 +1,54 @@
+
+-- +migrate Up
+CREATE TABLE `market_events`
+(
+  id               char(36) not null primary key comment 'ID',
+  chain_id         int not null comment 'チェーンID',
+  contract_address char(42) not null comment 'コントラクトアドレス',
+  block_number     bigint unsigned not null comment 'ブロック番号',
+  block_hash       char(66) not null comment 'ブロックハッシュ',
+  tx_hash          char(66) not null comment 'トランザクションハッシュ',
+  log_index        int unsigned not null comment 'ログの位置',
+  event_type       varchar(16) not null comment 'イベントの種類（mint / sale / resale）',
+  token_id         varchar(78) not null comment 'トークンID',
+  seller           char(42) comment '出品者アドレス',
+  buyer            char(42) comment '購入者アドレス',
+  price            varchar(78) comment '価格（wei）',
+  created_at       datetime not null comment '作成日時',
+  unique key unique_chain_log (chain_id, tx_hash, log_index),
+  index index_contract_block (chain_id, contract_address, block_number),
+  index index_token (chain_id, contract_address, token_id)
+) comment 'マーケットのイベント';
+
+CREATE TABLE `token_transfers`
+(
+  id               char(36) not null primary key comment 'ID',
+  chain_id         int not null comment 'チェーンID',
+  contract_address char(42) not null comment 'コントラクトアドレス',
+  block_number     bigint unsigned not null comment 'ブロック番号',
+  block_hash       char(66) not null comment 'ブロックハッシュ',
+  tx_hash          char(66) not null comment 'トランザクションハッシュ',
+  log_index        int unsigned not null comment 'ログの位置',
+  token_id         varchar(78) not null comment 'トークンID',
+  from_address     char(42) not null comment '送信元アドレス',
+  to_address       char(42) not null comment '送信先アドレス',
+  created_at       datetime not null comment '作成日時',
+  unique key unique_chain_log (chain_id, tx_hash, log_index),
+  index index_contract_block (chain_id, contract_address, block_number),
+  index index_token (chain_id, contract_address, token_id)
+) comment 'トークンの移転';
+
+CREATE TABLE `indexer_checkpoints`
+(
+  chain_id         int not null comment 'チェーンID',
+  contract_address char(42) not null comment 'コントラクトアドレス',
+  block_number     bigint unsigned not null comment '取り込み済みのブロック番号',
+  block_hash       char(66) not null comment '取り込み済みのブロックハッシュ',
+  updated_at       datetime not null comment '更新日時',
+  primary key (chain_id, contract_address)
+) comment 'インデクサーのチェックポイント';
+
+-- +migrate Down
+DROP TABLE `indexer_checkpoints`;
+DROP TABLE `token_transfers`;
+DROP TABLE `market_events`;
@@ -0,0 +1,28 @@
+
+-- +migrate Up
+CREATE TABLE `trades`
+(
+  id               char(36) not null primary key comment 'ID',
+  kind             varchar(16) not null comment '種類（purchase / resell）',
+  chain_id         int not null comment 'チェーンID',
+  contract_address char(42) not null comment 'コントラクトアドレス',
+  token_id         varchar(78) not null comment 'トークンID',
+  user_id          char(36) not null comment 'ユーザーID',
+  wallet           char(42) not null comment '署名するウォレットアドレス',
+  price            varchar(78) not null comment '販売価格（wei）',
+  value            varchar(78) not null comment '送金額（wei）',
+  calldata         text not null comment 'コントラクトの呼び出しデータ',
+  tx_hash          char(66) comment 'トランザクションハッシュ',
+  block_number     bigint unsigned comment '取り込まれたブロック番号',
+  status           varchar(16) not null comment 'ステータス（prepared / submitted / mined / failed）',
+  revert_reason    varchar(1024) comment '失敗した理由',
+  created_at       datetime not null comment '作成日時',
+  updated_at       datetime not null comment '更新日時',
+  unique key unique_tx_hash (tx_hash),
+  index index_status (status),
+  index index_token (chain_id, contract_address, token_id),
+  foreign key trade_user_foreign_key (user_id) references users (id)
+) comment '二次流通の取引';
+
+-- +migrate Down
+DROP TABLE `trades`;
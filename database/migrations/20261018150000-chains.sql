@@ -0,0 +1,31 @@
+-- +migrate Up
+CREATE TABLE `chains`
+(
+  chain_id             int not null primary key comment 'チェーンID',
+  name                 varchar(64) not null comment '名称',
+  symbol               varchar(16) not null comment 'ネイティブ通貨のシンボル',
+  decimals             int not null default 18 comment 'ネイティブ通貨の小数点以下の桁数',
+  rpc_url              varchar(255) not null comment 'RPCのURL',
+  explorer_url         varchar(255) not null default '' comment 'ブロックエクスプローラーのURL',
+  marketplace_address  char(42) comment '接続するマーケットプレイスのコントラクトアドレス',
+  confirmations        int unsigned not null default 1 comment '確定とみなす承認数',
+  created_at           datetime not null comment '作成日時',
+  updated_at           datetime not null comment '更新日時'
+) comment '対応しているチェーン';
+
+-- これまで価格を換算していたチェーンと、.env の RPC のチェーン
+INSERT INTO `chains` (chain_id, name, symbol, decimals, rpc_url, explorer_url, confirmations, created_at, updated_at) VALUES
+  (1,        'Ethereum',         'ETH',  18, 'https://ethereum-rpc.publicnode.com',                   'https://etherscan.io',                  12, now(), now()),
+  (5,        'Goerli',           'ETH',  18, 'https://ethereum-goerli-rpc.publicnode.com',            'https://goerli.etherscan.io',           3,  now(), now()),
+  (11155111, 'Sepolia',          'ETH',  18, 'https://rpc.sepolia.org',                               'https://sepolia.etherscan.io',          3,  now(), now()),
+  (1337,     'Ganache',          'ETH',  18, 'http://ganache:8545',                                   '',                                      1,  now(), now()),
+  (56,       'BNB Smart Chain',  'BNB',  18, 'https://bsc-dataseed.bnbchain.org',                     'https://bscscan.com',                   15, now(), now()),
+  (97,       'BNB Testnet',      'tBNB', 18, 'https://data-seed-prebsc-1-s1.bnbchain.org:8545',       'https://testnet.bscscan.com',           3,  now(), now()),
+  (42161,    'Arbitrum One',     'ETH',  18, 'https://arb1.arbitrum.io/rpc',                          'https://arbiscan.io',                   20, now(), now()),
+  (421613,   'Arbitrum Goerli',  'ETH',  18, 'https://goerli-rollup.arbitrum.io/rpc',                 'https://goerli.arbiscan.io',            3,  now(), now()),
+  (137,      'Polygon',          'POL',  18, 'https://polygon-rpc.com',                               'https://polygonscan.com',               64, now(), now()),
+  (80001,    'Polygon Mumbai',   'MATIC', 18, 'https://rpc-mumbai.maticvigil.com',                    'https://mumbai.polygonscan.com',        3,  now(), now()),
+  (80002,    'Polygon Amoy',     'POL',  18, 'https://rpc-amoy.polygon.technology',                   'https://amoy.polygonscan.com',          3,  now(), now());
+
+-- +migrate Down
+DROP TABLE `chains`;
@@ -0,0 +1,26 @@
+
+-- +migrate Up
+-- 金額はすべてweiの整数で保存する（uint256 の最大値は78桁）
+ALTER TABLE `transactions`
+  MODIFY COLUMN `price` decimal(78,0) not null comment '金額（wei）',
+  MODIFY COLUMN `cost` decimal(78,0) not null comment 'コスト（wei）';
+
+ALTER TABLE `trades`
+  MODIFY COLUMN `price` decimal(78,0) not null comment '販売価格（wei）',
+  MODIFY COLUMN `value` decimal(78,0) not null comment '送金額（wei）';
+
+ALTER TABLE `market_events`
+  MODIFY COLUMN `price` decimal(78,0) comment '価格（wei）';
+
+ALTER TABLE `nfts`
+  MODIFY COLUMN `price` decimal(78,0) comment '金額（wei）';
+
+-- +migrate Down
+-- transactions.price / cost と nfts.price は元の型（decimal(40,10) / bigint / int）に戻すとweiの金額が切り捨てられるか失敗するため戻さない（不可逆）
+-- weiの文字列で保存していたカラムのみ元の型に戻す
+ALTER TABLE `trades`
+  MODIFY COLUMN `price` varchar(78) not null comment '販売価格（wei）',
+  MODIFY COLUMN `value` varchar(78) not null comment '送金額（wei）';
+
+ALTER TABLE `market_events`
+  MODIFY COLUMN `price` varchar(78) comment '価格（wei）';
@@ -0,0 +1,20 @@
+
+-- +migrate Up
+ALTER TABLE `transactions`
+  ADD COLUMN `token_id` varchar(78) COMMENT 'ミントしたトークンID' AFTER `contract_address`,
+  ADD UNIQUE KEY `unique_token` (`chain_id`, `contract_address`, `token_id`);
+
+-- 取り込み済みのミントのイベントから埋める（残りと以前の created のミントは go run . backfill でレシートから埋める）
+UPDATE `transactions`
+  JOIN `market_events`
+    ON `market_events`.`chain_id` = `transactions`.`chain_id`
+   AND `market_events`.`contract_address` = `transactions`.`contract_address`
+   AND `market_events`.`tx_hash` = `transactions`.`tx_hash`
+   AND `market_events`.`event_type` = 'mint'
+   SET `transactions`.`token_id` = `market_events`.`token_id`
+ WHERE `transactions`.`token_id` IS NULL;
+
+-- +migrate Down
+ALTER TABLE `transactions`
+  DROP INDEX `unique_token`,
+  DROP COLUMN `token_id`;
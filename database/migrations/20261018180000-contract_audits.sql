@@ -0,0 +1,23 @@
+
+-- +migrate Up
+CREATE TABLE `contract_audits`
+(
+  id               char(36) not null primary key comment 'ID',
+  chain_id         int not null comment 'チェーンID',
+  contract_address char(42) not null comment 'コントラクトアドレス',
+  operation        varchar(32) not null comment '操作（pause / unpause / update_royalty_fee / update_listing_price / renounce_ownership）',
+  old_value        varchar(78) not null comment '変更前の値',
+  new_value        varchar(78) not null comment '変更後の値',
+  tx_hash          char(66) comment 'トランザクションハッシュ',
+  block_number     bigint unsigned comment '取り込まれたブロック番号',
+  status           varchar(16) not null comment 'ステータス（mined / failed）',
+  error            varchar(1024) comment '失敗した理由',
+  user_id          char(36) not null comment '操作したユーザーID',
+  wallet           char(42) not null comment '操作したユーザーのウォレットアドレス',
+  created_at       datetime not null comment '作成日時',
+  index index_contract (chain_id, contract_address, created_at),
+  foreign key contract_audit_user_foreign_key (user_id) references users (id)
+) comment 'コントラクトのオーナー操作の監査ログ';
+
+-- +migrate Down
+DROP TABLE `contract_audits`;
@@ -0,0 +1,8 @@
+
+-- +migrate Up
+ALTER TABLE `collections`
+  ADD COLUMN `deploy_tx` varchar(66) COMMENT 'コントラクトをデプロイしたトランザクションハッシュ（外部でデプロイした場合はNULL）' AFTER `contract_address`;
+
+-- +migrate Down
+ALTER TABLE `collections`
+  DROP COLUMN `deploy_tx`;
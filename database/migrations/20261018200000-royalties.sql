@@ -0,0 +1,30 @@
+
+-- +migrate Up
+-- コレクションのロイヤリティは ERC-2981 と同じくbpsで保存する（これまでの値は%）
+ALTER TABLE `collections`
+  CHANGE COLUMN `royalty` `royalty_bps` int not null default 0 comment 'ロイヤリティ率（bps）';
+
+UPDATE `collections` SET `royalty_bps` = `royalty_bps` * 100;
+
+CREATE TABLE `token_royalties`
+(
+  id               char(36) not null primary key comment 'ID',
+  chain_id         int not null comment 'チェーンID',
+  contract_address char(42) not null comment 'コントラクトアドレス',
+  token_id         varchar(78) not null comment 'トークンID',
+  royalty_bps      int not null comment 'ロイヤリティ率（bps）',
+  receiver         char(42) not null comment 'ロイヤリティの受取人',
+  user_id          char(36) not null comment '設定したユーザーID',
+  created_at       datetime not null comment '作成日時',
+  updated_at       datetime not null comment '更新日時',
+  unique key unique_token (chain_id, contract_address, token_id),
+  foreign key token_royalty_user_foreign_key (user_id) references users (id)
+) comment 'トークンごとのロイヤリティ';
+
+-- +migrate Down
+DROP TABLE `token_royalties`;
+
+UPDATE `collections` SET `royalty_bps` = `royalty_bps` DIV 100;
+
+ALTER TABLE `collections`
+  CHANGE COLUMN `royalty_bps` `royalty` int not null comment 'ロイヤリティ';
@@ -0,0 +1,33 @@
+
+-- +migrate Up
+CREATE TABLE `mint_vouchers`
+(
+  id               char(36) not null primary key comment 'ID',
+  collection_id    char(36) not null comment 'コレクションID',
+  chain_id         int not null comment 'チェーンID',
+  contract_address char(42) not null comment 'コレクションのコントラクトアドレス',
+  user_id          char(36) not null comment 'ユーザーID',
+  signer           char(42) not null comment '署名するクリエイターのウォレットアドレス',
+  token_url        varchar(255) not null comment 'メタJSONのIPFSパス',
+  genre_id         char(36) not null comment 'ジャンルID',
+  price            decimal(78,0) not null comment '販売価格（wei）',
+  royalty_bps      int not null default 0 comment 'ロイヤリティ率（bps）',
+  royalty_receiver char(42) not null default '' comment 'ロイヤリティの受取人',
+  expiry           bigint not null comment '有効期限（UNIX時間）',
+  signature        varchar(132) comment 'EIP-712 の署名',
+  status           varchar(16) not null comment 'ステータス（prepared / listed / redeeming / redeemed / cancelled）',
+  issued           boolean not null default false comment '購入者に redeem のトランザクションを返したか',
+  buyer            char(42) comment '購入者のウォレットアドレス',
+  tx_hash          char(66) comment 'redeem のトランザクションハッシュ',
+  block_number     bigint unsigned comment '取り込まれたブロック番号',
+  token_id         varchar(78) comment 'ミントしたトークンID',
+  created_at       datetime not null comment '作成日時',
+  updated_at       datetime not null comment '更新日時',
+  index index_status (status, expiry),
+  foreign key mint_voucher_collection_foreign_key (collection_id) references collections (id),
+  foreign key mint_voucher_user_foreign_key (user_id) references users (id),
+  foreign key mint_voucher_genre_foreign_key (genre_id) references genre_masters (id)
+) comment '遅延ミントのバウチャー';
+
+-- +migrate Down
+DROP TABLE `mint_vouchers`;
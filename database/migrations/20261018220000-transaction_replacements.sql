@@ -0,0 +1,25 @@
+
+-- +migrate Up
+CREATE TABLE `transaction_replacements`
+(
+  id               char(36) not null primary key comment 'ID',
+  transaction_id   varchar(80) not null comment '置き換えたミントジョブのID',
+  kind             varchar(16) not null comment '置き換え方（speed_up / cancel）',
+  nonce            int not null comment 'ノンス（元のトランザクションと同じ）',
+  replaced_tx_hash char(66) not null comment '置き換える前のトランザクションハッシュ',
+  tx_hash          char(66) not null comment '置き換えたトランザクションハッシュ',
+  gas_price        decimal(78,0) comment 'legacy: gasPrice（wei）',
+  gas_tip_cap      decimal(78,0) comment 'dynamic: maxPriorityFeePerGas（wei）',
+  gas_fee_cap      decimal(78,0) comment 'dynamic: maxFeePerGas（wei）',
+  status           varchar(16) not null comment 'ステータス（pending / mined / replaced）',
+  user_id          char(36) comment '置き換えた管理者のユーザーID（監視による置き換えは NULL）',
+  created_at       datetime not null comment '作成日時',
+  updated_at       datetime not null comment '更新日時',
+  unique key unique_tx_hash (tx_hash),
+  index index_transaction (transaction_id, created_at),
+  foreign key replacement_transaction_foreign_key (transaction_id) references transactions (id),
+  foreign key replacement_user_foreign_key (user_id) references users (id)
+) comment '詰まったトランザクションの置き換え';
+
+-- +migrate Down
+DROP TABLE `transaction_replacements`;
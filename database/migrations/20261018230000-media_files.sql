@@ -0,0 +1,21 @@
+
+-- +migrate Up
+CREATE TABLE `media_files`
+(
+  id         char(36) not null primary key comment 'ID',
+  cid        varchar(100) not null comment 'IPFSのCID',
+  user_id    char(36) not null comment 'アップロードしたユーザーのID',
+  filename   varchar(255) not null comment 'アップロード時のファイル名',
+  kind       varchar(16) not null comment 'ファイルの種類（audio / image / video、それ以外は空）',
+  size       bigint not null comment 'ファイルサイズ（バイト）',
+  sha256     char(64) not null comment 'ファイルのSHA-256（16進）',
+  audio      json comment '音声ファイルの技術的なメタデータ（形式・再生時間・サンプルレート・ビット深度・チャンネル数・ビットレート・タグ）',
+  created_at datetime not null comment '作成日時',
+  updated_at datetime not null comment '更新日時',
+  unique key unique_cid (cid),
+  index index_user (user_id, created_at),
+  foreign key media_file_user_foreign_key (user_id) references users (id)
+) comment 'IPFSにアップロードしたファイル';
+
+-- +migrate Down
+DROP TABLE `media_files`;
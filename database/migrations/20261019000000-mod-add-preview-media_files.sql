@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE `media_files`
+  ADD COLUMN `preview_cid` varchar(100) COMMENT '音声ファイルから切り出したプレビューのCID（切り出せない形式・音声以外はNULL）' AFTER `audio`,
+  ADD INDEX `index_sha256` (`sha256`);
+
+-- +migrate Down
+ALTER TABLE `media_files`
+  DROP INDEX `index_sha256`,
+  DROP COLUMN `preview_cid`;
@@ -0,0 +1,8 @@
+-- +migrate Up
+-- 送信したオーナー操作は取り込みを待つ前に pending で記録する
+ALTER TABLE `contract_audits`
+  MODIFY COLUMN `status` varchar(16) not null comment 'ステータス（pending / mined / failed）';
+
+-- +migrate Down
+ALTER TABLE `contract_audits`
+  MODIFY COLUMN `status` varchar(16) not null comment 'ステータス（mined / failed）';
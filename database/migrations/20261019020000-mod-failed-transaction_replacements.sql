@@ -0,0 +1,8 @@
+-- +migrate Up
+-- 置き換えは送信する前に pending で記録し、ノードが受け付けなかった場合は failed にする
+ALTER TABLE `transaction_replacements`
+  MODIFY COLUMN `status` varchar(16) not null comment 'ステータス（pending / mined / replaced / failed）';
+
+-- +migrate Down
+ALTER TABLE `transaction_replacements`
+  MODIFY COLUMN `status` varchar(16) not null comment 'ステータス（pending / mined / replaced）';
@@ -0,0 +1,6 @@
+-- +migrate Up
+-- プラットフォームでデプロイしていないコレクションのロイヤリティは販売時に支払われないため消す
+UPDATE `collections` SET `royalty_bps` = 0, `royalty_receiver` = '' WHERE `deploy_tx` IS NULL;
+
+-- +migrate Down
+-- 消したロイヤリティは戻せない
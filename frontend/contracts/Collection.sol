@@ -4,31 +4,68 @@ pragma solidity ^0.8.24;
 
 // OpenZeppelinの最新版（v5.x）をインポート
 import "@openzeppelin/contracts/token/ERC721/ERC721.sol";
+import "@openzeppelin/contracts/token/ERC721/extensions/ERC721URIStorage.sol";
 import "@openzeppelin/contracts/access/Ownable.sol";
+import "@openzeppelin/contracts/token/common/ERC2981.sol";
+import "@openzeppelin/contracts/utils/cryptography/EIP712.sol";
+import "@openzeppelin/contracts/utils/cryptography/ECDSA.sol";
 
 /**
  * @title Collection
  * @dev ERC721の作成、修正、削除の機能を持つNFTコレクションコントラクト
+ * @dev ロイヤリティは ERC-2981 でコレクションの既定値とトークンごとの値を設定できる
+ * @dev クリエイターが EIP-712 で署名したミントバウチャーを、最初の購入者が引き換えてミントする（レイジーミント）
  * @dev OpenZeppelin v5.x と Solidity v0.8.26+ に対応
  */
-contract Collection is ERC721, Ownable {
+contract Collection is ERC721URIStorage, ERC2981, Ownable, EIP712 {
   // トークンIDを管理するための変数。0から始まります。
   uint256 private _nextTokenId;
 
   // ベースURI（例: "https://api.example.com/nfts/"）
   string private _baseTokenURI;
 
+  // ミントバウチャー（クリエイターが EIP-712 で署名する）
+  struct MintVoucher {
+    bytes32 id; // バウチャーID（引き換えと取り消しに使う）
+    string uri; // トークンURI
+    uint256 price; // 販売価格（wei）
+    address royaltyReceiver; // トークンのロイヤリティの受取人
+    uint96 royaltyBps; // トークンのロイヤリティ率（0の場合はコレクションの既定値）
+    uint256 expiry; // 有効期限（UNIX時間）
+  }
+
+  bytes32 private constant MINT_VOUCHER_TYPEHASH =
+    keccak256("MintVoucher(bytes32 id,string uri,uint256 price,address royaltyReceiver,uint96 royaltyBps,uint256 expiry)");
+
+  // バウチャーに署名できるクリエイター
+  mapping(address => bool) public minters;
+
+  // 引き換え済みか取り消し済みのバウチャー
+  mapping(bytes32 => bool) public voucherUsed;
+
+  event MinterSet(address indexed minter, bool allowed);
+  event VoucherRedeemed(bytes32 indexed id, uint256 indexed tokenId, address indexed signer, address to);
+  event VoucherCancelled(bytes32 indexed id);
+
   /**
    * @dev コンストラクタ
    * @param name_ NFTコレクションの名前
    * @param symbol_ NFTコレクションのシンボル
    * @param initialOwner コントラクトの初期オーナーアドレス
+   * @param royaltyReceiver コレクションの既定のロイヤリティの受取人
+   * @param royaltyFeeBps コレクションの既定のロイヤリティ率 (BPS: 1/100 of 1%、0の場合は設定しない)
    */
   constructor(
     string memory name_,
     string memory symbol_,
-    address initialOwner
-  ) ERC721(name_, symbol_) Ownable(initialOwner) {}
+    address initialOwner,
+    address royaltyReceiver,
+    uint96 royaltyFeeBps
+  ) ERC721(name_, symbol_) Ownable(initialOwner) EIP712("NFT Music Collection", "1") {
+    if (royaltyFeeBps > 0) {
+      _setDefaultRoyalty(royaltyReceiver, royaltyFeeBps);
+    }
+  }
 
   /**
    * @notice ベースURIを設定する関数（オーナーのみ実行可能）
@@ -38,6 +75,54 @@ contract Collection is ERC721, Ownable {
     _baseTokenURI = baseURI_;
   }
 
+  /**
+   * @notice コレクションの既定のロイヤリティを設定する関数（オーナーのみ実行可能）
+   * @param receiver ロイヤリティの受取人
+   * @param feeNumerator ロイヤリティ率 (BPS: 1/100 of 1%、0の場合は既定のロイヤリティを削除する)
+   */
+  function setDefaultRoyalty(address receiver, uint96 feeNumerator) public onlyOwner {
+    if (feeNumerator == 0) {
+      _deleteDefaultRoyalty();
+      return;
+    }
+    _setDefaultRoyalty(receiver, feeNumerator);
+  }
+
+  /**
+   * @notice トークンごとのロイヤリティを設定する関数（オーナーのみ実行可能）
+   * @param tokenId 対象のNFTのID
+   * @param receiver ロイヤリティの受取人
+   * @param feeNumerator ロイヤリティ率 (BPS: 1/100 of 1%)
+   */
+  function setTokenRoyalty(uint256 tokenId, address receiver, uint96 feeNumerator) public onlyOwner {
+    _setTokenRoyalty(tokenId, receiver, feeNumerator);
+  }
+
+  /**
+   * @notice トークンごとのロイヤリティを解除してコレクションの既定値に戻す関数（オーナーのみ実行可能）
+   * @param tokenId 対象のNFTのID
+   */
+  function resetTokenRoyalty(uint256 tokenId) public onlyOwner {
+    _resetTokenRoyalty(tokenId);
+  }
+
+  /**
+   * @notice ミントバウチャーに署名できるクリエイターを設定する関数（オーナーのみ実行可能）
+   * @param minter クリエイターのアドレス
+   * @param allowed 署名を認める場合は true
+   */
+  function setMinter(address minter, bool allowed) public onlyOwner {
+    minters[minter] = allowed;
+    emit MinterSet(minter, allowed);
+  }
+
+  /**
+   * @dev ERC721 と ERC2981 の両方のインターフェースに対応していることを返す
+   */
+  function supportsInterface(bytes4 interfaceId) public view override(ERC721URIStorage, ERC2981) returns (bool) {
+    return super.supportsInterface(interfaceId);
+  }
+
   /**
    * @dev 全てのトークンURIの前に付与されるベースURIを返す
    */
@@ -63,6 +148,65 @@ contract Collection is ERC721, Ownable {
     emit Minted(to, tokenId);
   }
 
+  /**
+   * @notice クリエイターが署名したミントバウチャーを引き換えてミントする関数
+   * @dev 販売価格と同じ金額を送金する必要があり、送金額は署名したクリエイターに支払う
+   * @param to ミントしたトークンの受取人（購入者）
+   * @param voucher ミントバウチャー
+   * @param signature クリエイターの EIP-712 の署名
+   * @return ミントしたトークンID
+   */
+  function redeem(address to, MintVoucher calldata voucher, bytes calldata signature) public payable returns (uint256) {
+    require(block.timestamp <= voucher.expiry, "Voucher expired");
+    require(!voucherUsed[voucher.id], "Voucher already used");
+    require(msg.value == voucher.price, "Please submit the voucher price");
+    address signer = ECDSA.recover(_hashTypedDataV4(_hashVoucher(voucher)), signature);
+    require(minters[signer], "Signer is not a minter");
+
+    voucherUsed[voucher.id] = true;
+    uint256 tokenId = _nextTokenId;
+    _nextTokenId++;
+    _safeMint(to, tokenId);
+    _setTokenURI(tokenId, voucher.uri);
+    if (voucher.royaltyBps > 0) {
+      _setTokenRoyalty(tokenId, voucher.royaltyReceiver, voucher.royaltyBps);
+    }
+
+    (bool sent, ) = payable(signer).call{value: msg.value}("");
+    require(sent, "Failed to pay the creator");
+
+    emit Minted(to, tokenId);
+    emit VoucherRedeemed(voucher.id, tokenId, signer, to);
+    return tokenId;
+  }
+
+  /**
+   * @notice 引き換えられていないミントバウチャーを取り消す関数（オーナーのみ実行可能）
+   * @param id バウチャーID
+   */
+  function cancelVoucher(bytes32 id) public onlyOwner {
+    require(!voucherUsed[id], "Voucher already used");
+    voucherUsed[id] = true;
+    emit VoucherCancelled(id);
+  }
+
+  /**
+   * @dev ミントバウチャーの EIP-712 の構造体ハッシュを返す
+   */
+  function _hashVoucher(MintVoucher calldata voucher) internal pure returns (bytes32) {
+    return keccak256(
+      abi.encode(
+        MINT_VOUCHER_TYPEHASH,
+        voucher.id,
+        keccak256(bytes(voucher.uri)),
+        voucher.price,
+        voucher.royaltyReceiver,
+        voucher.royaltyBps,
+        voucher.expiry
+      )
+    );
+  }
+
   // =================================================================
   // 2. 修正 (Modification)
   // =================================================================
@@ -89,5 +233,6 @@ contract Collection is ERC721, Ownable {
     // このチェックは、トークンが存在しない場合にもエラーを発生させます。
     _checkAuthorized(ownerOf(tokenId), msg.sender, tokenId);
     _burn(tokenId);
+    _resetTokenRoyalty(tokenId);
   }
 }
@@ -28,6 +28,13 @@ const nextConfig: NextConfig = {
         hostname: "ipfs.io",
         pathname: "/ipfs/**",
       },
+      // S3・ローカルに保存したコンテンツはバックエンドの GET /api/v1/ipfs/:cid から配信する（IPFS_PUBLIC_URL の既定値）
+      {
+        protocol: "http",
+        hostname: "localhost",
+        port: "1323",
+        pathname: "/api/v1/ipfs/**",
+      },
     ],
   },
 };
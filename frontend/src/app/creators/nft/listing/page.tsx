@@ -58,7 +58,7 @@ const NftListing: NextPage = () => {
   const renderList = itemList.map((item) => {
     let NFTImage = "https://ipfs.io" + item.token_url;
     if (item.chain_id == 1337) {
-      NFTImage = "http://ipfs:8080" + item.image_url;
+      NFTImage = item.image_url.startsWith("/") ? "http://ipfs:8080" + item.image_url : item.image_url;
     }
     return (
       <div
@@ -52,14 +52,13 @@ const NftDetailPage: NextPage<Props> = async ({ params }) => {
   }
 
   // IPFS URLとブロックチェーン情報の決定
-  let imageUrl = "https://ipfs.io" + nft.image_url;
-  let mediaUrl = nft.video_url ? "https://ipfs.io" + nft.video_url : "";
-  if (nft.chain_id == 1337) {
-    imageUrl = "http://127.0.0.1:8080" + nft.image_url;
-    if (nft.video_url) {
-      mediaUrl = "http://127.0.0.1:8080" + nft.video_url;
-    }
-  }
+  // 保存先が公開URLを返す場合はそのまま使い、/ipfs/ のパスの場合はゲートウェイから取得する
+  const ipfsGateway = nft.chain_id == 1337 ? "http://127.0.0.1:8080" : "https://ipfs.io";
+  const contentUrl = (url: string) => (url.startsWith("/") ? ipfsGateway + url : url);
+  const imageUrl = contentUrl(nft.image_url);
+  // 音声はフル音源を公開しないため、プレビューを再生する（audio_url は以前に作成したメタデータのみ）
+  const mediaSource = nft.file_type === "audio" ? nft.preview_url || nft.audio_url : nft.video_url;
+  const mediaUrl = mediaSource ? contentUrl(mediaSource) : "";
 
   const chainInfo = getChainInfo(nft.chain_id);
 
@@ -121,7 +120,7 @@ const NftDetailPage: NextPage<Props> = async ({ params }) => {
             <div className="bg-gray-50 p-6 rounded-lg border border-gray-200 flex-grow flex flex-col">
               <div className="mb-4">
                 <p className="text-sm text-gray-500">価格</p>
-                <p className="text-3xl font-bold text-gray-800">{nft.price} ETH</p>
+                <p className="text-3xl font-bold text-gray-800">{nft.price_formatted ?? `${nft.price} ETH`}</p>
               </div>
               <div className="mb-4">
                 <p className="text-sm text-gray-500">クリエイターインセンティブ</p>
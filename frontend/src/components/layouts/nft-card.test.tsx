@@ -46,7 +46,8 @@ const mockNft: RenderableMarketItem = {
   updated_at: "2024-01-01",
   from: "0x789",
   to: "0xabc",
-  price: "0.1",
+  price: "100000000000000000",
+  price_formatted: "0.1 ETH",
   insentive: 0,
 };
 
@@ -72,7 +73,22 @@ describe("NftCard Component", () => {
     expect(image).toHaveAttribute("src", "http://127.0.0.1:8080/ipfs/image.png");
   });
 
-  it("音声ファイルの場合にaudioタグが表示されること", () => {
+  it("APIが公開URLを返す場合はそのまま使用すること", () => {
+    const storedNft = { ...mockNft, image_url: "http://localhost:1323/api/v1/ipfs/image" };
+    render(<NftCard nft={storedNft} />);
+    const image = screen.getByAltText("Test NFT");
+    expect(image).toHaveAttribute("src", "http://localhost:1323/api/v1/ipfs/image");
+  });
+
+  it("音声ファイルの場合にプレビューを再生すること", () => {
+    const audioNft = { ...mockNft, file_type: "audio", preview_url: "/ipfs/preview.mp3" };
+    render(<NftCard nft={audioNft} />);
+    const audioElement = document.querySelector("audio");
+    expect(audioElement).toBeInTheDocument();
+    expect(audioElement).toHaveAttribute("src", "https://ipfs.io/ipfs/preview.mp3");
+  });
+
+  it("以前に作成した音声のNFTはaudio_urlを再生すること", () => {
     const audioNft = { ...mockNft, file_type: "audio", audio_url: "/ipfs/audio.mp3" };
     render(<NftCard nft={audioNft} />);
     // audioタグが存在することを確認
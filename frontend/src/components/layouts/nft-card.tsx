@@ -10,11 +10,17 @@ export const NftCard = ({ nft }: NftCardProps) => {
   const isLocal = nft.chain_id === 1337;
   const ipfsGateway = isLocal ? "http://127.0.0.1:8080" : "https://ipfs.io";
 
-  const imageUrl = nft.image_url ? `${ipfsGateway}${nft.image_url}` : "/placeholder-image.png";
-  const audioUrl = nft.audio_url ? `${ipfsGateway}${nft.audio_url}` : "";
-  const videoUrl = nft.video_url ? `${ipfsGateway}${nft.video_url}` : "";
+  // 保存先が公開URLを返す場合はそのまま使い、/ipfs/ のパスの場合はゲートウェイから取得する
+  const contentUrl = (url: string) => (url.startsWith("/") ? `${ipfsGateway}${url}` : url);
 
-  const priceInEth = nft.price; // 価格はAPIから取得したものをそのまま使う
+  const imageUrl = nft.image_url ? contentUrl(nft.image_url) : "/placeholder-image.png";
+  // フル音源は公開しないため、プレビューを再生する（audio_url は以前に作成したメタデータのみ）
+  const audioSource = nft.preview_url || nft.audio_url;
+  const audioUrl = audioSource ? contentUrl(audioSource) : "";
+  const videoUrl = nft.video_url ? contentUrl(nft.video_url) : "";
+
+  // 価格はweiで返るため、APIがネイティブ通貨の単位にした price_formatted を表示する
+  const price = nft.price_formatted ?? `${nft.price} ETH`;
 
   return (
     <Link href={`/nfts/detail/${nft.id}`} className="block group">
@@ -52,7 +58,7 @@ export const NftCard = ({ nft }: NftCardProps) => {
           <p className="text-sm text-gray-600 mt-1 truncate">{nft.description}</p>
           <div className="mt-4">
             <p className="text-xs text-gray-500">Price</p>
-            <p className="text-lg font-semibold text-gray-800">{priceInEth} ETH</p>
+            <p className="text-lg font-semibold text-gray-800">{price}</p>
           </div>
         </div>
       </div>
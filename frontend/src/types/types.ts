@@ -31,7 +31,8 @@ export type RenderableMarketItem = {
   description: string;
   file_type: string;
   image_url: string;
-  audio_url: string;
+  audio_url?: string; // フル音源（以前に作成したメタデータのみ）
+  preview_url?: string; // 購入前に試聴するプレビュー
   video_url: string;
   contract_address: string;
   token_url: string;
@@ -42,7 +43,8 @@ export type RenderableMarketItem = {
   updated_at: string;
   from: string;
   to: string;
-  price: string;
+  price: string; // wei
+  price_formatted?: string; // ネイティブ通貨の単位（例: "0.015 ETH"）
   sale?: boolean;
   insentive: number;
 };